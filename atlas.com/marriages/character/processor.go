@@ -16,6 +16,9 @@ type Processor interface {
 	ByIdProvider(characterId uint32) model.Provider[Model]
 }
 
+// ProcessorProducer creates a character processor for a request
+type ProcessorProducer func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor
+
 type ProcessorImpl struct {
 	l   logrus.FieldLogger
 	ctx context.Context
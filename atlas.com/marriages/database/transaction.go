@@ -7,7 +7,7 @@ import (
 // ExecuteTransaction runs the given function within a transaction.
 // If the provided *gorm.DB is already in a transaction, it will just run the function without starting a new one.
 func ExecuteTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
-	if isTransaction(db) {
+	if IsTransaction(db) {
 		// Already in a transaction, execute directly
 		return fn(db)
 	}
@@ -16,7 +16,11 @@ func ExecuteTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
 	return db.Transaction(fn)
 }
 
-// isTransaction checks if the *gorm.DB is already in a transaction
-func isTransaction(db *gorm.DB) bool {
-	return db.Statement != nil && db.Statement.ConnPool != nil
+// IsTransaction checks if the *gorm.DB is already in a transaction
+func IsTransaction(db *gorm.DB) bool {
+	if db.Statement == nil || db.Statement.ConnPool == nil {
+		return false
+	}
+	_, ok := db.Statement.ConnPool.(gorm.TxCommitter)
+	return ok
 }
@@ -0,0 +1,51 @@
+package economy
+
+import "github.com/google/uuid"
+
+// Wallet represents the mesos held by a character
+type Wallet struct {
+	characterId uint32
+	mesos       uint32
+}
+
+func (m Wallet) CharacterId() uint32 {
+	return m.characterId
+}
+
+func (m Wallet) Mesos() uint32 {
+	return m.mesos
+}
+
+// Transaction represents mesos debited from a character's wallet, which remain refundable to the character
+type Transaction struct {
+	id          uuid.UUID
+	characterId uint32
+	amount      uint32
+	reason      string
+}
+
+func (m Transaction) Id() uuid.UUID {
+	return m.id
+}
+
+func (m Transaction) CharacterId() uint32 {
+	return m.characterId
+}
+
+func (m Transaction) Amount() uint32 {
+	return m.amount
+}
+
+func (m Transaction) Reason() string {
+	return m.reason
+}
+
+// NewTransaction creates a new transaction model for testing purposes
+func NewTransaction(id uuid.UUID, characterId uint32, amount uint32, reason string) Transaction {
+	return Transaction{
+		id:          id,
+		characterId: characterId,
+		amount:      amount,
+		reason:      reason,
+	}
+}
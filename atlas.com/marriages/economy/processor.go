@@ -0,0 +1,54 @@
+package economy
+
+import (
+	"context"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-rest/requests"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type Processor interface {
+	// GetMesos gets how many mesos a character holds
+	GetMesos(characterId uint32) (uint32, error)
+	// WalletProvider returns a provider for a character's wallet
+	WalletProvider(characterId uint32) model.Provider[Wallet]
+	// Debit takes mesos from a character's wallet under a transaction id, so a retried debit takes them once
+	Debit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) (Transaction, error)
+	// Refund returns the mesos taken by a debit to a character's wallet
+	Refund(characterId uint32, transactionId uuid.UUID) error
+}
+
+type ProcessorImpl struct {
+	l   logrus.FieldLogger
+	ctx context.Context
+}
+
+func NewProcessor(l logrus.FieldLogger, ctx context.Context) Processor {
+	return &ProcessorImpl{
+		l:   l,
+		ctx: ctx,
+	}
+}
+
+func (p *ProcessorImpl) WalletProvider(characterId uint32) model.Provider[Wallet] {
+	return requests.Provider[WalletRestModel, Wallet](p.l, p.ctx)(requestWallet(characterId), ExtractWallet)
+}
+
+func (p *ProcessorImpl) GetMesos(characterId uint32) (uint32, error) {
+	w, err := p.WalletProvider(characterId)()
+	if err != nil {
+		return 0, err
+	}
+	return w.Mesos(), nil
+}
+
+func (p *ProcessorImpl) Debit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) (Transaction, error) {
+	return requests.Provider[TransactionRestModel, Transaction](p.l, p.ctx)(requestDebit(transactionId, characterId, amount, reason), ExtractTransaction)()
+}
+
+func (p *ProcessorImpl) Refund(characterId uint32, transactionId uuid.UUID) error {
+	_, err := requestRefund(characterId, transactionId)(p.l, p.ctx)
+	return err
+}
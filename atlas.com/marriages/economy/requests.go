@@ -0,0 +1,34 @@
+package economy
+
+import (
+	"atlas-marriages/rest"
+	"fmt"
+
+	"github.com/Chronicle20/atlas-rest/requests"
+	"github.com/google/uuid"
+)
+
+const (
+	Resource          = "characters/%d/wallet"
+	Transactions      = Resource + "/transactions"
+	TransactionById   = Transactions + "/%s"
+	RefundTransaction = TransactionById + "/refund"
+)
+
+func getBaseRequest() string {
+	return requests.RootUrl("ECONOMY")
+}
+
+func requestWallet(characterId uint32) requests.Request[WalletRestModel] {
+	return rest.MakeGetRequest[WalletRestModel](fmt.Sprintf(getBaseRequest()+Resource, characterId))
+}
+
+func requestDebit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) requests.Request[TransactionRestModel] {
+	i := TransactionRestModel{Id: transactionId, CharacterId: characterId, Amount: amount, Reason: reason}
+	return rest.MakePostRequest[TransactionRestModel](fmt.Sprintf(getBaseRequest()+Transactions, characterId), i)
+}
+
+func requestRefund(characterId uint32, transactionId uuid.UUID) requests.Request[TransactionRestModel] {
+	i := TransactionRestModel{Id: transactionId, CharacterId: characterId}
+	return rest.MakePostRequest[TransactionRestModel](fmt.Sprintf(getBaseRequest()+RefundTransaction, characterId, transactionId.String()), i)
+}
@@ -0,0 +1,78 @@
+package economy
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type WalletRestModel struct {
+	Id    uint32 `json:"-"`
+	Mesos uint32 `json:"mesos"`
+}
+
+func (r WalletRestModel) GetName() string {
+	return "wallets"
+}
+
+func (r WalletRestModel) GetID() string {
+	return strconv.Itoa(int(r.Id))
+}
+
+func (r *WalletRestModel) SetID(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return err
+	}
+
+	r.Id = uint32(id)
+	return nil
+}
+
+func ExtractWallet(rm WalletRestModel) (Wallet, error) {
+	return Wallet{
+		characterId: rm.Id,
+		mesos:       rm.Mesos,
+	}, nil
+}
+
+type TransactionRestModel struct {
+	Id          uuid.UUID `json:"-"`
+	CharacterId uint32    `json:"characterId"`
+	Amount      uint32    `json:"amount"`
+	Reason      string    `json:"reason"`
+}
+
+func (r TransactionRestModel) GetName() string {
+	return "transactions"
+}
+
+func (r TransactionRestModel) GetID() string {
+	if r.Id == uuid.Nil {
+		return ""
+	}
+	return r.Id.String()
+}
+
+func (r *TransactionRestModel) SetID(idStr string) error {
+	if idStr == "" {
+		r.Id = uuid.Nil
+		return nil
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return err
+	}
+
+	r.Id = id
+	return nil
+}
+
+func ExtractTransaction(rm TransactionRestModel) (Transaction, error) {
+	return Transaction{
+		id:          rm.Id,
+		characterId: rm.CharacterId,
+		amount:      rm.Amount,
+		reason:      rm.Reason,
+	}, nil
+}
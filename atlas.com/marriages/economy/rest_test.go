@@ -0,0 +1,55 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestExtractTransaction(t *testing.T) {
+	id := uuid.New()
+	rm := TransactionRestModel{}
+	if err := rm.SetID(id.String()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rm.CharacterId = 1001
+	rm.Amount = 500000
+	rm.Reason = "divorce"
+
+	m, err := ExtractTransaction(rm)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Id() != id || m.CharacterId() != 1001 || m.Amount() != 500000 || m.Reason() != "divorce" {
+		t.Errorf("Unexpected transaction: %+v", m)
+	}
+	if rm.GetID() != id.String() {
+		t.Errorf("Expected id %s, got %s", id, rm.GetID())
+	}
+}
+
+func TestTransactionRestModel_NewTransactionHasNoId(t *testing.T) {
+	rm := TransactionRestModel{CharacterId: 1001, Amount: 500000}
+	if rm.GetID() != "" {
+		t.Errorf("Expected empty id for a new transaction, got %s", rm.GetID())
+	}
+	if err := rm.SetID("not-a-uuid"); err == nil {
+		t.Error("Expected invalid transaction id to be rejected")
+	}
+}
+
+func TestExtractWallet(t *testing.T) {
+	rm := WalletRestModel{}
+	if err := rm.SetID("1001"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rm.Mesos = 750000
+
+	m, err := ExtractWallet(rm)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.CharacterId() != 1001 || m.Mesos() != 750000 {
+		t.Errorf("Unexpected wallet: %+v", m)
+	}
+}
@@ -0,0 +1,56 @@
+package inventory
+
+import "github.com/google/uuid"
+
+// Asset represents a stack of an item held in a character's inventory
+type Asset struct {
+	id       uint32
+	itemId   uint32
+	quantity uint32
+}
+
+func (m Asset) Id() uint32 {
+	return m.id
+}
+
+func (m Asset) ItemId() uint32 {
+	return m.itemId
+}
+
+func (m Asset) Quantity() uint32 {
+	return m.quantity
+}
+
+// Reservation represents items held back in a character's inventory until they are consumed or released
+type Reservation struct {
+	id          uuid.UUID
+	characterId uint32
+	itemId      uint32
+	quantity    uint32
+}
+
+func (m Reservation) Id() uuid.UUID {
+	return m.id
+}
+
+func (m Reservation) CharacterId() uint32 {
+	return m.characterId
+}
+
+func (m Reservation) ItemId() uint32 {
+	return m.itemId
+}
+
+func (m Reservation) Quantity() uint32 {
+	return m.quantity
+}
+
+// NewReservation creates a new reservation model for testing purposes
+func NewReservation(id uuid.UUID, characterId uint32, itemId uint32, quantity uint32) Reservation {
+	return Reservation{
+		id:          id,
+		characterId: characterId,
+		itemId:      itemId,
+		quantity:    quantity,
+	}
+}
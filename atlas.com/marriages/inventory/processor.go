@@ -0,0 +1,66 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-rest/requests"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type Processor interface {
+	// GetItemQuantity gets how many of an item a character holds
+	GetItemQuantity(characterId uint32, itemId uint32) (uint32, error)
+	// AssetsByItemIdProvider returns a provider for the stacks of an item a character holds
+	AssetsByItemIdProvider(characterId uint32, itemId uint32) model.Provider[[]Asset]
+	// Reserve holds back a quantity of an item in a character's inventory
+	Reserve(characterId uint32, itemId uint32, quantity uint32) (Reservation, error)
+	// Consume removes reserved items from a character's inventory
+	Consume(characterId uint32, reservationId uuid.UUID) error
+	// Release returns reserved items to a character's inventory
+	Release(characterId uint32, reservationId uuid.UUID) error
+}
+
+type ProcessorImpl struct {
+	l   logrus.FieldLogger
+	ctx context.Context
+}
+
+func NewProcessor(l logrus.FieldLogger, ctx context.Context) Processor {
+	return &ProcessorImpl{
+		l:   l,
+		ctx: ctx,
+	}
+}
+
+func (p *ProcessorImpl) AssetsByItemIdProvider(characterId uint32, itemId uint32) model.Provider[[]Asset] {
+	return requests.SliceProvider[AssetRestModel, Asset](p.l, p.ctx)(requestAssetsByItemId(characterId, itemId), ExtractAsset)
+}
+
+func (p *ProcessorImpl) GetItemQuantity(characterId uint32, itemId uint32) (uint32, error) {
+	assets, err := p.AssetsByItemIdProvider(characterId, itemId)()
+	if err != nil {
+		return 0, err
+	}
+	var quantity uint32
+	for _, a := range assets {
+		if a.ItemId() == itemId {
+			quantity += a.Quantity()
+		}
+	}
+	return quantity, nil
+}
+
+func (p *ProcessorImpl) Reserve(characterId uint32, itemId uint32, quantity uint32) (Reservation, error) {
+	return requests.Provider[ReservationRestModel, Reservation](p.l, p.ctx)(requestReserve(characterId, itemId, quantity), ExtractReservation)()
+}
+
+func (p *ProcessorImpl) Consume(characterId uint32, reservationId uuid.UUID) error {
+	_, err := requestConsume(characterId, reservationId)(p.l, p.ctx)
+	return err
+}
+
+func (p *ProcessorImpl) Release(characterId uint32, reservationId uuid.UUID) error {
+	return requestRelease(characterId, reservationId)(p.l, p.ctx)
+}
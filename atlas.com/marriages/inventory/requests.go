@@ -0,0 +1,39 @@
+package inventory
+
+import (
+	"atlas-marriages/rest"
+	"fmt"
+
+	"github.com/Chronicle20/atlas-rest/requests"
+	"github.com/google/uuid"
+)
+
+const (
+	Resource           = "characters/%d/inventory"
+	AssetsByItemId     = Resource + "/assets?itemId=%d"
+	Reservations       = Resource + "/reservations"
+	ReservationById    = Reservations + "/%s"
+	ConsumeReservation = ReservationById + "/consume"
+)
+
+func getBaseRequest() string {
+	return requests.RootUrl("INVENTORY")
+}
+
+func requestAssetsByItemId(characterId uint32, itemId uint32) requests.Request[[]AssetRestModel] {
+	return rest.MakeGetRequest[[]AssetRestModel](fmt.Sprintf(getBaseRequest()+AssetsByItemId, characterId, itemId))
+}
+
+func requestReserve(characterId uint32, itemId uint32, quantity uint32) requests.Request[ReservationRestModel] {
+	i := ReservationRestModel{CharacterId: characterId, ItemId: itemId, Quantity: quantity}
+	return rest.MakePostRequest[ReservationRestModel](fmt.Sprintf(getBaseRequest()+Reservations, characterId), i)
+}
+
+func requestConsume(characterId uint32, reservationId uuid.UUID) requests.Request[ReservationRestModel] {
+	i := ReservationRestModel{Id: reservationId, CharacterId: characterId}
+	return rest.MakePostRequest[ReservationRestModel](fmt.Sprintf(getBaseRequest()+ConsumeReservation, characterId, reservationId.String()), i)
+}
+
+func requestRelease(characterId uint32, reservationId uuid.UUID) requests.EmptyBodyRequest {
+	return rest.MakeDeleteRequest(fmt.Sprintf(getBaseRequest()+ReservationById, characterId, reservationId.String()))
+}
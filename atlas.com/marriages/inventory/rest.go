@@ -0,0 +1,80 @@
+package inventory
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type AssetRestModel struct {
+	Id       uint32 `json:"-"`
+	ItemId   uint32 `json:"itemId"`
+	Quantity uint32 `json:"quantity"`
+}
+
+func (r AssetRestModel) GetName() string {
+	return "assets"
+}
+
+func (r AssetRestModel) GetID() string {
+	return strconv.Itoa(int(r.Id))
+}
+
+func (r *AssetRestModel) SetID(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return err
+	}
+
+	r.Id = uint32(id)
+	return nil
+}
+
+func ExtractAsset(rm AssetRestModel) (Asset, error) {
+	return Asset{
+		id:       rm.Id,
+		itemId:   rm.ItemId,
+		quantity: rm.Quantity,
+	}, nil
+}
+
+type ReservationRestModel struct {
+	Id          uuid.UUID `json:"-"`
+	CharacterId uint32    `json:"characterId"`
+	ItemId      uint32    `json:"itemId"`
+	Quantity    uint32    `json:"quantity"`
+}
+
+func (r ReservationRestModel) GetName() string {
+	return "reservations"
+}
+
+func (r ReservationRestModel) GetID() string {
+	if r.Id == uuid.Nil {
+		return ""
+	}
+	return r.Id.String()
+}
+
+func (r *ReservationRestModel) SetID(idStr string) error {
+	if idStr == "" {
+		r.Id = uuid.Nil
+		return nil
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return err
+	}
+
+	r.Id = id
+	return nil
+}
+
+func ExtractReservation(rm ReservationRestModel) (Reservation, error) {
+	return Reservation{
+		id:          rm.Id,
+		characterId: rm.CharacterId,
+		itemId:      rm.ItemId,
+		quantity:    rm.Quantity,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestExtractReservation(t *testing.T) {
+	id := uuid.New()
+	rm := ReservationRestModel{}
+	if err := rm.SetID(id.String()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rm.CharacterId = 1001
+	rm.ItemId = 2240000
+	rm.Quantity = 1
+
+	m, err := ExtractReservation(rm)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Id() != id || m.CharacterId() != 1001 || m.ItemId() != 2240000 || m.Quantity() != 1 {
+		t.Errorf("Unexpected reservation: %+v", m)
+	}
+	if rm.GetID() != id.String() {
+		t.Errorf("Expected id %s, got %s", id, rm.GetID())
+	}
+}
+
+func TestReservationRestModel_NewReservationHasNoId(t *testing.T) {
+	rm := ReservationRestModel{CharacterId: 1001, ItemId: 2240000, Quantity: 1}
+	if rm.GetID() != "" {
+		t.Errorf("Expected empty id for a new reservation, got %s", rm.GetID())
+	}
+	if err := rm.SetID("not-a-uuid"); err == nil {
+		t.Error("Expected invalid reservation id to be rejected")
+	}
+}
+
+func TestExtractAsset(t *testing.T) {
+	rm := AssetRestModel{}
+	if err := rm.SetID("42"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rm.ItemId = 2240000
+	rm.Quantity = 2
+
+	m, err := ExtractAsset(rm)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if m.Id() != 42 || m.ItemId() != 2240000 || m.Quantity() != 2 {
+		t.Errorf("Unexpected asset: %+v", m)
+	}
+}
@@ -4,9 +4,7 @@ import (
 	"context"
 
 	localConsumer "atlas-marriages/kafka/consumer"
-	"atlas-marriages/kafka/message"
 	characterMsg "atlas-marriages/kafka/message/character"
-	"atlas-marriages/kafka/producer"
 	marriageService "atlas-marriages/marriage"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
@@ -31,15 +29,18 @@ func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic str
 			var t string
 			t, _ = topic.EnvProvider(l)(characterMsg.EnvEventTopicStatus)()
 			// Character deleted event handler
-			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleCharacterDeleted(db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleCharacterDeleted(marriageService.NewProcessor, db))))
+			// Character presence event handlers, tracking partners of scheduled and active ceremonies
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleCharacterLogout(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleCharacterLogin(marriageService.NewProcessor, db))))
 		}
 	}
 }
 
 // handleCharacterDeleted handles character deleted status events
-func handleCharacterDeleted(db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[characterMsg.DeletedStatusEventBody]] {
+func handleCharacterDeleted(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[characterMsg.DeletedStatusEventBody]] {
 	return func(l logrus.FieldLogger, ctx context.Context, event characterMsg.StatusEvent[characterMsg.DeletedStatusEventBody]) {
-		processor := marriageService.NewProcessor(l, ctx, db)
+		processor := pp(l, ctx, db)
 		l.WithFields(logrus.Fields{
 			"type":        event.Type,
 			"characterId": event.CharacterId,
@@ -61,16 +62,7 @@ func handleCharacterDeleted(db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[
 			}).Error("Failed to process character deletion")
 
 			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				event.CharacterId,
-				"CHARACTER_DELETION_FAILED",
-				"CHARACTER_DELETION_ERROR",
-				err.Error(),
-				"character_deletion",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put("EVENT_TOPIC_MARRIAGE_STATUS", errorProvider)
-			}); emitErr != nil {
+			if emitErr := processor.ReportErrorAndEmit(uuid.New(), event.CharacterId, err, "character_deletion"); emitErr != nil {
 				l.WithError(emitErr).Error("Failed to emit error event for character deletion failure")
 			}
 			return
@@ -83,6 +75,56 @@ func handleCharacterDeleted(db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[
 	}
 }
 
+// handleCharacterLogout handles character logout status events, recording the character as logged out and starting the
+// disconnection timeout of a partner in an active ceremony
+func handleCharacterLogout(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[characterMsg.LogoutStatusEventBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, event characterMsg.StatusEvent[characterMsg.LogoutStatusEventBody]) {
+		if event.Type != characterMsg.StatusEventTypeLogout {
+			return
+		}
+
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"characterId": event.CharacterId,
+			"worldId":     event.WorldId,
+			"channelId":   event.Body.ChannelId,
+		}).Debug("Processing character logout event")
+
+		if _, err := processor.DisconnectPartnerAndEmit(uuid.New(), event.CharacterId); err != nil {
+			l.WithError(err).WithField("characterId", event.CharacterId).Error("Failed to process character logout")
+
+			if emitErr := processor.ReportErrorAndEmit(uuid.New(), event.CharacterId, err, "character_logout"); emitErr != nil {
+				l.WithError(emitErr).Error("Failed to emit error event for character logout failure")
+			}
+		}
+	}
+}
+
+// handleCharacterLogin handles character login status events, recording the character as logged in and cancelling the
+// disconnection timeout of a partner in an active ceremony
+func handleCharacterLogin(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[characterMsg.StatusEvent[characterMsg.LoginStatusEventBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, event characterMsg.StatusEvent[characterMsg.LoginStatusEventBody]) {
+		if event.Type != characterMsg.StatusEventTypeLogin {
+			return
+		}
+
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"characterId": event.CharacterId,
+			"worldId":     event.WorldId,
+			"channelId":   event.Body.ChannelId,
+		}).Debug("Processing character login event")
+
+		if _, err := processor.ReconnectPartnerAndEmit(uuid.New(), event.CharacterId); err != nil {
+			l.WithError(err).WithField("characterId", event.CharacterId).Error("Failed to process character login")
+
+			if emitErr := processor.ReportErrorAndEmit(uuid.New(), event.CharacterId, err, "character_login"); emitErr != nil {
+				l.WithError(emitErr).Error("Failed to emit error event for character login failure")
+			}
+		}
+	}
+}
+
 // InitConsumers initializes the character event consumers
 func InitConsumers(l logrus.FieldLogger) func(func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
 	return func(rf func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
@@ -0,0 +1,74 @@
+package character
+
+import (
+	"context"
+	"testing"
+
+	characterMsg "atlas-marriages/kafka/message/character"
+	marriageService "atlas-marriages/marriage"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockProcessor is a mock for the marriage processor
+type MockProcessor struct {
+	mock.Mock
+	marriageService.Processor
+}
+
+func (m *MockProcessor) DisconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*marriageService.Ceremony, error) {
+	args := m.Called(transactionId, characterId)
+	return nil, args.Error(1)
+}
+
+func (m *MockProcessor) ReconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*marriageService.Ceremony, error) {
+	args := m.Called(transactionId, characterId)
+	return nil, args.Error(1)
+}
+
+func (m *MockProcessor) ReportErrorAndEmit(transactionId uuid.UUID, characterId uint32, cause error, errorContext string) error {
+	args := m.Called(transactionId, characterId, cause, errorContext)
+	return args.Error(0)
+}
+
+func mockProducer(m *MockProcessor) marriageService.ProcessorProducer {
+	return func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return m
+	}
+}
+
+func TestHandleCharacterLogout_ReportsFailure(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	mockProcessor := new(MockProcessor)
+
+	mockProcessor.On("DisconnectPartnerAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1)).Return(nil, marriageService.ErrCeremonyNotFound)
+	mockProcessor.On("ReportErrorAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), marriageService.ErrCeremonyNotFound, "character_logout").Return(nil)
+
+	handler := handleCharacterLogout(mockProducer(mockProcessor), nil)
+	handler(logger, context.Background(), characterMsg.StatusEvent[characterMsg.LogoutStatusEventBody]{
+		CharacterId: 1,
+		Type:        characterMsg.StatusEventTypeLogout,
+	})
+
+	mockProcessor.AssertExpectations(t)
+}
+
+func TestHandleCharacterLogin_ReportsNothingOnSuccess(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	mockProcessor := new(MockProcessor)
+
+	mockProcessor.On("ReconnectPartnerAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1)).Return(nil, nil)
+
+	handler := handleCharacterLogin(mockProducer(mockProcessor), nil)
+	handler(logger, context.Background(), characterMsg.StatusEvent[characterMsg.LoginStatusEventBody]{
+		CharacterId: 1,
+		Type:        characterMsg.StatusEventTypeLogin,
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNotCalled(t, "ReportErrorAndEmit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,71 @@
+package inventory
+
+import (
+	"context"
+
+	localConsumer "atlas-marriages/kafka/consumer"
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	marriageService "atlas-marriages/marriage"
+
+	"github.com/Chronicle20/atlas-kafka/consumer"
+	"github.com/Chronicle20/atlas-kafka/handler"
+	kafka "github.com/Chronicle20/atlas-kafka/message"
+	"github.com/Chronicle20/atlas-kafka/topic"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// NewConfig creates a new consumer configuration for inventory status events
+func NewConfig(l logrus.FieldLogger) func(name string) func(token string) func(groupId string) consumer.Config {
+	return localConsumer.NewConfig(l)
+}
+
+// InitHandlers initializes all inventory status event handlers
+func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+	return func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+		return func(rf func(topic string, handler handler.Handler) (string, error)) {
+			var t string
+			t, _ = topic.EnvProvider(l)(inventoryMsg.EnvEventTopicStatus)()
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleItemTransferFailed(marriageService.NewProcessor, db))))
+		}
+	}
+}
+
+// handleItemTransferFailed handles the inventory service's report that it could not move the items of a gift to the
+// couple
+func handleItemTransferFailed(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[inventoryMsg.StatusEvent[inventoryMsg.ItemTransferFailedBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, event inventoryMsg.StatusEvent[inventoryMsg.ItemTransferFailedBody]) {
+		if event.Type != inventoryMsg.EventItemTransferFailed {
+			return
+		}
+
+		l = l.WithFields(logrus.Fields{
+			"transactionId": event.TransactionId,
+			"characterId":   event.CharacterId,
+			"ceremonyId":    event.Body.CeremonyId,
+			"reason":        event.Body.Reason,
+		})
+		l.Debug("Processing item transfer failed event")
+
+		processor := pp(l, ctx, db)
+		if err := processor.HandleGiftTransferFailedAndEmit(uuid.New(), event.TransactionId, event.Body.Reason); err != nil {
+			l.WithError(err).Error("Failed to return gift after failed item transfer")
+		}
+	}
+}
+
+// InitConsumers initializes the inventory status event consumers
+func InitConsumers(l logrus.FieldLogger) func(func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+	return func(rf func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+		return func(consumerGroupId string) {
+			config := NewConfig(l)("marriage_inventory_status")(inventoryMsg.EnvEventTopicStatus)(consumerGroupId)
+
+			// Set up header parsers for tenant and span context
+			rf(config,
+				consumer.SetHeaderParsers(consumer.SpanHeaderParser, consumer.TenantHeaderParser),
+			)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	marriageService "atlas-marriages/marriage"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockProcessor is a mock for the marriage processor
+type MockProcessor struct {
+	mock.Mock
+	marriageService.Processor
+}
+
+func (m *MockProcessor) HandleGiftTransferFailedAndEmit(transactionId uuid.UUID, giftTransactionId uuid.UUID, reason string) error {
+	args := m.Called(transactionId, giftTransactionId, reason)
+	return args.Error(0)
+}
+
+func TestHandleItemTransferFailed(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	giftTransactionId := uuid.New()
+	mockProcessor.On("HandleGiftTransferFailedAndEmit", mock.AnythingOfType("uuid.UUID"), giftTransactionId, "insufficient items").Return(nil)
+
+	handler := handleItemTransferFailed(processorProducer, nil)
+	handler(logger, ctx, inventoryMsg.StatusEvent[inventoryMsg.ItemTransferFailedBody]{
+		TransactionId: giftTransactionId,
+		CharacterId:   3,
+		Type:          inventoryMsg.EventItemTransferFailed,
+		Body:          inventoryMsg.ItemTransferFailedBody{ItemId: 2000000, Quantity: 2, RecipientCharacterId: 1, CeremonyId: 7, Reason: "insufficient items"},
+	})
+
+	// Events of other types are ignored
+	handler(logger, ctx, inventoryMsg.StatusEvent[inventoryMsg.ItemTransferFailedBody]{
+		TransactionId: uuid.New(),
+		CharacterId:   3,
+		Type:          "ITEM_TRANSFERRED",
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "HandleGiftTransferFailedAndEmit", 1)
+}
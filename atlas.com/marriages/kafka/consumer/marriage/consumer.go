@@ -2,12 +2,12 @@ package marriage
 
 import (
 	"context"
+	"errors"
 
 	localConsumer "atlas-marriages/kafka/consumer"
-	"atlas-marriages/kafka/message"
 	marriageMsg "atlas-marriages/kafka/message/marriage"
-	"atlas-marriages/kafka/producer"
 	marriageService "atlas-marriages/marriage"
+	"atlas-marriages/registry"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
 	"github.com/Chronicle20/atlas-kafka/handler"
@@ -43,14 +43,30 @@ func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic str
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleCancelCeremony(marriageService.NewProcessor, db))))
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handlePostponeCeremony(marriageService.NewProcessor, db))))
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleRescheduleCeremony(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleAdvanceCeremonyStage(marriageService.NewProcessor, db))))
 
 			// Invitee command handlers
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleAddInvitee(marriageService.NewProcessor, db))))
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleRemoveInvitee(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleRespondToInvitation(marriageService.NewProcessor, db))))
+
+			// Registry command handlers
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleAddRegistryItem(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleRemoveRegistryItem(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleBlessCeremony(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleGiveGift(marriageService.NewProcessor, db))))
 
 			// Divorce command handler
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleDivorce(marriageService.NewProcessor, db))))
 
+			// Divorce filing command handlers
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleFileDivorce(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleConsentDivorce(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleWithdrawDivorce(marriageService.NewProcessor, db))))
+
+			// Bond command handler
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleAwardBondPoints(marriageService.NewProcessor, db))))
+
 			// Advance ceremony state handler
 			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleAdvanceCeremonyState(marriageService.NewProcessor, db))))
 		}
@@ -71,29 +87,21 @@ func handlePropose(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Hand
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the proposal
-		proposal, err := processor.ProposeAndEmit(transactionId, cmd.CharacterId, cmd.Body.TargetCharacterId)
+		var proposal marriageService.Proposal
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_proposal", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			proposal, err = p.ProposeAndEmit(transactionId, cmd.CharacterId, cmd.Body.TargetCharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"proposerId": cmd.CharacterId,
 				"targetId":   cmd.Body.TargetCharacterId,
 			}).Error("Failed to process marriage proposal")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"PROPOSAL_FAILED",
-				"MARRIAGE_PROPOSAL_ERROR",
-				err.Error(),
-				"marriage_proposal",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for proposal failure")
-			}
 			return
 		}
 
@@ -119,29 +127,21 @@ func handleAccept(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handl
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the proposal acceptance
-		marriage, err := processor.AcceptProposalAndEmit(transactionId, cmd.Body.ProposalId)
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_proposal_accept", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.AcceptProposalAndEmit(transactionId, cmd.Body.ProposalId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"proposalId":  cmd.Body.ProposalId,
 				"characterId": cmd.CharacterId,
 			}).Error("Failed to process proposal acceptance")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"PROPOSAL_ACCEPT_FAILED",
-				"MARRIAGE_PROPOSAL_ACCEPT_ERROR",
-				err.Error(),
-				"marriage_proposal_accept",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for proposal acceptance failure")
-			}
 			return
 		}
 
@@ -167,29 +167,19 @@ func handleDecline(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Hand
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the proposal decline
-		_, err := processor.DeclineProposalAndEmit(transactionId, cmd.Body.ProposalId)
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_proposal_decline", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			_, err := p.DeclineProposalAndEmit(transactionId, cmd.Body.ProposalId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"proposalId":  cmd.Body.ProposalId,
 				"characterId": cmd.CharacterId,
 			}).Error("Failed to process proposal decline")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"PROPOSAL_DECLINE_FAILED",
-				"MARRIAGE_PROPOSAL_DECLINE_ERROR",
-				err.Error(),
-				"marriage_proposal_decline",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for proposal decline failure")
-			}
 			return
 		}
 
@@ -214,29 +204,19 @@ func handleCancel(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handl
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the proposal cancellation
-		_, err := processor.CancelProposalAndEmit(transactionId, cmd.Body.ProposalId)
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_proposal_cancel", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			_, err := p.CancelProposalAndEmit(transactionId, cmd.Body.ProposalId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"proposalId":  cmd.Body.ProposalId,
 				"characterId": cmd.CharacterId,
 			}).Error("Failed to process proposal cancellation")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"PROPOSAL_CANCEL_FAILED",
-				"MARRIAGE_PROPOSAL_CANCEL_ERROR",
-				err.Error(),
-				"marriage_proposal_cancel",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for proposal cancellation failure")
-			}
 			return
 		}
 
@@ -257,35 +237,28 @@ func handleScheduleCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) k
 			"marriageId":  cmd.Body.MarriageId,
 			"scheduledAt": cmd.Body.ScheduledAt,
 			"invitees":    len(cmd.Body.Invitees),
+			"venueTier":   cmd.Body.VenueTier,
 		}).Debug("Processing ceremony scheduling command")
 
 		if cmd.Type != marriageMsg.CommandCeremonySchedule {
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony scheduling
-		ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, cmd.Body.MarriageId, cmd.Body.ScheduledAt, cmd.Body.Invitees)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_schedule", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.ScheduleCeremonyAndEmit(transactionId, cmd.Body.MarriageId, cmd.Body.ScheduledAt, cmd.Body.Invitees, marriageService.VenueTier(cmd.Body.VenueTier), cmd.Body.VenueId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"marriageId":  cmd.Body.MarriageId,
 				"scheduledAt": cmd.Body.ScheduledAt,
 			}).Error("Failed to schedule ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_SCHEDULE_FAILED",
-				"CEREMONY_SCHEDULE_ERROR",
-				err.Error(),
-				"ceremony_schedule",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony schedule failure")
-			}
 			return
 		}
 
@@ -310,26 +283,18 @@ func handleStartCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) kafk
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony start
-		ceremony, err := processor.StartCeremonyAndEmit(transactionId, cmd.Body.CeremonyId)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_start", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.StartCeremonyAndEmit(transactionId, cmd.Body.CeremonyId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithField("ceremonyId", cmd.Body.CeremonyId).Error("Failed to start ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_START_FAILED",
-				"CEREMONY_START_ERROR",
-				err.Error(),
-				"ceremony_start",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony start failure")
-			}
 			return
 		}
 
@@ -337,6 +302,48 @@ func handleStartCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) kafk
 	}
 }
 
+// handleAdvanceCeremonyStage handles commands in which an officiant advances an active ceremony to its next stage
+func handleAdvanceCeremonyStage(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.AdvanceCeremonyStageBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.AdvanceCeremonyStageBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+			"stage":       cmd.Body.Stage,
+		}).Debug("Processing ceremony stage advancement command")
+
+		if cmd.Type != marriageMsg.CommandCeremonyAdvanceStage {
+			return
+		}
+
+		// Process the stage advancement
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_advance_stage", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.AdvanceCeremonyStageAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.Stage, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+				"stage":       cmd.Body.Stage,
+			}).Error("Failed to advance ceremony stage")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  ceremony.Id(),
+			"characterId": cmd.CharacterId,
+			"stage":       ceremony.Stage(),
+		}).Info("Ceremony stage advanced successfully")
+	}
+}
+
 // handleCompleteCeremony handles ceremony completion commands
 func handleCompleteCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.CompleteCeremonyBody]] {
 	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.CompleteCeremonyBody]) {
@@ -351,26 +358,18 @@ func handleCompleteCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) k
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony completion
-		ceremony, err := processor.CompleteCeremonyAndEmit(transactionId, cmd.Body.CeremonyId)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_complete", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.CompleteCeremonyAndEmit(transactionId, cmd.Body.CeremonyId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithField("ceremonyId", cmd.Body.CeremonyId).Error("Failed to complete ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_COMPLETE_FAILED",
-				"CEREMONY_COMPLETE_ERROR",
-				err.Error(),
-				"ceremony_complete",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony complete failure")
-			}
 			return
 		}
 
@@ -392,26 +391,18 @@ func handleCancelCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) kaf
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony cancellation
-		ceremony, err := processor.CancelCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, "ceremony_cancelled")
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_cancel", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.CancelCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, "ceremony_cancelled")
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithField("ceremonyId", cmd.Body.CeremonyId).Error("Failed to cancel ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_CANCEL_FAILED",
-				"CEREMONY_CANCEL_ERROR",
-				err.Error(),
-				"ceremony_cancel",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony cancel failure")
-			}
 			return
 		}
 
@@ -433,26 +424,18 @@ func handlePostponeCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) k
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony postponement
-		ceremony, err := processor.PostponeCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, "ceremony_postponed")
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_postpone", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.PostponeCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, "ceremony_postponed")
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithField("ceremonyId", cmd.Body.CeremonyId).Error("Failed to postpone ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_POSTPONE_FAILED",
-				"CEREMONY_POSTPONE_ERROR",
-				err.Error(),
-				"ceremony_postpone",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony postpone failure")
-			}
 			return
 		}
 
@@ -475,29 +458,21 @@ func handleRescheduleCeremony(pp marriageService.ProcessorProducer, db *gorm.DB)
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony rescheduling
-		ceremony, err := processor.RescheduleCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.ScheduledAt, cmd.CharacterId)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_reschedule", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.RescheduleCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.ScheduledAt, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"ceremonyId":  cmd.Body.CeremonyId,
 				"scheduledAt": cmd.Body.ScheduledAt,
 			}).Error("Failed to reschedule ceremony")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_RESCHEDULE_FAILED",
-				"CEREMONY_RESCHEDULE_ERROR",
-				err.Error(),
-				"ceremony_reschedule",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony reschedule failure")
-			}
 			return
 		}
 
@@ -520,29 +495,21 @@ func handleAddInvitee(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.H
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process adding the invitee
-		ceremony, err := processor.AddInviteeAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.CharacterId, cmd.CharacterId)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "invitee_add", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.AddInviteeAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.CharacterId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"ceremonyId": cmd.Body.CeremonyId,
 				"inviteeId":  cmd.Body.CharacterId,
 			}).Error("Failed to add invitee")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"INVITEE_ADD_FAILED",
-				"INVITEE_ADD_ERROR",
-				err.Error(),
-				"invitee_add",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for invitee add failure")
-			}
 			return
 		}
 
@@ -568,29 +535,21 @@ func handleRemoveInvitee(pp marriageService.ProcessorProducer, db *gorm.DB) kafk
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process removing the invitee
-		ceremony, err := processor.RemoveInviteeAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.CharacterId, cmd.CharacterId)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "invitee_remove", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.RemoveInviteeAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.CharacterId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"ceremonyId": cmd.Body.CeremonyId,
 				"inviteeId":  cmd.Body.CharacterId,
 			}).Error("Failed to remove invitee")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"INVITEE_REMOVE_FAILED",
-				"INVITEE_REMOVE_ERROR",
-				err.Error(),
-				"invitee_remove",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for invitee remove failure")
-			}
 			return
 		}
 
@@ -601,6 +560,218 @@ func handleRemoveInvitee(pp marriageService.ProcessorProducer, db *gorm.DB) kafk
 	}
 }
 
+// invitationResponses maps invitation response commands to the RSVP status they record
+var invitationResponses = map[string]marriageService.RsvpStatus{
+	marriageMsg.CommandInvitationAccept:  marriageService.RsvpStatusAccepted,
+	marriageMsg.CommandInvitationDecline: marriageService.RsvpStatusDeclined,
+	marriageMsg.CommandCeremonyAttend:    marriageService.RsvpStatusAttended,
+}
+
+// handleRespondToInvitation handles commands in which an invitee accepts or declines their invitation, or attends
+// the ceremony
+func handleRespondToInvitation(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.InvitationResponseBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.InvitationResponseBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+		}).Debug("Processing invitation response command")
+
+		status, ok := invitationResponses[cmd.Type]
+		if !ok {
+			return
+		}
+
+		// Process the invitee's response
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "invitation_response", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			_, err := p.RespondToInvitationAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, status)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+				"status":      status,
+			}).Error("Failed to respond to invitation")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  cmd.Body.CeremonyId,
+			"characterId": cmd.CharacterId,
+			"status":      status,
+		}).Info("Invitation response processed successfully")
+	}
+}
+
+// handleAddRegistryItem handles commands in which one of the couple adds an item to their ceremony's wish list
+func handleAddRegistryItem(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.AddRegistryItemBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.AddRegistryItemBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+			"itemId":      cmd.Body.ItemId,
+			"quantity":    cmd.Body.Quantity,
+		}).Debug("Processing registry item addition command")
+
+		if cmd.Type != marriageMsg.CommandRegistryAddItem {
+			return
+		}
+
+		var item registry.Item
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "registry_add_item", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			item, err = p.AddRegistryItemAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, cmd.Body.ItemId, cmd.Body.Quantity)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+				"itemId":      cmd.Body.ItemId,
+			}).Error("Failed to add registry item")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  item.CeremonyId(),
+			"characterId": cmd.CharacterId,
+			"itemId":      item.ItemId(),
+		}).Info("Registry item added successfully")
+	}
+}
+
+// handleRemoveRegistryItem handles commands in which one of the couple removes an item from their ceremony's wish list
+func handleRemoveRegistryItem(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.RemoveRegistryItemBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.RemoveRegistryItemBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+			"itemId":      cmd.Body.ItemId,
+		}).Debug("Processing registry item removal command")
+
+		if cmd.Type != marriageMsg.CommandRegistryRemoveItem {
+			return
+		}
+
+		var item registry.Item
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "registry_remove_item", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			item, err = p.RemoveRegistryItemAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, cmd.Body.ItemId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+				"itemId":      cmd.Body.ItemId,
+			}).Error("Failed to remove registry item")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  item.CeremonyId(),
+			"characterId": cmd.CharacterId,
+			"itemId":      item.ItemId(),
+		}).Info("Registry item removed successfully")
+	}
+}
+
+// handleBlessCeremony handles commands in which an invitee blesses an active ceremony
+func handleBlessCeremony(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.BlessCeremonyBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.BlessCeremonyBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+		}).Debug("Processing ceremony blessing command")
+
+		if cmd.Type != marriageMsg.CommandCeremonyBless {
+			return
+		}
+
+		var blessing registry.Blessing
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_bless", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			blessing, err = p.BlessCeremonyAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, cmd.Body.Message)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+			}).Error("Failed to bless ceremony")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  blessing.CeremonyId(),
+			"characterId": cmd.CharacterId,
+		}).Info("Ceremony blessed successfully")
+	}
+}
+
+// handleGiveGift handles commands in which an invitee gives items from the couple's wish list during their active ceremony
+func handleGiveGift(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.GiveGiftBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.GiveGiftBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"ceremonyId":  cmd.Body.CeremonyId,
+			"itemId":      cmd.Body.ItemId,
+			"quantity":    cmd.Body.Quantity,
+		}).Debug("Processing gift command")
+
+		if cmd.Type != marriageMsg.CommandRegistryGiveGift {
+			return
+		}
+
+		var gift registry.Gift
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "registry_give_gift", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			gift, err = p.GiveGiftAndEmit(transactionId, cmd.Body.CeremonyId, cmd.CharacterId, cmd.Body.ItemId, cmd.Body.Quantity)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"ceremonyId":  cmd.Body.CeremonyId,
+				"characterId": cmd.CharacterId,
+				"itemId":      cmd.Body.ItemId,
+			}).Error("Failed to give gift")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"ceremonyId":  gift.CeremonyId(),
+			"characterId": cmd.CharacterId,
+			"itemId":      gift.ItemId(),
+			"quantity":    gift.Quantity(),
+		}).Info("Gift given successfully")
+	}
+}
+
 // handleDivorce handles divorce commands
 func handleDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.DivorceBody]] {
 	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.DivorceBody]) {
@@ -615,29 +786,21 @@ func handleDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Hand
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the divorce
-		marriage, err := processor.DivorceAndEmit(transactionId, cmd.Body.MarriageId, cmd.CharacterId)
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_divorce", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.DivorceAndEmit(transactionId, cmd.Body.MarriageId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"marriageId":  cmd.Body.MarriageId,
 				"characterId": cmd.CharacterId,
 			}).Error("Failed to process divorce")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"DIVORCE_FAILED",
-				"MARRIAGE_DIVORCE_ERROR",
-				err.Error(),
-				"marriage_divorce",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for divorce failure")
-			}
 			return
 		}
 
@@ -648,6 +811,166 @@ func handleDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Hand
 	}
 }
 
+// handleFileDivorce handles divorce filing commands
+func handleFileDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.FileDivorceBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.FileDivorceBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"marriageId":  cmd.Body.MarriageId,
+		}).Debug("Processing divorce filing command")
+
+		if cmd.Type != marriageMsg.CommandDivorceFile {
+			return
+		}
+
+		// Process the divorce filing
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_divorce_file", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.FileDivorceAndEmit(transactionId, cmd.Body.MarriageId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"marriageId":  cmd.Body.MarriageId,
+				"characterId": cmd.CharacterId,
+			}).Error("Failed to process divorce filing")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"marriageId":  marriage.Id(),
+			"characterId": cmd.CharacterId,
+		}).Info("Divorce filing processed successfully")
+	}
+}
+
+// handleConsentDivorce handles divorce consent commands
+func handleConsentDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.ConsentDivorceBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.ConsentDivorceBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"marriageId":  cmd.Body.MarriageId,
+		}).Debug("Processing divorce consent command")
+
+		if cmd.Type != marriageMsg.CommandDivorceConsent {
+			return
+		}
+
+		// Process the divorce consent
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_divorce_consent", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.ConsentDivorceAndEmit(transactionId, cmd.Body.MarriageId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"marriageId":  cmd.Body.MarriageId,
+				"characterId": cmd.CharacterId,
+			}).Error("Failed to process divorce consent")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"marriageId":  marriage.Id(),
+			"characterId": cmd.CharacterId,
+		}).Info("Divorce consent processed successfully")
+	}
+}
+
+// handleWithdrawDivorce handles divorce withdrawal commands
+func handleWithdrawDivorce(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.WithdrawDivorceBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.WithdrawDivorceBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"marriageId":  cmd.Body.MarriageId,
+		}).Debug("Processing divorce withdrawal command")
+
+		if cmd.Type != marriageMsg.CommandDivorceWithdraw {
+			return
+		}
+
+		// Process the divorce withdrawal
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_divorce_withdraw", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.WithdrawDivorceAndEmit(transactionId, cmd.Body.MarriageId, cmd.CharacterId)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"marriageId":  cmd.Body.MarriageId,
+				"characterId": cmd.CharacterId,
+			}).Error("Failed to process divorce withdrawal")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"marriageId":  marriage.Id(),
+			"characterId": cmd.CharacterId,
+		}).Info("Divorce withdrawal processed successfully")
+	}
+}
+
+// handleAwardBondPoints handles commands awarding bond points to a character's marriage
+func handleAwardBondPoints(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.AwardBondPointsBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.AwardBondPointsBody]) {
+		processor := pp(l, ctx, db)
+		l.WithFields(logrus.Fields{
+			"type":        cmd.Type,
+			"characterId": cmd.CharacterId,
+			"points":      cmd.Body.Points,
+			"source":      cmd.Body.Source,
+		}).Debug("Processing bond point award command")
+
+		if cmd.Type != marriageMsg.CommandBondAwardPoints {
+			return
+		}
+
+		// Process the bond point award
+		var marriage marriageService.Marriage
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "marriage_bond_award", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			marriage, err = p.AwardBondPointsAndEmit(transactionId, cmd.CharacterId, cmd.Body.Points, cmd.Body.Source)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
+		if err != nil {
+			l.WithError(err).WithFields(logrus.Fields{
+				"characterId": cmd.CharacterId,
+				"points":      cmd.Body.Points,
+				"source":      cmd.Body.Source,
+			}).Error("Failed to process bond point award")
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"marriageId":  marriage.Id(),
+			"characterId": cmd.CharacterId,
+			"bondPoints":  marriage.BondPoints(),
+			"bondLevel":   marriage.BondLevel(),
+		}).Info("Bond point award processed successfully")
+	}
+}
+
 // handleAdvanceCeremonyState handles ceremony state advancement commands
 func handleAdvanceCeremonyState(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[marriageMsg.Command[marriageMsg.AdvanceCeremonyStateBody]] {
 	return func(l logrus.FieldLogger, ctx context.Context, cmd marriageMsg.Command[marriageMsg.AdvanceCeremonyStateBody]) {
@@ -663,29 +986,21 @@ func handleAdvanceCeremonyState(pp marriageService.ProcessorProducer, db *gorm.D
 			return
 		}
 
-		transactionId := uuid.New()
-
 		// Process the ceremony state advancement
-		ceremony, err := processor.AdvanceCeremonyStateAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.NextState)
+		var ceremony marriageService.Ceremony
+		err := processor.ProcessCommand(cmd.TransactionId, cmd.CharacterId, "ceremony_state_advance", func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			ceremony, err = p.AdvanceCeremonyStateAndEmit(transactionId, cmd.Body.CeremonyId, cmd.Body.NextState)
+			return err
+		})
+		if errors.Is(err, marriageService.ErrTransactionProcessed) {
+			return
+		}
 		if err != nil {
 			l.WithError(err).WithFields(logrus.Fields{
 				"ceremonyId": cmd.Body.CeremonyId,
 				"nextState":  cmd.Body.NextState,
 			}).Error("Failed to advance ceremony state")
-
-			// Emit error event
-			errorProvider := marriageService.MarriageErrorEventProvider(
-				cmd.CharacterId,
-				"CEREMONY_STATE_ADVANCE_FAILED",
-				"CEREMONY_STATE_ADVANCE_ERROR",
-				err.Error(),
-				"ceremony_state_advance",
-			)
-			if emitErr := message.Emit(producer.ProviderImpl(l)(ctx))(func(buf *message.Buffer) error {
-				return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
-			}); emitErr != nil {
-				l.WithError(emitErr).Error("Failed to emit error event for ceremony state advance failure")
-			}
 			return
 		}
 
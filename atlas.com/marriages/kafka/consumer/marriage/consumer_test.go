@@ -7,6 +7,7 @@ import (
 
 	marriageMsg "atlas-marriages/kafka/message/marriage"
 	marriageService "atlas-marriages/marriage"
+	"atlas-marriages/registry"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
 	"github.com/Chronicle20/atlas-model/model"
@@ -22,6 +23,7 @@ import (
 type MockProcessor struct {
 	mock.Mock
 	marriageService.Processor
+	processed map[uuid.UUID]bool
 }
 
 func (m *MockProcessor) ProposeAndEmit(transactionId uuid.UUID, proposerId, targetId uint32) (marriageService.Proposal, error) {
@@ -44,8 +46,8 @@ func (m *MockProcessor) CancelProposalAndEmit(transactionId uuid.UUID, proposalI
 	return args.Get(0).(marriageService.Proposal), args.Error(1)
 }
 
-func (m *MockProcessor) ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32) (marriageService.Ceremony, error) {
-	args := m.Called(transactionId, marriageId, scheduledAt, invitees)
+func (m *MockProcessor) ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier marriageService.VenueTier, venueId uint32) (marriageService.Ceremony, error) {
+	args := m.Called(transactionId, marriageId, scheduledAt, invitees, venueTier, venueId)
 	return args.Get(0).(marriageService.Ceremony), args.Error(1)
 }
 
@@ -89,11 +91,72 @@ func (m *MockProcessor) DivorceAndEmit(transactionId uuid.UUID, marriageId uint3
 	return args.Get(0).(marriageService.Marriage), args.Error(1)
 }
 
+func (m *MockProcessor) FileDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, filedBy uint32) (marriageService.Marriage, error) {
+	args := m.Called(transactionId, marriageId, filedBy)
+	return args.Get(0).(marriageService.Marriage), args.Error(1)
+}
+
+func (m *MockProcessor) ConsentDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, consentedBy uint32) (marriageService.Marriage, error) {
+	args := m.Called(transactionId, marriageId, consentedBy)
+	return args.Get(0).(marriageService.Marriage), args.Error(1)
+}
+
+func (m *MockProcessor) WithdrawDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, withdrawnBy uint32) (marriageService.Marriage, error) {
+	args := m.Called(transactionId, marriageId, withdrawnBy)
+	return args.Get(0).(marriageService.Marriage), args.Error(1)
+}
+
+func (m *MockProcessor) AwardBondPointsAndEmit(transactionId uuid.UUID, characterId uint32, points uint32, source string) (marriageService.Marriage, error) {
+	args := m.Called(transactionId, characterId, points, source)
+	return args.Get(0).(marriageService.Marriage), args.Error(1)
+}
+
 func (m *MockProcessor) AdvanceCeremonyStateAndEmit(transactionId uuid.UUID, ceremonyId uint32, nextState string) (marriageService.Ceremony, error) {
 	args := m.Called(transactionId, ceremonyId, nextState)
 	return args.Get(0).(marriageService.Ceremony), args.Error(1)
 }
 
+func (m *MockProcessor) RespondToInvitationAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, status marriageService.RsvpStatus) (marriageService.Ceremony, error) {
+	args := m.Called(transactionId, ceremonyId, characterId, status)
+	return args.Get(0).(marriageService.Ceremony), args.Error(1)
+}
+
+func (m *MockProcessor) AdvanceCeremonyStageAndEmit(transactionId uuid.UUID, ceremonyId uint32, stage string, advancedBy uint32) (marriageService.Ceremony, error) {
+	args := m.Called(transactionId, ceremonyId, stage, advancedBy)
+	return args.Get(0).(marriageService.Ceremony), args.Error(1)
+}
+
+func (m *MockProcessor) AddRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Item, error) {
+	args := m.Called(transactionId, ceremonyId, characterId, itemId, quantity)
+	return args.Get(0).(registry.Item), args.Error(1)
+}
+
+func (m *MockProcessor) RemoveRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32) (registry.Item, error) {
+	args := m.Called(transactionId, ceremonyId, characterId, itemId)
+	return args.Get(0).(registry.Item), args.Error(1)
+}
+
+func (m *MockProcessor) BlessCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, text string) (registry.Blessing, error) {
+	args := m.Called(transactionId, ceremonyId, characterId, text)
+	return args.Get(0).(registry.Blessing), args.Error(1)
+}
+
+func (m *MockProcessor) GiveGiftAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Gift, error) {
+	args := m.Called(transactionId, ceremonyId, characterId, itemId, quantity)
+	return args.Get(0).(registry.Gift), args.Error(1)
+}
+
+// ProcessCommand executes the operation unless its transaction id has been marked as processed
+func (m *MockProcessor) ProcessCommand(transactionId uuid.UUID, characterId uint32, errorContext string, operation func(marriageService.Processor, uuid.UUID) error) error {
+	if m.processed[transactionId] {
+		return marriageService.ErrTransactionProcessed
+	}
+	if transactionId == uuid.Nil {
+		transactionId = uuid.New()
+	}
+	return operation(m, transactionId)
+}
+
 func TestNewConfig(t *testing.T) {
 	logger, _ := test.NewNullLogger()
 
@@ -258,7 +321,7 @@ func TestHandleScheduleCeremony(t *testing.T) {
 	scheduledAt := time.Now().Add(24 * time.Hour)
 	invitees := []uint32{3, 4}
 
-	mockProcessor.On("ScheduleCeremonyAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), scheduledAt, invitees).Return(ceremony, nil)
+	mockProcessor.On("ScheduleCeremonyAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), scheduledAt, invitees, marriageService.VenueTierStandard, uint32(0)).Return(ceremony, nil)
 
 	handler := handleScheduleCeremony(processorProducer, nil)
 	assert.NotNil(t, handler)
@@ -271,6 +334,7 @@ func TestHandleScheduleCeremony(t *testing.T) {
 			MarriageId:  1,
 			ScheduledAt: scheduledAt,
 			Invitees:    invitees,
+			VenueTier:   string(marriageService.VenueTierStandard),
 		},
 	}
 
@@ -506,6 +570,74 @@ func TestHandleDivorce(t *testing.T) {
 	mockProcessor.AssertExpectations(t)
 }
 
+func TestHandleDivorceFiling(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	marriage, _ := marriageService.NewBuilder(1, 2, uuid.New()).Build()
+	mockProcessor.On("FileDivorceAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), uint32(1)).Return(marriage, nil)
+	mockProcessor.On("ConsentDivorceAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), uint32(2)).Return(marriage, nil)
+	mockProcessor.On("WithdrawDivorceAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), uint32(1)).Return(marriage, nil)
+
+	handleFileDivorce(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.FileDivorceBody]{
+		CharacterId: 1,
+		Type:        marriageMsg.CommandDivorceFile,
+		Body:        marriageMsg.FileDivorceBody{MarriageId: 1},
+	})
+	handleConsentDivorce(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.ConsentDivorceBody]{
+		CharacterId: 2,
+		Type:        marriageMsg.CommandDivorceConsent,
+		Body:        marriageMsg.ConsentDivorceBody{MarriageId: 1},
+	})
+	handleWithdrawDivorce(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.WithdrawDivorceBody]{
+		CharacterId: 1,
+		Type:        marriageMsg.CommandDivorceWithdraw,
+		Body:        marriageMsg.WithdrawDivorceBody{MarriageId: 1},
+	})
+
+	// Commands of other types are ignored
+	handleFileDivorce(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.FileDivorceBody]{
+		CharacterId: 1,
+		Type:        marriageMsg.CommandMarriageDivorce,
+		Body:        marriageMsg.FileDivorceBody{MarriageId: 1},
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "FileDivorceAndEmit", 1)
+}
+
+func TestHandleAwardBondPoints(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	marriage, _ := marriageService.NewBuilder(1, 2, uuid.New()).Build()
+	mockProcessor.On("AwardBondPointsAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(1), uint32(50), "QUEST").Return(marriage, nil)
+
+	handleAwardBondPoints(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.AwardBondPointsBody]{
+		CharacterId: 1,
+		Type:        marriageMsg.CommandBondAwardPoints,
+		Body:        marriageMsg.AwardBondPointsBody{Points: 50, Source: "QUEST"},
+	})
+
+	// Commands of other types are ignored
+	handleAwardBondPoints(processorProducer, nil)(logger, ctx, marriageMsg.Command[marriageMsg.AwardBondPointsBody]{
+		CharacterId: 1,
+		Type:        marriageMsg.CommandMarriageDivorce,
+		Body:        marriageMsg.AwardBondPointsBody{Points: 50, Source: "QUEST"},
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "AwardBondPointsAndEmit", 1)
+}
+
 func TestHandleAdvanceCeremonyState(t *testing.T) {
 	logger, _ := test.NewNullLogger()
 	ctx := context.Background()
@@ -534,3 +666,174 @@ func TestHandleAdvanceCeremonyState(t *testing.T) {
 	handler(logger, ctx, cmd)
 	mockProcessor.AssertExpectations(t)
 }
+
+func TestHandleRespondToInvitation(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	ceremony, _ := marriageService.NewCeremonyBuilder(1, 1, 2, uuid.New()).Build()
+	mockProcessor.On("RespondToInvitationAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), uint32(3), marriageService.RsvpStatusAccepted).Return(ceremony, nil)
+	mockProcessor.On("RespondToInvitationAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), uint32(3), marriageService.RsvpStatusDeclined).Return(ceremony, nil)
+	mockProcessor.On("RespondToInvitationAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), uint32(3), marriageService.RsvpStatusAttended).Return(ceremony, nil)
+
+	handler := handleRespondToInvitation(processorProducer, nil)
+	for _, commandType := range []string{
+		marriageMsg.CommandInvitationAccept,
+		marriageMsg.CommandInvitationDecline,
+		marriageMsg.CommandCeremonyAttend,
+		marriageMsg.CommandMarriageDivorce,
+	} {
+		handler(logger, ctx, marriageMsg.Command[marriageMsg.InvitationResponseBody]{
+			CharacterId: 3,
+			Type:        commandType,
+			Body:        marriageMsg.InvitationResponseBody{CeremonyId: 7},
+		})
+	}
+
+	// Commands of other types are ignored
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "RespondToInvitationAndEmit", 3)
+}
+
+func TestHandleAdvanceCeremonyStage(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	ceremony, _ := marriageService.NewCeremonyBuilder(1, 1, 2, uuid.New()).Build()
+	mockProcessor.On("AdvanceCeremonyStageAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), "VOWS", uint32(9)).Return(ceremony, nil)
+
+	handler := handleAdvanceCeremonyStage(processorProducer, nil)
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.AdvanceCeremonyStageBody]{
+		CharacterId: 9,
+		Type:        marriageMsg.CommandCeremonyAdvanceStage,
+		Body:        marriageMsg.AdvanceCeremonyStageBody{CeremonyId: 7, Stage: "VOWS"},
+	})
+
+	// Commands of other types are ignored
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.AdvanceCeremonyStageBody]{
+		CharacterId: 9,
+		Type:        marriageMsg.CommandCeremonyAdvanceState,
+		Body:        marriageMsg.AdvanceCeremonyStageBody{CeremonyId: 7, Stage: "VOWS"},
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "AdvanceCeremonyStageAndEmit", 1)
+}
+
+func TestHandleBlessCeremony(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	mockProcessor.On("BlessCeremonyAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), uint32(3), "Congratulations!").Return(registry.Blessing{}, nil)
+
+	handler := handleBlessCeremony(processorProducer, nil)
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.BlessCeremonyBody]{
+		CharacterId: 3,
+		Type:        marriageMsg.CommandCeremonyBless,
+		Body:        marriageMsg.BlessCeremonyBody{CeremonyId: 7, Message: "Congratulations!"},
+	})
+
+	// Commands of other types are ignored
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.BlessCeremonyBody]{
+		CharacterId: 3,
+		Type:        marriageMsg.CommandRegistryGiveGift,
+		Body:        marriageMsg.BlessCeremonyBody{CeremonyId: 7},
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "BlessCeremonyAndEmit", 1)
+}
+
+func TestHandleGiveGift(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	mockProcessor.On("GiveGiftAndEmit", mock.AnythingOfType("uuid.UUID"), uint32(7), uint32(3), uint32(2000000), uint32(2)).Return(registry.Gift{}, nil)
+
+	handler := handleGiveGift(processorProducer, nil)
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.GiveGiftBody]{
+		CharacterId: 3,
+		Type:        marriageMsg.CommandRegistryGiveGift,
+		Body:        marriageMsg.GiveGiftBody{CeremonyId: 7, ItemId: 2000000, Quantity: 2},
+	})
+
+	// Commands of other types are ignored
+	handler(logger, ctx, marriageMsg.Command[marriageMsg.GiveGiftBody]{
+		CharacterId: 3,
+		Type:        marriageMsg.CommandRegistryAddItem,
+		Body:        marriageMsg.GiveGiftBody{CeremonyId: 7, ItemId: 2000000, Quantity: 2},
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "GiveGiftAndEmit", 1)
+}
+
+func TestHandleDivorce_DuplicateTransactionReplayed(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	transactionId := uuid.New()
+	mockProcessor.processed = map[uuid.UUID]bool{transactionId: true}
+
+	handler := handleDivorce(processorProducer, nil)
+
+	cmd := marriageMsg.Command[marriageMsg.DivorceBody]{
+		TransactionId: transactionId,
+		CharacterId:   1,
+		Type:          marriageMsg.CommandMarriageDivorce,
+		Body: marriageMsg.DivorceBody{
+			MarriageId: 1,
+		},
+	}
+
+	handler(logger, ctx, cmd)
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNotCalled(t, "DivorceAndEmit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandlePropose_SuppliedTransactionId(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := context.Background()
+	mockProcessor := new(MockProcessor)
+	processorProducer := func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return mockProcessor
+	}
+
+	transactionId := uuid.New()
+	proposal, _ := marriageService.NewProposalBuilder(1, 2, uuid.New()).Build()
+	mockProcessor.On("ProposeAndEmit", transactionId, uint32(1), uint32(2)).Return(proposal, nil)
+
+	handler := handlePropose(processorProducer, nil)
+
+	cmd := marriageMsg.Command[marriageMsg.ProposeBody]{
+		TransactionId: transactionId,
+		CharacterId:   1,
+		Type:          marriageMsg.CommandMarriagePropose,
+		Body: marriageMsg.ProposeBody{
+			TargetCharacterId: 2,
+		},
+	}
+
+	handler(logger, ctx, cmd)
+	mockProcessor.AssertExpectations(t)
+}
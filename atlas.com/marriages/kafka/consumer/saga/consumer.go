@@ -0,0 +1,90 @@
+package saga
+
+import (
+	"context"
+
+	localConsumer "atlas-marriages/kafka/consumer"
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	marriageService "atlas-marriages/marriage"
+
+	"github.com/Chronicle20/atlas-kafka/consumer"
+	"github.com/Chronicle20/atlas-kafka/handler"
+	kafka "github.com/Chronicle20/atlas-kafka/message"
+	"github.com/Chronicle20/atlas-kafka/topic"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// NewConfig creates a new consumer configuration for saga step outcome events
+func NewConfig(l logrus.FieldLogger) func(name string) func(token string) func(groupId string) consumer.Config {
+	return localConsumer.NewConfig(l)
+}
+
+// InitHandlers initializes all saga step outcome event handlers
+func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+	return func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+		return func(rf func(topic string, handler handler.Handler) (string, error)) {
+			var t string
+			t, _ = topic.EnvProvider(l)(sagaMsg.EnvEventTopicStatus)()
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleStepCompleted(marriageService.NewProcessor, db))))
+			_, _ = rf(t, kafka.AdaptHandler(kafka.PersistentConfig(handleStepFailed(marriageService.NewProcessor, db))))
+		}
+	}
+}
+
+// handleStepCompleted handles a participant's report that it completed a saga step
+func handleStepCompleted(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[sagaMsg.StatusEvent[sagaMsg.StepCompletedBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, event sagaMsg.StatusEvent[sagaMsg.StepCompletedBody]) {
+		if event.Type != sagaMsg.EventStepCompleted {
+			return
+		}
+
+		l = l.WithFields(logrus.Fields{
+			"sagaId": event.SagaId,
+			"step":   event.Step,
+		})
+		l.Debug("Processing saga step completed event")
+
+		processor := pp(l, ctx, db)
+		if err := processor.HandleSagaStepCompletedAndEmit(uuid.New(), event.SagaId, event.Step); err != nil {
+			l.WithError(err).Error("Failed to process saga step completion")
+		}
+	}
+}
+
+// handleStepFailed handles a participant's report that it could not complete a saga step
+func handleStepFailed(pp marriageService.ProcessorProducer, db *gorm.DB) kafka.Handler[sagaMsg.StatusEvent[sagaMsg.StepFailedBody]] {
+	return func(l logrus.FieldLogger, ctx context.Context, event sagaMsg.StatusEvent[sagaMsg.StepFailedBody]) {
+		if event.Type != sagaMsg.EventStepFailed {
+			return
+		}
+
+		l = l.WithFields(logrus.Fields{
+			"sagaId": event.SagaId,
+			"step":   event.Step,
+			"reason": event.Body.Reason,
+		})
+		l.Debug("Processing saga step failed event")
+
+		processor := pp(l, ctx, db)
+		if err := processor.HandleSagaStepFailedAndEmit(uuid.New(), event.SagaId, event.Step, event.Body.Reason); err != nil {
+			l.WithError(err).Error("Failed to process saga step failure")
+		}
+	}
+}
+
+// InitConsumers initializes the saga step outcome event consumers
+func InitConsumers(l logrus.FieldLogger) func(func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+	return func(rf func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+		return func(consumerGroupId string) {
+			config := NewConfig(l)("marriage_saga_status")(sagaMsg.EnvEventTopicStatus)(consumerGroupId)
+
+			// Set up header parsers for tenant and span context
+			rf(config,
+				consumer.SetHeaderParsers(consumer.SpanHeaderParser, consumer.TenantHeaderParser),
+			)
+		}
+	}
+}
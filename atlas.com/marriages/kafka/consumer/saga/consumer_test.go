@@ -0,0 +1,88 @@
+package saga
+
+import (
+	"context"
+	"testing"
+
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	marriageService "atlas-marriages/marriage"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockProcessor is a mock for the marriage processor
+type MockProcessor struct {
+	mock.Mock
+	marriageService.Processor
+}
+
+func (m *MockProcessor) HandleSagaStepCompletedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string) error {
+	args := m.Called(transactionId, sagaId, step)
+	return args.Error(0)
+}
+
+func (m *MockProcessor) HandleSagaStepFailedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string, reason string) error {
+	args := m.Called(transactionId, sagaId, step, reason)
+	return args.Error(0)
+}
+
+func mockProducer(m *MockProcessor) marriageService.ProcessorProducer {
+	return func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) marriageService.Processor {
+		return m
+	}
+}
+
+func TestHandleStepCompleted(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	mockProcessor := new(MockProcessor)
+
+	sagaId := uuid.New()
+	mockProcessor.On("HandleSagaStepCompletedAndEmit", mock.AnythingOfType("uuid.UUID"), sagaId, sagaMsg.CommandReserveChapel).Return(nil)
+
+	handler := handleStepCompleted(mockProducer(mockProcessor), nil)
+	handler(logger, context.Background(), sagaMsg.StatusEvent[sagaMsg.StepCompletedBody]{
+		SagaId: sagaId,
+		Step:   sagaMsg.CommandReserveChapel,
+		Type:   sagaMsg.EventStepCompleted,
+	})
+
+	// Events of other types are ignored
+	handler(logger, context.Background(), sagaMsg.StatusEvent[sagaMsg.StepCompletedBody]{
+		SagaId: sagaId,
+		Step:   sagaMsg.CommandReserveChapel,
+		Type:   sagaMsg.EventStepFailed,
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "HandleSagaStepCompletedAndEmit", 1)
+}
+
+func TestHandleStepFailed(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	mockProcessor := new(MockProcessor)
+
+	sagaId := uuid.New()
+	mockProcessor.On("HandleSagaStepFailedAndEmit", mock.AnythingOfType("uuid.UUID"), sagaId, sagaMsg.CommandReserveChapel, "chapel booked").Return(nil)
+
+	handler := handleStepFailed(mockProducer(mockProcessor), nil)
+	handler(logger, context.Background(), sagaMsg.StatusEvent[sagaMsg.StepFailedBody]{
+		SagaId: sagaId,
+		Step:   sagaMsg.CommandReserveChapel,
+		Type:   sagaMsg.EventStepFailed,
+		Body:   sagaMsg.StepFailedBody{Reason: "chapel booked"},
+	})
+
+	// Events of other types are ignored
+	handler(logger, context.Background(), sagaMsg.StatusEvent[sagaMsg.StepFailedBody]{
+		SagaId: sagaId,
+		Step:   sagaMsg.CommandReserveChapel,
+		Type:   sagaMsg.EventStepCompleted,
+	})
+
+	mockProcessor.AssertExpectations(t)
+	mockProcessor.AssertNumberOfCalls(t, "HandleSagaStepFailedAndEmit", 1)
+}
@@ -8,8 +8,16 @@ import (
 
 	"atlas-marriages/character"
 	"atlas-marriages/kafka/consumer/marriage"
+	inventoryMessage "atlas-marriages/kafka/message/inventory"
 	marriageMessage "atlas-marriages/kafka/message/marriage"
+	sagaMessage "atlas-marriages/kafka/message/saga"
 	marriageService "atlas-marriages/marriage"
+	"atlas-marriages/outbox"
+	"atlas-marriages/registry"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+	"atlas-marriages/transaction"
+	"atlas-marriages/venue"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
 	"github.com/Chronicle20/atlas-kafka/handler"
@@ -39,6 +47,20 @@ func (m *mockCharacterProcessor) ByIdProvider(characterId uint32) model.Provider
 	}
 }
 
+// advanceToFinalStage advances an active ceremony through each of its stages so that it may be completed
+func advanceToFinalStage(t *testing.T, processor marriageService.Processor, ceremonyId uint32) {
+	for {
+		ceremony, err := processor.GetCeremonyById(ceremonyId)()
+		require.NoError(t, err)
+		require.NotNil(t, ceremony)
+		if ceremony.StagesCompleted() {
+			return
+		}
+		_, err = processor.AdvanceCeremonyStage(ceremonyId, "")()
+		require.NoError(t, err)
+	}
+}
+
 // TestKafkaIntegration tests the end-to-end message flow
 func TestKafkaIntegration(t *testing.T) {
 	// Create a test database
@@ -48,6 +70,18 @@ func TestKafkaIntegration(t *testing.T) {
 	// Run migrations
 	err = marriageService.Migration(db)
 	require.NoError(t, err)
+	err = outbox.Migration(db)
+	require.NoError(t, err)
+	err = rules.Migration(db)
+	require.NoError(t, err)
+	err = saga.Migration(db)
+	require.NoError(t, err)
+	err = venue.Migration(db)
+	require.NoError(t, err)
+	err = registry.Migration(db)
+	require.NoError(t, err)
+	err = transaction.Migration(db)
+	require.NoError(t, err)
 
 	// Set up test logger
 	logger := logrus.New()
@@ -434,7 +468,8 @@ func testEndToEndMessageFlow(t *testing.T, logger logrus.FieldLogger, ctx contex
 		startedCeremony, err := processor.StartCeremony(ceremony.Id())()
 		require.NoError(t, err)
 
-		// Complete the ceremony
+		// Complete the ceremony once it reaches its final stage
+		advanceToFinalStage(t, processor, startedCeremony.Id())
 		completedCeremony, err := processor.CompleteCeremony(startedCeremony.Id())()
 		require.NoError(t, err)
 		assert.NotNil(t, completedCeremony)
@@ -738,16 +773,27 @@ func testEventEmissionOnStateChanges(t *testing.T, logger logrus.FieldLogger, ct
 		scheduledAt := time.Now().Add(7 * 24 * time.Hour)
 		invitees := []uint32{10007, 10008}
 
-		ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, marriage.Id(), scheduledAt, invitees)
+		ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, marriage.Id(), scheduledAt, invitees, marriageService.VenueTierStandard, 0)
 		require.NoError(t, err)
 		assert.NotNil(t, ceremony)
 
-		// Verify that exactly one event was emitted
-		assert.Len(t, capturedMessages, 1)
+		// Verify that the ceremony scheduled event and the command for the first saga step were emitted. They are
+		// produced to separate topics, so group them by type
+		require.Len(t, capturedMessages, 2)
+		byType := make(map[string][][]byte)
+		for _, m := range capturedMessages {
+			var header struct {
+				Type string `json:"type"`
+			}
+			require.NoError(t, json.Unmarshal(m.Value, &header))
+			byType[header.Type] = append(byType[header.Type], m.Value)
+		}
+		require.Len(t, byType[marriageMessage.EventCeremonyScheduled], 1)
+		require.Len(t, byType[sagaMessage.CommandReserveChapel], 1)
 
 		// Verify the event content
 		var event marriageMessage.Event[marriageMessage.CeremonyScheduledBody]
-		err = json.Unmarshal(capturedMessages[0].Value, &event)
+		err = json.Unmarshal(byType[marriageMessage.EventCeremonyScheduled][0], &event)
 		require.NoError(t, err)
 
 		assert.Equal(t, marriageMessage.EventCeremonyScheduled, event.Type)
@@ -804,18 +850,31 @@ func testEventEmissionOnStateChanges(t *testing.T, logger logrus.FieldLogger, ct
 		assert.False(t, startEvent.Body.StartedAt.IsZero())
 
 		// Clear messages and test ceremony completion event emission
+		advanceToFinalStage(t, processor, ceremony.Id())
 		capturedMessages = []kafka.Message{}
 
 		completedCeremony, err := processor.CompleteCeremonyAndEmit(transactionId, ceremony.Id())
 		require.NoError(t, err)
 		assert.NotNil(t, completedCeremony)
 
-		// Verify that exactly one event was emitted
-		assert.Len(t, capturedMessages, 1)
+		// Verify that both ceremony completed and marriage created events, and a wedding ring command for each
+		// partner, were emitted. Events and commands are produced to separate topics, so group them by type
+		require.Len(t, capturedMessages, 4)
+		byType := make(map[string][][]byte)
+		for _, m := range capturedMessages {
+			var header struct {
+				Type string `json:"type"`
+			}
+			require.NoError(t, json.Unmarshal(m.Value, &header))
+			byType[header.Type] = append(byType[header.Type], m.Value)
+		}
+		require.Len(t, byType[marriageMessage.EventCeremonyCompleted], 1)
+		require.Len(t, byType[marriageMessage.EventMarriageCreated], 1)
+		require.Len(t, byType[inventoryMessage.CommandCreateRing], 2)
 
 		// Verify the event content
 		var completedEvent marriageMessage.Event[marriageMessage.CeremonyCompletedBody]
-		err = json.Unmarshal(capturedMessages[0].Value, &completedEvent)
+		err = json.Unmarshal(byType[marriageMessage.EventCeremonyCompleted][0], &completedEvent)
 		require.NoError(t, err)
 
 		assert.Equal(t, marriageMessage.EventCeremonyCompleted, completedEvent.Type)
@@ -824,6 +883,27 @@ func testEventEmissionOnStateChanges(t *testing.T, logger logrus.FieldLogger, ct
 		assert.Equal(t, marriage.CharacterId1(), completedEvent.Body.CharacterId1)
 		assert.Equal(t, marriage.CharacterId2(), completedEvent.Body.CharacterId2)
 		assert.False(t, completedEvent.Body.CompletedAt.IsZero())
+
+		var createdEvent marriageMessage.Event[marriageMessage.MarriageCreatedBody]
+		err = json.Unmarshal(byType[marriageMessage.EventMarriageCreated][0], &createdEvent)
+		require.NoError(t, err)
+
+		assert.Equal(t, marriageMessage.EventMarriageCreated, createdEvent.Type)
+		assert.Equal(t, marriage.Id(), createdEvent.Body.MarriageId)
+		assert.False(t, createdEvent.Body.MarriedAt.IsZero())
+
+		for i, characterId := range []uint32{marriage.CharacterId1(), marriage.CharacterId2()} {
+			var ringCommand inventoryMessage.Command[inventoryMessage.CreateRingCommandBody]
+			err = json.Unmarshal(byType[inventoryMessage.CommandCreateRing][i], &ringCommand)
+			require.NoError(t, err)
+
+			assert.Equal(t, transactionId, ringCommand.TransactionId)
+			assert.Equal(t, characterId, ringCommand.CharacterId)
+			assert.Equal(t, marriage.Id(), ringCommand.Body.MarriageId)
+			assert.Equal(t, uint32(rules.DefaultWeddingRingItemId), ringCommand.Body.ItemId)
+			assert.NotZero(t, ringCommand.Body.Serial)
+			assert.NotEqual(t, ringCommand.Body.Serial, ringCommand.Body.PartnerSerial)
+		}
 	})
 
 	t.Run("DivorceEventEmission", func(t *testing.T) {
@@ -850,6 +930,7 @@ func testEventEmissionOnStateChanges(t *testing.T, logger logrus.FieldLogger, ct
 		ceremony, err = processor.StartCeremony(ceremony.Id())()
 		require.NoError(t, err)
 
+		advanceToFinalStage(t, processor, ceremony.Id())
 		ceremony, err = processor.CompleteCeremony(ceremony.Id())()
 		require.NoError(t, err)
 
@@ -956,6 +1037,18 @@ func TestMessageHandlerIntegration(t *testing.T) {
 	// Run migrations
 	err = marriageService.Migration(db)
 	require.NoError(t, err)
+	err = outbox.Migration(db)
+	require.NoError(t, err)
+	err = rules.Migration(db)
+	require.NoError(t, err)
+	err = saga.Migration(db)
+	require.NoError(t, err)
+	err = venue.Migration(db)
+	require.NoError(t, err)
+	err = registry.Migration(db)
+	require.NoError(t, err)
+	err = transaction.Migration(db)
+	require.NoError(t, err)
 
 	// Set up test logger
 	logger := logrus.New()
@@ -1027,6 +1120,38 @@ func TestMessageHandlerIntegration(t *testing.T) {
 		assert.Equal(t, cmd.Body.TargetCharacterId, event.Body.TargetCharacterId)
 	})
 
+	t.Run("DuplicateCommandReplaysOriginalEvent", func(t *testing.T) {
+		capturedMessages = []kafka.Message{}
+		transactionId := uuid.New()
+
+		var proposal marriageService.Proposal
+		propose := func(p marriageService.Processor, transactionId uuid.UUID) error {
+			var err error
+			proposal, err = p.ProposeAndEmit(transactionId, 20011, 20012)
+			return err
+		}
+
+		err := processor.ProcessCommand(transactionId, 20011, "marriage_proposal", propose)
+		require.NoError(t, err)
+		require.Len(t, capturedMessages, 1)
+
+		// A redelivery replays the original event rather than proposing again
+		err = processor.ProcessCommand(transactionId, 20011, "marriage_proposal", propose)
+		assert.ErrorIs(t, err, marriageService.ErrTransactionProcessed)
+		require.Len(t, capturedMessages, 2)
+		assert.Equal(t, capturedMessages[0].Value, capturedMessages[1].Value)
+
+		var event marriageMessage.Event[marriageMessage.ProposalCreatedBody]
+		err = json.Unmarshal(capturedMessages[1].Value, &event)
+		require.NoError(t, err)
+		assert.Equal(t, marriageMessage.EventProposalCreated, event.Type)
+		assert.Equal(t, proposal.Id(), event.Body.ProposalId)
+
+		var count int64
+		require.NoError(t, db.Model(&marriageService.ProposalEntity{}).Where("proposer_id = ?", 20011).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
 	t.Run("AcceptCommandHandler", func(t *testing.T) {
 		capturedMessages = []kafka.Message{}
 
@@ -1099,17 +1224,22 @@ func TestMessageHandlerIntegration(t *testing.T) {
 		}
 
 		// Simulate processing the command
-		ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), cmd.Body.MarriageId, cmd.Body.ScheduledAt, cmd.Body.Invitees)
+		ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), cmd.Body.MarriageId, cmd.Body.ScheduledAt, cmd.Body.Invitees, marriageService.VenueTier(cmd.Body.VenueTier), cmd.Body.VenueId)
 		require.NoError(t, err)
 		assert.NotNil(t, ceremony)
 
-		// Verify that CeremonyScheduled event was emitted
-		assert.Len(t, capturedMessages, 1)
-
-		// Verify the event content
+		// Verify that CeremonyScheduled event was emitted alongside the first saga step command
+		require.Len(t, capturedMessages, 2)
 		var event marriageMessage.Event[marriageMessage.CeremonyScheduledBody]
-		err = json.Unmarshal(capturedMessages[0].Value, &event)
-		require.NoError(t, err)
+		for _, m := range capturedMessages {
+			var header struct {
+				Type string `json:"type"`
+			}
+			require.NoError(t, json.Unmarshal(m.Value, &header))
+			if header.Type == marriageMessage.EventCeremonyScheduled {
+				require.NoError(t, json.Unmarshal(m.Value, &event))
+			}
+		}
 
 		assert.Equal(t, marriageMessage.EventCeremonyScheduled, event.Type)
 		assert.Equal(t, ceremony.Id(), event.Body.CeremonyId)
@@ -1137,6 +1267,7 @@ func TestMessageHandlerIntegration(t *testing.T) {
 		ceremony, err = processor.StartCeremony(ceremony.Id())()
 		require.NoError(t, err)
 
+		advanceToFinalStage(t, processor, ceremony.Id())
 		ceremony, err = processor.CompleteCeremony(ceremony.Id())()
 		require.NoError(t, err)
 
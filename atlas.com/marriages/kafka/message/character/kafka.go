@@ -4,6 +4,8 @@ const (
 	EnvEventTopicStatus    = "EVENT_TOPIC_CHARACTER_STATUS"
 	StatusEventTypeCreated = "CREATED"
 	StatusEventTypeDeleted = "DELETED"
+	StatusEventTypeLogin   = "LOGIN"
+	StatusEventTypeLogout  = "LOGOUT"
 )
 
 type StatusEvent[E any] struct {
@@ -19,3 +21,13 @@ type CreatedStatusEventBody struct {
 
 type DeletedStatusEventBody struct {
 }
+
+type LoginStatusEventBody struct {
+	ChannelId byte   `json:"channelId"`
+	MapId     uint32 `json:"mapId"`
+}
+
+type LogoutStatusEventBody struct {
+	ChannelId byte   `json:"channelId"`
+	MapId     uint32 `json:"mapId"`
+}
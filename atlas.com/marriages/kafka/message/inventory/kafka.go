@@ -0,0 +1,54 @@
+package inventory
+
+import "github.com/google/uuid"
+
+const (
+	EnvCommandTopic     = "COMMAND_TOPIC_INVENTORY"
+	EnvEventTopicStatus = "EVENT_TOPIC_INVENTORY_STATUS"
+
+	CommandCreateRing   = "CREATE_RING"
+	CommandTransferItem = "TRANSFER_ITEM"
+
+	EventItemTransferFailed = "ITEM_TRANSFER_FAILED"
+)
+
+type Command[E any] struct {
+	TransactionId uuid.UUID `json:"transactionId"`
+	CharacterId   uint32    `json:"characterId"`
+	Type          string    `json:"type"`
+	Body          E         `json:"body"`
+}
+
+// CreateRingCommandBody requests a ring paired with a partner's ring be created in a character's inventory
+type CreateRingCommandBody struct {
+	ItemId             uint32 `json:"itemId"`
+	Serial             uint64 `json:"serial"`
+	PartnerCharacterId uint32 `json:"partnerCharacterId"`
+	PartnerSerial      uint64 `json:"partnerSerial"`
+	MarriageId         uint32 `json:"marriageId"`
+}
+
+// TransferItemCommandBody requests a quantity of an item be moved from a character's inventory to a recipient's
+type TransferItemCommandBody struct {
+	ItemId               uint32 `json:"itemId"`
+	Quantity             uint32 `json:"quantity"`
+	RecipientCharacterId uint32 `json:"recipientCharacterId"`
+	CeremonyId           uint32 `json:"ceremonyId"`
+}
+
+// StatusEvent reports the outcome of an inventory command. TransactionId correlates the event with the command
+type StatusEvent[E any] struct {
+	TransactionId uuid.UUID `json:"transactionId"`
+	CharacterId   uint32    `json:"characterId"`
+	Type          string    `json:"type"`
+	Body          E         `json:"body"`
+}
+
+// ItemTransferFailedBody reports that the items a transfer command requested could not be moved to the recipient
+type ItemTransferFailedBody struct {
+	ItemId               uint32 `json:"itemId"`
+	Quantity             uint32 `json:"quantity"`
+	RecipientCharacterId uint32 `json:"recipientCharacterId"`
+	CeremonyId           uint32 `json:"ceremonyId"`
+	Reason               string `json:"reason"`
+}
@@ -2,6 +2,8 @@ package marriage
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Topic environment variable names
@@ -24,6 +26,14 @@ const (
 	// Marriage commands
 	CommandMarriageDivorce = "DIVORCE"
 
+	// Divorce filing commands
+	CommandDivorceFile     = "FILE_DIVORCE"
+	CommandDivorceConsent  = "CONSENT_DIVORCE"
+	CommandDivorceWithdraw = "WITHDRAW_DIVORCE"
+
+	// Bond commands
+	CommandBondAwardPoints = "AWARD_BOND_POINTS"
+
 	// Ceremony commands
 	CommandCeremonySchedule         = "SCHEDULE_CEREMONY"
 	CommandCeremonyStart            = "START_CEREMONY"
@@ -34,6 +44,19 @@ const (
 	CommandCeremonyAddInvitee       = "ADD_INVITEE"
 	CommandCeremonyRemoveInvitee    = "REMOVE_INVITEE"
 	CommandCeremonyAdvanceState     = "ADVANCE_CEREMONY_STATE"
+	CommandCeremonyAdvanceStage     = "ADVANCE_CEREMONY_STAGE"
+
+	// Invitation response commands, issued by the invitee
+	CommandInvitationAccept  = "ACCEPT_INVITATION"
+	CommandInvitationDecline = "DECLINE_INVITATION"
+	CommandCeremonyAttend    = "ATTEND_CEREMONY"
+
+	// Registry commands. The couple lists the items they wish for while their ceremony is yet to finish, and invitees
+	// bless the ceremony and give items from the list while it is active
+	CommandRegistryAddItem    = "ADD_REGISTRY_ITEM"
+	CommandRegistryRemoveItem = "REMOVE_REGISTRY_ITEM"
+	CommandCeremonyBless      = "BLESS_CEREMONY"
+	CommandRegistryGiveGift   = "GIVE_GIFT"
 )
 
 // Event Types
@@ -50,6 +73,20 @@ const (
 	EventMarriageDivorced = "MARRIAGE_DIVORCED"
 	EventMarriageDeleted = "MARRIAGE_DELETED"
 
+	// Divorce filing events
+	EventDivorceFiled     = "DIVORCE_FILED"
+	EventDivorceWithdrawn = "DIVORCE_WITHDRAWN"
+
+	// Anniversary events
+	EventMarriageAnniversary = "MARRIAGE_ANNIVERSARY"
+
+	// Bond events
+	EventBondLevelChanged = "BOND_LEVEL_CHANGED"
+
+	// Couple skill events
+	EventCoupleSkillsGranted = "COUPLE_SKILLS_GRANTED"
+	EventCoupleSkillsRevoked = "COUPLE_SKILLS_REVOKED"
+
 	// Ceremony events
 	EventCeremonyScheduled = "CEREMONY_SCHEDULED"
 	EventCeremonyStarted   = "CEREMONY_STARTED"
@@ -59,16 +96,36 @@ const (
 	EventCeremonyRescheduled = "CEREMONY_RESCHEDULED"
 	EventInviteeAdded      = "INVITEE_ADDED"
 	EventInviteeRemoved    = "INVITEE_REMOVED"
+	EventInviteeRsvpChanged = "INVITEE_RSVP_CHANGED"
+	EventCeremonyReminder    = "CEREMONY_REMINDER"
+	EventPartnerDisconnected = "PARTNER_DISCONNECTED"
+	EventPartnerReconnected  = "PARTNER_RECONNECTED"
+	EventCeremonyStageChanged = "CEREMONY_STAGE_CHANGED"
+
+	// Registry events
+	EventRegistryItemAdded   = "REGISTRY_ITEM_ADDED"
+	EventRegistryItemRemoved = "REGISTRY_ITEM_REMOVED"
+	EventCeremonyBlessed     = "CEREMONY_BLESSED"
+	EventGiftGiven           = "GIFT_GIVEN"
+	EventGiftReturned        = "GIFT_RETURNED"
 
 	// Error events
 	EventMarriageError = "MARRIAGE_ERROR"
 )
 
-// Generic command structure
+// Reasons a couple's bond level changed, reported on BOND_LEVEL_CHANGED events
+const (
+	BondReasonAward = "AWARD"
+	BondReasonDecay = "DECAY"
+)
+
+// Generic command structure. TransactionId is supplied by the caller and identifies the command across
+// redeliveries, allowing it to be processed at most once
 type Command[E any] struct {
-	CharacterId uint32 `json:"characterId"`
-	Type        string `json:"type"`
-	Body        E      `json:"body"`
+	TransactionId uuid.UUID `json:"transactionId"`
+	CharacterId   uint32    `json:"characterId"`
+	Type          string    `json:"type"`
+	Body          E         `json:"body"`
 }
 
 // Generic event structure
@@ -105,12 +162,35 @@ type DivorceBody struct {
 	MarriageId uint32 `json:"marriageId"`
 }
 
+// FileDivorceBody represents the body of a divorce filing command
+type FileDivorceBody struct {
+	MarriageId uint32 `json:"marriageId"`
+}
+
+// ConsentDivorceBody represents the body of a divorce consent command
+type ConsentDivorceBody struct {
+	MarriageId uint32 `json:"marriageId"`
+}
+
+// WithdrawDivorceBody represents the body of a divorce withdrawal command
+type WithdrawDivorceBody struct {
+	MarriageId uint32 `json:"marriageId"`
+}
+
+// AwardBondPointsBody represents the body of a command awarding bond points to the marriage of the command's character
+type AwardBondPointsBody struct {
+	Points uint32 `json:"points"`
+	Source string `json:"source"` // QUEST, CO_OP or GIFT
+}
+
 
 // ScheduleCeremonyBody represents the body of a ceremony scheduling command
 type ScheduleCeremonyBody struct {
 	MarriageId  uint32    `json:"marriageId"`
 	ScheduledAt time.Time `json:"scheduledAt"`
 	Invitees    []uint32  `json:"invitees"`
+	VenueTier   string    `json:"venueTier,omitempty"` // STANDARD or PREMIUM, defaulting to STANDARD
+	VenueId     uint32    `json:"venueId,omitempty"`   // Venue to book the slot starting at scheduledAt at, if any
 }
 
 // StartCeremonyBody represents the body of a ceremony start command
@@ -151,6 +231,49 @@ type RemoveInviteeBody struct {
 	CharacterId uint32 `json:"characterId"`
 }
 
+// InvitationResponseBody represents the body of a command in which the command's character accepts or declines their
+// invitation to a ceremony, or attends it
+type InvitationResponseBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+}
+
+// AdvanceCeremonyStageBody represents the body of a command in which the command's character, the officiant, advances an
+// active ceremony to its next stage. When Stage is given it must be the ceremony's next stage
+type AdvanceCeremonyStageBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+	Stage      string `json:"stage,omitempty"`
+}
+
+// AddRegistryItemBody represents the body of a command in which the command's character, one of the couple, adds an
+// item to their ceremony's wish list
+type AddRegistryItemBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+	ItemId     uint32 `json:"itemId"`
+	Quantity   uint32 `json:"quantity"`
+}
+
+// RemoveRegistryItemBody represents the body of a command in which the command's character, one of the couple, removes
+// an item no invitee has given from their ceremony's wish list
+type RemoveRegistryItemBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+	ItemId     uint32 `json:"itemId"`
+}
+
+// BlessCeremonyBody represents the body of a command in which the command's character, an invitee, blesses an active
+// ceremony, optionally with a message to the couple
+type BlessCeremonyBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+	Message    string `json:"message,omitempty"`
+}
+
+// GiveGiftBody represents the body of a command in which the command's character, an invitee, gives items from the
+// couple's wish list during their active ceremony
+type GiveGiftBody struct {
+	CeremonyId uint32 `json:"ceremonyId"`
+	ItemId     uint32 `json:"itemId"`
+	Quantity   uint32 `json:"quantity"`
+}
+
 // AdvanceCeremonyStateBody represents the body of a ceremony state advancement command
 type AdvanceCeremonyStateBody struct {
 	CeremonyId uint32 `json:"ceremonyId"`
@@ -229,6 +352,59 @@ type MarriageDeletedBody struct {
 	Reason         string    `json:"reason"`
 }
 
+// DivorceFiledBody represents the body of a divorce filed event
+type DivorceFiledBody struct {
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	FiledBy      uint32    `json:"filedBy"`
+	FiledAt      time.Time `json:"filedAt"`
+	MaturesAt    time.Time `json:"maturesAt"`
+}
+
+// DivorceWithdrawnBody represents the body of a divorce withdrawn event
+type DivorceWithdrawnBody struct {
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	FiledBy      uint32    `json:"filedBy"`
+	WithdrawnAt  time.Time `json:"withdrawnAt"`
+}
+
+// MarriageAnniversaryBody represents the body of a marriage anniversary event, emitted once for each milestone a
+// marriage reaches
+type MarriageAnniversaryBody struct {
+	MarriageId    uint32    `json:"marriageId"`
+	CharacterId1  uint32    `json:"characterId1"`
+	CharacterId2  uint32    `json:"characterId2"`
+	MarriedAt     time.Time `json:"marriedAt"`
+	MilestoneDays uint32    `json:"milestoneDays"`
+	ReachedAt     time.Time `json:"reachedAt"`
+}
+
+// BondLevelChangedBody represents the body of an event emitted when bond points awarded to a couple, or lost to decay,
+// change their bond level
+type BondLevelChangedBody struct {
+	MarriageId    uint32    `json:"marriageId"`
+	CharacterId1  uint32    `json:"characterId1"`
+	CharacterId2  uint32    `json:"characterId2"`
+	PreviousLevel byte      `json:"previousLevel"`
+	Level         byte      `json:"level"`
+	BondPoints    uint32    `json:"bondPoints"`
+	Reason        string    `json:"reason"` // AWARD or DECAY
+	Source        string    `json:"source,omitempty"`
+	ChangedAt     time.Time `json:"changedAt"`
+}
+
+// CoupleSkillsBody represents the body of an event granting or revoking couple skills. Each partner receives their
+// own event, identified by CharacterId
+type CoupleSkillsBody struct {
+	MarriageId  uint32   `json:"marriageId"`
+	CharacterId uint32   `json:"characterId"`
+	PartnerId   uint32   `json:"partnerId"`
+	SkillIds    []uint32 `json:"skillIds"`
+}
+
 // CeremonyScheduledBody represents the body of a ceremony scheduled event
 type CeremonyScheduledBody struct {
 	CeremonyId   uint32    `json:"ceremonyId"`
@@ -311,14 +487,172 @@ type InviteeRemovedBody struct {
 	RemovedBy    uint32    `json:"removedBy"`
 }
 
+// InviteeRsvpChangedBody represents the body of an event notifying the couple that an invitee accepted or declined
+// their invitation, or attended their ceremony
+type InviteeRsvpChangedBody struct {
+	CeremonyId     uint32    `json:"ceremonyId"`
+	MarriageId     uint32    `json:"marriageId"`
+	CharacterId1   uint32    `json:"characterId1"`
+	CharacterId2   uint32    `json:"characterId2"`
+	InviteeId      uint32    `json:"inviteeId"`
+	PreviousStatus string    `json:"previousStatus"`
+	Status         string    `json:"status"` // ACCEPTED, DECLINED or ATTENDED
+	ChangedAt      time.Time `json:"changedAt"`
+}
+
+// CeremonyReminderBody represents the body of an event reminding the couple and their invitees that a ceremony is
+// about to start
+type CeremonyReminderBody struct {
+	CeremonyId   uint32    `json:"ceremonyId"`
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	ScheduledAt  time.Time `json:"scheduledAt"`
+	Invitees     []uint32  `json:"invitees"`
+}
+
+// PartnerDisconnectedBody represents the body of an event reporting that a partner logged out during their active
+// ceremony. The ceremony is postponed at TimeoutAt unless the partner reconnects
+type PartnerDisconnectedBody struct {
+	CeremonyId     uint32    `json:"ceremonyId"`
+	MarriageId     uint32    `json:"marriageId"`
+	CharacterId1   uint32    `json:"characterId1"`
+	CharacterId2   uint32    `json:"characterId2"`
+	CharacterId    uint32    `json:"characterId"`
+	DisconnectedAt time.Time `json:"disconnectedAt"`
+	TimeoutAt      time.Time `json:"timeoutAt"`
+}
+
+// PartnerReconnectedBody represents the body of an event reporting that a disconnected partner logged back in during
+// their active ceremony
+type PartnerReconnectedBody struct {
+	CeremonyId    uint32    `json:"ceremonyId"`
+	MarriageId    uint32    `json:"marriageId"`
+	CharacterId1  uint32    `json:"characterId1"`
+	CharacterId2  uint32    `json:"characterId2"`
+	CharacterId   uint32    `json:"characterId"`
+	ReconnectedAt time.Time `json:"reconnectedAt"`
+}
+
+// CeremonyStageChangedBody represents the body of an event reporting that an officiant advanced an active ceremony to
+// its next stage. FinalStage is true once the ceremony may be completed
+type CeremonyStageChangedBody struct {
+	CeremonyId    uint32    `json:"ceremonyId"`
+	MarriageId    uint32    `json:"marriageId"`
+	CharacterId1  uint32    `json:"characterId1"`
+	CharacterId2  uint32    `json:"characterId2"`
+	PreviousStage string    `json:"previousStage,omitempty"`
+	Stage         string    `json:"stage"`
+	StageIndex    int       `json:"stageIndex"`
+	FinalStage    bool      `json:"finalStage"`
+	AdvancedBy    uint32    `json:"advancedBy"`
+	ChangedAt     time.Time `json:"changedAt"`
+}
+
+// RegistryItemAddedBody represents the body of an event reporting that the couple added an item to their ceremony's
+// wish list
+type RegistryItemAddedBody struct {
+	CeremonyId   uint32    `json:"ceremonyId"`
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	ItemId       uint32    `json:"itemId"`
+	Quantity     uint32    `json:"quantity"`
+	AddedBy      uint32    `json:"addedBy"`
+	AddedAt      time.Time `json:"addedAt"`
+}
+
+// RegistryItemRemovedBody represents the body of an event reporting that the couple removed an item from their
+// ceremony's wish list
+type RegistryItemRemovedBody struct {
+	CeremonyId   uint32    `json:"ceremonyId"`
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	ItemId       uint32    `json:"itemId"`
+	RemovedBy    uint32    `json:"removedBy"`
+	RemovedAt    time.Time `json:"removedAt"`
+}
+
+// CeremonyBlessedBody represents the body of an event reporting that an invitee blessed an active ceremony.
+// BlessingCount is the number of blessings the ceremony has received
+type CeremonyBlessedBody struct {
+	CeremonyId    uint32    `json:"ceremonyId"`
+	MarriageId    uint32    `json:"marriageId"`
+	CharacterId1  uint32    `json:"characterId1"`
+	CharacterId2  uint32    `json:"characterId2"`
+	CharacterId   uint32    `json:"characterId"`
+	Message       string    `json:"message,omitempty"`
+	BlessingCount uint32    `json:"blessingCount"`
+	BlessedAt     time.Time `json:"blessedAt"`
+}
+
+// GiftGivenBody represents the body of an event reporting that an invitee gave items from the couple's wish list
+// during their active ceremony. Received and Remaining count the item against the wish list, and GiftCount is the
+// number of items the ceremony has received. Delivering the items to the couple is left to the inventory service
+type GiftGivenBody struct {
+	CeremonyId   uint32    `json:"ceremonyId"`
+	MarriageId   uint32    `json:"marriageId"`
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	CharacterId  uint32    `json:"characterId"`
+	ItemId       uint32    `json:"itemId"`
+	Quantity     uint32    `json:"quantity"`
+	Received     uint32    `json:"received"`
+	Remaining    uint32    `json:"remaining"`
+	GiftCount    uint32    `json:"giftCount"`
+	GivenAt      time.Time `json:"givenAt"`
+}
+
+// GiftReturnedBody represents the body of an event reporting that items an invitee gave could not be moved to the
+// couple, so the gift no longer counts against the wish list
+type GiftReturnedBody struct {
+	CeremonyId   uint32 `json:"ceremonyId"`
+	MarriageId   uint32 `json:"marriageId"`
+	CharacterId1 uint32 `json:"characterId1"`
+	CharacterId2 uint32 `json:"characterId2"`
+	CharacterId  uint32 `json:"characterId"`
+	ItemId       uint32 `json:"itemId"`
+	Quantity     uint32 `json:"quantity"`
+	Received     uint32 `json:"received"`
+	Remaining    uint32 `json:"remaining"`
+	GiftCount    uint32 `json:"giftCount"`
+	Reason       string `json:"reason"`
+}
+
 // MarriageErrorBody represents the body of a marriage error event
 type MarriageErrorBody struct {
-	ErrorType   string    `json:"errorType"`
-	ErrorCode   string    `json:"errorCode"`
-	Message     string    `json:"message"`
-	CharacterId uint32    `json:"characterId"`
-	Context     string    `json:"context"`
-	Timestamp   time.Time `json:"timestamp"`
+	ErrorType   string                 `json:"errorType"`
+	ErrorCode   string                 `json:"errorCode"`
+	Message     string                 `json:"message"`
+	CharacterId uint32                 `json:"characterId"`
+	Context     string                 `json:"context"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Violations  []EligibilityViolation `json:"violations,omitempty"`
+	Funds       *FundsShortfall        `json:"funds,omitempty"`
+	Cooldown    *CooldownRemaining     `json:"cooldown,omitempty"`
+}
+
+// CooldownRemaining describes the cooldown a character is in within a marriage error event
+type CooldownRemaining struct {
+	Scope            string    `json:"scope"`
+	RemainingSeconds int64     `json:"remainingSeconds"`
+	Until            time.Time `json:"until"`
+}
+
+// FundsShortfall describes the mesos a character lacked for an operation within a marriage error event
+type FundsShortfall struct {
+	Required  uint32 `json:"required"`
+	Available uint32 `json:"available"`
+}
+
+// EligibilityViolation describes a failed proposal eligibility rule within a marriage error event
+type EligibilityViolation struct {
+	Rule             string `json:"rule"`
+	CharacterId      uint32 `json:"characterId"`
+	Value            string `json:"value"`
+	Message          string `json:"message"`
+	RemainingSeconds int64  `json:"remainingSeconds,omitempty"`
 }
 
 // Error types for MarriageErrorBody
@@ -334,6 +668,8 @@ const (
 	ErrorTypeStateTransition     = "STATE_TRANSITION_ERROR"
 	ErrorTypeInviteeLimit        = "INVITEE_LIMIT_ERROR"
 	ErrorTypeDisconnectionTimeout = "DISCONNECTION_TIMEOUT_ERROR"
+	ErrorTypeItemRequirement     = "ITEM_REQUIREMENT_ERROR"
+	ErrorTypeInsufficientFunds   = "INSUFFICIENT_FUNDS_ERROR"
 )
 
 // Error codes for specific error scenarios
@@ -344,16 +680,49 @@ const (
 	ErrorCodeSelfProposal             = "SELF_PROPOSAL"
 	ErrorCodeGlobalCooldown           = "GLOBAL_COOLDOWN"
 	ErrorCodeTargetCooldown           = "TARGET_COOLDOWN"
+	ErrorCodeRemarriageCooldown       = "REMARRIAGE_COOLDOWN"
+	ErrorCodeExPartnerCooldown        = "EX_PARTNER_COOLDOWN"
 	ErrorCodeProposalExpired          = "PROPOSAL_EXPIRED"
 	ErrorCodeProposalNotFound         = "PROPOSAL_NOT_FOUND"
 	ErrorCodeMarriageNotFound         = "MARRIAGE_NOT_FOUND"
 	ErrorCodeCeremonyNotFound         = "CEREMONY_NOT_FOUND"
+	ErrorCodeVenueNotFound            = "VENUE_NOT_FOUND"
 	ErrorCodeInvalidState             = "INVALID_STATE"
 	ErrorCodeInviteeLimitExceeded     = "INVITEE_LIMIT_EXCEEDED"
 	ErrorCodeInviteeAlreadyInvited    = "INVITEE_ALREADY_INVITED"
 	ErrorCodeInviteeNotFound          = "INVITEE_NOT_FOUND"
+	ErrorCodeInviteeAttended          = "INVITEE_ATTENDED"
+	ErrorCodeInvalidRsvpStatus        = "INVALID_RSVP_STATUS"
 	ErrorCodePartnerDisconnected      = "PARTNER_DISCONNECTED"
 	ErrorCodeCeremonyTimeout          = "CEREMONY_TIMEOUT"
 	ErrorCodeConcurrentProposal       = "CONCURRENT_PROPOSAL"
 	ErrorCodeTenantMismatch           = "TENANT_MISMATCH"
-)
\ No newline at end of file
+	ErrorCodeNotPartner               = "NOT_PARTNER"
+	ErrorCodePartnerInvitee           = "PARTNER_INVITEE"
+	ErrorCodeEngagementRingRequired   = "ENGAGEMENT_RING_REQUIRED"
+	ErrorCodeInsufficientFunds        = "INSUFFICIENT_FUNDS"
+	ErrorCodeInvalidVenueTier         = "INVALID_VENUE_TIER"
+	ErrorCodeVenueSlotUnavailable     = "VENUE_SLOT_UNAVAILABLE"
+	ErrorCodeInvalidVenueSlot         = "INVALID_VENUE_SLOT"
+	ErrorCodeVenueRequired            = "VENUE_REQUIRED"
+	ErrorCodeInvalidVenue             = "INVALID_VENUE"
+	ErrorCodeDivorceFilingRequired    = "DIVORCE_FILING_REQUIRED"
+	ErrorCodeDivorceFiler             = "DIVORCE_FILER"
+	ErrorCodeNotDivorceFiler          = "NOT_DIVORCE_FILER"
+	ErrorCodeAnniversaryNotReached    = "ANNIVERSARY_NOT_REACHED"
+	ErrorCodeAnniversaryRecorded      = "ANNIVERSARY_ALREADY_RECORDED"
+	ErrorCodeInvalidBondSource        = "INVALID_BOND_SOURCE"
+	ErrorCodeInvalidBondPoints        = "INVALID_BOND_POINTS"
+	ErrorCodeInvalidCeremonyStage     = "INVALID_CEREMONY_STAGE"
+	ErrorCodeCeremonyFinalStage       = "CEREMONY_FINAL_STAGE"
+	ErrorCodeCeremonyStagesIncomplete = "CEREMONY_STAGES_INCOMPLETE"
+	ErrorCodeRegistryItemNotFound     = "REGISTRY_ITEM_NOT_FOUND"
+	ErrorCodeRegistryItemListed       = "REGISTRY_ITEM_ALREADY_LISTED"
+	ErrorCodeRegistryItemGiven        = "REGISTRY_ITEM_GIVEN"
+	ErrorCodeInvalidRegistryQuantity  = "INVALID_REGISTRY_QUANTITY"
+	ErrorCodeGiftExceedsRegistry      = "GIFT_EXCEEDS_REGISTRY"
+	ErrorCodeGiftItemRequired         = "GIFT_ITEM_REQUIRED"
+	ErrorCodeAlreadyBlessed           = "ALREADY_BLESSED"
+	ErrorCodeBlessingTooLong          = "BLESSING_TOO_LONG"
+	ErrorCodeInternal                 = "INTERNAL_ERROR"
+)
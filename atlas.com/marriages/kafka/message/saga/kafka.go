@@ -0,0 +1,85 @@
+package saga
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Topic environment variable names
+const (
+	// EnvCommandTopic carries the step and compensation commands sent to the services participating in a saga
+	EnvCommandTopic = "COMMAND_TOPIC_MARRIAGE_SAGA"
+
+	// EnvEventTopicStatus carries the step outcomes reported by the services participating in a saga
+	EnvEventTopicStatus = "EVENT_TOPIC_MARRIAGE_SAGA_STATUS"
+)
+
+// Command Types
+const (
+	// Step commands
+	CommandReserveChapel = "RESERVE_CHAPEL"
+	CommandWarpGuests    = "WARP_GUESTS"
+
+	// Compensation commands
+	CommandReleaseChapel = "RELEASE_CHAPEL"
+	CommandRefundFee     = "REFUND_FEE"
+)
+
+// Event Types
+const (
+	EventStepCompleted = "STEP_COMPLETED"
+	EventStepFailed    = "STEP_FAILED"
+)
+
+// Command is sent to a participant to perform or compensate a saga step. SagaId correlates the participant's
+// outcome event with the saga
+type Command[E any] struct {
+	SagaId     uuid.UUID `json:"sagaId"`
+	CeremonyId uint32    `json:"ceremonyId"`
+	Type       string    `json:"type"`
+	Body       E         `json:"body"`
+}
+
+// ReserveChapelBody requests the chapel be reserved for a ceremony
+type ReserveChapelBody struct {
+	CharacterId1 uint32    `json:"characterId1"`
+	CharacterId2 uint32    `json:"characterId2"`
+	ScheduledAt  time.Time `json:"scheduledAt"`
+}
+
+// ReleaseChapelBody releases the chapel reserved for a ceremony
+type ReleaseChapelBody struct {
+	CharacterId1 uint32 `json:"characterId1"`
+	CharacterId2 uint32 `json:"characterId2"`
+}
+
+// RefundFeeBody returns the ceremony fee debited from a character when the ceremony was scheduled. TransactionId
+// identifies the economy transaction which debited the fee
+type RefundFeeBody struct {
+	CharacterId   uint32    `json:"characterId"`
+	Amount        uint32    `json:"amount"`
+	TransactionId uuid.UUID `json:"transactionId"`
+}
+
+// WarpGuestsBody requests the couple and their invitees be warped to the chapel
+type WarpGuestsBody struct {
+	CharacterIds []uint32 `json:"characterIds"`
+}
+
+// StatusEvent reports the outcome of a saga step. Step names the command type the participant performed
+type StatusEvent[E any] struct {
+	SagaId uuid.UUID `json:"sagaId"`
+	Step   string    `json:"step"`
+	Type   string    `json:"type"`
+	Body   E         `json:"body"`
+}
+
+// StepCompletedBody reports that a participant completed a step
+type StepCompletedBody struct {
+}
+
+// StepFailedBody reports that a participant could not complete a step
+type StepFailedBody struct {
+	Reason string `json:"reason"`
+}
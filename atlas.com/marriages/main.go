@@ -3,12 +3,20 @@ package main
 import (
 	"atlas-marriages/database"
 	"atlas-marriages/kafka/consumer/character"
+	inventoryConsumer "atlas-marriages/kafka/consumer/inventory"
 	"atlas-marriages/kafka/consumer/marriage"
+	sagaConsumer "atlas-marriages/kafka/consumer/saga"
 	"atlas-marriages/logger"
 	marriageService "atlas-marriages/marriage"
+	"atlas-marriages/outbox"
+	"atlas-marriages/registry"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
 	"atlas-marriages/scheduler"
 	"atlas-marriages/service"
 	"atlas-marriages/tracing"
+	"atlas-marriages/transaction"
+	"atlas-marriages/venue"
 	"os"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
@@ -49,7 +57,7 @@ func main() {
 		l.WithError(err).Fatal("Unable to initialize tracer.")
 	}
 
-	db := database.Connect(l, database.SetMigrations(marriageService.Migration))
+	db := database.Connect(l, database.SetMigrations(marriageService.Migration, outbox.Migration, rules.Migration, saga.Migration, venue.Migration, registry.Migration, transaction.Migration))
 
 	// Initialize proposal expiry scheduler
 	proposalExpiryScheduler := scheduler.NewProposalExpiryScheduler(l, tdm.Context(), db)
@@ -59,18 +67,52 @@ func main() {
 	ceremonyTimeoutScheduler := scheduler.NewCeremonyTimeoutScheduler(l, tdm.Context(), db)
 	ceremonyTimeoutScheduler.Start()
 
+	// Initialize ceremony start scheduler
+	ceremonyStartScheduler := scheduler.NewCeremonyStartScheduler(l, tdm.Context(), db)
+	ceremonyStartScheduler.Start()
+
+	// Initialize saga timeout scheduler
+	sagaTimeoutScheduler := scheduler.NewSagaTimeoutScheduler(l, tdm.Context(), db)
+	sagaTimeoutScheduler.Start()
+
+	// Initialize divorce finalization scheduler
+	divorceFinalizationScheduler := scheduler.NewDivorceFinalizationScheduler(l, tdm.Context(), db)
+	divorceFinalizationScheduler.Start()
+
+	// Initialize anniversary scheduler
+	anniversaryScheduler := scheduler.NewAnniversaryScheduler(l, tdm.Context(), db)
+	anniversaryScheduler.Start()
+
+	// Initialize bond decay scheduler
+	bondDecayScheduler := scheduler.NewBondDecayScheduler(l, tdm.Context(), db)
+	bondDecayScheduler.Start()
+
+	// Initialize outbox relay
+	outboxRelay := outbox.NewRelay(l, tdm.Context(), db)
+	outboxRelay.Start()
+
 	// Register scheduler teardowns
 	tdm.TeardownFunc(func() {
 		proposalExpiryScheduler.Stop()
 		ceremonyTimeoutScheduler.Stop()
+		ceremonyStartScheduler.Stop()
+		sagaTimeoutScheduler.Stop()
+		divorceFinalizationScheduler.Stop()
+		anniversaryScheduler.Stop()
+		bondDecayScheduler.Stop()
+		outboxRelay.Stop()
 	})
 
 	// Initialize Kafka consumers
 	cmf := consumer.GetManager().AddConsumer(l, tdm.Context(), tdm.WaitGroup())
 	marriage.InitConsumers(l)(cmf)(consumerGroupId)
 	character.InitConsumers(l)(cmf)(consumerGroupId)
+	sagaConsumer.InitConsumers(l)(cmf)(consumerGroupId)
+	inventoryConsumer.InitConsumers(l)(cmf)(consumerGroupId)
 	marriage.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
 	character.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
+	sagaConsumer.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
+	inventoryConsumer.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
 
 	server.New(l).
 		WithContext(tdm.Context()).
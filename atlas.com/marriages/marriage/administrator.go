@@ -3,20 +3,31 @@ package marriage
 import (
 	"time"
 
+	"atlas-marriages/rules"
+
 	"github.com/Chronicle20/atlas-model/model"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CreateProposal creates a new proposal in the database
 func CreateProposal(db *gorm.DB, log logrus.FieldLogger) func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[ProposalEntity] {
 	return func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[ProposalEntity] {
+		return CreateProposalWithRing(db, log)(proposerId, targetId, tenantId, 0, nil)
+	}
+}
+
+// CreateProposalWithRing creates a new proposal in the database holding the given engagement ring reservation
+func CreateProposalWithRing(db *gorm.DB, log logrus.FieldLogger) func(proposerId, targetId uint32, tenantId uuid.UUID, ringItemId uint32, ringReservationId *uuid.UUID) model.Provider[ProposalEntity] {
+	return func(proposerId, targetId uint32, tenantId uuid.UUID, ringItemId uint32, ringReservationId *uuid.UUID) model.Provider[ProposalEntity] {
 		return func() (ProposalEntity, error) {
 			log.WithFields(logrus.Fields{
 				"proposerId": proposerId,
 				"targetId":   targetId,
 				"tenantId":   tenantId,
+				"ringItemId": ringItemId,
 			}).Debug("Creating proposal entity")
 
 			// Create new proposal entity
@@ -26,11 +37,14 @@ func CreateProposal(db *gorm.DB, log logrus.FieldLogger) func(proposerId, target
 				TargetId:       targetId,
 				Status:         ProposalStatusPending,
 				ProposedAt:     now,
-				ExpiresAt:      now.Add(ProposalExpiryDuration),
+				ExpiresAt:      now.Add(rules.ForTenant(log, db)(tenantId).ProposalExpiry()),
 				RejectionCount: 0,
 				TenantId:       tenantId,
 				CreatedAt:      now,
 				UpdatedAt:      now,
+
+				RingItemId:        ringItemId,
+				RingReservationId: ringReservationId,
 			}
 
 			if err := db.Create(&entity).Error; err != nil {
@@ -105,9 +119,44 @@ func UpdateMarriage(db *gorm.DB, log logrus.FieldLogger) func(marriage Marriage)
 	}
 }
 
-// CreateCeremony creates a new ceremony in the database
+// CreateAnniversary records that a marriage reached an anniversary milestone in the database
+func CreateAnniversary(db *gorm.DB, log logrus.FieldLogger) func(marriageId, milestoneDays uint32, reachedAt time.Time, tenantId uuid.UUID) model.Provider[AnniversaryEntity] {
+	return func(marriageId, milestoneDays uint32, reachedAt time.Time, tenantId uuid.UUID) model.Provider[AnniversaryEntity] {
+		return func() (AnniversaryEntity, error) {
+			log.WithFields(logrus.Fields{
+				"marriageId":    marriageId,
+				"milestoneDays": milestoneDays,
+				"tenantId":      tenantId,
+			}).Debug("Creating anniversary entity")
+
+			entity := AnniversaryEntity{
+				TenantId:      tenantId,
+				MarriageId:    marriageId,
+				MilestoneDays: milestoneDays,
+				ReachedAt:     reachedAt,
+				CreatedAt:     time.Now(),
+			}
+
+			if err := db.Create(&entity).Error; err != nil {
+				return AnniversaryEntity{}, err
+			}
+
+			return entity, nil
+		}
+	}
+}
+
+// CreateCeremony creates a new free ceremony at a standard venue in the database
 func CreateCeremony(db *gorm.DB, log logrus.FieldLogger) func(marriageId, characterId1, characterId2 uint32, scheduledAt time.Time, invitees []uint32, tenantId uuid.UUID) model.Provider[CeremonyEntity] {
 	return func(marriageId, characterId1, characterId2 uint32, scheduledAt time.Time, invitees []uint32, tenantId uuid.UUID) model.Provider[CeremonyEntity] {
+		return CreateCeremonyWithPayment(db, log)(marriageId, characterId1, characterId2, scheduledAt, invitees, VenueTierStandard, 0, nil, 0, 0, tenantId)
+	}
+}
+
+// CreateCeremonyWithPayment creates a new ceremony in the database, recording its venue tier, the economy transaction
+// which paid for it and the venue it has booked. A zero invitee limit falls back to the tenant's rules
+func CreateCeremonyWithPayment(db *gorm.DB, log logrus.FieldLogger) func(marriageId, characterId1, characterId2 uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, cost uint32, paymentId *uuid.UUID, venueId uint32, maxInvitees int, tenantId uuid.UUID) model.Provider[CeremonyEntity] {
+	return func(marriageId, characterId1, characterId2 uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, cost uint32, paymentId *uuid.UUID, venueId uint32, maxInvitees int, tenantId uuid.UUID) model.Provider[CeremonyEntity] {
 		return func() (CeremonyEntity, error) {
 			log.WithFields(logrus.Fields{
 				"marriageId":   marriageId,
@@ -115,15 +164,28 @@ func CreateCeremony(db *gorm.DB, log logrus.FieldLogger) func(marriageId, charac
 				"characterId2": characterId2,
 				"scheduledAt":  scheduledAt,
 				"invitees":     len(invitees),
+				"venueTier":    venueTier,
+				"cost":         cost,
+				"venueId":      venueId,
 				"tenantId":     tenantId,
 			}).Debug("Creating ceremony entity")
 
+			tenantRules := rules.ForTenant(log, db)(tenantId)
+			if maxInvitees <= 0 {
+				maxInvitees = tenantRules.MaxInvitees()
+			}
+
 			// Create new ceremony entity
 			now := time.Now()
 			inviteesJSON, err := inviteesToJSON(invitees)
 			if err != nil {
 				return CeremonyEntity{}, err
 			}
+			// The stages are fixed when scheduled, so a change to the tenant's rules does not alter ceremonies in progress
+			stagesJSON, err := stagesToJSON(tenantRules.CeremonyStages())
+			if err != nil {
+				return CeremonyEntity{}, err
+			}
 
 			entity := CeremonyEntity{
 				MarriageId:   marriageId,
@@ -132,9 +194,15 @@ func CreateCeremony(db *gorm.DB, log logrus.FieldLogger) func(marriageId, charac
 				Status:       CeremonyStatusScheduled,
 				ScheduledAt:  scheduledAt,
 				Invitees:     inviteesJSON,
+				MaxInvitees:  maxInvitees,
 				TenantId:     tenantId,
 				CreatedAt:    now,
 				UpdatedAt:    now,
+				VenueTier:    venueTier,
+				Cost:         cost,
+				PaymentId:    paymentId,
+				VenueId:      venueId,
+				Stages:       stagesJSON,
 			}
 
 			if err := db.Create(&entity).Error; err != nil {
@@ -160,4 +228,26 @@ func UpdateCeremony(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32,
 			return entity, nil
 		}
 	}
-}
\ No newline at end of file
+}
+
+// RecordPresence records whether a tenant's character is logged in, replacing the presence previously recorded
+func RecordPresence(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32, online bool, tenantId uuid.UUID) error {
+	return func(characterId uint32, online bool, tenantId uuid.UUID) error {
+		log.WithFields(logrus.Fields{
+			"characterId": characterId,
+			"online":      online,
+			"tenantId":    tenantId,
+		}).Debug("Recording character presence")
+
+		entity := PresenceEntity{
+			TenantId:    tenantId,
+			CharacterId: characterId,
+			Online:      online,
+			UpdatedAt:   time.Now(),
+		}
+		return db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "character_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"online", "updated_at"}),
+		}).Create(&entity).Error
+	}
+}
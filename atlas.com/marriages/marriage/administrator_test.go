@@ -48,6 +48,8 @@ func TestCreateProposal(t *testing.T) {
 						sqlmock.AnyArg(), // tenant_id
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
+						uint32(0),        // ring_item_id
+						sqlmock.AnyArg(), // ring_reservation_id (nil)
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mock.ExpectCommit()
@@ -77,6 +79,8 @@ func TestCreateProposal(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						uint32(0),
+						sqlmock.AnyArg(),
 					).
 					WillReturnError(gorm.ErrInvalidTransaction)
 				mock.ExpectRollback()
@@ -171,6 +175,8 @@ func TestUpdateProposal(t *testing.T) {
 						tenantId,                 // tenant_id
 						sqlmock.AnyArg(),         // created_at
 						sqlmock.AnyArg(),         // updated_at
+						uint32(0),                // ring_item_id
+						sqlmock.AnyArg(),         // ring_reservation_id
 						uint32(123),              // id
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -202,6 +208,9 @@ func TestUpdateProposal(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
 						uint32(123),
 					).
 					WillReturnError(gorm.ErrInvalidTransaction)
@@ -284,9 +293,14 @@ func TestCreateMarriage(t *testing.T) {
 						sqlmock.AnyArg(), // engaged_at (nil)
 						sqlmock.AnyArg(), // married_at (nil)
 						sqlmock.AnyArg(), // divorced_at (nil)
+						sqlmock.AnyArg(), // deleted_at (nil)
+						sqlmock.AnyArg(), // deletion_reason
 						sqlmock.AnyArg(), // tenant_id
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
+						sqlmock.AnyArg(), // ring_item_id
+						sqlmock.AnyArg(), // ring_serial1
+						sqlmock.AnyArg(), // ring_serial2
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mock.ExpectCommit()
@@ -315,6 +329,11 @@ func TestCreateMarriage(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
 					).
 					WillReturnError(gorm.ErrInvalidTransaction)
 				mock.ExpectRollback()
@@ -404,9 +423,14 @@ func TestUpdateMarriage(t *testing.T) {
 						sqlmock.AnyArg(), // engaged_at
 						sqlmock.AnyArg(), // married_at
 						sqlmock.AnyArg(), // divorced_at
+						sqlmock.AnyArg(), // deleted_at
+						sqlmock.AnyArg(), // deletion_reason
 						tenantId,         // tenant_id
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
+						sqlmock.AnyArg(), // ring_item_id
+						sqlmock.AnyArg(), // ring_serial1
+						sqlmock.AnyArg(), // ring_serial2
 						uint32(456),      // id
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -437,6 +461,11 @@ func TestUpdateMarriage(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
 						uint32(456),
 					).
 					WillReturnError(gorm.ErrInvalidTransaction)
@@ -533,9 +562,13 @@ func TestCreateCeremony(t *testing.T) {
 						sqlmock.AnyArg(),         // cancelled_at (nil)
 						sqlmock.AnyArg(),         // postponed_at (nil)
 						sqlmock.AnyArg(),         // invitees JSON
+						MaxInvitees,              // max_invitees
 						tenantId,                 // tenant_id
 						sqlmock.AnyArg(),         // created_at
 						sqlmock.AnyArg(),         // updated_at
+						VenueTierStandard,        // venue_tier
+						uint32(0),                // cost
+						sqlmock.AnyArg(),         // payment_id (nil)
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mock.ExpectCommit()
@@ -568,6 +601,7 @@ func TestCreateCeremony(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
 						tenantId,
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
@@ -667,9 +701,13 @@ func TestUpdateCeremony(t *testing.T) {
 						sqlmock.AnyArg(),         // cancelled_at
 						sqlmock.AnyArg(),         // postponed_at
 						sqlmock.AnyArg(),         // invitees
+						sqlmock.AnyArg(),         // max_invitees
 						tenantId,                 // tenant_id
 						sqlmock.AnyArg(),         // created_at
 						sqlmock.AnyArg(),         // updated_at
+						sqlmock.AnyArg(),         // venue_tier
+						sqlmock.AnyArg(),         // cost
+						sqlmock.AnyArg(),         // payment_id
 						uint32(123),              // id
 					).
 					WillReturnResult(sqlmock.NewResult(1, 1))
@@ -706,6 +744,7 @@ func TestUpdateCeremony(t *testing.T) {
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
 						sqlmock.AnyArg(),
+						sqlmock.AnyArg(),
 						uint32(123),
 					).
 					WillReturnError(gorm.ErrInvalidTransaction)
@@ -0,0 +1,182 @@
+package marriage
+
+import (
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Anniversary is the immutable record of a marriage reaching an anniversary milestone
+type Anniversary struct {
+	id            uint32
+	marriageId    uint32
+	milestoneDays uint32
+	reachedAt     time.Time
+	recordedAt    time.Time
+	tenantId      uuid.UUID
+}
+
+// Id returns the anniversary ID
+func (a Anniversary) Id() uint32 {
+	return a.id
+}
+
+// MarriageId returns the ID of the marriage which reached the milestone
+func (a Anniversary) MarriageId() uint32 {
+	return a.marriageId
+}
+
+// MilestoneDays returns the number of days married the milestone celebrates
+func (a Anniversary) MilestoneDays() uint32 {
+	return a.milestoneDays
+}
+
+// ReachedAt returns when the marriage reached the milestone
+func (a Anniversary) ReachedAt() time.Time {
+	return a.reachedAt
+}
+
+// RecordedAt returns when the milestone was celebrated
+func (a Anniversary) RecordedAt() time.Time {
+	return a.recordedAt
+}
+
+// TenantId returns the tenant ID
+func (a Anniversary) TenantId() uuid.UUID {
+	return a.tenantId
+}
+
+// RecordAnniversary records that an active marriage has reached an anniversary milestone. Each milestone is recorded
+// only once per marriage
+func (p *ProcessorImpl) RecordAnniversary(marriageId uint32, milestoneDays uint32) model.Provider[Anniversary] {
+	return func() (Anniversary, error) {
+		_, anniversary, err := p.recordAnniversary(marriageId, milestoneDays)
+		return anniversary, err
+	}
+}
+
+// recordAnniversary records an anniversary milestone, returning the marriage which reached it alongside the record
+func (p *ProcessorImpl) recordAnniversary(marriageId uint32, milestoneDays uint32) (Marriage, Anniversary, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId":    marriageId,
+		"milestoneDays": milestoneDays,
+	}).Debug("Recording marriage anniversary")
+
+	t := tenant.MustFromContext(p.ctx)
+
+	marriage, err := p.getMarriage(marriageId)
+	if err != nil {
+		return Marriage{}, Anniversary{}, err
+	}
+	if !marriage.HasReachedAnniversary(milestoneDays, time.Now()) {
+		return Marriage{}, Anniversary{}, ErrAnniversaryNotReached
+	}
+
+	existing, err := GetAnniversaryProvider(p.db, p.log)(marriageId, milestoneDays, t.Id())()
+	if err != nil {
+		return Marriage{}, Anniversary{}, err
+	}
+	if existing != nil {
+		return Marriage{}, Anniversary{}, ErrAnniversaryRecorded
+	}
+
+	reachedAt, _ := marriage.AnniversaryAt(milestoneDays)
+	entity, err := CreateAnniversary(p.db, p.log)(marriageId, milestoneDays, reachedAt, t.Id())()
+	if err != nil {
+		return Marriage{}, Anniversary{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"marriageId":    marriageId,
+		"milestoneDays": milestoneDays,
+	}).Info("Marriage anniversary recorded")
+
+	return marriage, MakeAnniversary(entity), nil
+}
+
+// RecordAnniversaryAndEmit records an anniversary milestone and emits a MarriageAnniversary event in the same transaction,
+// so that the event is emitted exactly once for each milestone
+func (p *ProcessorImpl) RecordAnniversaryAndEmit(transactionId uuid.UUID, marriageId uint32, milestoneDays uint32) (Anniversary, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Anniversary, error) {
+		marriage, anniversary, err := p.recordAnniversary(marriageId, milestoneDays)
+		if err != nil {
+			return Anniversary{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := MarriageAnniversaryEventProvider(
+				marriage.Id(),
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				*marriage.MarriedAt(),
+				anniversary.MilestoneDays(),
+				anniversary.ReachedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Anniversary{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"marriageId":    marriageId,
+			"milestoneDays": milestoneDays,
+		}).Debug("MarriageAnniversary event emitted")
+
+		return anniversary, nil
+	})
+}
+
+// GetAnniversaries retrieves every anniversary milestone a marriage has celebrated, earliest first
+func (p *ProcessorImpl) GetAnniversaries(marriageId uint32) model.Provider[[]Anniversary] {
+	return func() ([]Anniversary, error) {
+		t := tenant.MustFromContext(p.ctx)
+		return GetAnniversariesByMarriageProvider(p.db, p.log)(marriageId, t.Id())()
+	}
+}
+
+// ProcessAnniversaries celebrates every anniversary milestone of the tenant which active marriages have reached but
+// not yet celebrated
+func (p *ProcessorImpl) ProcessAnniversaries() error {
+	p.log.Debug("Processing marriage anniversaries")
+
+	t := tenant.MustFromContext(p.ctx)
+
+	for _, milestoneDays := range p.rules().AnniversaryMilestones() {
+		marriages, err := GetMarriagesReachingAnniversaryProvider(p.db, p.log)(milestoneDays, t.Id())()
+		if err != nil {
+			p.log.WithError(err).WithField("milestoneDays", milestoneDays).Error("Failed to retrieve marriages reaching anniversary")
+			return err
+		}
+
+		if len(marriages) == 0 {
+			continue
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"milestoneDays": milestoneDays,
+			"count":         len(marriages),
+		}).Info("Processing marriage anniversaries")
+
+		for _, marriage := range marriages {
+			if _, err := p.RecordAnniversaryAndEmit(uuid.New(), marriage.Id(), milestoneDays); err != nil {
+				p.log.WithFields(logrus.Fields{
+					"marriageId":    marriage.Id(),
+					"milestoneDays": milestoneDays,
+					"error":         err,
+				}).Error("Failed to record marriage anniversary")
+				// Continue processing other marriages even if one fails
+				continue
+			}
+		}
+	}
+
+	return nil
+}
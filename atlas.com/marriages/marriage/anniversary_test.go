@@ -0,0 +1,186 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupAnniversaryTest creates a processor emitting to a mock producer, for a tenant celebrating the default milestones
+func setupAnniversaryTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor, *MockProducer) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	rules.GetRegistry().Invalidate(tenantId)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).WithProducer(producer.Provider)
+	return db, tenantId, processor, producer
+}
+
+// createMarriedCouple creates a marriage between two characters which married the given number of days ago
+func createMarriedCouple(t *testing.T, db *gorm.DB, tenantId uuid.UUID, characterId1, characterId2 uint32, status MarriageStatus, daysMarried int) Entity {
+	now := time.Now()
+	marriedAt := now.Add(-time.Duration(daysMarried)*24*time.Hour - time.Minute)
+	entity := Entity{
+		CharacterId1: characterId1,
+		CharacterId2: characterId2,
+		Status:       status,
+		ProposedAt:   marriedAt,
+		EngagedAt:    &marriedAt,
+		MarriedAt:    &marriedAt,
+		TenantId:     tenantId,
+		CreatedAt:    marriedAt,
+		UpdatedAt:    now,
+	}
+	if status == StatusDivorced {
+		entity.DivorcedAt = &now
+	}
+	if err := db.Create(&entity).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+	return entity
+}
+
+// anniversaryEvents returns the marriage anniversary events among the produced messages
+func anniversaryEvents(t *testing.T, producer *MockProducer) []marriageMsg.MarriageAnniversaryBody {
+	var events []marriageMsg.MarriageAnniversaryBody
+	for _, m := range producer.GetProducedMessages() {
+		var event marriageMsg.Event[marriageMsg.MarriageAnniversaryBody]
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		if event.Type == marriageMsg.EventMarriageAnniversary {
+			events = append(events, event.Body)
+		}
+	}
+	return events
+}
+
+func TestProcessor_ProcessAnniversaries(t *testing.T) {
+	db, tenantId, processor, producer := setupAnniversaryTest(t)
+
+	newlyweds := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 3)
+	monthOld := createMarriedCouple(t, db, tenantId, 3, 4, StatusMarried, 31)
+	filing := createMarriedCouple(t, db, tenantId, 5, 6, StatusDivorcePending, 8)
+	createMarriedCouple(t, db, tenantId, 7, 8, StatusDivorced, 400)
+
+	if err := processor.ProcessAnniversaries(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reached := map[uint32][]uint32{}
+	for _, event := range anniversaryEvents(t, producer) {
+		reached[event.MarriageId] = append(reached[event.MarriageId], event.MilestoneDays)
+		if event.ReachedAt.After(time.Now()) {
+			t.Errorf("Expected milestone %d to have been reached, got %v", event.MilestoneDays, event.ReachedAt)
+		}
+	}
+	if len(reached) != 2 {
+		t.Fatalf("Expected anniversaries for 2 marriages, got %v", reached)
+	}
+	if got := reached[monthOld.ID]; len(got) != 2 || got[0] != 7 || got[1] != 30 {
+		t.Errorf("Expected the month old marriage to celebrate 7 and 30 days, got %v", got)
+	}
+	if got := reached[filing.ID]; len(got) != 1 || got[0] != 7 {
+		t.Errorf("Expected a marriage with a pending divorce to celebrate 7 days, got %v", got)
+	}
+	if _, ok := reached[newlyweds.ID]; ok {
+		t.Error("Expected no anniversary for a marriage of 3 days")
+	}
+
+	anniversaries, err := processor.GetAnniversaries(monthOld.ID)()
+	if err != nil || len(anniversaries) != 2 {
+		t.Fatalf("Expected 2 recorded anniversaries, got %d (%v)", len(anniversaries), err)
+	}
+
+	// Milestones already celebrated are not emitted again
+	producer.ClearMessages()
+	if err := processor.ProcessAnniversaries(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if events := anniversaryEvents(t, producer); len(events) != 0 {
+		t.Errorf("Expected no repeated anniversaries, got %d", len(events))
+	}
+}
+
+func TestProcessor_RecordAnniversary(t *testing.T) {
+	db, tenantId, processor, _ := setupAnniversaryTest(t)
+	marriage := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+
+	if _, err := processor.RecordAnniversaryAndEmit(uuid.New(), marriage.ID, 30); !errors.Is(err, ErrAnniversaryNotReached) {
+		t.Errorf("Expected anniversary not reached, got %v", err)
+	}
+
+	anniversary, err := processor.RecordAnniversaryAndEmit(uuid.New(), marriage.ID, 7)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if anniversary.MarriageId() != marriage.ID || anniversary.MilestoneDays() != 7 {
+		t.Errorf("Unexpected anniversary: marriage %d, %d days", anniversary.MarriageId(), anniversary.MilestoneDays())
+	}
+	if want := marriage.MarriedAt.Add(7 * 24 * time.Hour); !anniversary.ReachedAt().Equal(want) {
+		t.Errorf("Expected milestone reached at %v, got %v", want, anniversary.ReachedAt())
+	}
+
+	if _, err := processor.RecordAnniversaryAndEmit(uuid.New(), marriage.ID, 7); !errors.Is(err, ErrAnniversaryRecorded) {
+		t.Errorf("Expected anniversary already recorded, got %v", err)
+	}
+}
+
+func TestProcessor_ProcessAnniversaries_TenantMilestones(t *testing.T) {
+	db, tenantId, processor, producer := setupAnniversaryTest(t)
+	createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 31)
+
+	milestones := "14"
+	if err := db.Create(&rules.Entity{TenantId: tenantId, AnniversaryMilestoneDays: &milestones, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	if err := processor.ProcessAnniversaries(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	events := anniversaryEvents(t, producer)
+	if len(events) != 1 || events[0].MilestoneDays != 14 {
+		t.Errorf("Expected only the tenant's 14 day milestone, got %v", events)
+	}
+}
+
+func TestProcessor_ProcessAnniversaries_ConcurrentRunsCelebrateOnce(t *testing.T) {
+	db := setupConcurrentTestDB(t)
+	tenantId := uuid.New()
+	rules.GetRegistry().Invalidate(tenantId)
+	monthOld := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 31)
+
+	// Every run finds the milestones uncelebrated before any of them records one
+	types := runConcurrently(t, db, tenantId, 4, func(p Processor) error {
+		return p.ProcessAnniversaries()
+	})
+	if celebrated := countEvents(types, marriageMsg.EventMarriageAnniversary); celebrated != 2 {
+		t.Errorf("Expected the 7 and 30 day milestones to be celebrated once each, got %d events", celebrated)
+	}
+
+	// A later run finds nothing left to celebrate
+	types = runConcurrently(t, db, tenantId, 1, func(p Processor) error {
+		return p.ProcessAnniversaries()
+	})
+	if celebrated := countEvents(types, marriageMsg.EventMarriageAnniversary); celebrated != 0 {
+		t.Errorf("Expected no repeated anniversaries, got %d", celebrated)
+	}
+
+	var recorded int64
+	if err := db.Model(&AnniversaryEntity{}).Where("marriage_id = ?", monthOld.ID).Count(&recorded).Error; err != nil || recorded != 2 {
+		t.Errorf("Expected 2 recorded anniversaries, got %d (%v)", recorded, err)
+	}
+}
@@ -0,0 +1,305 @@
+package marriage
+
+import (
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// BondSource identifies the activity which earned a couple bond points
+type BondSource string
+
+const (
+	BondSourceQuest BondSource = "QUEST"
+	BondSourceCoOp  BondSource = "CO_OP"
+	BondSourceGift  BondSource = "GIFT"
+)
+
+// ParseBondSource parses the source of a bond point award
+func ParseBondSource(value string) (BondSource, error) {
+	switch BondSource(value) {
+	case BondSourceQuest, BondSourceCoOp, BondSourceGift:
+		return BondSource(value), nil
+	default:
+		return "", ErrInvalidBondSource
+	}
+}
+
+// Bond is the immutable view of a couple's bond level and their progress towards the next level
+type Bond struct {
+	marriageId       uint32
+	level            byte
+	points           uint32
+	currentThreshold uint32
+	nextThreshold    uint32
+	maxLevel         bool
+	activityAt       *time.Time
+}
+
+// MakeBond creates the bond view of a marriage, measured against the tenant's bond level thresholds
+func MakeBond(marriage Marriage, thresholds []uint32) Bond {
+	level := BondLevelFor(marriage.BondPoints(), thresholds)
+	bond := Bond{
+		marriageId: marriage.Id(),
+		level:      level,
+		points:     marriage.BondPoints(),
+		maxLevel:   int(level) >= len(thresholds),
+		activityAt: marriage.BondActivityAt(),
+	}
+	if level > 0 {
+		bond.currentThreshold = thresholds[level-1]
+	}
+	if !bond.maxLevel {
+		bond.nextThreshold = thresholds[level]
+	}
+	return bond
+}
+
+// MarriageId returns the ID of the marriage the bond belongs to
+func (b Bond) MarriageId() uint32 {
+	return b.marriageId
+}
+
+// Level returns the bond level the couple has reached
+func (b Bond) Level() byte {
+	return b.level
+}
+
+// Points returns the bond points the couple has earned, less any decay
+func (b Bond) Points() uint32 {
+	return b.points
+}
+
+// CurrentThreshold returns the bond points at which the couple reached their level, or 0 at level 0
+func (b Bond) CurrentThreshold() uint32 {
+	return b.currentThreshold
+}
+
+// NextThreshold returns the bond points the couple needs to reach the next level, or 0 at the maximum level
+func (b Bond) NextThreshold() uint32 {
+	return b.nextThreshold
+}
+
+// IsMaxLevel returns true if the couple has reached the highest bond level
+func (b Bond) IsMaxLevel() bool {
+	return b.maxLevel
+}
+
+// Progress returns the fraction of the way from the current level to the next, or 1 at the maximum level
+func (b Bond) Progress() float64 {
+	if b.maxLevel {
+		return 1
+	}
+	return float64(b.points-b.currentThreshold) / float64(b.nextThreshold-b.currentThreshold)
+}
+
+// ActivityAt returns when bond points were last awarded or decayed, or nil before the first award
+func (b Bond) ActivityAt() *time.Time {
+	return b.activityAt
+}
+
+// AwardBondPoints awards bond points earned by a character to the character's marriage, levelling the couple's bond
+// against the tenant's thresholds. Awarding points also restarts the bond's decay interval
+func (p *ProcessorImpl) AwardBondPoints(characterId uint32, points uint32, source string) model.Provider[Marriage] {
+	return func() (Marriage, error) {
+		return emitTransactionally(p, uuid.New(), func(p *ProcessorImpl) (Marriage, error) {
+			_, marriage, err := p.awardBondPoints(characterId, points, source)
+			return marriage, err
+		})
+	}
+}
+
+// awardBondPoints awards bond points, returning the bond level the couple held beforehand alongside the marriage. The
+// marriage is locked until the processor's transaction ends, so concurrent awards and decays each build on the points
+// stored by the other
+func (p *ProcessorImpl) awardBondPoints(characterId uint32, points uint32, source string) (byte, Marriage, error) {
+	p.log.WithFields(logrus.Fields{
+		"characterId": characterId,
+		"points":      points,
+		"source":      source,
+	}).Debug("Awarding bond points")
+
+	if _, err := ParseBondSource(source); err != nil {
+		return 0, Marriage{}, err
+	}
+	if points == 0 {
+		return 0, Marriage{}, ErrInvalidBondPoints
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+
+	active, err := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())()
+	if err != nil {
+		return 0, Marriage{}, err
+	}
+	if active == nil {
+		return 0, Marriage{}, ErrMarriageNotFound
+	}
+	current, err := p.lockMarriage(active.Id())
+	if err != nil {
+		return 0, Marriage{}, err
+	}
+	if !current.IsActive() {
+		return 0, Marriage{}, marriageTransitionError(current, current.Status())
+	}
+
+	awarded, err := current.AwardBond(points, p.rules().BondLevelThresholds())
+	if err != nil {
+		return 0, Marriage{}, err
+	}
+	if _, err = UpdateMarriage(p.db, p.log)(awarded)(); err != nil {
+		return 0, Marriage{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"marriageId": awarded.Id(),
+		"points":     awarded.BondPoints(),
+		"level":      awarded.BondLevel(),
+	}).Info("Bond points awarded")
+
+	return current.BondLevel(), awarded, nil
+}
+
+// AwardBondPointsAndEmit awards bond points and emits a BondLevelChanged event when they change the couple's bond level
+func (p *ProcessorImpl) AwardBondPointsAndEmit(transactionId uuid.UUID, characterId uint32, points uint32, source string) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		previousLevel, marriage, err := p.awardBondPoints(characterId, points, source)
+		if err != nil {
+			return Marriage{}, err
+		}
+		if err = p.emitBondLevelChanged(transactionId, marriage, previousLevel, marriageMsg.BondReasonAward, source); err != nil {
+			return Marriage{}, err
+		}
+		return marriage, nil
+	})
+}
+
+// DecayBondAndEmit decays the bond of a couple which has gone a full decay interval without being awarded bond points,
+// emitting a BondLevelChanged event when the decay costs them a level
+func (p *ProcessorImpl) DecayBondAndEmit(transactionId uuid.UUID, marriageId uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		// Locking the marriage keeps points awarded meanwhile from being overwritten by the decay
+		marriage, err := p.lockMarriage(marriageId)
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		r := p.rules()
+		decayed, changed, err := marriage.DecayBond(r.BondDecayPoints(), r.BondDecayInterval(), r.BondLevelThresholds(), time.Now())
+		if err != nil || !changed {
+			return marriage, err
+		}
+		if _, err = UpdateMarriage(p.db, p.log)(decayed)(); err != nil {
+			return Marriage{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"marriageId": marriageId,
+			"points":     decayed.BondPoints(),
+			"level":      decayed.BondLevel(),
+		}).Info("Bond decayed")
+
+		if err = p.emitBondLevelChanged(transactionId, decayed, marriage.BondLevel(), marriageMsg.BondReasonDecay, ""); err != nil {
+			return Marriage{}, err
+		}
+		return decayed, nil
+	})
+}
+
+// emitBondLevelChanged buffers a BondLevelChanged event if the marriage's bond level differs from its previous level,
+// alongside events for the couple skills the change unlocks or locks
+func (p *ProcessorImpl) emitBondLevelChanged(transactionId uuid.UUID, marriage Marriage, previousLevel byte, reason string, source string) error {
+	if marriage.BondLevel() == previousLevel {
+		return nil
+	}
+
+	err := message.Emit(p.producer)(func(buf *message.Buffer) error {
+		eventProvider := BondLevelChangedEventProvider(
+			marriage.Id(),
+			marriage.CharacterId1(),
+			marriage.CharacterId2(),
+			previousLevel,
+			marriage.BondLevel(),
+			marriage.BondPoints(),
+			reason,
+			source,
+			marriage.UpdatedAt(),
+		)
+		if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+			return err
+		}
+		return p.putCoupleSkillChanges(buf, marriage, marriage.Status(), previousLevel)
+	})
+	if err != nil {
+		return err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"transactionId": transactionId,
+		"marriageId":    marriage.Id(),
+		"previousLevel": previousLevel,
+		"level":         marriage.BondLevel(),
+	}).Debug("BondLevelChanged event emitted")
+
+	return nil
+}
+
+// GetBond retrieves the bond of a character's active marriage, measured against the tenant's thresholds
+func (p *ProcessorImpl) GetBond(characterId uint32) model.Provider[Bond] {
+	return func() (Bond, error) {
+		t := tenant.MustFromContext(p.ctx)
+
+		marriage, err := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())()
+		if err != nil {
+			return Bond{}, err
+		}
+		if marriage == nil || !marriage.IsActive() {
+			return Bond{}, ErrMarriageNotFound
+		}
+		return MakeBond(*marriage, p.rules().BondLevelThresholds()), nil
+	}
+}
+
+// ProcessBondDecay decays the bond of every couple of the tenant which has gone a full decay interval without being
+// awarded bond points. Decay is disabled while the tenant's decay points are 0
+func (p *ProcessorImpl) ProcessBondDecay() error {
+	p.log.Debug("Processing bond decay")
+
+	r := p.rules()
+	if r.BondDecayPoints() == 0 {
+		return nil
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+
+	marriages, err := GetMarriagesWithDecayingBondProvider(p.db, p.log)(time.Now().Add(-r.BondDecayInterval()), t.Id())()
+	if err != nil {
+		p.log.WithError(err).Error("Failed to retrieve marriages with decaying bonds")
+		return err
+	}
+
+	if len(marriages) == 0 {
+		return nil
+	}
+
+	p.log.WithField("count", len(marriages)).Info("Processing bond decay")
+
+	for _, marriage := range marriages {
+		if _, err := p.DecayBondAndEmit(uuid.New(), marriage.Id()); err != nil {
+			p.log.WithFields(logrus.Fields{
+				"marriageId": marriage.Id(),
+				"error":      err,
+			}).Error("Failed to decay bond")
+			// Continue processing other marriages even if one fails
+			continue
+		}
+	}
+
+	return nil
+}
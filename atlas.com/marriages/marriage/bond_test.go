@@ -0,0 +1,185 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupBondTest creates a processor emitting to a mock producer, for a tenant levelling bonds at 100, 300 and 600 points
+func setupBondTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor, *MockProducer) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	thresholds := "100,300,600"
+	if err := db.Create(&rules.Entity{TenantId: tenantId, BondLevelThresholds: &thresholds, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).WithProducer(producer.Provider)
+	return db, tenantId, processor, producer
+}
+
+// bondLevelChangedEvents returns the bond level changed events among the produced messages
+func bondLevelChangedEvents(t *testing.T, producer *MockProducer) []marriageMsg.BondLevelChangedBody {
+	var events []marriageMsg.BondLevelChangedBody
+	for _, m := range producer.GetProducedMessages() {
+		var event marriageMsg.Event[marriageMsg.BondLevelChangedBody]
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		if event.Type == marriageMsg.EventBondLevelChanged {
+			events = append(events, event.Body)
+		}
+	}
+	return events
+}
+
+// setBond stores a bond on a marriage, last changed at the given time
+func setBond(t *testing.T, db *gorm.DB, marriageId uint32, points uint32, level byte, activityAt time.Time) {
+	if err := db.Model(&Entity{}).Where("id = ?", marriageId).Updates(map[string]interface{}{
+		"bond_points":      points,
+		"bond_level":       level,
+		"bond_activity_at": activityAt,
+	}).Error; err != nil {
+		t.Fatalf("Failed to set bond: %v", err)
+	}
+}
+
+func TestBondLevelFor(t *testing.T) {
+	thresholds := []uint32{100, 300, 600}
+	cases := map[uint32]byte{0: 0, 99: 0, 100: 1, 299: 1, 300: 2, 600: 3, 10000: 3}
+	for points, want := range cases {
+		if got := BondLevelFor(points, thresholds); got != want {
+			t.Errorf("Expected %d points to reach level %d, got %d", points, want, got)
+		}
+	}
+	if got := BondLevelFor(1000, nil); got != 0 {
+		t.Errorf("Expected no levels without thresholds, got %d", got)
+	}
+}
+
+func TestProcessor_AwardBondPoints(t *testing.T) {
+	db, tenantId, processor, producer := setupBondTest(t)
+	couple := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+
+	marriage, err := processor.AwardBondPointsAndEmit(uuid.New(), 2, 60, string(BondSourceQuest))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if marriage.BondPoints() != 60 || marriage.BondLevel() != 0 || marriage.BondActivityAt() == nil {
+		t.Fatalf("Expected 60 points at level 0, got %d points at level %d", marriage.BondPoints(), marriage.BondLevel())
+	}
+	if events := bondLevelChangedEvents(t, producer); len(events) != 0 {
+		t.Errorf("Expected no level change below the first threshold, got %v", events)
+	}
+
+	// Points carry the couple past two thresholds at once
+	marriage, err = processor.AwardBondPointsAndEmit(uuid.New(), 1, 250, string(BondSourceGift))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if marriage.BondPoints() != 310 || marriage.BondLevel() != 2 {
+		t.Errorf("Expected 310 points at level 2, got %d points at level %d", marriage.BondPoints(), marriage.BondLevel())
+	}
+	events := bondLevelChangedEvents(t, producer)
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 level change, got %d", len(events))
+	}
+	if events[0].MarriageId != couple.ID || events[0].PreviousLevel != 0 || events[0].Level != 2 || events[0].Reason != marriageMsg.BondReasonAward || events[0].Source != string(BondSourceGift) {
+		t.Errorf("Unexpected level change %+v", events[0])
+	}
+
+	bond, err := processor.GetBond(2)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bond.Level() != 2 || bond.CurrentThreshold() != 300 || bond.NextThreshold() != 600 || bond.IsMaxLevel() {
+		t.Errorf("Unexpected bond: level %d between %d and %d", bond.Level(), bond.CurrentThreshold(), bond.NextThreshold())
+	}
+
+	var stored Entity
+	if err = db.First(&stored, couple.ID).Error; err != nil || stored.BondPoints != 310 || stored.BondLevel != 2 {
+		t.Errorf("Expected the bond to be stored, got %d points at level %d (%v)", stored.BondPoints, stored.BondLevel, err)
+	}
+}
+
+func TestProcessor_AwardBondPoints_Rejected(t *testing.T) {
+	db, tenantId, processor, _ := setupBondTest(t)
+	createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+	createMarriedCouple(t, db, tenantId, 3, 4, StatusDivorced, 10)
+
+	if _, err := processor.AwardBondPointsAndEmit(uuid.New(), 1, 10, "FISHING"); !errors.Is(err, ErrInvalidBondSource) {
+		t.Errorf("Expected invalid bond source, got %v", err)
+	}
+	if _, err := processor.AwardBondPointsAndEmit(uuid.New(), 1, 0, string(BondSourceCoOp)); !errors.Is(err, ErrInvalidBondPoints) {
+		t.Errorf("Expected invalid bond points, got %v", err)
+	}
+	if _, err := processor.AwardBondPointsAndEmit(uuid.New(), 3, 10, string(BondSourceCoOp)); !errors.Is(err, ErrMarriageNotFound) {
+		t.Errorf("Expected a divorced character to have no marriage to award, got %v", err)
+	}
+	if _, err := processor.GetBond(3)(); !errors.Is(err, ErrMarriageNotFound) {
+		t.Errorf("Expected a divorced character to have no bond, got %v", err)
+	}
+}
+
+func TestProcessor_ProcessBondDecay(t *testing.T) {
+	db, tenantId, processor, producer := setupBondTest(t)
+	now := time.Now()
+	inactive := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+	setBond(t, db, inactive.ID, 120, 1, now.Add(-150*time.Minute))
+	active := createMarriedCouple(t, db, tenantId, 3, 4, StatusMarried, 10)
+	setBond(t, db, active.ID, 120, 1, now.Add(-30*time.Minute))
+
+	// Bonds do not decay by default
+	if err := processor.ProcessBondDecay(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var stored Entity
+	if err := db.First(&stored, inactive.ID).Error; err != nil || stored.BondPoints != 120 {
+		t.Fatalf("Expected no decay by default, got %d points (%v)", stored.BondPoints, err)
+	}
+
+	decayPoints := uint32(50)
+	decayInterval := int64(60 * 60)
+	if err := db.Model(&rules.Entity{}).Where("tenant_id = ?", tenantId).Updates(map[string]interface{}{
+		"bond_decay_points":           decayPoints,
+		"bond_decay_interval_seconds": decayInterval,
+	}).Error; err != nil {
+		t.Fatalf("Failed to update rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	if err := processor.ProcessBondDecay(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Two full hours of inactivity cost 100 points, and the partial hour carries over
+	if err := db.First(&stored, inactive.ID).Error; err != nil || stored.BondPoints != 20 || stored.BondLevel != 0 {
+		t.Fatalf("Expected the inactive bond to decay to 20 points at level 0, got %d at level %d (%v)", stored.BondPoints, stored.BondLevel, err)
+	}
+	if want := now.Add(-30 * time.Minute); stored.BondActivityAt == nil || stored.BondActivityAt.Sub(want).Abs() > time.Second {
+		t.Errorf("Expected the decay interval to carry over to %v, got %v", want, stored.BondActivityAt)
+	}
+	if err := db.First(&stored, active.ID).Error; err != nil || stored.BondPoints != 120 {
+		t.Errorf("Expected the active bond not to decay, got %d points (%v)", stored.BondPoints, err)
+	}
+
+	events := bondLevelChangedEvents(t, producer)
+	if len(events) != 1 || events[0].MarriageId != inactive.ID || events[0].PreviousLevel != 1 || events[0].Level != 0 || events[0].Reason != marriageMsg.BondReasonDecay {
+		t.Errorf("Expected the inactive couple to lose a level, got %v", events)
+	}
+}
@@ -2,6 +2,7 @@ package marriage
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,10 +17,25 @@ type Builder struct {
 	proposedAt   time.Time
 	engagedAt    *time.Time
 	marriedAt    *time.Time
-	divorcedAt   *time.Time
-	tenantId     uuid.UUID
-	createdAt    time.Time
-	updatedAt    time.Time
+	divorcedAt     *time.Time
+	deletedAt      *time.Time
+	deletionReason string
+	tenantId       uuid.UUID
+	createdAt      time.Time
+	updatedAt      time.Time
+
+	ringItemId  uint32
+	ringSerial1 uint64
+	ringSerial2 uint64
+
+	divorceFiledBy   uint32
+	divorceFiledAt   *time.Time
+	divorceMaturesAt *time.Time
+	divorcePaymentId *uuid.UUID
+
+	bondPoints     uint32
+	bondLevel      byte
+	bondActivityAt *time.Time
 }
 
 // NewBuilder creates a new builder with required parameters
@@ -72,6 +88,43 @@ func (b *Builder) SetDivorcedAt(divorcedAt *time.Time) *Builder {
 	return b
 }
 
+// SetDeletedAt sets the deletion timestamp
+func (b *Builder) SetDeletedAt(deletedAt *time.Time) *Builder {
+	b.deletedAt = deletedAt
+	return b
+}
+
+// SetDeletionReason sets the reason the marriage was deleted
+func (b *Builder) SetDeletionReason(reason string) *Builder {
+	b.deletionReason = reason
+	return b
+}
+
+// SetRings sets the wedding ring issued to both partners and the serial of each partner's ring
+func (b *Builder) SetRings(itemId uint32, serial1, serial2 uint64) *Builder {
+	b.ringItemId = itemId
+	b.ringSerial1 = serial1
+	b.ringSerial2 = serial2
+	return b
+}
+
+// SetDivorceFiling sets the partner who filed for divorce, when it was filed, when it matures and the payment for it
+func (b *Builder) SetDivorceFiling(filedBy uint32, filedAt *time.Time, maturesAt *time.Time, paymentId *uuid.UUID) *Builder {
+	b.divorceFiledBy = filedBy
+	b.divorceFiledAt = filedAt
+	b.divorceMaturesAt = maturesAt
+	b.divorcePaymentId = paymentId
+	return b
+}
+
+// SetBond sets the couple's bond points, the bond level they reached and when the bond last changed
+func (b *Builder) SetBond(points uint32, level byte, activityAt *time.Time) *Builder {
+	b.bondPoints = points
+	b.bondLevel = level
+	b.bondActivityAt = activityAt
+	return b
+}
+
 // SetCreatedAt sets the creation timestamp
 func (b *Builder) SetCreatedAt(createdAt time.Time) *Builder {
 	b.createdAt = createdAt
@@ -107,6 +160,18 @@ func (b *Builder) Build() (Marriage, error) {
 		return Marriage{}, err
 	}
 	
+	if err := b.validateRings(); err != nil {
+		return Marriage{}, err
+	}
+
+	if err := b.validateDivorceFiling(); err != nil {
+		return Marriage{}, err
+	}
+
+	if err := b.validateBond(); err != nil {
+		return Marriage{}, err
+	}
+	
 	return Marriage{
 		id:           b.id,
 		characterId1: b.characterId1,
@@ -115,15 +180,92 @@ func (b *Builder) Build() (Marriage, error) {
 		proposedAt:   b.proposedAt,
 		engagedAt:    b.engagedAt,
 		marriedAt:    b.marriedAt,
-		divorcedAt:   b.divorcedAt,
-		tenantId:     b.tenantId,
-		createdAt:    b.createdAt,
-		updatedAt:    b.updatedAt,
+		divorcedAt:     b.divorcedAt,
+		deletedAt:      b.deletedAt,
+		deletionReason: b.deletionReason,
+		tenantId:       b.tenantId,
+		createdAt:      b.createdAt,
+		updatedAt:      b.updatedAt,
+
+		ringItemId:  b.ringItemId,
+		ringSerial1: b.ringSerial1,
+		ringSerial2: b.ringSerial2,
+
+		divorceFiledBy:   b.divorceFiledBy,
+		divorceFiledAt:   b.divorceFiledAt,
+		divorceMaturesAt: b.divorceMaturesAt,
+		divorcePaymentId: b.divorcePaymentId,
+
+		bondPoints:     b.bondPoints,
+		bondLevel:      b.bondLevel,
+		bondActivityAt: b.bondActivityAt,
 	}, nil
 }
 
+// validateBond validates that bond points are only recorded for couples who married, alongside when the bond last changed
+func (b *Builder) validateBond() error {
+	if b.bondActivityAt == nil {
+		if b.bondPoints != 0 || b.bondLevel != 0 {
+			return errors.New("bond points require a bond activity timestamp")
+		}
+		return nil
+	}
+	if b.marriedAt == nil {
+		return errors.New("bond requires a marriage timestamp")
+	}
+	return nil
+}
+
+// validateDivorceFiling validates that a divorce filing is complete, was filed by a partner, and is only recorded
+// while the divorce is pending or as the history of a marriage which ended after it was filed
+func (b *Builder) validateDivorceFiling() error {
+	if b.divorceFiledBy == 0 {
+		if b.divorceFiledAt != nil || b.divorceMaturesAt != nil || b.divorcePaymentId != nil {
+			return errors.New("divorce filing details require the filing partner")
+		}
+		if b.status == StatusDivorcePending {
+			return errors.New("pending divorce must have a filing partner")
+		}
+		return nil
+	}
+	if b.divorceFiledBy != b.characterId1 && b.divorceFiledBy != b.characterId2 {
+		return errors.New("divorce must be filed by a partner")
+	}
+	if b.divorceFiledAt == nil || b.divorceMaturesAt == nil {
+		return errors.New("divorce filing must have filing and maturity timestamps")
+	}
+	if b.status != StatusDivorcePending && b.status != StatusDivorced && b.status != StatusDeleted {
+		return errors.New("only pending, divorced or deleted marriages can have a divorce filing")
+	}
+	return nil
+}
+
+// validateRings validates that wedding rings are only recorded for couples who married, as a matching pair
+func (b *Builder) validateRings() error {
+	if b.ringItemId == 0 {
+		if b.ringSerial1 != 0 || b.ringSerial2 != 0 {
+			return errors.New("wedding ring serials require a ring item")
+		}
+		return nil
+	}
+	if b.marriedAt == nil {
+		return errors.New("wedding rings require a marriage timestamp")
+	}
+	if b.ringSerial1 == 0 || b.ringSerial2 == 0 {
+		return errors.New("wedding rings require a serial for each partner")
+	}
+	if b.ringSerial1 == b.ringSerial2 {
+		return errors.New("wedding ring serials must differ")
+	}
+	return nil
+}
+
 // validateStateTransitions validates the consistency of state transitions
 func (b *Builder) validateStateTransitions() error {
+	if b.status != StatusDeleted && b.deletedAt != nil {
+		return errors.New("only deleted marriages can have deletion timestamp")
+	}
+	
 	switch b.status {
 	case StatusProposed:
 		if b.engagedAt != nil {
@@ -155,6 +297,16 @@ func (b *Builder) validateStateTransitions() error {
 		if b.divorcedAt != nil {
 			return errors.New("married marriage cannot have divorce timestamp")
 		}
+	case StatusDivorcePending:
+		if b.engagedAt == nil {
+			return errors.New("divorce pending marriage must have engagement timestamp")
+		}
+		if b.marriedAt == nil {
+			return errors.New("divorce pending marriage must have marriage timestamp")
+		}
+		if b.divorcedAt != nil {
+			return errors.New("divorce pending marriage cannot have divorce timestamp")
+		}
 	case StatusDivorced:
 		if b.engagedAt == nil {
 			return errors.New("divorced marriage must have engagement timestamp")
@@ -175,6 +327,16 @@ func (b *Builder) validateStateTransitions() error {
 		if b.divorcedAt != nil {
 			return errors.New("expired marriage cannot have divorce timestamp")
 		}
+	case StatusDeleted:
+		if b.deletedAt == nil {
+			return errors.New("deleted marriage must have deletion timestamp")
+		}
+		if b.marriedAt != nil && b.engagedAt == nil {
+			return errors.New("deleted marriage with marriage timestamp must have engagement timestamp")
+		}
+		if b.divorcedAt != nil {
+			return errors.New("deleted marriage cannot have divorce timestamp")
+		}
 	default:
 		return errors.New("invalid marriage status")
 	}
@@ -196,6 +358,9 @@ type ProposalBuilder struct {
 	tenantId       uuid.UUID
 	createdAt      time.Time
 	updatedAt      time.Time
+
+	ringItemId        uint32
+	ringReservationId *uuid.UUID
 }
 
 // NewProposalBuilder creates a new builder with required parameters
@@ -256,6 +421,18 @@ func (b *ProposalBuilder) SetCooldownUntil(cooldownUntil *time.Time) *ProposalBu
 	return b
 }
 
+// SetRingItemId sets the engagement ring item held for the proposal
+func (b *ProposalBuilder) SetRingItemId(ringItemId uint32) *ProposalBuilder {
+	b.ringItemId = ringItemId
+	return b
+}
+
+// SetRingReservationId sets the inventory reservation holding the engagement ring
+func (b *ProposalBuilder) SetRingReservationId(ringReservationId *uuid.UUID) *ProposalBuilder {
+	b.ringReservationId = ringReservationId
+	return b
+}
+
 // SetCreatedAt sets the creation timestamp
 func (b *ProposalBuilder) SetCreatedAt(createdAt time.Time) *ProposalBuilder {
 	b.createdAt = createdAt
@@ -289,6 +466,10 @@ func (b *ProposalBuilder) Build() (Proposal, error) {
 	if b.expiresAt.Before(b.proposedAt) {
 		return Proposal{}, errors.New("expiry time cannot be before proposal time")
 	}
+
+	if b.ringReservationId != nil && b.ringItemId == 0 {
+		return Proposal{}, errors.New("ring reservation requires a ring item")
+	}
 	
 	// Validate state transitions
 	if err := b.validateProposalStateTransitions(); err != nil {
@@ -308,6 +489,9 @@ func (b *ProposalBuilder) Build() (Proposal, error) {
 		tenantId:       b.tenantId,
 		createdAt:      b.createdAt,
 		updatedAt:      b.updatedAt,
+
+		ringItemId:        b.ringItemId,
+		ringReservationId: b.ringReservationId,
 	}, nil
 }
 
@@ -357,9 +541,23 @@ type CeremonyBuilder struct {
 	cancelledAt  *time.Time
 	postponedAt  *time.Time
 	invitees     []uint32
+	rsvps        map[uint32]Rsvp
+	maxInvitees  int
 	tenantId     uuid.UUID
 	createdAt    time.Time
 	updatedAt    time.Time
+	venueTier    VenueTier
+	cost         uint32
+	paymentId    *uuid.UUID
+	remindedAt   *time.Time
+
+	disconnectedAt1 *time.Time
+	disconnectedAt2 *time.Time
+
+	venueId uint32
+
+	stages []string
+	stage  string
 }
 
 // NewCeremonyBuilder creates a new builder with required parameters
@@ -372,9 +570,12 @@ func NewCeremonyBuilder(marriageId, characterId1, characterId2 uint32, tenantId
 		status:       CeremonyStatusScheduled,
 		scheduledAt:  now,
 		invitees:     make([]uint32, 0),
+		rsvps:        make(map[uint32]Rsvp),
+		maxInvitees:  MaxInvitees,
 		tenantId:     tenantId,
 		createdAt:    now,
 		updatedAt:    now,
+		venueTier:    VenueTierStandard,
 	}
 }
 
@@ -434,6 +635,76 @@ func (b *CeremonyBuilder) SetInvitees(invitees []uint32) *CeremonyBuilder {
 	return b
 }
 
+// SetRsvps sets the responses of the invitees who have responded to their invitation
+func (b *CeremonyBuilder) SetRsvps(rsvps []Rsvp) *CeremonyBuilder {
+	b.rsvps = make(map[uint32]Rsvp, len(rsvps))
+	for _, rsvp := range rsvps {
+		b.rsvps[rsvp.CharacterId()] = rsvp
+	}
+	return b
+}
+
+// SetMaxInvitees sets the maximum number of invitees
+func (b *CeremonyBuilder) SetMaxInvitees(maxInvitees int) *CeremonyBuilder {
+	b.maxInvitees = maxInvitees
+	return b
+}
+
+// SetVenueTier sets the class of venue the ceremony is held at
+func (b *CeremonyBuilder) SetVenueTier(venueTier VenueTier) *CeremonyBuilder {
+	b.venueTier = venueTier
+	return b
+}
+
+// SetVenueId sets the venue the ceremony has booked, zero if it holds no booking
+func (b *CeremonyBuilder) SetVenueId(venueId uint32) *CeremonyBuilder {
+	b.venueId = venueId
+	return b
+}
+
+// SetStages sets the stages an officiant advances the active ceremony through, in order
+func (b *CeremonyBuilder) SetStages(stages []string) *CeremonyBuilder {
+	b.stages = make([]string, len(stages))
+	copy(b.stages, stages)
+	return b
+}
+
+// SetStage sets the stage the active ceremony has reached, empty before its first stage
+func (b *CeremonyBuilder) SetStage(stage string) *CeremonyBuilder {
+	b.stage = stage
+	return b
+}
+
+// SetCost sets the mesos paid to schedule the ceremony
+func (b *CeremonyBuilder) SetCost(cost uint32) *CeremonyBuilder {
+	b.cost = cost
+	return b
+}
+
+// SetPaymentId sets the economy transaction which paid for the ceremony
+func (b *CeremonyBuilder) SetPaymentId(paymentId *uuid.UUID) *CeremonyBuilder {
+	b.paymentId = paymentId
+	return b
+}
+
+// SetRemindedAt sets when the couple and their invitees were reminded that the ceremony is about to start
+func (b *CeremonyBuilder) SetRemindedAt(remindedAt *time.Time) *CeremonyBuilder {
+	b.remindedAt = remindedAt
+	return b
+}
+
+// SetDisconnectedAt1 sets when the first partner logged out during the active ceremony
+func (b *CeremonyBuilder) SetDisconnectedAt1(disconnectedAt *time.Time) *CeremonyBuilder {
+	b.disconnectedAt1 = disconnectedAt
+	return b
+}
+
+// SetDisconnectedAt2 sets when the second partner logged out during the active ceremony
+func (b *CeremonyBuilder) SetDisconnectedAt2(disconnectedAt *time.Time) *CeremonyBuilder {
+	b.disconnectedAt2 = disconnectedAt
+	return b
+}
+
 // SetCreatedAt sets the creation timestamp
 func (b *CeremonyBuilder) SetCreatedAt(createdAt time.Time) *CeremonyBuilder {
 	b.createdAt = createdAt
@@ -468,9 +739,17 @@ func (b *CeremonyBuilder) Build() (Ceremony, error) {
 		return Ceremony{}, errors.New("tenant ID is required")
 	}
 	
-	if len(b.invitees) > MaxInvitees {
+	if len(b.invitees) > b.maxInvitees {
 		return Ceremony{}, errors.New("too many invitees")
 	}
+
+	if b.venueTier != VenueTierStandard && b.venueTier != VenueTierPremium {
+		return Ceremony{}, errors.New("invalid venue tier")
+	}
+
+	if b.cost > 0 && b.paymentId == nil {
+		return Ceremony{}, errors.New("paid ceremony requires a payment")
+	}
 	
 	// Validate that invitees don't include the partners themselves
 	for _, invitee := range b.invitees {
@@ -487,12 +766,29 @@ func (b *CeremonyBuilder) Build() (Ceremony, error) {
 		}
 		inviteeMap[invitee] = true
 	}
+
+	// Validate that responses belong to invitees who have responded
+	for characterId, rsvp := range b.rsvps {
+		if !inviteeMap[characterId] {
+			return Ceremony{}, errors.New("response recorded for a character who is not invited")
+		}
+		if !rsvp.Status().IsResponse() || rsvp.RespondedAt() == nil {
+			return Ceremony{}, errors.New("invalid invitee response")
+		}
+	}
 	
 	// Validate state transitions
 	if err := b.validateCeremonyStateTransitions(); err != nil {
 		return Ceremony{}, err
 	}
 	
+	// Validate that the stage reached is one of the ceremony's stages
+	stages := make([]string, len(b.stages))
+	copy(stages, b.stages)
+	if b.stage != "" && !slices.Contains(stages, b.stage) {
+		return Ceremony{}, errors.New("stage is not one of the ceremony's stages")
+	}
+
 	// Copy invitees to maintain immutability
 	invitees := make([]uint32, len(b.invitees))
 	copy(invitees, b.invitees)
@@ -509,9 +805,23 @@ func (b *CeremonyBuilder) Build() (Ceremony, error) {
 		cancelledAt:  b.cancelledAt,
 		postponedAt:  b.postponedAt,
 		invitees:     invitees,
+		rsvps:        copyRsvps(b.rsvps),
+		maxInvitees:  b.maxInvitees,
 		tenantId:     b.tenantId,
 		createdAt:    b.createdAt,
 		updatedAt:    b.updatedAt,
+		venueTier:    b.venueTier,
+		cost:         b.cost,
+		paymentId:    b.paymentId,
+		remindedAt:   b.remindedAt,
+
+		disconnectedAt1: b.disconnectedAt1,
+		disconnectedAt2: b.disconnectedAt2,
+
+		venueId: b.venueId,
+
+		stages: stages,
+		stage:  b.stage,
 	}, nil
 }
 
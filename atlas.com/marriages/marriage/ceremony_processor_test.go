@@ -1,6 +1,10 @@
 package marriage
 
 import (
+	"atlas-marriages/outbox"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+
 	"context"
 	"testing"
 	"time"
@@ -23,7 +27,7 @@ func TestCeremonyStateTransitions(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -81,7 +85,8 @@ func TestCeremonyStateTransitions(t *testing.T) {
 	assert.Equal(t, CeremonyStatusActive, startedCeremony.Status())
 	assert.NotNil(t, startedCeremony.StartedAt())
 	
-	// Test completing the ceremony
+	// Test completing the ceremony once it reaches its final stage
+	advanceToFinalStage(t, processor, ceremony.Id())
 	completedCeremony, err := processor.CompleteCeremony(ceremony.Id())()
 	assert.NoError(t, err)
 	assert.Equal(t, CeremonyStatusCompleted, completedCeremony.Status())
@@ -95,7 +100,7 @@ func TestCeremonyInviteeManagement(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -165,7 +170,7 @@ func TestCeremonyQueries(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -245,7 +250,7 @@ func TestCeremonyTimeoutScenarios(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -450,7 +455,7 @@ func TestProposalTimeoutScenarios(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -606,7 +611,7 @@ func TestCeremonyAndEmitMethods(t *testing.T) {
 	assert.NoError(t, err)
 	
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	assert.NoError(t, err)
 	
 	// Create logger
@@ -652,7 +657,7 @@ func TestCeremonyAndEmitMethods(t *testing.T) {
 		invitees := []uint32{102, 103}
 		transactionId := uuid.New()
 		
-		ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, marriageEntity.ID, scheduledAt, invitees)
+		ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, marriageEntity.ID, scheduledAt, invitees, VenueTierStandard, 0)
 		assert.NoError(t, err)
 		assert.NotNil(t, ceremony)
 		assert.Equal(t, CeremonyStatusScheduled, ceremony.Status())
@@ -716,6 +721,8 @@ func TestCeremonyAndEmitMethods(t *testing.T) {
 		startedCeremony, err := processor.StartCeremony(ceremony.Id())()
 		assert.NoError(t, err)
 		
+		advanceToFinalStage(t, processor, startedCeremony.Id())
+
 		// Test CompleteCeremonyAndEmit
 		transactionId := uuid.New()
 		completedCeremony, err := processor.CompleteCeremonyAndEmit(transactionId, startedCeremony.Id())
@@ -728,10 +735,8 @@ func TestCeremonyAndEmitMethods(t *testing.T) {
 		var updatedMarriage Entity
 		err = db.First(&updatedMarriage, marriageEntity.ID).Error
 		assert.NoError(t, err)
-		// Note: The marriage status should be updated to married when ceremony completes
-		// This might be handled in a different part of the logic
-		assert.Equal(t, StatusEngaged, updatedMarriage.Status) // For now, keeping as engaged
-		// assert.NotNil(t, updatedMarriage.MarriedAt) // MarriedAt might not be set in this test
+		assert.Equal(t, StatusMarried, updatedMarriage.Status)
+		assert.NotNil(t, updatedMarriage.MarriedAt)
 	})
 	
 	t.Run("TestCancelCeremonyAndEmit", func(t *testing.T) {
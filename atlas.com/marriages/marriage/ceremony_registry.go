@@ -0,0 +1,540 @@
+package marriage
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"atlas-marriages/kafka/message"
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/registry"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// getCeremony retrieves a ceremony, reporting a ceremony which does not exist as not found
+func (p *ProcessorImpl) getCeremony(ceremonyId uint32) (Ceremony, error) {
+	t := tenant.MustFromContext(p.ctx)
+
+	ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil {
+		return Ceremony{}, err
+	}
+	if ceremony == nil {
+		return Ceremony{}, ErrCeremonyNotFound
+	}
+	return *ceremony, nil
+}
+
+// GetCeremonyRegistry retrieves the wish list of a ceremony alongside the gifts and blessings invitees sent
+func (p *ProcessorImpl) GetCeremonyRegistry(ceremonyId uint32) model.Provider[registry.Model] {
+	return func() (registry.Model, error) {
+		if _, err := p.getCeremony(ceremonyId); err != nil {
+			return registry.Model{}, err
+		}
+
+		t := tenant.MustFromContext(p.ctx)
+		return registry.GetByCeremonyIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	}
+}
+
+// AddRegistryItem adds an item to a ceremony's wish list on behalf of one of the couple
+func (p *ProcessorImpl) AddRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) model.Provider[registry.Item] {
+	return func() (registry.Item, error) {
+		_, item, err := p.addRegistryItem(ceremonyId, characterId, itemId, quantity)
+		return item, err
+	}
+}
+
+// addRegistryItem adds an item to a ceremony's wish list, returning the ceremony alongside the listed item
+func (p *ProcessorImpl) addRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (Ceremony, registry.Item, error) {
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+		"itemId":      itemId,
+		"quantity":    quantity,
+	}).Debug("Adding registry item")
+
+	if itemId == 0 || quantity == 0 {
+		return Ceremony{}, registry.Item{}, ErrInvalidRegistryItem
+	}
+
+	ceremony, err := p.getCeremony(ceremonyId)
+	if err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+	if err = registryChangeError(ceremony, characterId); err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+	listed, err := registry.GetItemProvider(p.db, p.log)(ceremonyId, itemId, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+	if listed != nil {
+		return Ceremony{}, registry.Item{}, ErrRegistryItemListed
+	}
+
+	item, err := registry.CreateItem(p.db, p.log)(ceremonyId, itemId, quantity, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId": ceremonyId,
+		"itemId":     itemId,
+		"quantity":   quantity,
+	}).Info("Registry item added")
+
+	return ceremony, item, nil
+}
+
+// AddRegistryItemAndEmit adds an item to a ceremony's wish list and emits a RegistryItemAdded event
+func (p *ProcessorImpl) AddRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Item, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (registry.Item, error) {
+		ceremony, item, err := p.addRegistryItem(ceremonyId, characterId, itemId, quantity)
+		if err != nil {
+			return registry.Item{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := RegistryItemAddedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				item.ItemId(),
+				item.Quantity(),
+				characterId,
+				item.CreatedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return registry.Item{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"itemId":        itemId,
+		}).Debug("RegistryItemAdded event emitted")
+
+		return item, nil
+	})
+}
+
+// RemoveRegistryItem removes an item no invitee has given from a ceremony's wish list on behalf of one of the couple
+func (p *ProcessorImpl) RemoveRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32) model.Provider[registry.Item] {
+	return func() (registry.Item, error) {
+		_, item, err := p.removeRegistryItem(ceremonyId, characterId, itemId)
+		return item, err
+	}
+}
+
+// removeRegistryItem removes an item from a ceremony's wish list, returning the ceremony alongside the removed item
+func (p *ProcessorImpl) removeRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32) (Ceremony, registry.Item, error) {
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+		"itemId":      itemId,
+	}).Debug("Removing registry item")
+
+	ceremony, err := p.getCeremony(ceremonyId)
+	if err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+	if err = registryChangeError(ceremony, characterId); err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+	item, err := registry.GetItemProvider(p.db, p.log)(ceremonyId, itemId, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+	if item == nil {
+		return Ceremony{}, registry.Item{}, ErrRegistryNotFound
+	}
+	// Gifts already given are part of the couple's history, so their item stays on the wish list
+	if item.Received() > 0 {
+		return Ceremony{}, registry.Item{}, ErrRegistryItemGiven
+	}
+
+	if err = registry.DeleteItem(p.db, p.log)(ceremonyId, itemId, t.Id()); err != nil {
+		return Ceremony{}, registry.Item{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId": ceremonyId,
+		"itemId":     itemId,
+	}).Info("Registry item removed")
+
+	return ceremony, *item, nil
+}
+
+// RemoveRegistryItemAndEmit removes an item from a ceremony's wish list and emits a RegistryItemRemoved event
+func (p *ProcessorImpl) RemoveRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32) (registry.Item, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (registry.Item, error) {
+		ceremony, item, err := p.removeRegistryItem(ceremonyId, characterId, itemId)
+		if err != nil {
+			return registry.Item{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := RegistryItemRemovedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				item.ItemId(),
+				characterId,
+				time.Now(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return registry.Item{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"itemId":        itemId,
+		}).Debug("RegistryItemRemoved event emitted")
+
+		return item, nil
+	})
+}
+
+// BlessCeremony records an invitee's blessing of an active ceremony. Each invitee blesses a ceremony at most once
+func (p *ProcessorImpl) BlessCeremony(ceremonyId uint32, characterId uint32, text string) model.Provider[registry.Blessing] {
+	return func() (registry.Blessing, error) {
+		_, blessing, _, err := p.blessCeremony(ceremonyId, characterId, text)
+		return blessing, err
+	}
+}
+
+// blessCeremony records a blessing, returning the ceremony and the blessing alongside the number of blessings the
+// ceremony has received
+func (p *ProcessorImpl) blessCeremony(ceremonyId uint32, characterId uint32, text string) (Ceremony, registry.Blessing, uint32, error) {
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+	}).Debug("Blessing ceremony")
+
+	if utf8.RuneCountInString(text) > registry.MaxBlessingMessageLength {
+		return Ceremony{}, registry.Blessing{}, 0, ErrBlessingTooLong
+	}
+
+	ceremony, err := p.getCeremony(ceremonyId)
+	if err != nil {
+		return Ceremony{}, registry.Blessing{}, 0, err
+	}
+	if err = guestError(ceremony, characterId); err != nil {
+		return Ceremony{}, registry.Blessing{}, 0, err
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+	current, err := registry.GetByCeremonyIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Blessing{}, 0, err
+	}
+	if current.HasBlessed(characterId) {
+		return Ceremony{}, registry.Blessing{}, 0, ErrAlreadyBlessed
+	}
+
+	blessing, err := registry.CreateBlessing(p.db, p.log)(ceremonyId, characterId, text, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Blessing{}, 0, err
+	}
+	count := current.BlessingCount() + 1
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":    ceremonyId,
+		"characterId":   characterId,
+		"blessingCount": count,
+	}).Info("Ceremony blessed")
+
+	return ceremony, blessing, count, nil
+}
+
+// BlessCeremonyAndEmit records an invitee's blessing of an active ceremony and emits a CeremonyBlessed event
+func (p *ProcessorImpl) BlessCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, text string) (registry.Blessing, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (registry.Blessing, error) {
+		ceremony, blessing, count, err := p.blessCeremony(ceremonyId, characterId, text)
+		if err != nil {
+			return registry.Blessing{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := CeremonyBlessedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				blessing.Message(),
+				count,
+				blessing.BlessedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return registry.Blessing{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"characterId":   characterId,
+		}).Debug("CeremonyBlessed event emitted")
+
+		return blessing, nil
+	})
+}
+
+// GiveGift records items an invitee gave from the couple's wish list during their active ceremony. An invitee may give
+// no more of an item than remains on the wish list, nor more than they hold
+func (p *ProcessorImpl) GiveGift(ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) model.Provider[registry.Gift] {
+	return func() (registry.Gift, error) {
+		_, _, gift, err := p.giveGift(uuid.New(), ceremonyId, characterId, itemId, quantity)
+		return gift, err
+	}
+}
+
+// giveGift records a gift whose items the inventory transfer identified by transactionId moves, returning the ceremony
+// and its registry as they stand afterwards alongside the gift
+func (p *ProcessorImpl) giveGift(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (Ceremony, registry.Model, registry.Gift, error) {
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+		"itemId":      itemId,
+		"quantity":    quantity,
+	}).Debug("Giving gift")
+
+	if quantity == 0 {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, ErrInvalidRegistryItem
+	}
+
+	ceremony, err := p.getCeremony(ceremonyId)
+	if err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+	if err = guestError(ceremony, characterId); err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+	item, err := registry.GetItemProvider(p.db, p.log)(ceremonyId, itemId, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+	if item == nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, ErrRegistryNotFound
+	}
+	if quantity > item.Remaining() {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, ErrGiftExceedsRegistry
+	}
+	if err = p.verifyGiftItems(characterId, itemId, quantity); err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+
+	gift, err := registry.CreateGift(p.db, p.log)(transactionId, ceremonyId, characterId, itemId, quantity, t.Id())()
+	if errors.Is(err, registry.ErrItemUnavailable) {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, ErrGiftExceedsRegistry
+	}
+	if err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+
+	updated, err := registry.GetByCeremonyIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil {
+		return Ceremony{}, registry.Model{}, registry.Gift{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+		"itemId":      itemId,
+		"quantity":    quantity,
+		"giftCount":   updated.GiftCount(),
+	}).Info("Gift given")
+
+	return ceremony, updated, gift, nil
+}
+
+// verifyGiftItems reports ErrGiftItemMissing when an invitee holds fewer of an item than they are giving
+func (p *ProcessorImpl) verifyGiftItems(characterId uint32, itemId uint32, quantity uint32) error {
+	held, err := p.inventoryProcessor.GetItemQuantity(characterId, itemId)
+	if err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"characterId": characterId,
+			"itemId":      itemId,
+		}).Error("Failed to verify gift items")
+		return err
+	}
+	if held < quantity {
+		return ErrGiftItemMissing
+	}
+	return nil
+}
+
+// GiveGiftAndEmit records items an invitee gave from the couple's wish list, emits a GiftGiven event and commands the
+// inventory service to move the items to the couple's first partner. The command is staged in the same transaction as
+// the gift, so a gift is recorded only alongside the transfer of its items. Should the transfer fail, the gift is
+// removed again by HandleGiftTransferFailedAndEmit
+func (p *ProcessorImpl) GiveGiftAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Gift, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (registry.Gift, error) {
+		ceremony, updated, gift, err := p.giveGift(transactionId, ceremonyId, characterId, itemId, quantity)
+		if err != nil {
+			return registry.Gift{}, err
+		}
+
+		item, _ := updated.Item(itemId)
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := GiftGivenEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				itemId,
+				gift.Quantity(),
+				item.Received(),
+				item.Remaining(),
+				updated.GiftCount(),
+				gift.GivenAt(),
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+				return err
+			}
+
+			transferProvider := TransferGiftCommandProvider(
+				transactionId,
+				ceremony.Id(),
+				characterId,
+				ceremony.CharacterId1(),
+				itemId,
+				gift.Quantity(),
+			)
+			return buf.Put(inventoryMsg.EnvCommandTopic, transferProvider)
+		})
+		if err != nil {
+			return registry.Gift{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"characterId":   characterId,
+			"itemId":        itemId,
+		}).Debug("GiftGiven event and item transfer command emitted")
+
+		return gift, nil
+	})
+}
+
+// HandleGiftTransferFailedAndEmit removes the gift whose items the inventory service could not move to the couple,
+// identified by the transaction which gave it, so the items no longer count against the wish list. It emits a
+// GiftReturned event, and does nothing when the gift was already removed
+func (p *ProcessorImpl) HandleGiftTransferFailedAndEmit(transactionId uuid.UUID, giftTransactionId uuid.UUID, reason string) error {
+	return p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		p.log.WithFields(logrus.Fields{
+			"giftTransactionId": giftTransactionId,
+			"reason":            reason,
+		}).Debug("Handling failed gift transfer")
+
+		t := tenant.MustFromContext(p.ctx)
+		gift, err := registry.DeleteGift(p.db, p.log)(giftTransactionId, t.Id())()
+		if err != nil {
+			return err
+		}
+		if gift == nil {
+			p.log.WithField("giftTransactionId", giftTransactionId).Debug("Gift already returned, ignoring failed transfer")
+			return nil
+		}
+
+		ceremony, err := p.getCeremony(gift.CeremonyId())
+		if err != nil {
+			return err
+		}
+		updated, err := registry.GetByCeremonyIdProvider(p.db, p.log)(gift.CeremonyId(), t.Id())()
+		if err != nil {
+			return err
+		}
+		var received, remaining uint32
+		if item, ok := updated.Item(gift.ItemId()); ok {
+			received, remaining = item.Received(), item.Remaining()
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := GiftReturnedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				gift.CharacterId(),
+				gift.ItemId(),
+				gift.Quantity(),
+				received,
+				remaining,
+				updated.GiftCount(),
+				reason,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"giftTransactionId": giftTransactionId,
+			"ceremonyId":        gift.CeremonyId(),
+			"characterId":       gift.CharacterId(),
+			"itemId":            gift.ItemId(),
+			"quantity":          gift.Quantity(),
+		}).Info("Gift returned after failed item transfer")
+
+		return nil
+	})
+}
+
+// awardRegistryBondAndEmit awards a newly married couple the bond points earned by the blessings and gifts received at
+// their ceremony, as configured by the tenant, emitting a BondLevelChanged event when the award raises their bond level
+func (p *ProcessorImpl) awardRegistryBondAndEmit(transactionId uuid.UUID, ceremony Ceremony, marriage Marriage) (Marriage, error) {
+	t := tenant.MustFromContext(p.ctx)
+	received, err := registry.GetByCeremonyIdProvider(p.db, p.log)(ceremony.Id(), t.Id())()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	r := p.rules()
+	points := received.BlessingCount()*r.BlessingBondPoints() + received.GiftCount()*r.GiftBondPoints()
+	if points == 0 {
+		return marriage, nil
+	}
+
+	previousLevel, awarded, err := p.awardBondPoints(marriage.CharacterId1(), points, string(BondSourceGift))
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId": ceremony.Id(),
+		"marriageId": awarded.Id(),
+		"blessings":  received.BlessingCount(),
+		"gifts":      received.GiftCount(),
+		"points":     points,
+	}).Info("Ceremony registry bond points awarded")
+
+	if err = p.emitBondLevelChanged(transactionId, awarded, previousLevel, marriageMsg.BondReasonAward, string(BondSourceGift)); err != nil {
+		return Marriage{}, err
+	}
+	return awarded, nil
+}
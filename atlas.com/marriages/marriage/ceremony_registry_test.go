@@ -0,0 +1,275 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+)
+
+func TestProcessor_AddRegistryItemAndEmit(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+
+	if _, err := processor.AddRegistryItemAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 3); !errors.Is(err, ErrNotRegistryOwner) {
+		t.Errorf("Expected only the couple to change their registry, got %v", err)
+	}
+	if _, err := processor.AddRegistryItemAndEmit(uuid.New(), ceremony.Id(), 1, 2000000, 0); !errors.Is(err, ErrInvalidRegistryItem) {
+		t.Errorf("Expected an invalid registry item error, got %v", err)
+	}
+
+	producer.ClearMessages()
+	item, err := processor.AddRegistryItemAndEmit(uuid.New(), ceremony.Id(), 1, 2000000, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item.ItemId() != 2000000 || item.Quantity() != 3 || item.Remaining() != 3 {
+		t.Errorf("Unexpected registry item %+v", item)
+	}
+	if types := producedEventTypes(t, producer); len(types) != 1 || types[0] != marriageMsg.EventRegistryItemAdded {
+		t.Errorf("Expected a registry item added event, got %v", types)
+	}
+	if _, err = processor.AddRegistryItemAndEmit(uuid.New(), ceremony.Id(), 2, 2000000, 1); !errors.Is(err, ErrRegistryItemListed) {
+		t.Errorf("Expected an already listed error, got %v", err)
+	}
+
+	if _, err = processor.RemoveRegistryItemAndEmit(uuid.New(), ceremony.Id(), 2, 2000001); !errors.Is(err, ErrRegistryNotFound) {
+		t.Errorf("Expected registry item not found, got %v", err)
+	}
+	if _, err = processor.RemoveRegistryItemAndEmit(uuid.New(), ceremony.Id(), 2, 2000000); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	current, err := processor.GetCeremonyRegistry(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(current.Items()) != 0 {
+		t.Errorf("Expected the removed item to leave the registry, got %d items", len(current.Items()))
+	}
+}
+
+func TestProcessor_GiveGiftAndEmit(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	inv := NewMockInventoryProcessor()
+	inv.AddItem(3, 2)
+	processor = processor.WithInventoryProcessor(inv)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	if _, err := processor.AddRegistryItem(ceremony.Id(), 1, 2000000, 3)(); err != nil {
+		t.Fatalf("Failed to add registry item: %v", err)
+	}
+
+	if _, err := processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 1); !errors.As(err, new(StateTransitionError)) {
+		t.Errorf("Expected a state transition error before the ceremony starts, got %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+
+	if _, err := processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 1, 2000000, 1); !errors.Is(err, ErrInviteeNotInvited) {
+		t.Errorf("Expected only invitees to give gifts, got %v", err)
+	}
+	if _, err := processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000001, 1); !errors.Is(err, ErrRegistryNotFound) {
+		t.Errorf("Expected registry item not found for an unlisted item, got %v", err)
+	}
+	if _, err := processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 3); !errors.Is(err, ErrGiftItemMissing) {
+		t.Errorf("Expected an invitee to give no more than they hold, got %v", err)
+	}
+
+	producer.ClearMessages()
+	gift, err := processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gift.CharacterId() != 3 || gift.Quantity() != 2 {
+		t.Errorf("Unexpected gift %+v", gift)
+	}
+	messages := producer.GetProducedMessages()
+	if len(messages) != 2 {
+		t.Fatalf("Expected a gift given event and an item transfer command, got %v", producedEventTypes(t, producer))
+	}
+	var event marriageMsg.Event[marriageMsg.GiftGivenBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.Type != marriageMsg.EventGiftGiven || event.Body.Received != 2 || event.Body.Remaining != 1 || event.Body.GiftCount != 2 {
+		t.Errorf("Unexpected gift given event %+v", event.Body)
+	}
+	var command inventoryMsg.Command[inventoryMsg.TransferItemCommandBody]
+	if err = json.Unmarshal(messages[1].Value, &command); err != nil {
+		t.Fatalf("Failed to decode command: %v", err)
+	}
+	if command.Type != inventoryMsg.CommandTransferItem || command.CharacterId != 3 || command.Body.RecipientCharacterId != 1 {
+		t.Errorf("Expected the items to move from the invitee to the first partner, got %+v", command)
+	}
+	if command.Body.ItemId != 2000000 || command.Body.Quantity != 2 || command.Body.CeremonyId != ceremony.Id() {
+		t.Errorf("Unexpected item transfer command %+v", command.Body)
+	}
+
+	if _, err = processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 2); !errors.Is(err, ErrGiftExceedsRegistry) {
+		t.Errorf("Expected a gift exceeding the registry to be rejected, got %v", err)
+	}
+	if _, err = processor.RemoveRegistryItem(ceremony.Id(), 1, 2000000)(); !errors.Is(err, ErrRegistryItemGiven) {
+		t.Errorf("Expected a given item to stay on the registry, got %v", err)
+	}
+
+	current, err := processor.GetCeremonyRegistry(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(current.Gifts()) != 1 || current.GiftCount() != 2 {
+		t.Errorf("Expected the registry to record the gift, got %d gifts", len(current.Gifts()))
+	}
+}
+
+func TestProcessor_HandleGiftTransferFailedAndEmit(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	inv := NewMockInventoryProcessor()
+	inv.AddItem(3, 2)
+	processor = processor.WithInventoryProcessor(inv)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	if _, err := processor.AddRegistryItem(ceremony.Id(), 1, 2000000, 3)(); err != nil {
+		t.Fatalf("Failed to add registry item: %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	giftTransactionId := uuid.New()
+	gift, err := processor.GiveGiftAndEmit(giftTransactionId, ceremony.Id(), 3, 2000000, 2)
+	if err != nil {
+		t.Fatalf("Failed to give gift: %v", err)
+	}
+	if gift.TransactionId() != giftTransactionId {
+		t.Errorf("Expected the gift to record its transfer, got %v", gift.TransactionId())
+	}
+
+	producer.ClearMessages()
+	if err = processor.HandleGiftTransferFailedAndEmit(uuid.New(), giftTransactionId, "insufficient items"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected a gift returned event, got %v", producedEventTypes(t, producer))
+	}
+	var event marriageMsg.Event[marriageMsg.GiftReturnedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.Type != marriageMsg.EventGiftReturned || event.Body.CharacterId != 3 || event.Body.Quantity != 2 {
+		t.Errorf("Unexpected gift returned event %+v", event.Body)
+	}
+	if event.Body.Received != 0 || event.Body.Remaining != 3 || event.Body.GiftCount != 0 || event.Body.Reason != "insufficient items" {
+		t.Errorf("Expected the event to describe the registry without the gift, got %+v", event.Body)
+	}
+
+	current, err := processor.GetCeremonyRegistry(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	item, _ := current.Item(2000000)
+	if len(current.Gifts()) != 0 || item.Received() != 0 {
+		t.Errorf("Expected the gift to be removed, got %d gifts and %d received", len(current.Gifts()), item.Received())
+	}
+
+	producer.ClearMessages()
+	if err = processor.HandleGiftTransferFailedAndEmit(uuid.New(), giftTransactionId, "insufficient items"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Errorf("Expected a redelivered failure to change nothing, got %v", producedEventTypes(t, producer))
+	}
+
+	if _, err = processor.GiveGiftAndEmit(uuid.New(), ceremony.Id(), 3, 2000000, 2); err != nil {
+		t.Errorf("Expected the returned items to be given again, got %v", err)
+	}
+}
+
+func TestProcessor_BlessCeremonyAndEmit(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+
+	if _, err := processor.BlessCeremonyAndEmit(uuid.New(), ceremony.Id(), 3, "Congratulations!"); !errors.As(err, new(StateTransitionError)) {
+		t.Errorf("Expected a state transition error before the ceremony starts, got %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	if _, err := processor.BlessCeremonyAndEmit(uuid.New(), ceremony.Id(), 3, strings.Repeat("a", 121)); !errors.Is(err, ErrBlessingTooLong) {
+		t.Errorf("Expected a blessing too long error, got %v", err)
+	}
+
+	producer.ClearMessages()
+	blessing, err := processor.BlessCeremonyAndEmit(uuid.New(), ceremony.Id(), 3, "Congratulations!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if blessing.CharacterId() != 3 || blessing.Message() != "Congratulations!" {
+		t.Errorf("Unexpected blessing %+v", blessing)
+	}
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected a ceremony blessed event, got %v", producedEventTypes(t, producer))
+	}
+	var event marriageMsg.Event[marriageMsg.CeremonyBlessedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.Type != marriageMsg.EventCeremonyBlessed || event.Body.CharacterId != 3 || event.Body.BlessingCount != 1 {
+		t.Errorf("Unexpected ceremony blessed event %+v", event.Body)
+	}
+
+	if _, err = processor.BlessCeremonyAndEmit(uuid.New(), ceremony.Id(), 3, ""); !errors.Is(err, ErrAlreadyBlessed) {
+		t.Errorf("Expected an invitee to bless a ceremony once, got %v", err)
+	}
+}
+
+func TestProcessor_CompleteCeremony_AwardsRegistryBond(t *testing.T) {
+	db, tenantId, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	thresholds := "10,100"
+	if err := db.Create(&rules.Entity{TenantId: tenantId, BondLevelThresholds: &thresholds, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+	inv := NewMockInventoryProcessor()
+	inv.AddItem(3, 2)
+	processor = processor.WithInventoryProcessor(inv)
+
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	if _, err := processor.AddRegistryItem(ceremony.Id(), 1, 2000000, 3)(); err != nil {
+		t.Fatalf("Failed to add registry item: %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	if _, err := processor.BlessCeremony(ceremony.Id(), 3, "")(); err != nil {
+		t.Fatalf("Failed to bless ceremony: %v", err)
+	}
+	if _, err := processor.GiveGift(ceremony.Id(), 3, 2000000, 2)(); err != nil {
+		t.Fatalf("Failed to give gift: %v", err)
+	}
+	advanceToFinalStage(t, processor, ceremony.Id())
+
+	producer.ClearMessages()
+	if _, err := processor.CompleteCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// One blessing and two items given earn 1 + 2*5 bond points under the default rules
+	married, err := processor.GetMarriageByCharacter(1)()
+	if err != nil || married == nil {
+		t.Fatalf("Failed to get marriage: %v", err)
+	}
+	if married.Status() != StatusMarried || married.BondPoints() != 11 || married.BondLevel() != 1 {
+		t.Errorf("Expected a married couple at bond level 1 with 11 points, got %s at level %d with %d points", married.Status(), married.BondLevel(), married.BondPoints())
+	}
+	if types := producedEventTypes(t, producer); !slices.Contains(types, marriageMsg.EventBondLevelChanged) {
+		t.Errorf("Expected a bond level changed event, got %v", types)
+	}
+}
@@ -0,0 +1,381 @@
+package marriage
+
+import (
+	"fmt"
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	"atlas-marriages/saga"
+
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// sagaTimeoutReason is the failure reason recorded for a saga step which was not completed before its deadline
+const sagaTimeoutReason = "timed out"
+
+// sagaFailureReason is the reason recorded on the cancellation of a ceremony whose saga failed
+const sagaFailureReason = "saga_failed"
+
+// beginCeremonySaga starts the saga orchestrating the services involved in a newly scheduled ceremony and sends the
+// command for its first step. The saga is identified by the transaction which scheduled the ceremony, and records the
+// ceremony's payment as its fee, so that rolling it back refunds the payment
+func (p *ProcessorImpl) beginCeremonySaga(sagaId uuid.UUID, ceremony Ceremony) (saga.Model, error) {
+	t := tenant.MustFromContext(p.ctx)
+
+	m, err := saga.NewCeremonyBuilder(sagaId, t.Id(), ceremony.Id(), ceremony.MarriageId(), ceremony.CharacterId1(), ceremony.CharacterId2()).
+		SetFee(ceremony.Cost()).
+		Build()
+	if err != nil {
+		return saga.Model{}, err
+	}
+	m, err = saga.Create(p.db, p.log)(m)()
+	if err != nil {
+		return saga.Model{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"sagaId":     m.Id(),
+		"ceremonyId": ceremony.Id(),
+	}).Debug("Ceremony saga started")
+
+	return p.advanceCeremonySaga(m, ceremony)
+}
+
+// advanceCeremonySaga starts the next step of a ceremony saga, persists its progress and sends the step command
+func (p *ProcessorImpl) advanceCeremonySaga(m saga.Model, ceremony Ceremony) (saga.Model, error) {
+	next, step, err := m.Next(ceremony.IsActive(), p.rules().SagaStepTimeout(), time.Now())
+	if err != nil {
+		return saga.Model{}, err
+	}
+	next, err = saga.Update(p.db, p.log)(next)()
+	if err != nil {
+		return saga.Model{}, err
+	}
+
+	if step == nil {
+		p.log.WithFields(logrus.Fields{
+			"sagaId":     next.Id(),
+			"ceremonyId": ceremony.Id(),
+			"status":     next.Status().String(),
+		}).Debug("Ceremony saga has no step to start")
+		return next, nil
+	}
+
+	if err = p.sendSagaStepCommand(next, *step, ceremony); err != nil {
+		return saga.Model{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"sagaId":     next.Id(),
+		"ceremonyId": ceremony.Id(),
+		"step":       step.Action(),
+	}).Debug("Ceremony saga step started")
+
+	return next, nil
+}
+
+// sendSagaStepCommand sends the command asking a participant to perform a saga step
+func (p *ProcessorImpl) sendSagaStepCommand(m saga.Model, step saga.Step, ceremony Ceremony) error {
+	return message.Emit(p.producer)(func(buf *message.Buffer) error {
+		switch step.Action() {
+		case saga.ActionReserveChapel:
+			return buf.Put(sagaMsg.EnvCommandTopic, ReserveChapelCommandProvider(m.Id(), m.CeremonyId(), m.CharacterId1(), m.CharacterId2(), ceremony.ScheduledAt()))
+		case saga.ActionWarpGuests:
+			characterIds := append([]uint32{m.CharacterId1(), m.CharacterId2()}, ceremony.Invitees()...)
+			return buf.Put(sagaMsg.EnvCommandTopic, WarpGuestsCommandProvider(m.Id(), m.CeremonyId(), characterIds))
+		default:
+			return fmt.Errorf("unknown saga step %s", step.Action())
+		}
+	})
+}
+
+// sendSagaCompensations sends the commands rolling back the completed steps of a compensated saga, most recent first.
+// The commands are staged with the change compensating the saga, so a fee is refunded once and only if it commits. A
+// fee is refunded under the payment the ceremony was scheduled with
+func (p *ProcessorImpl) sendSagaCompensations(m saga.Model, steps []saga.Step, ceremony Ceremony) error {
+	return message.Emit(p.producer)(func(buf *message.Buffer) error {
+		for _, step := range steps {
+			var err error
+			switch step.Action() {
+			case saga.ActionReserveChapel:
+				err = buf.Put(sagaMsg.EnvCommandTopic, ReleaseChapelCommandProvider(m.Id(), m.CeremonyId(), m.CharacterId1(), m.CharacterId2()))
+			case saga.ActionChargeFee:
+				if !ceremony.IsPaid() {
+					return fmt.Errorf("ceremony %d records no payment for its fee", ceremony.Id())
+				}
+				err = buf.Put(sagaMsg.EnvCommandTopic, RefundFeeCommandProvider(m.Id(), m.CeremonyId(), m.CharacterId1(), m.Fee(), *ceremony.PaymentId()))
+			default:
+				err = fmt.Errorf("saga step %s cannot be compensated", step.Action())
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// resumeCeremonySaga starts the steps of a started ceremony's saga which were awaiting the ceremony start
+func (p *ProcessorImpl) resumeCeremonySaga(ceremony Ceremony) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	m, err := saga.GetUnfinishedByCeremonyIdProvider(p.db, p.log)(ceremony.Id(), t.Id())()
+	if err != nil {
+		return err
+	}
+	// A saga with a step in progress moves on once the participant reports back
+	if m == nil || m.Status() != saga.StatusAwaiting {
+		return nil
+	}
+	_, err = p.advanceCeremonySaga(*m, ceremony)
+	return err
+}
+
+// abortCeremonySaga compensates the saga of a ceremony which was cancelled outside of the saga
+func (p *ProcessorImpl) abortCeremonySaga(ceremonyId uint32, reason string) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	m, err := saga.GetUnfinishedByCeremonyIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil || m == nil {
+		return err
+	}
+
+	ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil {
+		return err
+	}
+	if ceremony == nil {
+		return ErrCeremonyNotFound
+	}
+
+	aborted, rollback, err := m.Abort(reason, time.Now())
+	if err != nil {
+		return err
+	}
+	if aborted, err = saga.Update(p.db, p.log)(aborted)(); err != nil {
+		return err
+	}
+	if err = p.sendSagaCompensations(aborted, rollback, *ceremony); err != nil {
+		return err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"sagaId":      aborted.Id(),
+		"ceremonyId":  ceremonyId,
+		"compensated": len(rollback),
+		"reason":      reason,
+	}).Info("Ceremony saga aborted")
+
+	return nil
+}
+
+// rewindCeremonySaga releases the chapel reserved by the saga of a ceremony which was postponed or rescheduled, leaving
+// the saga to reserve it again once the ceremony has its new time
+func (p *ProcessorImpl) rewindCeremonySaga(ceremony Ceremony) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	m, err := saga.GetUnfinishedByCeremonyIdProvider(p.db, p.log)(ceremony.Id(), t.Id())()
+	if err != nil || m == nil {
+		return err
+	}
+
+	rewound, release, err := m.Rewind(time.Now())
+	if err != nil {
+		return err
+	}
+	if rewound, err = saga.Update(p.db, p.log)(rewound)(); err != nil {
+		return err
+	}
+	if err = p.sendSagaCompensations(rewound, release, ceremony); err != nil {
+		return err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"sagaId":     rewound.Id(),
+		"ceremonyId": ceremony.Id(),
+		"released":   len(release),
+	}).Info("Ceremony saga rewound")
+
+	return nil
+}
+
+// finishCeremonySaga completes the saga of a completed ceremony, skipping any step which has not run
+func (p *ProcessorImpl) finishCeremonySaga(ceremonyId uint32) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	m, err := saga.GetUnfinishedByCeremonyIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil || m == nil {
+		return err
+	}
+
+	finished, err := m.Finish(time.Now())
+	if err != nil {
+		return err
+	}
+	_, err = saga.Update(p.db, p.log)(finished)()
+	return err
+}
+
+// failCeremonySaga records the failure of a saga step, sends the commands rolling back the completed steps and
+// cancels the ceremony
+func (p *ProcessorImpl) failCeremonySaga(m saga.Model, action saga.Action, reason string) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	failed, rollback, err := m.Fail(action, reason, time.Now())
+	if err != nil {
+		return err
+	}
+	if failed, err = saga.Update(p.db, p.log)(failed)(); err != nil {
+		return err
+	}
+
+	ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(m.CeremonyId(), t.Id())()
+	if err != nil {
+		return err
+	}
+	if ceremony == nil {
+		return ErrCeremonyNotFound
+	}
+	if err = p.sendSagaCompensations(failed, rollback, *ceremony); err != nil {
+		return err
+	}
+	if ceremony.CanCancel() {
+		cancelled, err := p.CancelCeremony(ceremony.Id())()
+		if err != nil {
+			return err
+		}
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			cancelledAt := time.Now()
+			if cancelled.CancelledAt() != nil {
+				cancelledAt = *cancelled.CancelledAt()
+			}
+			eventProvider := CeremonyCancelledEventProvider(
+				cancelled.Id(),
+				cancelled.MarriageId(),
+				cancelled.CharacterId1(),
+				cancelled.CharacterId2(),
+				cancelledAt,
+				0, // Cancelled by the service rather than a character
+				sagaFailureReason,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"sagaId":      failed.Id(),
+		"ceremonyId":  m.CeremonyId(),
+		"step":        action,
+		"reason":      reason,
+		"compensated": len(rollback),
+	}).Warn("Ceremony saga step failed, saga compensated")
+
+	return nil
+}
+
+// currentSagaStep retrieves a saga and reports whether the given step is in progress. Outcomes for sagas which do
+// not exist, have finished or have moved on are redeliveries or arrive too late, and are ignored
+func (p *ProcessorImpl) currentSagaStep(sagaId uuid.UUID, step string) (saga.Model, bool, error) {
+	t := tenant.MustFromContext(p.ctx)
+	l := p.log.WithFields(logrus.Fields{
+		"sagaId": sagaId,
+		"step":   step,
+	})
+
+	m, err := saga.GetByIdProvider(p.db, p.log)(sagaId, t.Id())()
+	if err != nil {
+		return saga.Model{}, false, err
+	}
+	if m == nil {
+		l.Warn("Ignoring outcome for unknown saga")
+		return saga.Model{}, false, nil
+	}
+	current, ok := m.CurrentStep()
+	if !ok || current.Action() != saga.Action(step) {
+		l.WithField("status", m.Status().String()).Debug("Ignoring outcome for saga step which is not in progress")
+		return saga.Model{}, false, nil
+	}
+	return *m, true, nil
+}
+
+// HandleSagaStepCompletedAndEmit records a participant's completion of a ceremony saga step and starts the next step
+func (p *ProcessorImpl) HandleSagaStepCompletedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string) error {
+	return p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		m, ok, err := p.currentSagaStep(sagaId, step)
+		if err != nil || !ok {
+			return err
+		}
+
+		completed, err := m.CompleteStep(saga.Action(step), time.Now())
+		if err != nil {
+			return err
+		}
+
+		t := tenant.MustFromContext(p.ctx)
+		ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(m.CeremonyId(), t.Id())()
+		if err != nil {
+			return err
+		}
+		if ceremony == nil {
+			return ErrCeremonyNotFound
+		}
+
+		_, err = p.advanceCeremonySaga(completed, *ceremony)
+		return err
+	})
+}
+
+// HandleSagaStepFailedAndEmit records a participant's failure to perform a ceremony saga step, rolling back the
+// completed steps and cancelling the ceremony
+func (p *ProcessorImpl) HandleSagaStepFailedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string, reason string) error {
+	return p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		m, ok, err := p.currentSagaStep(sagaId, step)
+		if err != nil || !ok {
+			return err
+		}
+		return p.failCeremonySaga(m, saga.Action(step), reason)
+	})
+}
+
+// ProcessSagaTimeouts fails every ceremony saga step which was not completed before its deadline, compensating
+// each saga in its own transaction
+func (p *ProcessorImpl) ProcessSagaTimeouts() error {
+	t := tenant.MustFromContext(p.ctx)
+
+	sagas, err := saga.GetTimedOutProvider(p.db, p.log)(t.Id(), time.Now())()
+	if err != nil {
+		p.log.WithError(err).Error("Failed to retrieve timed out sagas")
+		return err
+	}
+
+	var failures int
+	for _, m := range sagas {
+		current, ok := m.CurrentStep()
+		if !ok {
+			continue
+		}
+		err = p.emitInTransaction(uuid.New(), func(p *ProcessorImpl) error {
+			latest, ok, err := p.currentSagaStep(m.Id(), string(current.Action()))
+			if err != nil || !ok || !latest.IsTimedOut(time.Now()) {
+				return err
+			}
+			return p.failCeremonySaga(latest, current.Action(), sagaTimeoutReason)
+		})
+		if err != nil {
+			failures++
+			p.log.WithError(err).WithField("sagaId", m.Id()).Error("Failed to compensate timed out saga")
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to compensate %d of %d timed out sagas", failures, len(sagas))
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+package marriage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupSagaTest creates a processor for a tenant with the default rules, and an engaged couple
+func setupSagaTest(t *testing.T) (*gorm.DB, uuid.UUID, *MockProducer, Processor, Entity) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	producer := NewMockProducer()
+	processor := NewProcessor(log, setupTestContext(tenantId), db).WithProducer(producer.Provider)
+
+	now := time.Now()
+	marriageEntity := Entity{
+		CharacterId1: 1,
+		CharacterId2: 2,
+		Status:       StatusEngaged,
+		ProposedAt:   now,
+		EngagedAt:    &now,
+		TenantId:     tenantId,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := db.Create(&marriageEntity).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+	return db, tenantId, producer, processor, marriageEntity
+}
+
+// messagesByType groups the produced messages by their type, as events and commands are produced to separate topics
+func messagesByType(t *testing.T, producer *MockProducer) map[string][][]byte {
+	result := make(map[string][][]byte)
+	for _, m := range producer.GetProducedMessages() {
+		var header struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(m.Value, &header); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		result[header.Type] = append(result[header.Type], m.Value)
+	}
+	return result
+}
+
+// loadSaga retrieves a saga, failing the test if it does not exist
+func loadSaga(t *testing.T, db *gorm.DB, tenantId uuid.UUID, sagaId uuid.UUID) saga.Model {
+	m, err := saga.GetByIdProvider(db, logrus.New())(sagaId, tenantId)()
+	if err != nil || m == nil {
+		t.Fatalf("Failed to load saga %s: %v", sagaId, err)
+	}
+	return *m
+}
+
+func TestCeremonySaga_RunsStepsInOrder(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+
+	sagaId := uuid.New()
+	ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{3, 4}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if len(messagesByType(t, producer)[sagaMsg.CommandReserveChapel]) != 1 {
+		t.Fatalf("Expected the chapel to be reserved when the ceremony is scheduled")
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.CeremonyId() != ceremony.Id() || m.Status() != saga.StatusRunning {
+		t.Fatalf("Expected a running saga for ceremony %d, got %s for ceremony %d", ceremony.Id(), m.Status(), m.CeremonyId())
+	}
+
+	producer.ClearMessages()
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Fatalf("Failed to complete chapel reservation: %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Fatalf("Expected guests to be warped only once the ceremony starts")
+	}
+
+	// A redelivered outcome for a finished step is ignored
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Fatalf("Expected a redelivered outcome to be ignored, got %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Fatalf("Expected no messages for a redelivered outcome, got %d", len(producer.GetProducedMessages()))
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusAwaiting {
+		t.Fatalf("Expected the saga to await the ceremony start, got %s", m.Status())
+	}
+
+	if _, err = processor.StartCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	warps := messagesByType(t, producer)[sagaMsg.CommandWarpGuests]
+	if len(warps) != 1 {
+		t.Fatalf("Expected guests to be warped when the ceremony starts")
+	}
+	var warp sagaMsg.Command[sagaMsg.WarpGuestsBody]
+	if err = json.Unmarshal(warps[0], &warp); err != nil {
+		t.Fatalf("Failed to decode warp command: %v", err)
+	}
+	if len(warp.Body.CharacterIds) != 4 {
+		t.Errorf("Expected the couple and both invitees to be warped, got %v", warp.Body.CharacterIds)
+	}
+
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandWarpGuests); err != nil {
+		t.Fatalf("Failed to complete guest warp: %v", err)
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusCompleted {
+		t.Fatalf("Expected the saga to be completed, got %s", m.Status())
+	}
+}
+
+func TestCeremonySaga_StepFailureCompensates(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+
+	sagaId := uuid.New()
+	ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Fatalf("Failed to complete chapel reservation: %v", err)
+	}
+	if _, err = processor.StartCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+
+	producer.ClearMessages()
+	if err = processor.HandleSagaStepFailedAndEmit(uuid.New(), sagaId, sagaMsg.CommandWarpGuests, "map unavailable"); err != nil {
+		t.Fatalf("Failed to handle warp failure: %v", err)
+	}
+
+	messages := messagesByType(t, producer)
+	if len(messages[sagaMsg.CommandReleaseChapel]) != 1 {
+		t.Errorf("Expected the chapel reservation to be released")
+	}
+	if len(messages[marriageMsg.EventCeremonyCancelled]) != 1 {
+		t.Fatalf("Expected the ceremony to be cancelled")
+	}
+	var event marriageMsg.Event[marriageMsg.CeremonyCancelledBody]
+	if err = json.Unmarshal(messages[marriageMsg.EventCeremonyCancelled][0], &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.Body.CeremonyId != ceremony.Id() || event.Body.Reason != sagaFailureReason {
+		t.Errorf("Unexpected cancellation %+v", event.Body)
+	}
+
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusCompensated {
+		t.Errorf("Expected the saga to be compensated, got %s", m.Status())
+	}
+	var cancelled CeremonyEntity
+	if err = db.First(&cancelled, ceremony.Id()).Error; err != nil || cancelled.Status != CeremonyStatusCancelled {
+		t.Errorf("Expected the ceremony to be cancelled, got %v (%v)", cancelled.Status, err)
+	}
+}
+
+func TestCeremonySaga_CancellationCompensatesCompletedSteps(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+
+	sagaId := uuid.New()
+	ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Fatalf("Failed to complete chapel reservation: %v", err)
+	}
+
+	producer.ClearMessages()
+	if _, err = processor.CancelCeremonyAndEmit(uuid.New(), ceremony.Id(), marriageEntity.CharacterId1, "changed_mind"); err != nil {
+		t.Fatalf("Failed to cancel ceremony: %v", err)
+	}
+
+	messages := messagesByType(t, producer)
+	if len(messages[sagaMsg.CommandReleaseChapel]) != 1 {
+		t.Errorf("Expected the chapel to be released")
+	}
+	m := loadSaga(t, db, tenantId, sagaId)
+	if m.Status() != saga.StatusCompensated || m.FailureReason() != "changed_mind" {
+		t.Errorf("Expected the saga to be compensated for the cancellation, got %s (%s)", m.Status(), m.FailureReason())
+	}
+}
+
+// missCeremony schedules a ceremony whose chapel was reserved, then backdates it so its grace period has passed
+func missCeremony(t *testing.T, db *gorm.DB, processor Processor, marriageId uint32, sagaId uuid.UUID) Ceremony {
+	ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageId, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Fatalf("Failed to complete chapel reservation: %v", err)
+	}
+	if err = db.Model(&CeremonyEntity{}).Where("id = ?", ceremony.Id()).Update("scheduled_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("Failed to backdate ceremony: %v", err)
+	}
+	return ceremony
+}
+
+func TestCeremonySaga_MissedCeremonyReleasesChapelUntilRescheduled(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+	sagaId := uuid.New()
+	ceremony := missCeremony(t, db, processor, marriageEntity.ID, sagaId)
+
+	producer.ClearMessages()
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(messagesByType(t, producer)[sagaMsg.CommandReleaseChapel]) != 1 {
+		t.Errorf("Expected the chapel to be released when the ceremony is missed")
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusAwaiting {
+		t.Errorf("Expected the saga to await the ceremony being rescheduled, got %s", m.Status())
+	}
+
+	producer.ClearMessages()
+	rescheduledAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	if _, err := processor.RescheduleCeremonyAndEmit(uuid.New(), ceremony.Id(), rescheduledAt, marriageEntity.CharacterId1); err != nil {
+		t.Fatalf("Failed to reschedule ceremony: %v", err)
+	}
+	messages := messagesByType(t, producer)
+	if len(messages[sagaMsg.CommandReleaseChapel]) != 0 {
+		t.Errorf("Expected a released chapel not to be released again")
+	}
+	if len(messages[sagaMsg.CommandReserveChapel]) != 1 {
+		t.Fatalf("Expected the chapel to be reserved again for the rescheduled ceremony")
+	}
+	var reserve sagaMsg.Command[sagaMsg.ReserveChapelBody]
+	if err := json.Unmarshal(messages[sagaMsg.CommandReserveChapel][0], &reserve); err != nil {
+		t.Fatalf("Failed to decode reserve command: %v", err)
+	}
+	if !reserve.Body.ScheduledAt.Equal(rescheduledAt) {
+		t.Errorf("Expected the chapel to be reserved for %v, got %v", rescheduledAt, reserve.Body.ScheduledAt)
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusRunning {
+		t.Errorf("Expected the saga to run again, got %s", m.Status())
+	}
+}
+
+func TestCeremonySaga_CancelledMissedCeremonyCompensates(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+	cancel := true
+	if err := db.Create(&rules.Entity{TenantId: tenantId, CancelMissedCeremonies: &cancel, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+	sagaId := uuid.New()
+	missCeremony(t, db, processor, marriageEntity.ID, sagaId)
+
+	producer.ClearMessages()
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(messagesByType(t, producer)[sagaMsg.CommandReleaseChapel]) != 1 {
+		t.Errorf("Expected the chapel to be released when the missed ceremony is cancelled")
+	}
+	if m := loadSaga(t, db, tenantId, sagaId); m.Status() != saga.StatusCompensated {
+		t.Errorf("Expected the saga to be compensated, got %s", m.Status())
+	}
+}
+
+func TestProcessor_ProcessSagaTimeouts(t *testing.T) {
+	db, tenantId, producer, processor, marriageEntity := setupSagaTest(t)
+
+	sagaId := uuid.New()
+	ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+
+	// Nothing has timed out yet
+	producer.ClearMessages()
+	if err = processor.ProcessSagaTimeouts(); err != nil {
+		t.Fatalf("Failed to process saga timeouts: %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Fatalf("Expected no messages before the step deadline")
+	}
+
+	if err = db.Model(&saga.Entity{}).Where("id = ?", sagaId).Update("step_deadline", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatalf("Failed to expire saga step: %v", err)
+	}
+	if err = processor.ProcessSagaTimeouts(); err != nil {
+		t.Fatalf("Failed to process saga timeouts: %v", err)
+	}
+
+	m := loadSaga(t, db, tenantId, sagaId)
+	if m.Status() != saga.StatusCompensated {
+		t.Fatalf("Expected the timed out saga to be compensated, got %s", m.Status())
+	}
+	if len(messagesByType(t, producer)[marriageMsg.EventCeremonyCancelled]) != 1 {
+		t.Errorf("Expected the ceremony of the timed out saga to be cancelled")
+	}
+	var cancelled CeremonyEntity
+	if err = db.First(&cancelled, ceremony.Id()).Error; err != nil || cancelled.Status != CeremonyStatusCancelled {
+		t.Errorf("Expected the ceremony to be cancelled, got %v (%v)", cancelled.Status, err)
+	}
+
+	// A late outcome for the timed out step is ignored
+	if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+		t.Errorf("Expected a late outcome to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package marriage
+
+import (
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ceremonyMissedReason is the reason recorded on events for ceremonies not started within the grace period
+const ceremonyMissedReason = "ceremony_missed"
+
+// RemindCeremonyAndEmit records that the couple and their invitees were reminded that a scheduled ceremony is about to
+// start, and emits a CeremonyReminder event
+func (p *ProcessorImpl) RemindCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		t := tenant.MustFromContext(p.ctx)
+
+		ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		if ceremony == nil {
+			return Ceremony{}, ErrCeremonyNotFound
+		}
+		if ceremony.Status() != CeremonyStatusScheduled {
+			return Ceremony{}, ceremonyTransitionError(*ceremony, ceremony.Status())
+		}
+
+		reminded, err := ceremony.Remind()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		entity, err := UpdateCeremony(p.db, p.log)(ceremonyId, reminded.ToEntity(), t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		result, err := MakeCeremony(entity)
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := CeremonyReminderEventProvider(
+				result.Id(),
+				result.MarriageId(),
+				result.CharacterId1(),
+				result.CharacterId2(),
+				result.ScheduledAt(),
+				result.Invitees(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"scheduledAt":   result.ScheduledAt(),
+		}).Info("Ceremony reminder emitted")
+
+		return result, nil
+	})
+}
+
+// MissCeremonyAndEmit postpones a scheduled ceremony which was not started within the grace period after its scheduled
+// start, releasing the chapel its saga reserved, and emits a CeremonyPostponed event. The couple must reschedule it
+func (p *ProcessorImpl) MissCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		t := tenant.MustFromContext(p.ctx)
+
+		ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		if ceremony == nil {
+			return Ceremony{}, ErrCeremonyNotFound
+		}
+		if ceremony.Status() != CeremonyStatusScheduled {
+			return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusPostponed)
+		}
+
+		missed, err := ceremony.Miss()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		entity, err := UpdateCeremony(p.db, p.log)(ceremonyId, missed.ToEntity(), t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		if err = p.releaseVenue(*ceremony); err != nil {
+			return Ceremony{}, err
+		}
+		// The chapel is reserved again when the couple reschedule the ceremony
+		if err = p.rewindCeremonySaga(*ceremony); err != nil {
+			return Ceremony{}, err
+		}
+		result, err := MakeCeremony(entity)
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := CeremonyPostponedEventProvider(
+				result.Id(),
+				result.MarriageId(),
+				result.CharacterId1(),
+				result.CharacterId2(),
+				*result.PostponedAt(),
+				ceremonyMissedReason,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+		}).Info("Missed ceremony postponed")
+
+		return result, nil
+	})
+}
+
+// ProcessCeremonySchedules reminds the couple and invitees of the tenant's ceremonies which are about to start, starts
+// due ceremonies once both partners are logged in, and cancels or postpones ceremonies not started within the grace
+// period, as the tenant's rules configure
+func (p *ProcessorImpl) ProcessCeremonySchedules() error {
+	p.log.Debug("Processing ceremony schedules")
+
+	t := tenant.MustFromContext(p.ctx)
+	r := p.rules()
+
+	if leadTime := r.CeremonyReminderLeadTime(); leadTime > 0 {
+		ceremonies, err := GetCeremoniesAwaitingReminderProvider(p.db, p.log)(leadTime, t.Id())()
+		if err != nil {
+			p.log.WithError(err).Error("Failed to retrieve ceremonies awaiting a reminder")
+			return err
+		}
+		for _, ceremony := range ceremonies {
+			if _, err := p.RemindCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+				p.log.WithFields(logrus.Fields{
+					"ceremonyId": ceremony.Id(),
+					"error":      err,
+				}).Error("Failed to remind ceremony")
+				// Continue processing other ceremonies even if one fails
+				continue
+			}
+		}
+	}
+
+	ceremonies, err := GetDueCeremoniesProvider(p.db, p.log)(t.Id())()
+	if err != nil {
+		p.log.WithError(err).Error("Failed to retrieve due ceremonies")
+		return err
+	}
+
+	now := time.Now()
+	for _, ceremony := range ceremonies {
+		var online bool
+		online, err = GetPartnersOnlineProvider(p.db, p.log)(ceremony, t.Id())()
+		if err != nil {
+			p.log.WithFields(logrus.Fields{
+				"ceremonyId": ceremony.Id(),
+				"error":      err,
+			}).Error("Failed to retrieve presence of ceremony partners")
+			continue
+		}
+
+		switch {
+		case online:
+			_, err = p.StartCeremonyAndEmit(uuid.New(), ceremony.Id())
+		case !ceremony.IsMissed(now, r.CeremonyGracePeriod()):
+			// Waiting for the couple to log in
+			continue
+		case r.CancelMissedCeremonies():
+			_, err = p.CancelCeremonyAndEmit(uuid.New(), ceremony.Id(), 0, ceremonyMissedReason)
+		default:
+			_, err = p.MissCeremonyAndEmit(uuid.New(), ceremony.Id())
+		}
+		if err != nil {
+			p.log.WithFields(logrus.Fields{
+				"ceremonyId": ceremony.Id(),
+				"error":      err,
+			}).Error("Failed to process due ceremony")
+			// Continue processing other ceremonies even if one fails
+			continue
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package marriage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupCeremonyScheduleTest creates a processor emitting to a mock producer, and an engaged couple of characters 1
+// and 2
+func setupCeremonyScheduleTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor, *MockProducer, uint32) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	couple := createMarriedCouple(t, db, tenantId, 1, 2, StatusEngaged, 0)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).WithProducer(producer.Provider)
+	return db, tenantId, processor, producer, couple.ID
+}
+
+// scheduleCeremonyAt schedules the couple's ceremony with one invitee, starting at the given time
+func scheduleCeremonyAt(t *testing.T, processor Processor, marriageId uint32, scheduledAt time.Time) Ceremony {
+	ceremony, err := processor.ScheduleCeremony(marriageId, scheduledAt, []uint32{3})()
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	return ceremony
+}
+
+// producedEventTypes returns the types of the events among the produced messages, in order
+func producedEventTypes(t *testing.T, producer *MockProducer) []string {
+	types := make([]string, 0)
+	for _, m := range producer.GetProducedMessages() {
+		var event marriageMsg.Event[json.RawMessage]
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// countEvents returns how many of the event types are of the given type
+func countEvents(types []string, eventType string) int {
+	count := 0
+	for _, t := range types {
+		if t == eventType {
+			count++
+		}
+	}
+	return count
+}
+
+// getCeremony retrieves a ceremony, failing the test if it does not exist
+func getCeremony(t *testing.T, processor Processor, ceremonyId uint32) Ceremony {
+	ceremony, err := processor.GetCeremonyById(ceremonyId)()
+	if err != nil || ceremony == nil {
+		t.Fatalf("Failed to get ceremony: %v", err)
+	}
+	return *ceremony
+}
+
+func TestProcessor_ProcessCeremonySchedules_Reminder(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(10*time.Minute))
+
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.CeremonyReminderBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventCeremonyReminder || event.Body.CeremonyId != ceremony.Id() || len(event.Body.Invitees) != 1 {
+		t.Errorf("Unexpected ceremony reminder event %+v", event)
+	}
+	if getCeremony(t, processor, ceremony.Id()).RemindedAt() == nil {
+		t.Error("Expected the reminder to be recorded")
+	}
+
+	// The couple is only reminded once
+	producer.ClearMessages()
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Errorf("Expected no messages, got %v", producedEventTypes(t, producer))
+	}
+}
+
+func TestProcessor_ProcessCeremonySchedules_StartsOncePartnersLoggedIn(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(-time.Minute))
+
+	// A due ceremony waits for both partners to be logged in within the grace period
+	for _, characterId := range []uint32{1, 2} {
+		if _, err := processor.ReconnectPartner(characterId)(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if _, err := processor.DisconnectPartner(2)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusScheduled {
+		t.Fatalf("Expected ceremony to await the second partner, got %s", status)
+	}
+
+	if _, err := processor.ReconnectPartner(2)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusActive {
+		t.Errorf("Expected ceremony to start, got %s", status)
+	}
+	types := producedEventTypes(t, producer)
+	if len(types) != 1 || types[0] != marriageMsg.EventCeremonyStarted {
+		t.Errorf("Expected a ceremony started event, got %v", types)
+	}
+}
+
+func TestProcessor_ProcessCeremonySchedules_MissedCeremony(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(-time.Hour))
+
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	postponed := getCeremony(t, processor, ceremony.Id())
+	if postponed.Status() != CeremonyStatusPostponed || postponed.PostponedAt() == nil {
+		t.Fatalf("Expected missed ceremony to be postponed, got %s", postponed.Status())
+	}
+
+	var event marriageMsg.Event[marriageMsg.CeremonyPostponedBody]
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventCeremonyPostponed || event.Body.Reason != ceremonyMissedReason {
+		t.Errorf("Expected ceremony postponed as missed, got %+v", event)
+	}
+
+	// The couple can reschedule the missed ceremony
+	if _, err := processor.RescheduleCeremony(ceremony.Id(), time.Now().Add(time.Hour))(); err != nil {
+		t.Errorf("Expected missed ceremony to be rescheduled, got %v", err)
+	}
+}
+
+func TestProcessor_ProcessCeremonySchedules_CancelsMissedCeremony(t *testing.T) {
+	db, tenantId, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	cancel := true
+	if err := db.Create(&rules.Entity{TenantId: tenantId, CancelMissedCeremonies: &cancel, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(-time.Hour))
+	if err := processor.ProcessCeremonySchedules(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusCancelled {
+		t.Errorf("Expected missed ceremony to be cancelled, got %s", status)
+	}
+	types := producedEventTypes(t, producer)
+	if len(types) != 1 || types[0] != marriageMsg.EventCeremonyCancelled {
+		t.Errorf("Expected a ceremony cancelled event, got %v", types)
+	}
+}
@@ -0,0 +1,102 @@
+package marriage
+
+import (
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// AdvanceCeremonyStage advances an active ceremony to its next stage. When stage is given it must be the ceremony's
+// next stage, guarding against an officiant repeating or skipping a step
+func (p *ProcessorImpl) AdvanceCeremonyStage(ceremonyId uint32, stage string) model.Provider[Ceremony] {
+	return func() (Ceremony, error) {
+		p.log.WithFields(logrus.Fields{
+			"ceremonyId": ceremonyId,
+			"stage":      stage,
+		}).Debug("Advancing ceremony stage")
+
+		t := tenant.MustFromContext(p.ctx)
+
+		ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		if ceremony == nil {
+			return Ceremony{}, ErrCeremonyNotFound
+		}
+
+		if err = stageAdvanceError(*ceremony, stage); err != nil {
+			return Ceremony{}, err
+		}
+
+		advanced, err := ceremony.AdvanceStage()
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		entity, err := UpdateCeremony(p.db, p.log)(ceremonyId, advanced.ToEntity(), t.Id())()
+		if err != nil {
+			return Ceremony{}, err
+		}
+		result, err := MakeCeremony(entity)
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"ceremonyId":    ceremonyId,
+			"previousStage": ceremony.Stage(),
+			"stage":         result.Stage(),
+			"finalStage":    result.StagesCompleted(),
+		}).Info("Ceremony stage advanced")
+
+		return result, nil
+	}
+}
+
+// AdvanceCeremonyStageAndEmit advances an active ceremony to its next stage and emits a CeremonyStageChanged event
+func (p *ProcessorImpl) AdvanceCeremonyStageAndEmit(transactionId uuid.UUID, ceremonyId uint32, stage string, advancedBy uint32) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.AdvanceCeremonyStage(ceremonyId, stage)()
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		// A ceremony advances one stage at a time, so the stage before the current one is the stage it left
+		previousStage := ""
+		if index := ceremony.StageIndex(); index > 0 {
+			previousStage = ceremony.Stages()[index-1]
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := CeremonyStageChangedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				previousStage,
+				ceremony.Stage(),
+				ceremony.StageIndex(),
+				ceremony.StagesCompleted(),
+				advancedBy,
+				ceremony.UpdatedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"stage":         ceremony.Stage(),
+		}).Debug("CeremonyStageChanged event emitted")
+
+		return ceremony, nil
+	})
+}
@@ -0,0 +1,169 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// advanceToFinalStage advances an active ceremony through each of its stages so that it may be completed
+func advanceToFinalStage(t *testing.T, processor Processor, ceremonyId uint32) Ceremony {
+	ceremony := getCeremony(t, processor, ceremonyId)
+	for !ceremony.StagesCompleted() {
+		var err error
+		ceremony, err = processor.AdvanceCeremonyStage(ceremonyId, "")()
+		if err != nil {
+			t.Fatalf("Failed to advance ceremony stage: %v", err)
+		}
+	}
+	return ceremony
+}
+
+// setCeremonyStages configures the stages of the tenant's ceremonies
+func setCeremonyStages(t *testing.T, db *gorm.DB, tenantId uuid.UUID, stages string) {
+	if err := db.Save(&rules.Entity{TenantId: tenantId, CeremonyStages: &stages, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to save rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+}
+
+// startCeremony schedules and starts the couple's ceremony
+func startCeremony(t *testing.T, processor Processor, marriageId uint32) Ceremony {
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	started, err := processor.StartCeremony(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	return started
+}
+
+func TestProcessor_ScheduleCeremony_SnapshotsStages(t *testing.T) {
+	db, tenantId, processor, _, marriageId := setupCeremonyScheduleTest(t)
+	expected := []string{"VOWS", "RING_EXCHANGE", "RECEPTION"}
+	setCeremonyStages(t, db, tenantId, "VOWS,RING_EXCHANGE,RECEPTION")
+
+	ceremony := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	if !reflect.DeepEqual(ceremony.Stages(), expected) || ceremony.Stage() != "" {
+		t.Fatalf("Expected the tenant's stages before the first stage, got %v at %q", ceremony.Stages(), ceremony.Stage())
+	}
+
+	// A change to the tenant's stages does not alter ceremonies already scheduled
+	setCeremonyStages(t, db, tenantId, "VOWS,RECEPTION")
+
+	if got := getCeremony(t, processor, ceremony.Id()).Stages(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected the scheduled ceremony to keep its stages, got %v", got)
+	}
+}
+
+func TestProcessor_AdvanceCeremonyStageAndEmit(t *testing.T) {
+	db, tenantId, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	setCeremonyStages(t, db, tenantId, "VOWS,RING_EXCHANGE,RECEPTION")
+
+	scheduled := scheduleCeremonyAt(t, processor, marriageId, time.Now().Add(time.Hour))
+	if _, err := processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "", 9); !errors.As(err, new(StateTransitionError)) {
+		t.Errorf("Expected a state transition error before the ceremony starts, got %v", err)
+	}
+	if _, err := processor.StartCeremony(scheduled.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+
+	if _, err := processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "RECEPTION", 9); !errors.Is(err, ErrCeremonyStageInvalid) {
+		t.Errorf("Expected an invalid stage error when skipping a stage, got %v", err)
+	}
+
+	producer.ClearMessages()
+	ceremony, err := processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "VOWS", 9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ceremony.Stage() != "VOWS" || ceremony.StagesCompleted() {
+		t.Errorf("Expected the ceremony at its first stage, got %q", ceremony.Stage())
+	}
+	if _, err = processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "VOWS", 9); !errors.Is(err, ErrCeremonyStageInvalid) {
+		t.Errorf("Expected an invalid stage error when repeating a stage, got %v", err)
+	}
+	if _, err = processor.CompleteCeremonyAndEmit(uuid.New(), scheduled.Id()); !errors.Is(err, ErrCeremonyStagesPending) {
+		t.Errorf("Expected completion to wait for the final stage, got %v", err)
+	}
+
+	if _, err = processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "", 9); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ceremony, err = processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "RECEPTION", 9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ceremony.StagesCompleted() {
+		t.Error("Expected the ceremony to have reached its final stage")
+	}
+	if _, err = processor.AdvanceCeremonyStageAndEmit(uuid.New(), scheduled.Id(), "", 9); !errors.Is(err, ErrCeremonyFinalStage) {
+		t.Errorf("Expected a final stage error, got %v", err)
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 3 {
+		t.Fatalf("Expected a stage changed event per stage, got %v", producedEventTypes(t, producer))
+	}
+	var event marriageMsg.Event[marriageMsg.CeremonyStageChangedBody]
+	if err = json.Unmarshal(messages[2].Value, &event); err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	if event.Type != marriageMsg.EventCeremonyStageChanged || event.Body.PreviousStage != "RING_EXCHANGE" || event.Body.Stage != "RECEPTION" {
+		t.Errorf("Unexpected stage changed event %+v", event.Body)
+	}
+	if event.Body.StageIndex != 2 || !event.Body.FinalStage || event.Body.AdvancedBy != 9 {
+		t.Errorf("Unexpected stage changed event %+v", event.Body)
+	}
+
+	completed, err := processor.CompleteCeremonyAndEmit(uuid.New(), scheduled.Id())
+	if err != nil {
+		t.Fatalf("Expected completion after the final stage, got %v", err)
+	}
+	if completed.Status() != CeremonyStatusCompleted {
+		t.Errorf("Expected the ceremony to be completed, got %s", completed.Status())
+	}
+}
+
+func TestProcessor_AdvanceCeremonyStage_RestartsAfterPostponement(t *testing.T) {
+	db, tenantId, processor, _, marriageId := setupCeremonyScheduleTest(t)
+	setCeremonyStages(t, db, tenantId, "VOWS,RECEPTION")
+	ceremony := startCeremony(t, processor, marriageId)
+
+	if _, err := processor.AdvanceCeremonyStage(ceremony.Id(), "")(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := processor.PostponeCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	restarted, err := processor.StartCeremony(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if restarted.Stage() != "" || restarted.StageIndex() != -1 {
+		t.Errorf("Expected the restarted ceremony before its first stage, got %q", restarted.Stage())
+	}
+}
+
+func TestProcessor_CompleteCeremony_WithoutStages(t *testing.T) {
+	_, _, processor, _, marriageId := setupCeremonyScheduleTest(t)
+
+	// Tenants without configured stages hold ceremonies without stages
+	ceremony := startCeremony(t, processor, marriageId)
+	if len(ceremony.Stages()) != 0 {
+		t.Fatalf("Expected no stages by default, got %v", ceremony.Stages())
+	}
+	if _, err := processor.AdvanceCeremonyStage(ceremony.Id(), "")(); !errors.Is(err, ErrCeremonyFinalStage) {
+		t.Errorf("Expected a final stage error for a ceremony without stages, got %v", err)
+	}
+	if _, err := processor.CompleteCeremony(ceremony.Id())(); err != nil {
+		t.Errorf("Expected a ceremony without stages to complete once started, got %v", err)
+	}
+}
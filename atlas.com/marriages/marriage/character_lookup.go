@@ -0,0 +1,133 @@
+package marriage
+
+import (
+	"atlas-marriages/character"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// characterLookup resolves the names and levels of characters shown in a REST response. Each character is
+// requested at most once per lookup, and characters the character service cannot resolve are reported by id only
+type characterLookup struct {
+	l                  logrus.FieldLogger
+	characterProcessor character.Processor
+	mu                 sync.Mutex
+	cache              map[uint32]*character.Model
+}
+
+// newCharacterLookup creates a character lookup scoped to a single request
+func newCharacterLookup(l logrus.FieldLogger, characterProcessor character.Processor) *characterLookup {
+	return &characterLookup{
+		l:                  l,
+		characterProcessor: characterProcessor,
+		cache:              make(map[uint32]*character.Model),
+	}
+}
+
+// Prefetch resolves every character not yet looked up concurrently
+func (c *characterLookup) Prefetch(characterIds ...uint32) {
+	pending := make(map[uint32]struct{})
+	c.mu.Lock()
+	for _, characterId := range characterIds {
+		if _, ok := c.cache[characterId]; !ok {
+			pending[characterId] = struct{}{}
+		}
+	}
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for characterId := range pending {
+		wg.Add(1)
+		go func(characterId uint32) {
+			defer wg.Done()
+			var resolved *character.Model
+			m, err := c.characterProcessor.GetById(characterId)
+			if err != nil {
+				c.l.WithError(err).Warnf("Unable to retrieve character [%d], responding without name and level.", characterId)
+			} else {
+				resolved = &m
+			}
+			c.mu.Lock()
+			c.cache[characterId] = resolved
+			c.mu.Unlock()
+		}(characterId)
+	}
+	wg.Wait()
+}
+
+// Character returns the REST representation of a character, with name and level when they could be resolved
+func (c *characterLookup) Character(characterId uint32) *RestPartner {
+	c.Prefetch(characterId)
+
+	c.mu.Lock()
+	m := c.cache[characterId]
+	c.mu.Unlock()
+
+	partner := &RestPartner{CharacterID: characterId}
+	if m != nil {
+		partner.Name = m.Name()
+		partner.Level = m.Level()
+	}
+	return partner
+}
+
+// WithPartner returns the marriage with the partner of the given character, and the partner of each wedding ring
+// holder, resolved
+func (c *characterLookup) WithPartner(rm RestMarriage, characterId uint32) RestMarriage {
+	switch characterId {
+	case rm.CharacterId1:
+		rm.Partner = c.Character(rm.CharacterId2)
+	case rm.CharacterId2:
+		rm.Partner = c.Character(rm.CharacterId1)
+	}
+
+	if len(rm.Rings) > 0 {
+		rings := make([]RestWeddingRing, 0, len(rm.Rings))
+		for _, ring := range rm.Rings {
+			ring.PartnerName = c.Character(ring.PartnerCharacterId).Name
+			rings = append(rings, ring)
+		}
+		rm.Rings = rings
+	}
+	return rm
+}
+
+// WithPartners returns the marriages with the partner of the given character resolved, looking partners up together
+func (c *characterLookup) WithPartners(rms []RestMarriage, characterId uint32) []RestMarriage {
+	ids := make([]uint32, 0, len(rms))
+	for _, rm := range rms {
+		if rm.CharacterId1 == characterId {
+			ids = append(ids, rm.CharacterId2)
+		} else {
+			ids = append(ids, rm.CharacterId1)
+		}
+		for _, ring := range rm.Rings {
+			ids = append(ids, ring.PartnerCharacterId)
+		}
+	}
+	c.Prefetch(ids...)
+
+	results := make([]RestMarriage, 0, len(rms))
+	for _, rm := range rms {
+		results = append(results, c.WithPartner(rm, characterId))
+	}
+	return results
+}
+
+// WithParticipants returns the proposals with proposer and target resolved, looking characters up together
+func (c *characterLookup) WithParticipants(rps []RestProposal) []RestProposal {
+	ids := make([]uint32, 0, len(rps)*2)
+	for _, rp := range rps {
+		ids = append(ids, rp.ProposerID, rp.TargetID)
+	}
+	c.Prefetch(ids...)
+
+	results := make([]RestProposal, 0, len(rps))
+	for _, rp := range rps {
+		rp.Proposer = c.Character(rp.ProposerID)
+		rp.Target = c.Character(rp.TargetID)
+		results = append(results, rp)
+	}
+	return results
+}
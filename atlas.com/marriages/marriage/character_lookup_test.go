@@ -0,0 +1,90 @@
+package marriage
+
+import (
+	"errors"
+	"testing"
+
+	"atlas-marriages/character"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCharacterProcessor counts character service calls made through a MockCharacterProcessor
+type countingCharacterProcessor struct {
+	*MockCharacterProcessor
+	calls map[uint32]int
+}
+
+func (c *countingCharacterProcessor) GetById(characterId uint32) (character.Model, error) {
+	c.calls[characterId]++
+	return c.MockCharacterProcessor.GetById(characterId)
+}
+
+func TestCharacterLookup_WithParticipants(t *testing.T) {
+	mock := NewMockCharacterProcessor()
+	mock.AddCharacter(1001, "Alice", 30)
+	mock.AddCharacter(1002, "Bob", 45)
+	mock.AddCharacterError(1003, errors.New("character service unavailable"))
+	processor := &countingCharacterProcessor{MockCharacterProcessor: mock, calls: make(map[uint32]int)}
+
+	lookup := newCharacterLookup(logrus.New(), processor)
+	proposals := lookup.WithParticipants([]RestProposal{
+		{ID: 1, ProposerID: 1001, TargetID: 1002},
+		{ID: 2, ProposerID: 1003, TargetID: 1001},
+	})
+
+	require.Len(t, proposals, 2)
+	assert.Equal(t, &RestPartner{CharacterID: 1001, Name: "Alice", Level: 30}, proposals[0].Proposer)
+	assert.Equal(t, &RestPartner{CharacterID: 1002, Name: "Bob", Level: 45}, proposals[0].Target)
+	assert.Equal(t, &RestPartner{CharacterID: 1003}, proposals[1].Proposer, "expected id-only fallback when the character cannot be resolved")
+	assert.Equal(t, &RestPartner{CharacterID: 1001, Name: "Alice", Level: 30}, proposals[1].Target)
+	assert.Equal(t, map[uint32]int{1001: 1, 1002: 1, 1003: 1}, processor.calls, "expected each character to be requested once")
+}
+
+func TestCharacterLookup_WithPartners(t *testing.T) {
+	mock := NewMockCharacterProcessor()
+	mock.AddCharacter(1002, "Bob", 45)
+	processor := &countingCharacterProcessor{MockCharacterProcessor: mock, calls: make(map[uint32]int)}
+
+	lookup := newCharacterLookup(logrus.New(), processor)
+	marriages := lookup.WithPartners([]RestMarriage{
+		{ID: 1, CharacterId1: 1001, CharacterId2: 1002},
+		{ID: 2, CharacterId1: 1002, CharacterId2: 1001},
+		{ID: 3, CharacterId1: 1003, CharacterId2: 1001},
+	}, 1001)
+
+	require.Len(t, marriages, 3)
+	assert.Equal(t, &RestPartner{CharacterID: 1002, Name: "Bob", Level: 45}, marriages[0].Partner)
+	assert.Equal(t, &RestPartner{CharacterID: 1002, Name: "Bob", Level: 45}, marriages[1].Partner)
+	assert.Equal(t, &RestPartner{CharacterID: 1003}, marriages[2].Partner)
+	assert.Equal(t, map[uint32]int{1002: 1, 1003: 1}, processor.calls, "expected only partners to be requested, once each")
+}
+
+func TestCharacterLookup_WithPartner_WeddingRings(t *testing.T) {
+	mock := NewMockCharacterProcessor()
+	mock.AddCharacter(1001, "Alice", 30)
+	mock.AddCharacter(1002, "Bob", 45)
+	processor := &countingCharacterProcessor{MockCharacterProcessor: mock, calls: make(map[uint32]int)}
+
+	married, err := NewBuilder(1001, 1002, uuid.New()).Build()
+	require.NoError(t, err)
+	married, err = married.Accept()
+	require.NoError(t, err)
+	married, err = married.Marry()
+	require.NoError(t, err)
+	married, err = married.IssueRings(1112803, 100, 200)
+	require.NoError(t, err)
+	rm, err := TransformMarriage(married)
+	require.NoError(t, err)
+
+	lookup := newCharacterLookup(logrus.New(), processor)
+	rm = lookup.WithPartner(rm, 1001)
+
+	require.Len(t, rm.Rings, 2)
+	assert.Equal(t, RestWeddingRing{CharacterId: 1001, ItemId: 1112803, Serial: 100, PartnerCharacterId: 1002, PartnerSerial: 200, PartnerName: "Bob"}, rm.Rings[0])
+	assert.Equal(t, RestWeddingRing{CharacterId: 1002, ItemId: 1112803, Serial: 200, PartnerCharacterId: 1001, PartnerSerial: 100, PartnerName: "Alice"}, rm.Rings[1])
+	assert.Equal(t, map[uint32]int{1001: 1, 1002: 1}, processor.calls, "expected each character to be requested once")
+}
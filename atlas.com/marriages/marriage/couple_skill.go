@@ -0,0 +1,143 @@
+package marriage
+
+import (
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/sirupsen/logrus"
+)
+
+// CoupleSkill is the immutable view of a skill from the tenant's couple skill catalogue, as it applies to a character
+type CoupleSkill struct {
+	skillId           uint32
+	requiredStatus    MarriageStatus
+	requiredBondLevel byte
+	unlocked          bool
+}
+
+// MakeCoupleSkill creates the view of a catalogued couple skill for a couple in the given status and bond level
+func MakeCoupleSkill(skill rules.CoupleSkill, status MarriageStatus, bondLevel byte) CoupleSkill {
+	requiredStatus := StatusMarried
+	if skill.FromEngagement() {
+		requiredStatus = StatusEngaged
+	}
+	return CoupleSkill{
+		skillId:           skill.SkillId(),
+		requiredStatus:    requiredStatus,
+		requiredBondLevel: skill.BondLevel(),
+		unlocked:          coupleSkillUnlocked(skill, status, bondLevel),
+	}
+}
+
+// SkillId returns the ID of the skill
+func (s CoupleSkill) SkillId() uint32 {
+	return s.skillId
+}
+
+// RequiredStatus returns the marriage status which unlocks the skill, either engaged or married
+func (s CoupleSkill) RequiredStatus() MarriageStatus {
+	return s.requiredStatus
+}
+
+// RequiredBondLevel returns the bond level a married couple must reach to unlock the skill
+func (s CoupleSkill) RequiredBondLevel() byte {
+	return s.requiredBondLevel
+}
+
+// Unlocked returns true if the character currently holds the skill
+func (s CoupleSkill) Unlocked() bool {
+	return s.unlocked
+}
+
+// coupleSkillUnlocked returns true if a couple in the given status and bond level holds a catalogued skill. Skills
+// unlocked from engagement are kept once the couple marries, and every skill is kept while a divorce is pending
+func coupleSkillUnlocked(skill rules.CoupleSkill, status MarriageStatus, bondLevel byte) bool {
+	switch status {
+	case StatusEngaged:
+		return skill.FromEngagement()
+	case StatusMarried, StatusDivorcePending:
+		return bondLevel >= skill.BondLevel()
+	default:
+		return false
+	}
+}
+
+// coupleSkillChanges returns the skills a couple gains and loses moving from one status and bond level to another
+func coupleSkillChanges(catalogue []rules.CoupleSkill, previousStatus MarriageStatus, previousLevel byte, status MarriageStatus, level byte) ([]uint32, []uint32) {
+	granted := make([]uint32, 0)
+	revoked := make([]uint32, 0)
+	for _, skill := range catalogue {
+		before := coupleSkillUnlocked(skill, previousStatus, previousLevel)
+		after := coupleSkillUnlocked(skill, status, level)
+		if after && !before {
+			granted = append(granted, skill.SkillId())
+		}
+		if before && !after {
+			revoked = append(revoked, skill.SkillId())
+		}
+	}
+	return granted, revoked
+}
+
+// putCoupleSkillChanges buffers CoupleSkillsGranted and CoupleSkillsRevoked events for both partners, for the skills
+// the couple gained and lost since they held the previous status and bond level
+func (p *ProcessorImpl) putCoupleSkillChanges(buf *message.Buffer, marriage Marriage, previousStatus MarriageStatus, previousLevel byte) error {
+	granted, revoked := coupleSkillChanges(p.rules().CoupleSkills(), previousStatus, previousLevel, marriage.Status(), marriage.BondLevel())
+
+	partners := [][2]uint32{
+		{marriage.CharacterId1(), marriage.CharacterId2()},
+		{marriage.CharacterId2(), marriage.CharacterId1()},
+	}
+	for _, partner := range partners {
+		if len(granted) > 0 {
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, CoupleSkillsGrantedEventProvider(marriage.Id(), partner[0], partner[1], granted)); err != nil {
+				return err
+			}
+		}
+		if len(revoked) > 0 {
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, CoupleSkillsRevokedEventProvider(marriage.Id(), partner[0], partner[1], revoked)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(granted) > 0 || len(revoked) > 0 {
+		p.log.WithFields(logrus.Fields{
+			"marriageId": marriage.Id(),
+			"granted":    granted,
+			"revoked":    revoked,
+		}).Debug("Couple skill events emitted")
+	}
+
+	return nil
+}
+
+// GetCoupleSkills retrieves the tenant's couple skill catalogue as it applies to a character. Every skill is locked for
+// a character who is not engaged or married
+func (p *ProcessorImpl) GetCoupleSkills(characterId uint32) model.Provider[[]CoupleSkill] {
+	return func() ([]CoupleSkill, error) {
+		t := tenant.MustFromContext(p.ctx)
+
+		marriage, err := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())()
+		if err != nil {
+			return nil, err
+		}
+
+		status := StatusProposed
+		bondLevel := byte(0)
+		if marriage != nil {
+			status = marriage.Status()
+			bondLevel = marriage.BondLevel()
+		}
+
+		catalogue := p.rules().CoupleSkills()
+		skills := make([]CoupleSkill, 0, len(catalogue))
+		for _, skill := range catalogue {
+			skills = append(skills, MakeCoupleSkill(skill, status, bondLevel))
+		}
+		return skills, nil
+	}
+}
@@ -0,0 +1,209 @@
+package marriage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupCoupleSkillTest creates a processor emitting to a mock producer, for a tenant levelling bonds at 100, 300 and
+// 600 points and cataloguing skill 1000 from engagement, 1001 once married and 1002 at bond level 2
+func setupCoupleSkillTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor, *MockProducer) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	thresholds := "100,300,600"
+	skills := "1000:engaged,1001,1002:2"
+	if err := db.Create(&rules.Entity{TenantId: tenantId, BondLevelThresholds: &thresholds, CoupleSkills: &skills, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	mockCharacterProcessor := NewMockCharacterProcessor()
+	mockCharacterProcessor.AddCharacter(1, "Character1", 15)
+	mockCharacterProcessor.AddCharacter(2, "Character2", 15)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).
+		WithCharacterProcessor(mockCharacterProcessor).
+		WithProducer(producer.Provider)
+	return db, tenantId, processor, producer
+}
+
+// coupleSkillEvents returns the skills granted and revoked for each character among the produced messages
+func coupleSkillEvents(t *testing.T, producer *MockProducer) (map[uint32][]uint32, map[uint32][]uint32) {
+	granted := map[uint32][]uint32{}
+	revoked := map[uint32][]uint32{}
+	for _, m := range producer.GetProducedMessages() {
+		var event marriageMsg.Event[marriageMsg.CoupleSkillsBody]
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		switch event.Type {
+		case marriageMsg.EventCoupleSkillsGranted:
+			granted[event.Body.CharacterId] = append(granted[event.Body.CharacterId], event.Body.SkillIds...)
+		case marriageMsg.EventCoupleSkillsRevoked:
+			revoked[event.Body.CharacterId] = append(revoked[event.Body.CharacterId], event.Body.SkillIds...)
+		}
+	}
+	return granted, revoked
+}
+
+// equalSkillIds returns true if two lists of skill IDs hold the same skills in the same order
+func equalSkillIds(a []uint32, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCoupleSkillChanges(t *testing.T) {
+	catalogue := []rules.CoupleSkill{
+		rules.NewCoupleSkill(1000, true, 0),
+		rules.NewCoupleSkill(1001, false, 0),
+		rules.NewCoupleSkill(1002, false, 2),
+	}
+	cases := []struct {
+		name           string
+		previousStatus MarriageStatus
+		previousLevel  byte
+		status         MarriageStatus
+		level          byte
+		granted        []uint32
+		revoked        []uint32
+	}{
+		{"engagement", StatusProposed, 0, StatusEngaged, 0, []uint32{1000}, []uint32{}},
+		{"marriage", StatusEngaged, 0, StatusMarried, 0, []uint32{1001}, []uint32{}},
+		{"level up", StatusMarried, 1, StatusMarried, 2, []uint32{1002}, []uint32{}},
+		{"level down", StatusMarried, 3, StatusMarried, 1, []uint32{}, []uint32{1002}},
+		{"filing", StatusMarried, 2, StatusDivorcePending, 2, []uint32{}, []uint32{}},
+		{"divorce", StatusDivorcePending, 2, StatusDivorced, 2, []uint32{}, []uint32{1000, 1001, 1002}},
+		{"deletion while engaged", StatusEngaged, 0, StatusDeleted, 0, []uint32{}, []uint32{1000}},
+	}
+	for _, c := range cases {
+		granted, revoked := coupleSkillChanges(catalogue, c.previousStatus, c.previousLevel, c.status, c.level)
+		if !equalSkillIds(granted, c.granted) || !equalSkillIds(revoked, c.revoked) {
+			t.Errorf("%s: expected %v granted and %v revoked, got %v and %v", c.name, c.granted, c.revoked, granted, revoked)
+		}
+	}
+}
+
+func TestProcessor_CoupleSkills_GrantedOnEngagement(t *testing.T) {
+	_, _, processor, producer := setupCoupleSkillTest(t)
+
+	proposal, err := processor.Propose(1, 2)()
+	if err != nil {
+		t.Fatalf("Failed to create proposal: %v", err)
+	}
+	if _, err = processor.AcceptProposalAndEmit(uuid.New(), proposal.Id()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	granted, revoked := coupleSkillEvents(t, producer)
+	for _, characterId := range []uint32{1, 2} {
+		if !equalSkillIds(granted[characterId], []uint32{1000}) {
+			t.Errorf("Expected character %d to be granted the engagement skill, got %v", characterId, granted[characterId])
+		}
+	}
+	if len(revoked) != 0 {
+		t.Errorf("Expected no skills revoked, got %v", revoked)
+	}
+
+	skills, err := processor.GetCoupleSkills(2)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(skills) != 3 || !skills[0].Unlocked() || skills[1].Unlocked() || skills[2].Unlocked() {
+		t.Errorf("Expected only the engagement skill to be unlocked, got %v", skills)
+	}
+}
+
+func TestProcessor_CoupleSkills_BondLevel(t *testing.T) {
+	db, tenantId, processor, producer := setupCoupleSkillTest(t)
+	createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+
+	if _, err := processor.AwardBondPointsAndEmit(uuid.New(), 1, 350, string(BondSourceQuest)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	granted, _ := coupleSkillEvents(t, producer)
+	for _, characterId := range []uint32{1, 2} {
+		if !equalSkillIds(granted[characterId], []uint32{1002}) {
+			t.Errorf("Expected character %d to be granted the level 2 skill, got %v", characterId, granted[characterId])
+		}
+	}
+
+	skills, err := processor.GetCoupleSkills(1)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, skill := range skills {
+		if !skill.Unlocked() {
+			t.Errorf("Expected skill %d to be unlocked", skill.SkillId())
+		}
+	}
+	if skills[2].RequiredStatus() != StatusMarried || skills[2].RequiredBondLevel() != 2 {
+		t.Errorf("Expected skill 1002 to require marriage at level 2, got %s at %d", skills[2].RequiredStatus(), skills[2].RequiredBondLevel())
+	}
+}
+
+func TestProcessor_CoupleSkills_RevokedOnDivorce(t *testing.T) {
+	db, tenantId, processor, producer := setupCoupleSkillTest(t)
+	couple := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+	setBond(t, db, couple.ID, 120, 1, time.Now())
+
+	if _, err := processor.DivorceAndEmit(uuid.New(), couple.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	granted, revoked := coupleSkillEvents(t, producer)
+	if len(granted) != 0 {
+		t.Errorf("Expected no skills granted, got %v", granted)
+	}
+	for _, characterId := range []uint32{1, 2} {
+		if !equalSkillIds(revoked[characterId], []uint32{1000, 1001}) {
+			t.Errorf("Expected character %d to lose the skills below level 2, got %v", characterId, revoked[characterId])
+		}
+	}
+
+	skills, err := processor.GetCoupleSkills(1)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, skill := range skills {
+		if skill.Unlocked() {
+			t.Errorf("Expected skill %d to be locked after divorce", skill.SkillId())
+		}
+	}
+}
+
+func TestProcessor_CoupleSkills_RevokedOnDeletion(t *testing.T) {
+	db, tenantId, processor, producer := setupCoupleSkillTest(t)
+	createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+
+	if err := processor.HandleCharacterDeletionAndEmit(uuid.New(), 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, revoked := coupleSkillEvents(t, producer)
+	for _, characterId := range []uint32{1, 2} {
+		if !equalSkillIds(revoked[characterId], []uint32{1000, 1001}) {
+			t.Errorf("Expected character %d to lose the couple's skills, got %v", characterId, revoked[characterId])
+		}
+	}
+}
@@ -0,0 +1,395 @@
+package marriage
+
+import (
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// getMarriage retrieves a marriage of the tenant in context, reporting ErrMarriageNotFound when it does not exist
+func (p *ProcessorImpl) getMarriage(marriageId uint32) (Marriage, error) {
+	t := tenant.MustFromContext(p.ctx)
+
+	marriage, err := GetMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())()
+	if err != nil {
+		return Marriage{}, err
+	}
+	if marriage == nil {
+		return Marriage{}, ErrMarriageNotFound
+	}
+	return *marriage, nil
+}
+
+// lockMarriage retrieves a marriage of the tenant in context like getMarriage, locking it until the processor's
+// transaction ends so the marriage is changed by one operation at a time
+func (p *ProcessorImpl) lockMarriage(marriageId uint32) (Marriage, error) {
+	t := tenant.MustFromContext(p.ctx)
+
+	marriage, err := LockMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())()
+	if err != nil {
+		return Marriage{}, err
+	}
+	if marriage == nil {
+		return Marriage{}, ErrMarriageNotFound
+	}
+	return *marriage, nil
+}
+
+// FileDivorce files for divorce on behalf of a partner, debiting the tenant's divorce cost from them. The divorce is
+// finalized when the other partner consents, or once the tenant's waiting period has passed if they contest it. The
+// filing is made in its own transaction, so the cost is refunded if it cannot be recorded
+func (p *ProcessorImpl) FileDivorce(marriageId uint32, filedBy uint32) model.Provider[Marriage] {
+	return func() (Marriage, error) {
+		transactionId := uuid.New()
+		return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+			return p.fileDivorce(transactionId, marriageId, filedBy)
+		})
+	}
+}
+
+// fileDivorce files for divorce, debiting the divorce cost from the filing partner before the marriage is changed. The
+// debit is keyed by the filing transaction, and refunded if the processor's transaction rolls back
+func (p *ProcessorImpl) fileDivorce(transactionId uuid.UUID, marriageId uint32, filedBy uint32) (Marriage, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId": marriageId,
+		"filedBy":    filedBy,
+	}).Debug("Processing divorce filing")
+
+	marriage, err := p.lockMarriage(marriageId)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	if !marriage.CanFileDivorce() {
+		return Marriage{}, marriageTransitionError(marriage, StatusDivorcePending)
+	}
+	if !marriage.IsPartner(filedBy) {
+		return Marriage{}, ErrNotMarriagePartner
+	}
+
+	// The filing partner pays for the divorce, and is refunded if they withdraw it
+	payment, err := p.debit(paymentId(transactionId, paymentReasonDivorce), filedBy, p.rules().DivorceCost(), paymentReasonDivorce)
+	if err != nil {
+		return Marriage{}, err
+	}
+	var divorcePaymentId *uuid.UUID
+	if payment != nil {
+		id := payment.Id()
+		divorcePaymentId = &id
+	}
+
+	filedMarriage, err := marriage.FileDivorce(filedBy, time.Now().Add(p.rules().DivorceWaitingPeriod()), divorcePaymentId)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	updatedEntity, err := UpdateMarriage(p.db, p.log)(filedMarriage)()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	result, err := Make(updatedEntity)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"marriageId": marriageId,
+		"filedBy":    filedBy,
+		"maturesAt":  result.DivorceMaturesAt(),
+	}).Info("Divorce filed successfully")
+
+	return result, nil
+}
+
+// FileDivorceAndEmit files for divorce and emits events
+func (p *ProcessorImpl) FileDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, filedBy uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		marriage, err := p.fileDivorce(transactionId, marriageId, filedBy)
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := DivorceFiledEventProvider(
+				marriageId,
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				filedBy,
+				*marriage.DivorceFiledAt(),
+				*marriage.DivorceMaturesAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"marriageId":    marriageId,
+			"filedBy":       filedBy,
+		}).Debug("DivorceFiled event emitted")
+
+		return marriage, nil
+	})
+}
+
+// ConsentDivorce finalizes a pending divorce immediately with the consent of the partner who did not file it
+func (p *ProcessorImpl) ConsentDivorce(marriageId uint32, consentedBy uint32) model.Provider[Marriage] {
+	return func() (Marriage, error) {
+		return emitTransactionally(p, uuid.New(), func(p *ProcessorImpl) (Marriage, error) {
+			return p.consentDivorce(marriageId, consentedBy)
+		})
+	}
+}
+
+// consentDivorce finalizes a pending divorce with the other partner's consent. The marriage stays locked until the
+// processor's transaction ends, so a divorce finalized meanwhile by its waiting period is seen as no longer pending
+func (p *ProcessorImpl) consentDivorce(marriageId uint32, consentedBy uint32) (Marriage, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId":  marriageId,
+		"consentedBy": consentedBy,
+	}).Debug("Processing divorce consent")
+
+	marriage, err := p.lockMarriage(marriageId)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	if !marriage.IsDivorcePending() {
+		return Marriage{}, marriageTransitionError(marriage, StatusDivorced)
+	}
+	if !marriage.IsPartner(consentedBy) {
+		return Marriage{}, ErrNotMarriagePartner
+	}
+	if marriage.DivorceFiledBy() == consentedBy {
+		return Marriage{}, ErrDivorceFilerConsent
+	}
+
+	return p.finalizeDivorce(marriage)
+}
+
+// ConsentDivorceAndEmit consents to a pending divorce and emits events
+func (p *ProcessorImpl) ConsentDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, consentedBy uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		marriage, err := p.consentDivorce(marriageId, consentedBy)
+		if err != nil {
+			return Marriage{}, err
+		}
+		if err = p.emitDivorceFinalized(transactionId, marriage); err != nil {
+			return Marriage{}, err
+		}
+		return marriage, nil
+	})
+}
+
+// WithdrawDivorce withdraws a pending divorce on behalf of the partner who filed it. The divorce cost is refunded once
+// the withdrawal commits, which clears the filing's payment in the same update
+func (p *ProcessorImpl) WithdrawDivorce(marriageId uint32, withdrawnBy uint32) model.Provider[Marriage] {
+	return func() (Marriage, error) {
+		return emitTransactionally(p, uuid.New(), func(p *ProcessorImpl) (Marriage, error) {
+			return p.withdrawDivorce(marriageId, withdrawnBy)
+		})
+	}
+}
+
+// withdrawDivorce withdraws a pending divorce, locking the marriage until the processor's transaction ends so the
+// divorce cannot be finalized while it is withdrawn
+func (p *ProcessorImpl) withdrawDivorce(marriageId uint32, withdrawnBy uint32) (Marriage, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId":  marriageId,
+		"withdrawnBy": withdrawnBy,
+	}).Debug("Processing divorce withdrawal")
+
+	marriage, err := p.lockMarriage(marriageId)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	if !marriage.IsDivorcePending() {
+		return Marriage{}, marriageTransitionError(marriage, StatusMarried)
+	}
+	if !marriage.IsPartner(withdrawnBy) {
+		return Marriage{}, ErrNotMarriagePartner
+	}
+	if marriage.DivorceFiledBy() != withdrawnBy {
+		return Marriage{}, ErrNotDivorceFiler
+	}
+
+	withdrawnMarriage, err := marriage.WithdrawDivorce()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	updatedEntity, err := UpdateMarriage(p.db, p.log)(withdrawnMarriage)()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	if marriage.DivorcePaymentId() != nil {
+		p.refundAfterCommit(marriage.DivorceFiledBy(), *marriage.DivorcePaymentId())
+	}
+
+	result, err := Make(updatedEntity)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"marriageId":  marriageId,
+		"withdrawnBy": withdrawnBy,
+	}).Info("Divorce withdrawn successfully")
+
+	return result, nil
+}
+
+// WithdrawDivorceAndEmit withdraws a pending divorce and emits events
+func (p *ProcessorImpl) WithdrawDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, withdrawnBy uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		marriage, err := p.withdrawDivorce(marriageId, withdrawnBy)
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := DivorceWithdrawnEventProvider(
+				marriageId,
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				withdrawnBy,
+				marriage.UpdatedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"marriageId":    marriageId,
+			"withdrawnBy":   withdrawnBy,
+		}).Debug("DivorceWithdrawn event emitted")
+
+		return marriage, nil
+	})
+}
+
+// FinalizeDivorceAndEmit finalizes a pending divorce whose waiting period has passed and emits events
+func (p *ProcessorImpl) FinalizeDivorceAndEmit(transactionId uuid.UUID, marriageId uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		// Locking the marriage keeps a divorce consented to or withdrawn meanwhile from being finalized again
+		marriage, err := p.lockMarriage(marriageId)
+		if err != nil {
+			return Marriage{}, err
+		}
+		if !marriage.IsDivorceMatured(time.Now()) {
+			return Marriage{}, marriageTransitionError(marriage, StatusDivorced)
+		}
+
+		divorcedMarriage, err := p.finalizeDivorce(marriage)
+		if err != nil {
+			return Marriage{}, err
+		}
+		if err = p.emitDivorceFinalized(transactionId, divorcedMarriage); err != nil {
+			return Marriage{}, err
+		}
+		return divorcedMarriage, nil
+	})
+}
+
+// finalizeDivorce divorces a marriage with a pending divorce, keeping the filing as history
+func (p *ProcessorImpl) finalizeDivorce(marriage Marriage) (Marriage, error) {
+	divorcedMarriage, err := marriage.FinalizeDivorce()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	updatedEntity, err := UpdateMarriage(p.db, p.log)(divorcedMarriage)()
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	result, err := Make(updatedEntity)
+	if err != nil {
+		return Marriage{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"marriageId": marriage.Id(),
+		"filedBy":    marriage.DivorceFiledBy(),
+	}).Info("Filed divorce finalized successfully")
+
+	return result, nil
+}
+
+// emitDivorceFinalized emits the MarriageDivorced event for a finalized divorce, attributed to the partner who filed it
+func (p *ProcessorImpl) emitDivorceFinalized(transactionId uuid.UUID, marriage Marriage) error {
+	err := message.Emit(p.producer)(func(buf *message.Buffer) error {
+		eventProvider := MarriageDivorcedEventProvider(
+			marriage.Id(),
+			marriage.CharacterId1(),
+			marriage.CharacterId2(),
+			*marriage.DivorcedAt(),
+			marriage.DivorceFiledBy(),
+		)
+		if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+			return err
+		}
+		return p.putCoupleSkillChanges(buf, marriage, StatusDivorcePending, marriage.BondLevel())
+	})
+	if err != nil {
+		return err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"transactionId": transactionId,
+		"marriageId":    marriage.Id(),
+		"filedBy":       marriage.DivorceFiledBy(),
+	}).Debug("MarriageDivorced event emitted")
+
+	return nil
+}
+
+// ProcessMaturedDivorces finalizes every pending divorce of the tenant whose waiting period has passed
+func (p *ProcessorImpl) ProcessMaturedDivorces() error {
+	p.log.Debug("Processing matured divorces")
+
+	t := tenant.MustFromContext(p.ctx)
+
+	maturedDivorces, err := GetMaturedDivorcesProvider(p.db, p.log)(t.Id())()
+	if err != nil {
+		p.log.WithError(err).Error("Failed to retrieve matured divorces")
+		return err
+	}
+
+	if len(maturedDivorces) == 0 {
+		p.log.Debug("No matured divorces found")
+		return nil
+	}
+
+	p.log.WithField("count", len(maturedDivorces)).Info("Processing matured divorces")
+
+	for _, marriage := range maturedDivorces {
+		if _, err := p.FinalizeDivorceAndEmit(uuid.New(), marriage.Id()); err != nil {
+			p.log.WithFields(logrus.Fields{
+				"marriageId": marriage.Id(),
+				"error":      err,
+			}).Error("Failed to finalize matured divorce")
+			// Continue processing other divorces even if one fails
+			continue
+		}
+
+		p.log.WithField("marriageId", marriage.Id()).Debug("Successfully finalized matured divorce")
+	}
+
+	p.log.WithField("processedCount", len(maturedDivorces)).Info("Completed processing matured divorces")
+	return nil
+}
@@ -0,0 +1,309 @@
+package marriage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// requireDivorceFiling configures the tenant of a marriage to require divorces to be filed
+func requireDivorceFiling(t *testing.T, db *gorm.DB, tenantId uuid.UUID) {
+	required := true
+	if err := db.Model(&rules.Entity{}).Where("tenant_id = ?", tenantId).Update("divorce_filing_required", &required).Error; err != nil {
+		t.Fatalf("Failed to update rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+}
+
+func TestProcessor_FileDivorce_ConsentFinalizes(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost
+
+	filed, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filed.Status() != StatusDivorcePending || filed.DivorceFiledBy() != 1 {
+		t.Fatalf("Expected a divorce pending filed by character 1, got %s filed by %d", filed.Status(), filed.DivorceFiledBy())
+	}
+	if filed.DivorceMaturesAt() == nil || filed.DivorceMaturesAt().Before(time.Now().Add(rules.DefaultDivorceWaitingPeriod-time.Minute)) {
+		t.Errorf("Expected the divorce to mature after the waiting period, got %v", filed.DivorceMaturesAt())
+	}
+	if filed.DivorcePaymentId() == nil || econ.mesos[1] != 0 {
+		t.Errorf("Expected the filing partner to pay for the divorce, got payment %v", filed.DivorcePaymentId())
+	}
+	if !filed.IsActive() {
+		t.Error("Expected the marriage to remain active while the divorce is pending")
+	}
+
+	if _, err = processor.ConsentDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); !errors.Is(err, ErrDivorceFilerConsent) {
+		t.Fatalf("Expected the filing partner to be unable to consent, got %v", err)
+	}
+
+	divorced, err := processor.ConsentDivorceAndEmit(uuid.New(), marriageEntity.ID, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if divorced.Status() != StatusDivorced || divorced.DivorcedAt() == nil {
+		t.Errorf("Expected the marriage to be divorced, got %s", divorced.Status())
+	}
+	if divorced.DivorceFiledBy() != 1 {
+		t.Errorf("Expected the filing to be kept as history, got filed by %d", divorced.DivorceFiledBy())
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusDivorced {
+		t.Errorf("Expected the stored marriage to be divorced, got %v (%v)", stored.Status, err)
+	}
+	if len(econ.refunded) != 0 {
+		t.Errorf("Expected a finalized divorce not to be refunded, found %d refunds", len(econ.refunded))
+	}
+}
+
+func TestProcessor_FileDivorce_InsufficientFunds(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost - 1
+
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("Expected insufficient funds error, got %v", err)
+	}
+
+	var stored Entity
+	if err := db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusMarried {
+		t.Errorf("Expected the marriage to remain married, got %v (%v)", stored.Status, err)
+	}
+}
+
+func TestProcessor_FileDivorce_NotMarried(t *testing.T) {
+	_, processor, _, marriageEntity := setupPaymentTest(t, StatusEngaged)
+
+	var transitionErr StateTransitionError
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected a state transition error, got %v", err)
+	}
+}
+
+func TestProcessor_WithdrawDivorce_RefundsFiler(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost
+
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := processor.WithdrawDivorceAndEmit(uuid.New(), marriageEntity.ID, 2); !errors.Is(err, ErrNotDivorceFiler) {
+		t.Fatalf("Expected only the filing partner to be able to withdraw, got %v", err)
+	}
+
+	marriage, err := processor.WithdrawDivorceAndEmit(uuid.New(), marriageEntity.ID, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if marriage.Status() != StatusMarried || marriage.DivorceFiledBy() != 0 || marriage.DivorceMaturesAt() != nil {
+		t.Errorf("Expected the filing to be withdrawn, got %s filed by %d", marriage.Status(), marriage.DivorceFiledBy())
+	}
+	if len(econ.refunded) != 1 || econ.mesos[1] != testDivorceCost {
+		t.Errorf("Expected the divorce cost to be refunded, found %d refunds", len(econ.refunded))
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusMarried || stored.DivorcePaymentId != nil {
+		t.Errorf("Expected the stored marriage to be married without a divorce payment, got %v (%v)", stored.Status, err)
+	}
+}
+
+func TestProcessor_WithdrawDivorce_RolledBackWithdrawalNotRefunded(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost
+
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := processor.ProcessCommand(uuid.New(), 1, "divorce_withdraw", func(p Processor, transactionId uuid.UUID) error {
+		if _, err := p.WithdrawDivorceAndEmit(transactionId, marriageEntity.ID, 1); err != nil {
+			return err
+		}
+		return ErrNotMarriagePartner
+	})
+	if !errors.Is(err, ErrNotMarriagePartner) {
+		t.Fatalf("Expected the command to fail, got %v", err)
+	}
+	if len(econ.refunded) != 0 {
+		t.Errorf("Expected no refund for a withdrawal which rolled back, found %d refunds", len(econ.refunded))
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusDivorcePending || stored.DivorcePaymentId == nil {
+		t.Errorf("Expected the divorce to remain pending with its payment, got %v (%v)", stored.Status, err)
+	}
+}
+
+func TestProcessor_WithdrawDivorce_StandsWhenRefundFails(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost
+
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The refund is made once the withdrawal has committed, so its failure is logged rather than undoing it
+	econ.refundErr = errors.New("economy service unavailable")
+	if _, err := processor.WithdrawDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var stored Entity
+	if err := db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusMarried {
+		t.Errorf("Expected the divorce to be withdrawn, got %v (%v)", stored.Status, err)
+	}
+}
+
+func TestProcessor_ProcessMaturedDivorces(t *testing.T) {
+	db, processor, _, marriageEntity := setupPaymentTest(t, StatusMarried)
+
+	// File a second couple's divorce which has not matured yet
+	now := time.Now()
+	pendingEntity := marriageEntity
+	pendingEntity.ID = 0
+	pendingEntity.CharacterId1 = 3
+	pendingEntity.CharacterId2 = 4
+	pendingEntity.Status = StatusDivorcePending
+	pendingEntity.DivorceFiledBy = 3
+	pendingEntity.DivorceFiledAt = &now
+	pendingEntity.DivorceMaturesAt = &[]time.Time{now.Add(time.Hour)}[0]
+	if err := db.Create(&pendingEntity).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+
+	// The first couple's divorce matured an hour ago
+	filedAt := now.Add(-2 * time.Hour)
+	if err := db.Model(&Entity{}).Where("id = ?", marriageEntity.ID).Updates(map[string]interface{}{
+		"status":             StatusDivorcePending,
+		"divorce_filed_by":   2,
+		"divorce_filed_at":   filedAt,
+		"divorce_matures_at": now.Add(-time.Hour),
+	}).Error; err != nil {
+		t.Fatalf("Failed to file divorce: %v", err)
+	}
+
+	if err := processor.ProcessMaturedDivorces(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var matured Entity
+	if err := db.First(&matured, marriageEntity.ID).Error; err != nil || matured.Status != StatusDivorced || matured.DivorcedAt == nil {
+		t.Errorf("Expected the matured divorce to be finalized, got %v (%v)", matured.Status, err)
+	}
+
+	var pending Entity
+	if err := db.First(&pending, pendingEntity.ID).Error; err != nil || pending.Status != StatusDivorcePending {
+		t.Errorf("Expected the divorce within its waiting period to remain pending, got %v (%v)", pending.Status, err)
+	}
+
+	var transitionErr StateTransitionError
+	if _, err := processor.FinalizeDivorceAndEmit(uuid.New(), pendingEntity.ID); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected a divorce within its waiting period not to be finalized, got %v", err)
+	}
+}
+
+func TestProcessor_FinalizeDivorce_AfterConsentRejected(t *testing.T) {
+	db, processor, _, marriageEntity := setupPaymentTest(t, StatusMarried)
+	now := time.Now()
+	if err := db.Model(&Entity{}).Where("id = ?", marriageEntity.ID).Updates(map[string]interface{}{
+		"status":             StatusDivorcePending,
+		"divorce_filed_by":   1,
+		"divorce_filed_at":   now.Add(-2 * time.Hour),
+		"divorce_matures_at": now.Add(-time.Hour),
+	}).Error; err != nil {
+		t.Fatalf("Failed to file divorce: %v", err)
+	}
+
+	divorced, err := processor.ConsentDivorceAndEmit(uuid.New(), marriageEntity.ID, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The finalization scheduler found the divorce matured before the consent committed
+	var transitionErr StateTransitionError
+	if _, err = processor.FinalizeDivorceAndEmit(uuid.New(), marriageEntity.ID); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected a consented divorce not to be finalized again, got %v", err)
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.DivorcedAt == nil || !stored.DivorcedAt.Equal(*divorced.DivorcedAt()) {
+		t.Errorf("Expected the consented divorce to stand, got divorced at %v (%v)", stored.DivorcedAt, err)
+	}
+}
+
+func TestProcessor_Divorce_RejectedWhenFilingRequired(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	requireDivorceFiling(t, db, marriageEntity.TenantId)
+	econ.mesos[1] = testDivorceCost
+
+	if _, err := processor.DivorceAndEmit(uuid.New(), marriageEntity.ID, 1); !errors.Is(err, ErrDivorceFilingRequired) {
+		t.Fatalf("Expected divorce filing to be required, got %v", err)
+	}
+	if len(econ.debits) != 0 {
+		t.Errorf("Expected no debit, found %d", len(econ.debits))
+	}
+
+	if _, err := processor.FileDivorceAndEmit(uuid.New(), marriageEntity.ID, 1); err != nil {
+		t.Fatalf("Expected the divorce to be filed, got %v", err)
+	}
+}
+
+func TestProcessor_ProcessMaturedDivorces_ConcurrentRunsFinalizeOnce(t *testing.T) {
+	db := setupConcurrentTestDB(t)
+	tenantId := uuid.New()
+	rules.GetRegistry().Invalidate(tenantId)
+	marriageEntity := createMarriedCouple(t, db, tenantId, 1, 2, StatusMarried, 10)
+
+	now := time.Now()
+	if err := db.Model(&Entity{}).Where("id = ?", marriageEntity.ID).Updates(map[string]interface{}{
+		"status":             StatusDivorcePending,
+		"divorce_filed_by":   1,
+		"divorce_filed_at":   now.Add(-2 * time.Hour),
+		"divorce_matures_at": now.Add(-time.Hour),
+	}).Error; err != nil {
+		t.Fatalf("Failed to file divorce: %v", err)
+	}
+
+	// Every run finds the divorce matured before any of them finalizes it
+	types := runConcurrently(t, db, tenantId, 4, func(p Processor) error {
+		return p.ProcessMaturedDivorces()
+	})
+	if divorced := countEvents(types, marriageMsg.EventMarriageDivorced); divorced != 1 {
+		t.Errorf("Expected the divorce to be finalized once, got %d events", divorced)
+	}
+
+	var stored Entity
+	if err := db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusDivorced || stored.DivorcedAt == nil {
+		t.Fatalf("Expected the divorce to be finalized, got %v (%v)", stored.Status, err)
+	}
+
+	// A later run, or a redelivered finalization, leaves the divorce as it stands
+	types = runConcurrently(t, db, tenantId, 1, func(p Processor) error {
+		return p.ProcessMaturedDivorces()
+	})
+	if divorced := countEvents(types, marriageMsg.EventMarriageDivorced); divorced != 0 {
+		t.Errorf("Expected no repeated finalization, got %d events", divorced)
+	}
+	processor := NewProcessor(logrus.New(), setupTestContext(tenantId), db).WithProducer(NewMockProducer().Provider)
+	var transitionErr StateTransitionError
+	if _, err := processor.FinalizeDivorceAndEmit(uuid.New(), marriageEntity.ID); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected a finalized divorce not to be finalized again, got %v", err)
+	}
+
+	var finalized Entity
+	if err := db.First(&finalized, marriageEntity.ID).Error; err != nil || !finalized.DivorcedAt.Equal(*stored.DivorcedAt) {
+		t.Errorf("Expected the divorce to keep its finalization time, got %v (%v)", finalized.DivorcedAt, err)
+	}
+}
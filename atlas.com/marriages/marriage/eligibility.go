@@ -0,0 +1,142 @@
+package marriage
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// EligibilityViolation describes a single proposal eligibility rule which failed, the character failing it and
+// the offending value. Remaining is set for cooldown rules only
+type EligibilityViolation struct {
+	Rule        string
+	CharacterId uint32
+	Value       string
+	Message     string
+	Remaining   time.Duration
+}
+
+// IsCooldown returns true if the violation is a proposal or remarriage cooldown rather than a character eligibility rule
+func (v EligibilityViolation) IsCooldown() bool {
+	_, ok := cooldownScopeOf(v.Rule)
+	return ok
+}
+
+// RemainingSeconds returns the whole seconds remaining of a cooldown violation, rounded up
+func (v EligibilityViolation) RemainingSeconds() int64 {
+	return ceilSeconds(v.Remaining)
+}
+
+// ceilSeconds returns a duration in whole seconds, rounded up
+func ceilSeconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// newEligibilityViolation creates a violation of the rule described by an eligibility error
+func newEligibilityViolation(rule EligibilityError, characterId uint32, value string) EligibilityViolation {
+	return EligibilityViolation{
+		Rule:        rule.Code,
+		CharacterId: characterId,
+		Value:       value,
+		Message:     rule.Message,
+	}
+}
+
+// newCooldownViolation creates a violation for an active proposal or remarriage cooldown
+func newCooldownViolation(cooldown CooldownError, characterId uint32) EligibilityViolation {
+	return EligibilityViolation{
+		Rule:        cooldown.ErrorCode(),
+		CharacterId: characterId,
+		Value:       cooldown.Remaining.Round(time.Second).String(),
+		Message:     cooldown.Error(),
+		Remaining:   cooldown.Remaining,
+	}
+}
+
+// EligibilityVerdict is the immutable outcome of evaluating every proposal eligibility rule
+type EligibilityVerdict struct {
+	proposerId uint32
+	targetId   uint32
+	violations []EligibilityViolation
+}
+
+// NewEligibilityVerdict creates a verdict for a proposal from the rules it fails
+func NewEligibilityVerdict(proposerId, targetId uint32, violations []EligibilityViolation) EligibilityVerdict {
+	copied := make([]EligibilityViolation, len(violations))
+	copy(copied, violations)
+	return EligibilityVerdict{
+		proposerId: proposerId,
+		targetId:   targetId,
+		violations: copied,
+	}
+}
+
+// ProposerId returns the proposing character ID
+func (v EligibilityVerdict) ProposerId() uint32 {
+	return v.proposerId
+}
+
+// TargetId returns the target character ID
+func (v EligibilityVerdict) TargetId() uint32 {
+	return v.targetId
+}
+
+// Violations returns every failing rule
+func (v EligibilityVerdict) Violations() []EligibilityViolation {
+	violations := make([]EligibilityViolation, len(v.violations))
+	copy(violations, v.violations)
+	return violations
+}
+
+// Eligible returns true if the proposal fails no rules
+func (v EligibilityVerdict) Eligible() bool {
+	return len(v.violations) == 0
+}
+
+// CharactersEligible returns true if the proposal fails no character eligibility rules, ignoring cooldowns
+func (v EligibilityVerdict) CharactersEligible() bool {
+	for _, violation := range v.violations {
+		if !violation.IsCooldown() {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the error describing why the proposal may not be made, or nil when it is eligible. Character
+// eligibility failures are reported as an EligibilityError listing every violation, while a proposal failing
+// only cooldowns is reported as the CooldownError for the first of them
+func (v EligibilityVerdict) Err() error {
+	if v.Eligible() {
+		return nil
+	}
+
+	if v.CharactersEligible() {
+		first := v.violations[0]
+		scope, _ := cooldownScopeOf(first.Rule)
+		return CooldownError{Scope: scope, Remaining: first.Remaining}
+	}
+
+	var first EligibilityViolation
+	for _, violation := range v.violations {
+		if !violation.IsCooldown() {
+			first = violation
+			break
+		}
+	}
+	return EligibilityError{
+		Code:        first.Rule,
+		Message:     v.message(),
+		CharacterId: first.CharacterId,
+		Violations:  v.Violations(),
+	}
+}
+
+// message joins the messages of every violation
+func (v EligibilityVerdict) message() string {
+	messages := make([]string, 0, len(v.violations))
+	for _, violation := range v.violations {
+		messages = append(messages, violation.Message)
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,101 @@
+package marriage
+
+import (
+	"atlas-marriages/inventory"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// reserveEngagementRing verifies the proposer holds the engagement ring item required by the tenant's rules and
+// reserves one for the proposal. The reservation is released if the processor's database transaction rolls back. It
+// returns nil when the tenant does not require a ring
+func (p *ProcessorImpl) reserveEngagementRing(proposerId uint32) (*inventory.Reservation, error) {
+	itemId := p.rules().EngagementRingItemId()
+	if itemId == 0 {
+		return nil, nil
+	}
+
+	quantity, err := p.inventoryProcessor.GetItemQuantity(proposerId, itemId)
+	if err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"proposerId": proposerId,
+			"itemId":     itemId,
+		}).Error("Failed to verify engagement ring")
+		return nil, err
+	}
+	if quantity == 0 {
+		return nil, ItemRequirementError{ItemId: itemId, CharacterId: proposerId}
+	}
+
+	reservation, err := p.inventoryProcessor.Reserve(proposerId, itemId, 1)
+	if err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"proposerId": proposerId,
+			"itemId":     itemId,
+		}).Error("Failed to reserve engagement ring")
+		return nil, err
+	}
+	p.onRollback(func() {
+		p.releaseRing(proposerId, reservation.Id(), "Failed to release engagement ring reservation for a proposal which was rolled back")
+	})
+
+	p.log.WithFields(logrus.Fields{
+		"proposerId":    proposerId,
+		"itemId":        itemId,
+		"reservationId": reservation.Id(),
+	}).Debug("Engagement ring reserved")
+
+	return &reservation, nil
+}
+
+// releaseReservation returns a reserved engagement ring to the proposer after the proposal it was reserved for
+// could not be created. Within a database transaction the ring is instead released when the transaction rolls back
+func (p *ProcessorImpl) releaseReservation(proposerId uint32, reservation *inventory.Reservation) {
+	if reservation == nil || p.scope != nil {
+		return
+	}
+	p.releaseRing(proposerId, reservation.Id(), "Failed to release engagement ring reservation for a proposal which was not created")
+}
+
+// releaseRing returns a reserved engagement ring to the proposer. Failures are logged, as there is nothing further to
+// compensate, and the ring stays reserved
+func (p *ProcessorImpl) releaseRing(proposerId uint32, reservationId uuid.UUID, failure string) {
+	if err := p.inventoryProcessor.Release(proposerId, reservationId); err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"proposerId":    proposerId,
+			"reservationId": reservationId,
+		}).Error(failure)
+	}
+}
+
+// consumeEngagementRing removes the engagement ring reserved for an accepted proposal from the proposer's inventory
+// once the acceptance commits. The acceptance stands when the inventory service fails, so the failure is logged and
+// the ring is left reserved
+func (p *ProcessorImpl) consumeEngagementRing(proposal Proposal) {
+	if !proposal.HasRingReservation() {
+		return
+	}
+	proposerId, reservationId := proposal.ProposerId(), *proposal.RingReservationId()
+	p.afterCommit(func() {
+		if err := p.inventoryProcessor.Consume(proposerId, reservationId); err != nil {
+			p.log.WithError(err).WithFields(logrus.Fields{
+				"proposalId":    proposal.Id(),
+				"reservationId": reservationId,
+			}).Error("Failed to consume engagement ring of accepted proposal")
+		}
+	})
+}
+
+// returnEngagementRing returns the engagement ring reserved for a declined, cancelled or expired proposal to the
+// proposer once the change to the proposal commits, so a change which rolls back never gives the ring back. The change
+// stands when the inventory service fails, so the failure is logged and the ring is left reserved
+func (p *ProcessorImpl) returnEngagementRing(proposal Proposal) {
+	if !proposal.HasRingReservation() {
+		return
+	}
+	proposerId, reservationId := proposal.ProposerId(), *proposal.RingReservationId()
+	p.afterCommit(func() {
+		p.releaseRing(proposerId, reservationId, "Failed to return engagement ring")
+	})
+}
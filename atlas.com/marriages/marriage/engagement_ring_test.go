@@ -0,0 +1,378 @@
+package marriage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-marriages/inventory"
+	"atlas-marriages/rules"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const testEngagementRingItemId = uint32(2240000)
+
+// MockInventoryProcessor provides a mock implementation of the inventory service for testing
+type MockInventoryProcessor struct {
+	quantities   map[uint32]uint32
+	reservations map[uuid.UUID]inventory.Reservation
+	consumed     []uuid.UUID
+	released     []uuid.UUID
+	reserveErr   error
+	consumeErr   error
+	releaseErr   error
+}
+
+func NewMockInventoryProcessor() *MockInventoryProcessor {
+	return &MockInventoryProcessor{
+		quantities:   make(map[uint32]uint32),
+		reservations: make(map[uuid.UUID]inventory.Reservation),
+	}
+}
+
+func (m *MockInventoryProcessor) AddItem(characterId uint32, quantity uint32) {
+	m.quantities[characterId] += quantity
+}
+
+func (m *MockInventoryProcessor) AssetsByItemIdProvider(_ uint32, _ uint32) model.Provider[[]inventory.Asset] {
+	return model.FixedProvider[[]inventory.Asset](nil)
+}
+
+func (m *MockInventoryProcessor) GetItemQuantity(characterId uint32, _ uint32) (uint32, error) {
+	return m.quantities[characterId], nil
+}
+
+func (m *MockInventoryProcessor) Reserve(characterId uint32, itemId uint32, quantity uint32) (inventory.Reservation, error) {
+	if m.reserveErr != nil {
+		return inventory.Reservation{}, m.reserveErr
+	}
+	reservation := inventory.NewReservation(uuid.New(), characterId, itemId, quantity)
+	m.reservations[reservation.Id()] = reservation
+	return reservation, nil
+}
+
+func (m *MockInventoryProcessor) Consume(_ uint32, reservationId uuid.UUID) error {
+	if m.consumeErr != nil {
+		return m.consumeErr
+	}
+	m.consumed = append(m.consumed, reservationId)
+	return nil
+}
+
+func (m *MockInventoryProcessor) Release(_ uint32, reservationId uuid.UUID) error {
+	if m.releaseErr != nil {
+		return m.releaseErr
+	}
+	m.released = append(m.released, reservationId)
+	return nil
+}
+
+// setupEngagementRingTest creates a processor for a tenant requiring an engagement ring, with two eligible characters
+func setupEngagementRingTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor, *MockInventoryProcessor) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	itemId := testEngagementRingItemId
+	if err := db.Create(&rules.Entity{TenantId: tenantId, EngagementRingItemId: &itemId, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	mockCharacterProcessor := NewMockCharacterProcessor()
+	mockCharacterProcessor.AddCharacter(1, "Proposer", 50)
+	mockCharacterProcessor.AddCharacter(2, "Target", 50)
+	mockInventoryProcessor := NewMockInventoryProcessor()
+
+	processor := NewProcessor(log, ctx, db).
+		WithProducer(NewMockProducer().Provider).
+		WithCharacterProcessor(mockCharacterProcessor).
+		WithInventoryProcessor(mockInventoryProcessor)
+	return db, tenantId, processor, mockInventoryProcessor
+}
+
+func TestProcessor_Propose_RequiresEngagementRing(t *testing.T) {
+	db, _, processor, inv := setupEngagementRingTest(t)
+
+	_, err := processor.Propose(1, 2)()
+	var itemErr ItemRequirementError
+	if !errors.As(err, &itemErr) || itemErr.ItemId != testEngagementRingItemId || itemErr.CharacterId != 1 {
+		t.Fatalf("Expected engagement ring requirement error, got %v", err)
+	}
+
+	var count int64
+	db.Model(&ProposalEntity{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no proposal without an engagement ring, found %d", count)
+	}
+	if len(inv.reservations) != 0 {
+		t.Errorf("Expected no reservation, found %d", len(inv.reservations))
+	}
+}
+
+func TestProcessor_Propose_ReservesEngagementRing(t *testing.T) {
+	_, _, processor, inv := setupEngagementRingTest(t)
+	inv.AddItem(1, 1)
+
+	proposal, err := processor.Propose(1, 2)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if proposal.RingItemId() != testEngagementRingItemId || !proposal.HasRingReservation() {
+		t.Fatalf("Expected proposal to hold the engagement ring reservation, got item %d reservation %v", proposal.RingItemId(), proposal.RingReservationId())
+	}
+	if _, ok := inv.reservations[*proposal.RingReservationId()]; !ok {
+		t.Error("Expected the proposal reservation to exist in the inventory")
+	}
+}
+
+func TestProcessor_EngagementRing_Lifecycle(t *testing.T) {
+	t.Run("consumed on accept", func(t *testing.T) {
+		_, _, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		proposal, err := processor.Propose(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, err = processor.AcceptProposalAndEmit(uuid.New(), proposal.Id()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(inv.consumed) != 1 || inv.consumed[0] != *proposal.RingReservationId() {
+			t.Errorf("Expected the reservation to be consumed, got %v", inv.consumed)
+		}
+		if len(inv.released) != 0 {
+			t.Errorf("Expected no reservation to be released, got %v", inv.released)
+		}
+	})
+
+	for name, respond := range map[string]func(Processor, uint32) error{
+		"returned on decline": func(p Processor, id uint32) error { _, err := p.DeclineProposalAndEmit(uuid.New(), id); return err },
+		"returned on cancel":  func(p Processor, id uint32) error { _, err := p.CancelProposalAndEmit(uuid.New(), id); return err },
+		"returned on expiry":  func(p Processor, id uint32) error { _, err := p.ExpireProposalAndEmit(uuid.New(), id); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, processor, inv := setupEngagementRingTest(t)
+			inv.AddItem(1, 1)
+			proposal, err := processor.Propose(1, 2)()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if err = respond(processor, proposal.Id()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(inv.released) != 1 || inv.released[0] != *proposal.RingReservationId() {
+				t.Errorf("Expected the reservation to be released, got %v", inv.released)
+			}
+		})
+	}
+}
+
+func TestProcessor_EngagementRing_Compensation(t *testing.T) {
+	t.Run("acceptance stands when the ring cannot be consumed", func(t *testing.T) {
+		db, tenantId, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		proposal, err := processor.Propose(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		inv.consumeErr = errors.New("inventory unavailable")
+		if _, err = processor.AcceptProposalAndEmit(uuid.New(), proposal.Id()); err != nil {
+			t.Fatalf("Expected the committed acceptance to stand, got %v", err)
+		}
+
+		stored, err := GetProposalByIdProvider(db, logrus.New())(proposal.Id(), tenantId)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stored.Status() != ProposalStatusAccepted {
+			t.Errorf("Expected proposal to be accepted, got %s", stored.Status())
+		}
+		if len(inv.released) != 0 {
+			t.Errorf("Expected the ring to stay reserved, got releases %v", inv.released)
+		}
+	})
+
+	t.Run("ring not consumed when the acceptance rolls back", func(t *testing.T) {
+		db, tenantId, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		proposal, err := processor.Propose(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		err = processor.ProcessCommand(uuid.New(), 2, "marriage_proposal_accept", func(p Processor, transactionId uuid.UUID) error {
+			if _, err := p.AcceptProposalAndEmit(transactionId, proposal.Id()); err != nil {
+				return err
+			}
+			return ErrNotMarriagePartner
+		})
+		if !errors.Is(err, ErrNotMarriagePartner) {
+			t.Fatalf("Expected the command to fail, got %v", err)
+		}
+		if len(inv.consumed) != 0 {
+			t.Errorf("Expected the ring not to be consumed, got %v", inv.consumed)
+		}
+
+		stored, err := GetProposalByIdProvider(db, logrus.New())(proposal.Id(), tenantId)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stored.Status() != ProposalStatusPending {
+			t.Errorf("Expected proposal to remain pending, got %s", stored.Status())
+		}
+		var count int64
+		db.Model(&Entity{}).Count(&count)
+		if count != 0 {
+			t.Errorf("Expected the marriage to be rolled back, found %d", count)
+		}
+	})
+
+	t.Run("decline stands when the ring cannot be returned", func(t *testing.T) {
+		db, tenantId, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		proposal, err := processor.Propose(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		inv.releaseErr = errors.New("inventory unavailable")
+		if _, err = processor.DeclineProposalAndEmit(uuid.New(), proposal.Id()); err != nil {
+			t.Fatalf("Expected the committed decline to stand, got %v", err)
+		}
+
+		stored, err := GetProposalByIdProvider(db, logrus.New())(proposal.Id(), tenantId)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stored.Status() != ProposalStatusRejected {
+			t.Errorf("Expected proposal to be declined, got %s", stored.Status())
+		}
+	})
+
+	for name, respond := range map[string]func(Processor, uuid.UUID, uint32) error{
+		"ring not returned when the decline rolls back": func(p Processor, transactionId uuid.UUID, id uint32) error {
+			_, err := p.DeclineProposalAndEmit(transactionId, id)
+			return err
+		},
+		"ring not returned when the cancellation rolls back": func(p Processor, transactionId uuid.UUID, id uint32) error {
+			_, err := p.CancelProposalAndEmit(transactionId, id)
+			return err
+		},
+		"ring not returned when the expiry rolls back": func(p Processor, transactionId uuid.UUID, id uint32) error {
+			_, err := p.ExpireProposalAndEmit(transactionId, id)
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, tenantId, processor, inv := setupEngagementRingTest(t)
+			inv.AddItem(1, 1)
+			proposal, err := processor.Propose(1, 2)()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			err = processor.ProcessCommand(uuid.New(), 1, "marriage_proposal", func(p Processor, transactionId uuid.UUID) error {
+				if err := respond(p, transactionId, proposal.Id()); err != nil {
+					return err
+				}
+				return ErrNotMarriagePartner
+			})
+			if !errors.Is(err, ErrNotMarriagePartner) {
+				t.Fatalf("Expected the command to fail, got %v", err)
+			}
+			if len(inv.released) != 0 {
+				t.Errorf("Expected the ring to stay reserved, got releases %v", inv.released)
+			}
+
+			stored, err := GetProposalByIdProvider(db, logrus.New())(proposal.Id(), tenantId)()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if stored.Status() != ProposalStatusPending {
+				t.Errorf("Expected proposal to remain pending, got %s", stored.Status())
+			}
+		})
+	}
+
+	t.Run("ring returned only once the character deletion commits", func(t *testing.T) {
+		_, _, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		proposal, err := processor.Propose(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		err = processor.ProcessCommand(uuid.New(), 1, "character_deletion", func(p Processor, transactionId uuid.UUID) error {
+			if err := p.HandleCharacterDeletionAndEmit(transactionId, 1); err != nil {
+				return err
+			}
+			return ErrNotMarriagePartner
+		})
+		if !errors.Is(err, ErrNotMarriagePartner) {
+			t.Fatalf("Expected the command to fail, got %v", err)
+		}
+		if len(inv.released) != 0 {
+			t.Fatalf("Expected the ring to stay reserved after the deletion rolled back, got releases %v", inv.released)
+		}
+
+		if err = processor.HandleCharacterDeletionAndEmit(uuid.New(), 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(inv.released) != 1 || inv.released[0] != *proposal.RingReservationId() {
+			t.Errorf("Expected the reservation to be released, got %v", inv.released)
+		}
+	})
+
+	t.Run("reservation released when the proposal rolls back", func(t *testing.T) {
+		db, _, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+
+		err := processor.ProcessCommand(uuid.New(), 1, "marriage_proposal", func(p Processor, transactionId uuid.UUID) error {
+			if _, err := p.ProposeAndEmit(transactionId, 1, 2); err != nil {
+				return err
+			}
+			return ErrNotMarriagePartner
+		})
+		if !errors.Is(err, ErrNotMarriagePartner) {
+			t.Fatalf("Expected the command to fail, got %v", err)
+		}
+		if len(inv.reservations) != 1 || len(inv.released) != 1 {
+			t.Errorf("Expected the reservation to be released once, reserved %d released %d", len(inv.reservations), len(inv.released))
+		}
+
+		var count int64
+		db.Model(&ProposalEntity{}).Count(&count)
+		if count != 0 {
+			t.Errorf("Expected the proposal to be rolled back, found %d", count)
+		}
+	})
+
+	t.Run("reservation released when the proposal cannot be created", func(t *testing.T) {
+		db, _, processor, inv := setupEngagementRingTest(t)
+		inv.AddItem(1, 1)
+		err := db.Callback().Create().Before("gorm:create").Register("fail_proposal_create", func(tx *gorm.DB) {
+			if tx.Statement.Table == (ProposalEntity{}).TableName() {
+				_ = tx.AddError(errors.New("database unavailable"))
+			}
+		})
+		if err != nil {
+			t.Fatalf("Failed to register callback: %v", err)
+		}
+
+		if _, err := processor.Propose(1, 2)(); err == nil {
+			t.Fatal("Expected proposal creation to fail")
+		}
+		if len(inv.reservations) != 1 || len(inv.released) != 1 {
+			t.Errorf("Expected the reservation to be released, reserved %d released %d", len(inv.reservations), len(inv.released))
+		}
+	})
+}
@@ -17,10 +17,25 @@ type Entity struct {
 	ProposedAt   time.Time      `gorm:"not null"`
 	EngagedAt    *time.Time     `gorm:"index"`
 	MarriedAt    *time.Time     `gorm:"index"`
-	DivorcedAt   *time.Time     `gorm:"index"`
-	TenantId     uuid.UUID      `gorm:"type:uuid;index;not null"`
-	CreatedAt    time.Time      `gorm:"not null"`
-	UpdatedAt    time.Time      `gorm:"not null"`
+	DivorcedAt     *time.Time     `gorm:"index"`
+	DeletedAt      *time.Time     `gorm:"index"`
+	DeletionReason string         `gorm:"type:text"`
+	TenantId       uuid.UUID      `gorm:"type:uuid;index;not null"`
+	CreatedAt      time.Time      `gorm:"not null"`
+	UpdatedAt      time.Time      `gorm:"not null"`
+
+	RingItemId  uint32 `gorm:"not null;default:0"` // Wedding ring issued to both partners
+	RingSerial1 uint64 `gorm:"not null;default:0"` // Serial of the ring held by the first character
+	RingSerial2 uint64 `gorm:"not null;default:0"` // Serial of the ring held by the second character
+
+	DivorceFiledBy   uint32     `gorm:"not null;default:0"` // Partner who filed for divorce
+	DivorceFiledAt   *time.Time
+	DivorceMaturesAt *time.Time `gorm:"index"`     // When the filed divorce is finalized without consent
+	DivorcePaymentId *uuid.UUID `gorm:"type:uuid"` // Economy transaction refunded if the filing is withdrawn
+
+	BondPoints     uint32     `gorm:"not null;default:0"` // Bond points the couple has earned, less any decay
+	BondLevel      byte       `gorm:"not null;default:0"` // Bond level the points reached when they last changed
+	BondActivityAt *time.Time `gorm:"index"`              // When bond points were last awarded or decayed
 }
 
 // TableName returns the table name for the marriage entity
@@ -28,7 +43,7 @@ func (Entity) TableName() string {
 	return "marriages"
 }
 
-// Migration performs the database migration for the marriage entity, proposal entity, and ceremony entity
+// Migration performs the database migration for the marriage, proposal, ceremony, anniversary and presence entities
 func Migration(db *gorm.DB) error {
 	if err := db.AutoMigrate(&Entity{}); err != nil {
 		return err
@@ -36,7 +51,13 @@ func Migration(db *gorm.DB) error {
 	if err := db.AutoMigrate(&ProposalEntity{}); err != nil {
 		return err
 	}
-	return db.AutoMigrate(&CeremonyEntity{})
+	if err := db.AutoMigrate(&CeremonyEntity{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&AnniversaryEntity{}); err != nil {
+		return err
+	}
+	return db.AutoMigrate(&PresenceEntity{})
 }
 
 // Make transforms a marriage entity to a domain model
@@ -48,6 +69,11 @@ func Make(entity Entity) (Marriage, error) {
 		SetEngagedAt(entity.EngagedAt).
 		SetMarriedAt(entity.MarriedAt).
 		SetDivorcedAt(entity.DivorcedAt).
+		SetDeletedAt(entity.DeletedAt).
+		SetDeletionReason(entity.DeletionReason).
+		SetRings(entity.RingItemId, entity.RingSerial1, entity.RingSerial2).
+		SetDivorceFiling(entity.DivorceFiledBy, entity.DivorceFiledAt, entity.DivorceMaturesAt, entity.DivorcePaymentId).
+		SetBond(entity.BondPoints, entity.BondLevel, entity.BondActivityAt).
 		SetCreatedAt(entity.CreatedAt).
 		SetUpdatedAt(entity.UpdatedAt).
 		Build()
@@ -63,10 +89,25 @@ func (m Marriage) ToEntity() Entity {
 		ProposedAt:   m.proposedAt,
 		EngagedAt:    m.engagedAt,
 		MarriedAt:    m.marriedAt,
-		DivorcedAt:   m.divorcedAt,
-		TenantId:     m.tenantId,
+		DivorcedAt:     m.divorcedAt,
+		DeletedAt:      m.deletedAt,
+		DeletionReason: m.deletionReason,
+		TenantId:       m.tenantId,
 		CreatedAt:    m.createdAt,
 		UpdatedAt:    m.updatedAt,
+
+		RingItemId:  m.ringItemId,
+		RingSerial1: m.ringSerial1,
+		RingSerial2: m.ringSerial2,
+
+		DivorceFiledBy:   m.divorceFiledBy,
+		DivorceFiledAt:   m.divorceFiledAt,
+		DivorceMaturesAt: m.divorceMaturesAt,
+		DivorcePaymentId: m.divorcePaymentId,
+
+		BondPoints:     m.bondPoints,
+		BondLevel:      m.bondLevel,
+		BondActivityAt: m.bondActivityAt,
 	}
 }
 
@@ -84,6 +125,9 @@ type ProposalEntity struct {
 	TenantId       uuid.UUID      `gorm:"type:uuid;index;not null"`
 	CreatedAt      time.Time      `gorm:"not null"`
 	UpdatedAt      time.Time      `gorm:"not null"`
+
+	RingItemId        uint32     `gorm:"not null;default:0"` // Engagement ring item held for the proposal
+	RingReservationId *uuid.UUID `gorm:"type:uuid"`          // Inventory reservation holding the ring
 }
 
 // TableName returns the table name for the proposal entity
@@ -108,6 +152,8 @@ func MakeProposal(entity ProposalEntity) (Proposal, error) {
 		SetCooldownUntil(entity.CooldownUntil).
 		SetCreatedAt(entity.CreatedAt).
 		SetUpdatedAt(entity.UpdatedAt).
+		SetRingItemId(entity.RingItemId).
+		SetRingReservationId(entity.RingReservationId).
 		Build()
 }
 
@@ -126,6 +172,9 @@ func (p Proposal) ToProposalEntity() ProposalEntity {
 		TenantId:       p.tenantId,
 		CreatedAt:      p.createdAt,
 		UpdatedAt:      p.updatedAt,
+
+		RingItemId:        p.ringItemId,
+		RingReservationId: p.ringReservationId,
 	}
 }
 
@@ -142,9 +191,26 @@ type CeremonyEntity struct {
 	CancelledAt  *time.Time     `gorm:"index"`
 	PostponedAt  *time.Time     `gorm:"index"`
 	Invitees     string         `gorm:"type:text"` // JSON array of uint32s
+	MaxInvitees  int            `gorm:"not null;default:0"` // Invitee limit when scheduled, zero for the default
 	TenantId     uuid.UUID      `gorm:"type:uuid;index;not null"`
 	CreatedAt    time.Time      `gorm:"not null"`
 	UpdatedAt    time.Time      `gorm:"not null"`
+
+	VenueTier VenueTier  `gorm:"not null;default:STANDARD"`
+	Cost      uint32     `gorm:"not null;default:0"` // Mesos paid by the first partner to schedule the ceremony
+	PaymentId *uuid.UUID `gorm:"type:uuid"`          // Economy transaction the ceremony saga charges and refunds
+
+	Rsvps string `gorm:"type:text"` // JSON array of the responses of invitees who have responded
+
+	RemindedAt *time.Time // When the couple and invitees were reminded of the scheduled start
+
+	DisconnectedAt1 *time.Time // When the first partner logged out during the active ceremony
+	DisconnectedAt2 *time.Time // When the second partner logged out during the active ceremony
+
+	VenueId uint32 `gorm:"index;not null;default:0"` // Venue booked for the ceremony, zero if it holds no booking
+
+	Stages string `gorm:"type:text"` // JSON array of the stage names, in order, fixed when the ceremony was scheduled
+	Stage  string // Stage the active ceremony has reached, empty before its first stage
 }
 
 // TableName returns the table name for the ceremony entity
@@ -159,8 +225,24 @@ func MakeCeremony(entity CeremonyEntity) (Ceremony, error) {
 	if err != nil {
 		return Ceremony{}, err
 	}
+	rsvps, err := parseRsvps(entity.Rsvps)
+	if err != nil {
+		return Ceremony{}, err
+	}
+	stages, err := parseStages(entity.Stages)
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	builder := NewCeremonyBuilder(entity.MarriageId, entity.CharacterId1, entity.CharacterId2, entity.TenantId)
+	if entity.MaxInvitees > 0 {
+		builder.SetMaxInvitees(entity.MaxInvitees)
+	}
+	if entity.VenueTier != "" {
+		builder.SetVenueTier(entity.VenueTier)
+	}
 
-	return NewCeremonyBuilder(entity.MarriageId, entity.CharacterId1, entity.CharacterId2, entity.TenantId).
+	return builder.
 		SetId(entity.ID).
 		SetStatus(entity.Status).
 		SetScheduledAt(entity.ScheduledAt).
@@ -169,6 +251,15 @@ func MakeCeremony(entity CeremonyEntity) (Ceremony, error) {
 		SetCancelledAt(entity.CancelledAt).
 		SetPostponedAt(entity.PostponedAt).
 		SetInvitees(invitees).
+		SetRsvps(rsvps).
+		SetCost(entity.Cost).
+		SetPaymentId(entity.PaymentId).
+		SetRemindedAt(entity.RemindedAt).
+		SetDisconnectedAt1(entity.DisconnectedAt1).
+		SetDisconnectedAt2(entity.DisconnectedAt2).
+		SetVenueId(entity.VenueId).
+		SetStages(stages).
+		SetStage(entity.Stage).
 		SetCreatedAt(entity.CreatedAt).
 		SetUpdatedAt(entity.UpdatedAt).
 		Build()
@@ -181,6 +272,14 @@ func (c Ceremony) ToCeremonyEntity() (CeremonyEntity, error) {
 	if err != nil {
 		return CeremonyEntity{}, err
 	}
+	rsvpsJSON, err := rsvpsToJSON(c.respondedRsvps())
+	if err != nil {
+		return CeremonyEntity{}, err
+	}
+	stagesJSON, err := stagesToJSON(c.stages)
+	if err != nil {
+		return CeremonyEntity{}, err
+	}
 
 	return CeremonyEntity{
 		ID:           c.id,
@@ -194,9 +293,26 @@ func (c Ceremony) ToCeremonyEntity() (CeremonyEntity, error) {
 		CancelledAt:  c.cancelledAt,
 		PostponedAt:  c.postponedAt,
 		Invitees:     inviteesJSON,
+		MaxInvitees:  c.maxInvitees,
 		TenantId:     c.tenantId,
 		CreatedAt:    c.createdAt,
 		UpdatedAt:    c.updatedAt,
+
+		VenueTier: c.venueTier,
+		Cost:      c.cost,
+		PaymentId: c.paymentId,
+
+		Rsvps: rsvpsJSON,
+
+		RemindedAt: c.remindedAt,
+
+		DisconnectedAt1: c.disconnectedAt1,
+		DisconnectedAt2: c.disconnectedAt2,
+
+		VenueId: c.venueId,
+
+		Stages: stagesJSON,
+		Stage:  c.stage,
 	}, nil
 }
 
@@ -226,4 +342,112 @@ func inviteesToJSON(invitees []uint32) (string, error) {
 	}
 	
 	return string(data), nil
-}
\ No newline at end of file
+}
+
+// rsvpJSON is the serialized form of an invitee's response to their invitation
+type rsvpJSON struct {
+	CharacterId uint32     `json:"characterId"`
+	Status      RsvpStatus `json:"status"`
+	RespondedAt *time.Time `json:"respondedAt"`
+}
+
+// parseRsvps converts a JSON string to the responses of invitees
+func parseRsvps(rsvpsJSON string) ([]Rsvp, error) {
+	if rsvpsJSON == "" {
+		return []Rsvp{}, nil
+	}
+
+	var entries []rsvpJSON
+	if err := json.Unmarshal([]byte(rsvpsJSON), &entries); err != nil {
+		return nil, err
+	}
+
+	rsvps := make([]Rsvp, 0, len(entries))
+	for _, entry := range entries {
+		rsvps = append(rsvps, Rsvp{characterId: entry.CharacterId, status: entry.Status, respondedAt: entry.RespondedAt})
+	}
+	return rsvps, nil
+}
+
+// rsvpsToJSON converts the responses of invitees to a JSON string
+func rsvpsToJSON(rsvps []Rsvp) (string, error) {
+	entries := make([]rsvpJSON, 0, len(rsvps))
+	for _, rsvp := range rsvps {
+		entries = append(entries, rsvpJSON{CharacterId: rsvp.CharacterId(), Status: rsvp.Status(), RespondedAt: rsvp.RespondedAt()})
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// parseStages converts a JSON string to a ceremony's stages. Ceremonies scheduled before stages were configured have none
+func parseStages(stagesJSON string) ([]string, error) {
+	if stagesJSON == "" {
+		return []string{}, nil
+	}
+
+	var stages []string
+	if err := json.Unmarshal([]byte(stagesJSON), &stages); err != nil {
+		return nil, err
+	}
+	return stages, nil
+}
+
+// stagesToJSON converts a ceremony's stages to a JSON string
+func stagesToJSON(stages []string) (string, error) {
+	if len(stages) == 0 {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(stages)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// AnniversaryEntity records an anniversary milestone a marriage has reached, so that it is celebrated only once
+type AnniversaryEntity struct {
+	ID            uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_marriage_anniversary"`
+	MarriageId    uint32    `gorm:"not null;uniqueIndex:idx_marriage_anniversary"`
+	MilestoneDays uint32    `gorm:"not null;uniqueIndex:idx_marriage_anniversary"`
+	ReachedAt     time.Time `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the anniversary entity
+func (AnniversaryEntity) TableName() string {
+	return "marriage_anniversaries"
+}
+
+// MakeAnniversary transforms an anniversary entity to a domain model
+func MakeAnniversary(entity AnniversaryEntity) Anniversary {
+	return Anniversary{
+		id:            entity.ID,
+		marriageId:    entity.MarriageId,
+		milestoneDays: entity.MilestoneDays,
+		reachedAt:     entity.ReachedAt,
+		recordedAt:    entity.CreatedAt,
+		tenantId:      entity.TenantId,
+	}
+}
+
+// PresenceEntity records whether a character is logged in, as their last login or logout status event reported. A
+// tenant's character has a single presence, which each status event overwrites
+type PresenceEntity struct {
+	TenantId    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CharacterId uint32    `gorm:"primaryKey;autoIncrement:false"`
+	Online      bool      `gorm:"not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the presence entity
+func (PresenceEntity) TableName() string {
+	return "character_presences"
+}
@@ -0,0 +1,547 @@
+package marriage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+)
+
+// DomainError is implemented by every error in the marriage error catalogue. The error type and code
+// are reported verbatim on MARRIAGE_ERROR events, and the status on REST responses
+type DomainError interface {
+	error
+	ErrorType() string
+	ErrorCode() string
+	HTTPStatus() int
+}
+
+// Entity names used by NotFoundError and StateTransitionError
+const (
+	EntityProposal = "proposal"
+	EntityMarriage = "marriage"
+	EntityCeremony = "ceremony"
+	EntityVenue    = "venue"
+	EntityRegistry = "registry item"
+)
+
+// NotFoundError reports that a proposal, marriage, ceremony, venue or registry item does not exist
+type NotFoundError struct {
+	Entity string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("%s not found", e.Entity)
+}
+
+// ErrorType returns the error event type for NotFoundError
+func (e NotFoundError) ErrorType() string {
+	return marriageMsg.ErrorTypeNotFound
+}
+
+// ErrorCode returns the error event code for the missing entity
+func (e NotFoundError) ErrorCode() string {
+	switch e.Entity {
+	case EntityProposal:
+		return marriageMsg.ErrorCodeProposalNotFound
+	case EntityMarriage:
+		return marriageMsg.ErrorCodeMarriageNotFound
+	case EntityCeremony:
+		return marriageMsg.ErrorCodeCeremonyNotFound
+	case EntityVenue:
+		return marriageMsg.ErrorCodeVenueNotFound
+	case EntityRegistry:
+		return marriageMsg.ErrorCodeRegistryItemNotFound
+	default:
+		return marriageMsg.ErrorCodeInternal
+	}
+}
+
+// HTTPStatus returns the REST response status for NotFoundError
+func (e NotFoundError) HTTPStatus() int {
+	return http.StatusNotFound
+}
+
+// CooldownScope identifies which proposal cooldown is active
+type CooldownScope string
+
+const (
+	CooldownScopeGlobal     CooldownScope = "global"
+	CooldownScopeTarget     CooldownScope = "target"
+	CooldownScopeRemarriage CooldownScope = "remarriage"
+	CooldownScopeExPartner  CooldownScope = "ex_partner"
+)
+
+// CooldownError reports that a proposer is in a cooldown period, and how long remains of it
+type CooldownError struct {
+	Scope     CooldownScope
+	Remaining time.Duration
+}
+
+func (e CooldownError) Error() string {
+	var message string
+	switch e.Scope {
+	case CooldownScopeTarget:
+		message = "proposer is in cooldown period for this target"
+	case CooldownScopeRemarriage:
+		message = "character is in remarriage cooldown period after divorce"
+	case CooldownScopeExPartner:
+		message = "proposer is in remarriage cooldown period for this ex-partner"
+	default:
+		message = "proposer is in global cooldown period"
+	}
+	if e.Remaining > 0 {
+		message = fmt.Sprintf("%s (%s remaining)", message, e.Remaining.Round(time.Second))
+	}
+	return message
+}
+
+// Is matches cooldown errors of the same scope regardless of the remaining duration
+func (e CooldownError) Is(target error) bool {
+	t, ok := target.(CooldownError)
+	return ok && t.Scope == e.Scope
+}
+
+// ErrorType returns the error event type for CooldownError
+func (e CooldownError) ErrorType() string {
+	return marriageMsg.ErrorTypeCooldown
+}
+
+// ErrorCode returns the error event code for the cooldown scope
+func (e CooldownError) ErrorCode() string {
+	switch e.Scope {
+	case CooldownScopeTarget:
+		return marriageMsg.ErrorCodeTargetCooldown
+	case CooldownScopeRemarriage:
+		return marriageMsg.ErrorCodeRemarriageCooldown
+	case CooldownScopeExPartner:
+		return marriageMsg.ErrorCodeExPartnerCooldown
+	default:
+		return marriageMsg.ErrorCodeGlobalCooldown
+	}
+}
+
+// HTTPStatus returns the REST response status for CooldownError
+func (e CooldownError) HTTPStatus() int {
+	return http.StatusTooManyRequests
+}
+
+// cooldownScopeOf returns the cooldown scope reported by an error event code, or false if the code is not a cooldown
+func cooldownScopeOf(code string) (CooldownScope, bool) {
+	for _, scope := range []CooldownScope{CooldownScopeGlobal, CooldownScopeTarget, CooldownScopeRemarriage, CooldownScopeExPartner} {
+		if (CooldownError{Scope: scope}).ErrorCode() == code {
+			return scope, true
+		}
+	}
+	return "", false
+}
+
+// EligibilityError reports that a character is not eligible to propose or be proposed to. Code is the
+// reason, and CharacterId the character failing the rule when known. When produced from an eligibility
+// verdict, Violations lists every failing rule
+type EligibilityError struct {
+	Code        string
+	Message     string
+	CharacterId uint32
+	Violations  []EligibilityViolation
+}
+
+func (e EligibilityError) Error() string {
+	return e.Message
+}
+
+// Is matches eligibility errors with the same reason, or which list a violation of that reason, regardless of the character
+func (e EligibilityError) Is(target error) bool {
+	t, ok := target.(EligibilityError)
+	if !ok {
+		return false
+	}
+	if t.Code == e.Code {
+		return true
+	}
+	for _, violation := range e.Violations {
+		if violation.Rule == t.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorType returns the error event type for EligibilityError
+func (e EligibilityError) ErrorType() string {
+	return marriageMsg.ErrorTypeEligibility
+}
+
+// ErrorCode returns the eligibility reason
+func (e EligibilityError) ErrorCode() string {
+	return e.Code
+}
+
+// HTTPStatus returns the REST response status for EligibilityError
+func (e EligibilityError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ForCharacter returns a copy of the error attributed to the given character
+func (e EligibilityError) ForCharacter(characterId uint32) EligibilityError {
+	e.CharacterId = characterId
+	return e
+}
+
+// StateTransitionError reports an operation which is not permitted in the current state of a proposal,
+// marriage or ceremony. When From and To are equal the operation modifies the entity without changing its state
+type StateTransitionError struct {
+	Entity string
+	From   string
+	To     string
+}
+
+func (e StateTransitionError) Error() string {
+	if e.From == e.To {
+		return fmt.Sprintf("%s cannot be modified while %s", e.Entity, e.From)
+	}
+	return fmt.Sprintf("%s cannot transition from %s to %s", e.Entity, e.From, e.To)
+}
+
+// ErrorType returns the error event type for StateTransitionError
+func (e StateTransitionError) ErrorType() string {
+	return marriageMsg.ErrorTypeStateTransition
+}
+
+// ErrorCode returns the error event code for StateTransitionError
+func (e StateTransitionError) ErrorCode() string {
+	return marriageMsg.ErrorCodeInvalidState
+}
+
+// HTTPStatus returns the REST response status for StateTransitionError
+func (e StateTransitionError) HTTPStatus() int {
+	return http.StatusConflict
+}
+
+// InviteeLimitError reports that a ceremony would exceed the maximum number of invitees
+type InviteeLimitError struct {
+	Limit     int
+	Requested int
+}
+
+func (e InviteeLimitError) Error() string {
+	return fmt.Sprintf("too many invitees, maximum is %d", e.Limit)
+}
+
+// Is matches any invitee limit error
+func (e InviteeLimitError) Is(target error) bool {
+	_, ok := target.(InviteeLimitError)
+	return ok
+}
+
+// ErrorType returns the error event type for InviteeLimitError
+func (e InviteeLimitError) ErrorType() string {
+	return marriageMsg.ErrorTypeInviteeLimit
+}
+
+// ErrorCode returns the error event code for InviteeLimitError
+func (e InviteeLimitError) ErrorCode() string {
+	return marriageMsg.ErrorCodeInviteeLimitExceeded
+}
+
+// HTTPStatus returns the REST response status for InviteeLimitError
+func (e InviteeLimitError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// ValidationError reports a request which is malformed or not permitted for the requesting character. Status
+// overrides the REST response status of a validation failure when set
+type ValidationError struct {
+	Code    string
+	Message string
+	Status  int
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+// ErrorType returns the error event type for ValidationError
+func (e ValidationError) ErrorType() string {
+	return marriageMsg.ErrorTypeValidation
+}
+
+// ErrorCode returns the error event code for ValidationError
+func (e ValidationError) ErrorCode() string {
+	return e.Code
+}
+
+// HTTPStatus returns the REST response status for ValidationError, defaulting to 422 Unprocessable Entity
+func (e ValidationError) HTTPStatus() int {
+	if e.Status == 0 {
+		return http.StatusUnprocessableEntity
+	}
+	return e.Status
+}
+
+// ItemRequirementError reports that a character does not hold an item the operation requires
+type ItemRequirementError struct {
+	ItemId      uint32
+	CharacterId uint32
+}
+
+func (e ItemRequirementError) Error() string {
+	if e.ItemId == 0 {
+		return "character does not hold the required engagement ring"
+	}
+	return fmt.Sprintf("character does not hold the required engagement ring (item %d)", e.ItemId)
+}
+
+// Is matches any item requirement error regardless of the item or character
+func (e ItemRequirementError) Is(target error) bool {
+	_, ok := target.(ItemRequirementError)
+	return ok
+}
+
+// ErrorType returns the error event type for ItemRequirementError
+func (e ItemRequirementError) ErrorType() string {
+	return marriageMsg.ErrorTypeItemRequirement
+}
+
+// ErrorCode returns the error event code for ItemRequirementError
+func (e ItemRequirementError) ErrorCode() string {
+	return marriageMsg.ErrorCodeEngagementRingRequired
+}
+
+// HTTPStatus returns the REST response status for ItemRequirementError
+func (e ItemRequirementError) HTTPStatus() int {
+	return http.StatusUnprocessableEntity
+}
+
+// InsufficientFundsError reports that a character cannot afford the cost of an operation
+type InsufficientFundsError struct {
+	CharacterId uint32
+	Required    uint32
+	Available   uint32
+}
+
+func (e InsufficientFundsError) Error() string {
+	return fmt.Sprintf("character has insufficient mesos, %d required and %d available", e.Required, e.Available)
+}
+
+// Is matches any insufficient funds error regardless of the character or amounts
+func (e InsufficientFundsError) Is(target error) bool {
+	_, ok := target.(InsufficientFundsError)
+	return ok
+}
+
+// ErrorType returns the error event type for InsufficientFundsError
+func (e InsufficientFundsError) ErrorType() string {
+	return marriageMsg.ErrorTypeInsufficientFunds
+}
+
+// ErrorCode returns the error event code for InsufficientFundsError
+func (e InsufficientFundsError) ErrorCode() string {
+	return marriageMsg.ErrorCodeInsufficientFunds
+}
+
+// HTTPStatus returns the REST response status for InsufficientFundsError
+func (e InsufficientFundsError) HTTPStatus() int {
+	return http.StatusPaymentRequired
+}
+
+// Predefined lookup errors
+var (
+	ErrProposalNotFound = NotFoundError{Entity: EntityProposal}
+	ErrMarriageNotFound = NotFoundError{Entity: EntityMarriage}
+	ErrCeremonyNotFound = NotFoundError{Entity: EntityCeremony}
+	ErrVenueNotFound    = NotFoundError{Entity: EntityVenue}
+	ErrRegistryNotFound = NotFoundError{Entity: EntityRegistry}
+)
+
+// Predefined validation errors
+var (
+	ErrTooManyInvitees       = InviteeLimitError{Limit: MaxInvitees}
+	ErrNotMarriagePartner    = ValidationError{Code: marriageMsg.ErrorCodeNotPartner, Message: "only married partners can initiate divorce", Status: http.StatusForbidden}
+	ErrPartnerInvitee        = ValidationError{Code: marriageMsg.ErrorCodePartnerInvitee, Message: "partners cannot be invitees"}
+	ErrInviteeAlreadyInvited = ValidationError{Code: marriageMsg.ErrorCodeInviteeAlreadyInvited, Message: "character is already invited"}
+	ErrInviteeNotInvited     = ValidationError{Code: marriageMsg.ErrorCodeInviteeNotFound, Message: "character is not invited"}
+	ErrInviteeAttended       = ValidationError{Code: marriageMsg.ErrorCodeInviteeAttended, Message: "invitee has already attended the ceremony"}
+	ErrInvalidRsvpStatus     = ValidationError{Code: marriageMsg.ErrorCodeInvalidRsvpStatus, Message: "invitees can only accept, decline or attend"}
+	ErrEngagementRingMissing = ItemRequirementError{}
+	ErrInvalidVenueTier      = ValidationError{Code: marriageMsg.ErrorCodeInvalidVenueTier, Message: "unknown venue tier"}
+	ErrVenueSlotUnavailable  = ValidationError{Code: marriageMsg.ErrorCodeVenueSlotUnavailable, Message: "venue is already booked at that time", Status: http.StatusConflict}
+	ErrInvalidVenueSlot      = ValidationError{Code: marriageMsg.ErrorCodeInvalidVenueSlot, Message: "scheduled time is not the start of one of the venue's slots"}
+	ErrVenueRequired         = ValidationError{Code: marriageMsg.ErrorCodeVenueRequired, Message: "a venue from the catalogue must be booked for the ceremony"}
+	ErrInsufficientFunds     = InsufficientFundsError{}
+	ErrDivorceFilingRequired = ValidationError{Code: marriageMsg.ErrorCodeDivorceFilingRequired, Message: "divorce must be filed and consented to or left to mature"}
+	ErrDivorceFilerConsent   = ValidationError{Code: marriageMsg.ErrorCodeDivorceFiler, Message: "the partner who filed for divorce cannot consent to it", Status: http.StatusForbidden}
+	ErrNotDivorceFiler       = ValidationError{Code: marriageMsg.ErrorCodeNotDivorceFiler, Message: "only the partner who filed for divorce can withdraw it", Status: http.StatusForbidden}
+	ErrAnniversaryNotReached = ValidationError{Code: marriageMsg.ErrorCodeAnniversaryNotReached, Message: "marriage has not reached the anniversary milestone"}
+	ErrAnniversaryRecorded   = ValidationError{Code: marriageMsg.ErrorCodeAnniversaryRecorded, Message: "anniversary milestone has already been celebrated"}
+	ErrInvalidBondSource     = ValidationError{Code: marriageMsg.ErrorCodeInvalidBondSource, Message: "unknown bond point source"}
+	ErrInvalidBondPoints     = ValidationError{Code: marriageMsg.ErrorCodeInvalidBondPoints, Message: "bond points awarded must be positive"}
+	ErrCeremonyStageInvalid  = ValidationError{Code: marriageMsg.ErrorCodeInvalidCeremonyStage, Message: "stage is not the ceremony's next stage"}
+	ErrCeremonyFinalStage    = ValidationError{Code: marriageMsg.ErrorCodeCeremonyFinalStage, Message: "ceremony has already reached its final stage", Status: http.StatusConflict}
+	ErrCeremonyStagesPending = ValidationError{Code: marriageMsg.ErrorCodeCeremonyStagesIncomplete, Message: "ceremony cannot be completed before its final stage", Status: http.StatusConflict}
+	ErrNotRegistryOwner      = ValidationError{Code: marriageMsg.ErrorCodeNotPartner, Message: "only the couple can change their registry"}
+	ErrRegistryItemListed    = ValidationError{Code: marriageMsg.ErrorCodeRegistryItemListed, Message: "item is already on the registry"}
+	ErrRegistryItemGiven     = ValidationError{Code: marriageMsg.ErrorCodeRegistryItemGiven, Message: "item has already been given and cannot be removed"}
+	ErrInvalidRegistryItem   = ValidationError{Code: marriageMsg.ErrorCodeInvalidRegistryQuantity, Message: "registry item and quantity must be positive"}
+	ErrGiftExceedsRegistry   = ValidationError{Code: marriageMsg.ErrorCodeGiftExceedsRegistry, Message: "gift exceeds the quantity remaining on the registry"}
+	ErrGiftItemMissing       = ValidationError{Code: marriageMsg.ErrorCodeGiftItemRequired, Message: "invitee does not hold the items given"}
+	ErrAlreadyBlessed        = ValidationError{Code: marriageMsg.ErrorCodeAlreadyBlessed, Message: "invitee has already blessed the ceremony"}
+	ErrBlessingTooLong       = ValidationError{Code: marriageMsg.ErrorCodeBlessingTooLong, Message: "blessing message is too long"}
+)
+
+// Predefined eligibility errors
+var (
+	ErrCharacterTooLowLevel = EligibilityError{
+		Code:    marriageMsg.ErrorCodeInsufficientLevel,
+		Message: "character level is too low for marriage",
+	}
+	ErrCharacterAlreadyMarried = EligibilityError{
+		Code:    marriageMsg.ErrorCodeAlreadyMarried,
+		Message: "character is already married or engaged",
+	}
+	ErrTargetAlreadyEngaged = EligibilityError{
+		Code:    marriageMsg.ErrorCodeConcurrentProposal,
+		Message: "target character already has a pending proposal",
+	}
+)
+
+// Predefined cooldown errors
+var (
+	ErrGlobalCooldownActive     = CooldownError{Scope: CooldownScopeGlobal}
+	ErrTargetCooldownActive     = CooldownError{Scope: CooldownScopeTarget}
+	ErrRemarriageCooldownActive = CooldownError{Scope: CooldownScopeRemarriage}
+	ErrExPartnerCooldownActive  = CooldownError{Scope: CooldownScopeExPartner}
+)
+
+// ErrTransactionProcessed reports a command redelivered under a transaction id the tenant has already processed. The
+// command is not executed again
+var ErrTransactionProcessed = errors.New("transaction has already been processed")
+
+// ClassifyError returns the error event type and code for an error, falling back to an internal marriage
+// error when it is not part of the catalogue
+func ClassifyError(err error) (string, string) {
+	var de DomainError
+	if errors.As(err, &de) {
+		return de.ErrorType(), de.ErrorCode()
+	}
+	return marriageMsg.ErrorTypeMarriage, marriageMsg.ErrorCodeInternal
+}
+
+// ErrorStatus returns the REST response status for an error, falling back to 500 Internal Server Error when it is
+// not part of the catalogue
+func ErrorStatus(err error) int {
+	var de DomainError
+	if errors.As(err, &de) {
+		return de.HTTPStatus()
+	}
+	return http.StatusInternalServerError
+}
+
+// proposalTransitionError describes a proposal which cannot move to the given status. Pending proposals past
+// their expiry are reported as expired
+func proposalTransitionError(proposal Proposal, to ProposalStatus) StateTransitionError {
+	from := proposal.Status()
+	if from == ProposalStatusPending && proposal.IsExpired() {
+		from = ProposalStatusExpired
+	}
+	return StateTransitionError{Entity: EntityProposal, From: from.String(), To: to.String()}
+}
+
+// marriageTransitionError describes a marriage which cannot move to the given status
+func marriageTransitionError(marriage Marriage, to MarriageStatus) StateTransitionError {
+	return StateTransitionError{Entity: EntityMarriage, From: marriage.Status().String(), To: to.String()}
+}
+
+// ceremonyTransitionError describes a ceremony which cannot move to the given status
+func ceremonyTransitionError(ceremony Ceremony, to CeremonyStatus) StateTransitionError {
+	return StateTransitionError{Entity: EntityCeremony, From: ceremony.Status().String(), To: to.String()}
+}
+
+// inviteeAdditionError returns why a character cannot be invited to a ceremony, or nil when it can be
+func inviteeAdditionError(ceremony Ceremony, characterId uint32) error {
+	if ceremony.Status() != CeremonyStatusScheduled && ceremony.Status() != CeremonyStatusPostponed {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	if ceremony.InviteeCount() >= ceremony.MaxInvitees() {
+		return InviteeLimitError{Limit: ceremony.MaxInvitees(), Requested: ceremony.InviteeCount() + 1}
+	}
+	if ceremony.IsPartner(characterId) {
+		return ErrPartnerInvitee
+	}
+	if ceremony.IsInvited(characterId) {
+		return ErrInviteeAlreadyInvited
+	}
+	return nil
+}
+
+// inviteeRemovalError returns why a character cannot be removed from a ceremony, or nil when it can be
+func inviteeRemovalError(ceremony Ceremony, characterId uint32) error {
+	if ceremony.Status() != CeremonyStatusScheduled && ceremony.Status() != CeremonyStatusPostponed {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	if !ceremony.IsInvited(characterId) {
+		return ErrInviteeNotInvited
+	}
+	return nil
+}
+
+// rsvpError returns why an invitee cannot respond to their invitation with the given status, or nil when they can
+func rsvpError(ceremony Ceremony, characterId uint32, status RsvpStatus) error {
+	if !status.IsResponse() {
+		return ErrInvalidRsvpStatus
+	}
+	rsvp, ok := ceremony.Rsvp(characterId)
+	if !ok {
+		return ErrInviteeNotInvited
+	}
+	if rsvp.Status() == RsvpStatusAttended {
+		return ErrInviteeAttended
+	}
+	if !ceremony.CanRespond(characterId, status) {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	return nil
+}
+
+// stageAdvanceError returns why a ceremony cannot advance to a stage, or nil when it can. An empty stage advances to
+// whichever stage is next
+func stageAdvanceError(ceremony Ceremony, stage string) error {
+	if ceremony.Status() != CeremonyStatusActive {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	next, ok := ceremony.NextStage()
+	if !ok {
+		return ErrCeremonyFinalStage
+	}
+	if stage != "" && stage != next {
+		return ErrCeremonyStageInvalid
+	}
+	return nil
+}
+
+// registryChangeError returns why a character cannot change a ceremony's wish list, or nil when they can. The couple
+// may change it until the ceremony is completed or cancelled
+func registryChangeError(ceremony Ceremony, characterId uint32) error {
+	if !ceremony.IsPartner(characterId) {
+		return ErrNotRegistryOwner
+	}
+	if ceremony.IsFinished() {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	return nil
+}
+
+// guestError returns why a character cannot bless or give a gift at a ceremony, or nil when they can. Only invitees who
+// have not declined their invitation take part, and only while the ceremony is active
+func guestError(ceremony Ceremony, characterId uint32) error {
+	rsvp, ok := ceremony.Rsvp(characterId)
+	if !ok || rsvp.Status() == RsvpStatusDeclined {
+		return ErrInviteeNotInvited
+	}
+	if ceremony.Status() != CeremonyStatusActive {
+		return ceremonyTransitionError(ceremony, ceremony.Status())
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package marriage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/google/uuid"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedType string
+		expectedCode string
+	}{
+		{"proposal not found", ErrProposalNotFound, marriageMsg.ErrorTypeNotFound, marriageMsg.ErrorCodeProposalNotFound},
+		{"marriage not found", ErrMarriageNotFound, marriageMsg.ErrorTypeNotFound, marriageMsg.ErrorCodeMarriageNotFound},
+		{"ceremony not found", ErrCeremonyNotFound, marriageMsg.ErrorTypeNotFound, marriageMsg.ErrorCodeCeremonyNotFound},
+		{"global cooldown", CooldownError{Scope: CooldownScopeGlobal, Remaining: time.Hour}, marriageMsg.ErrorTypeCooldown, marriageMsg.ErrorCodeGlobalCooldown},
+		{"target cooldown", ErrTargetCooldownActive, marriageMsg.ErrorTypeCooldown, marriageMsg.ErrorCodeTargetCooldown},
+		{"too low level", ErrCharacterTooLowLevel.ForCharacter(1), marriageMsg.ErrorTypeEligibility, marriageMsg.ErrorCodeInsufficientLevel},
+		{"already married", ErrCharacterAlreadyMarried, marriageMsg.ErrorTypeEligibility, marriageMsg.ErrorCodeAlreadyMarried},
+		{"invalid transition", StateTransitionError{Entity: EntityCeremony, From: "completed", To: "active"}, marriageMsg.ErrorTypeStateTransition, marriageMsg.ErrorCodeInvalidState},
+		{"invitee limit", InviteeLimitError{Limit: MaxInvitees, Requested: 16}, marriageMsg.ErrorTypeInviteeLimit, marriageMsg.ErrorCodeInviteeLimitExceeded},
+		{"not partner", ErrNotMarriagePartner, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeNotPartner},
+		{"engagement ring missing", ItemRequirementError{ItemId: 2240000, CharacterId: 1}, marriageMsg.ErrorTypeItemRequirement, marriageMsg.ErrorCodeEngagementRingRequired},
+		{"insufficient funds", InsufficientFundsError{CharacterId: 1, Required: 500, Available: 100}, marriageMsg.ErrorTypeInsufficientFunds, marriageMsg.ErrorCodeInsufficientFunds},
+		{"invalid venue tier", ErrInvalidVenueTier, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeInvalidVenueTier},
+		{"venue not found", ErrVenueNotFound, marriageMsg.ErrorTypeNotFound, marriageMsg.ErrorCodeVenueNotFound},
+		{"venue slot unavailable", ErrVenueSlotUnavailable, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeVenueSlotUnavailable},
+		{"invalid venue slot", ErrInvalidVenueSlot, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeInvalidVenueSlot},
+		{"invalid ceremony stage", ErrCeremonyStageInvalid, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeInvalidCeremonyStage},
+		{"ceremony final stage", ErrCeremonyFinalStage, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeCeremonyFinalStage},
+		{"ceremony stages incomplete", ErrCeremonyStagesPending, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeCeremonyStagesIncomplete},
+		{"registry item not found", ErrRegistryNotFound, marriageMsg.ErrorTypeNotFound, marriageMsg.ErrorCodeRegistryItemNotFound},
+		{"gift exceeds registry", ErrGiftExceedsRegistry, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeGiftExceedsRegistry},
+		{"gift item missing", ErrGiftItemMissing, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeGiftItemRequired},
+		{"already blessed", ErrAlreadyBlessed, marriageMsg.ErrorTypeValidation, marriageMsg.ErrorCodeAlreadyBlessed},
+		{"wrapped", fmt.Errorf("scheduling: %w", ErrTooManyInvitees), marriageMsg.ErrorTypeInviteeLimit, marriageMsg.ErrorCodeInviteeLimitExceeded},
+		{"uncatalogued", errors.New("connection refused"), marriageMsg.ErrorTypeMarriage, marriageMsg.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errorType, errorCode := ClassifyError(tt.err)
+			if errorType != tt.expectedType {
+				t.Errorf("Expected error type %s, got %s", tt.expectedType, errorType)
+			}
+			if errorCode != tt.expectedCode {
+				t.Errorf("Expected error code %s, got %s", tt.expectedCode, errorCode)
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"ProposalNotFound", ErrProposalNotFound, http.StatusNotFound},
+		{"MarriageNotFound", ErrMarriageNotFound, http.StatusNotFound},
+		{"CeremonyNotFound", ErrCeremonyNotFound, http.StatusNotFound},
+		{"VenueNotFound", ErrVenueNotFound, http.StatusNotFound},
+		{"RegistryNotFound", ErrRegistryNotFound, http.StatusNotFound},
+		{"WrappedNotFound", fmt.Errorf("lookup: %w", ErrMarriageNotFound), http.StatusNotFound},
+		{"NotMarriagePartner", ErrNotMarriagePartner, http.StatusForbidden},
+		{"NotDivorceFiler", ErrNotDivorceFiler, http.StatusForbidden},
+		{"DivorceFilerConsent", ErrDivorceFilerConsent, http.StatusForbidden},
+		{"DivorceFilingRequired", ErrDivorceFilingRequired, http.StatusUnprocessableEntity},
+		{"TooManyInvitees", InviteeLimitError{Limit: MaxInvitees, Requested: 16}, http.StatusUnprocessableEntity},
+		{"AlreadyInvited", ErrInviteeAlreadyInvited, http.StatusUnprocessableEntity},
+		{"Ineligible", ErrCharacterTooLowLevel.ForCharacter(100), http.StatusUnprocessableEntity},
+		{"GlobalCooldown", CooldownError{Scope: CooldownScopeGlobal, Remaining: time.Hour}, http.StatusTooManyRequests},
+		{"TargetCooldown", ErrTargetCooldownActive, http.StatusTooManyRequests},
+		{"InsufficientFunds", InsufficientFundsError{CharacterId: 1, Required: 500, Available: 100}, http.StatusPaymentRequired},
+		{"StateTransition", StateTransitionError{Entity: EntityProposal, From: "rejected", To: "accepted"}, http.StatusConflict},
+		{"VenueSlotUnavailable", ErrVenueSlotUnavailable, http.StatusConflict},
+		{"InvalidVenueSlot", ErrInvalidVenueSlot, http.StatusUnprocessableEntity},
+		{"VenueRequired", ErrVenueRequired, http.StatusUnprocessableEntity},
+		{"EngagementRingMissing", ErrEngagementRingMissing, http.StatusUnprocessableEntity},
+		{"CeremonyFinalStage", ErrCeremonyFinalStage, http.StatusConflict},
+		{"CeremonyStagesPending", ErrCeremonyStagesPending, http.StatusConflict},
+		{"CeremonyStageInvalid", ErrCeremonyStageInvalid, http.StatusUnprocessableEntity},
+		{"Unknown", fmt.Errorf("database unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := ErrorStatus(tt.err); status != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, status)
+			}
+		})
+	}
+}
+
+func TestDomainErrors_Is(t *testing.T) {
+	if !errors.Is(CooldownError{Scope: CooldownScopeGlobal, Remaining: time.Minute}, ErrGlobalCooldownActive) {
+		t.Error("Expected cooldown with remaining duration to match its scope")
+	}
+	if errors.Is(ErrGlobalCooldownActive, ErrTargetCooldownActive) {
+		t.Error("Expected cooldowns of different scopes not to match")
+	}
+	if !errors.Is(ErrCharacterTooLowLevel.ForCharacter(7), ErrCharacterTooLowLevel) {
+		t.Error("Expected eligibility error for a character to match its reason")
+	}
+	if !errors.Is(InviteeLimitError{Limit: MaxInvitees, Requested: 20}, ErrTooManyInvitees) {
+		t.Error("Expected any invitee limit error to match")
+	}
+	if errors.Is(ErrProposalNotFound, ErrCeremonyNotFound) {
+		t.Error("Expected not found errors for different entities not to match")
+	}
+}
+
+func TestStateTransitionError_Error(t *testing.T) {
+	transition := StateTransitionError{Entity: EntityProposal, From: "rejected", To: "accepted"}
+	if transition.Error() != "proposal cannot transition from rejected to accepted" {
+		t.Errorf("Unexpected message: %s", transition.Error())
+	}
+
+	modification := StateTransitionError{Entity: EntityCeremony, From: "completed", To: "completed"}
+	if modification.Error() != "ceremony cannot be modified while completed" {
+		t.Errorf("Unexpected message: %s", modification.Error())
+	}
+}
+
+func TestInviteeAdditionError(t *testing.T) {
+	ceremony, err := NewCeremonyBuilder(1, 100, 101, uuid.New()).
+		SetScheduledAt(time.Now().Add(time.Hour)).
+		SetInvitees([]uint32{200}).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build ceremony: %v", err)
+	}
+
+	if !errors.Is(inviteeAdditionError(ceremony, 100), ErrPartnerInvitee) {
+		t.Error("Expected partner invitee error")
+	}
+	if !errors.Is(inviteeAdditionError(ceremony, 200), ErrInviteeAlreadyInvited) {
+		t.Error("Expected already invited error")
+	}
+	if err := inviteeAdditionError(ceremony, 300); err != nil {
+		t.Errorf("Expected invitee to be addable, got %v", err)
+	}
+	if !errors.Is(inviteeRemovalError(ceremony, 300), ErrInviteeNotInvited) {
+		t.Error("Expected not invited error")
+	}
+}
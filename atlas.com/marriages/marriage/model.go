@@ -2,8 +2,11 @@ package marriage
 
 import (
 	"errors"
+	"math"
 	"time"
 
+	"atlas-marriages/rules"
+
 	"github.com/google/uuid"
 )
 
@@ -16,6 +19,8 @@ const (
 	StatusMarried
 	StatusDivorced
 	StatusExpired
+	StatusDeleted
+	StatusDivorcePending
 )
 
 // String returns the string representation of MarriageStatus
@@ -31,6 +36,10 @@ func (s MarriageStatus) String() string {
 		return "divorced"
 	case StatusExpired:
 		return "expired"
+	case StatusDeleted:
+		return "deleted"
+	case StatusDivorcePending:
+		return "divorce_pending"
 	default:
 		return "unknown"
 	}
@@ -45,10 +54,25 @@ type Marriage struct {
 	proposedAt   time.Time
 	engagedAt    *time.Time
 	marriedAt    *time.Time
-	divorcedAt   *time.Time
-	tenantId     uuid.UUID
-	createdAt    time.Time
-	updatedAt    time.Time
+	divorcedAt     *time.Time
+	deletedAt      *time.Time
+	deletionReason string
+	tenantId       uuid.UUID
+	createdAt      time.Time
+	updatedAt      time.Time
+
+	ringItemId  uint32 // Wedding ring issued to both partners, or 0 when no rings were issued
+	ringSerial1 uint64 // Serial of the ring held by the first character
+	ringSerial2 uint64 // Serial of the ring held by the second character
+
+	divorceFiledBy   uint32     // Partner who filed for divorce, or 0 when no divorce was filed
+	divorceFiledAt   *time.Time // When the divorce was filed
+	divorceMaturesAt *time.Time // When the filed divorce is finalized without the partner's consent
+	divorcePaymentId *uuid.UUID // Economy transaction paying for the filed divorce, refunded if it is withdrawn
+
+	bondPoints     uint32     // Bond points the couple has earned together, less any decay
+	bondLevel      byte       // Bond level the points reached under the tenant's thresholds when they last changed
+	bondActivityAt *time.Time // When bond points were last awarded or decayed, or nil before the first award
 }
 
 // Id returns the marriage ID
@@ -91,6 +115,85 @@ func (m Marriage) DivorcedAt() *time.Time {
 	return m.divorcedAt
 }
 
+// DeletedAt returns the timestamp at which the marriage was ended by character deletion
+func (m Marriage) DeletedAt() *time.Time {
+	return m.deletedAt
+}
+
+// DeletionReason returns why the marriage was ended by deletion
+func (m Marriage) DeletionReason() string {
+	return m.deletionReason
+}
+
+// RingItemId returns the wedding ring issued to both partners, or 0 when no rings were issued
+func (m Marriage) RingItemId() uint32 {
+	return m.ringItemId
+}
+
+// RingSerial1 returns the serial of the wedding ring held by the first character
+func (m Marriage) RingSerial1() uint64 {
+	return m.ringSerial1
+}
+
+// RingSerial2 returns the serial of the wedding ring held by the second character
+func (m Marriage) RingSerial2() uint64 {
+	return m.ringSerial2
+}
+
+// HasRings returns true if wedding rings were issued for the marriage
+func (m Marriage) HasRings() bool {
+	return m.ringItemId != 0
+}
+
+// RingSerial returns the serial of the wedding ring held by the given partner
+func (m Marriage) RingSerial(characterId uint32) (uint64, bool) {
+	if !m.HasRings() {
+		return 0, false
+	}
+	if m.characterId1 == characterId {
+		return m.ringSerial1, true
+	}
+	if m.characterId2 == characterId {
+		return m.ringSerial2, true
+	}
+	return 0, false
+}
+
+// DivorceFiledBy returns the partner who filed for divorce, or 0 when no divorce was filed
+func (m Marriage) DivorceFiledBy() uint32 {
+	return m.divorceFiledBy
+}
+
+// DivorceFiledAt returns when the divorce was filed
+func (m Marriage) DivorceFiledAt() *time.Time {
+	return m.divorceFiledAt
+}
+
+// DivorceMaturesAt returns when the filed divorce is finalized without the partner's consent
+func (m Marriage) DivorceMaturesAt() *time.Time {
+	return m.divorceMaturesAt
+}
+
+// DivorcePaymentId returns the economy transaction which paid for the filed divorce, if it was not free
+func (m Marriage) DivorcePaymentId() *uuid.UUID {
+	return m.divorcePaymentId
+}
+
+// BondPoints returns the bond points the couple has earned together, less any decay
+func (m Marriage) BondPoints() uint32 {
+	return m.bondPoints
+}
+
+// BondLevel returns the bond level the couple has reached
+func (m Marriage) BondLevel() byte {
+	return m.bondLevel
+}
+
+// BondActivityAt returns when bond points were last awarded or decayed, or nil before the first award
+func (m Marriage) BondActivityAt() *time.Time {
+	return m.bondActivityAt
+}
+
 // TenantId returns the tenant ID
 func (m Marriage) TenantId() uuid.UUID {
 	return m.tenantId
@@ -127,9 +230,23 @@ func (m Marriage) GetPartner(characterId uint32) (uint32, bool) {
 	return 0, false
 }
 
-// IsActive returns true if the marriage is currently active (married status)
+// IsActive returns true if the marriage is currently active (married status, including while a divorce is pending)
 func (m Marriage) IsActive() bool {
-	return m.status == StatusMarried
+	return m.status == StatusMarried || m.status == StatusDivorcePending
+}
+
+// AnniversaryAt returns when the marriage reaches the given number of days married, or false if it is not married
+func (m Marriage) AnniversaryAt(milestoneDays uint32) (time.Time, bool) {
+	if m.marriedAt == nil {
+		return time.Time{}, false
+	}
+	return m.marriedAt.Add(time.Duration(milestoneDays) * 24 * time.Hour), true
+}
+
+// HasReachedAnniversary returns true if the marriage is active and has lasted the given number of days by now
+func (m Marriage) HasReachedAnniversary(milestoneDays uint32, now time.Time) bool {
+	reachedAt, ok := m.AnniversaryAt(milestoneDays)
+	return ok && m.IsActive() && !reachedAt.After(now)
 }
 
 // IsExpired returns true if the proposal has expired
@@ -142,6 +259,21 @@ func (m Marriage) IsDivorced() bool {
 	return m.status == StatusDivorced
 }
 
+// IsDeleted returns true if the marriage was ended by character deletion
+func (m Marriage) IsDeleted() bool {
+	return m.status == StatusDeleted
+}
+
+// IsDivorcePending returns true if a partner has filed for divorce and it has not been finalized or withdrawn
+func (m Marriage) IsDivorcePending() bool {
+	return m.status == StatusDivorcePending
+}
+
+// IsDivorceMatured returns true if a pending divorce has reached the end of its waiting period
+func (m Marriage) IsDivorceMatured(now time.Time) bool {
+	return m.IsDivorcePending() && m.divorceMaturesAt != nil && !now.Before(*m.divorceMaturesAt)
+}
+
 // CanAccept returns true if the marriage proposal can be accepted
 func (m Marriage) CanAccept() bool {
 	return m.status == StatusProposed
@@ -157,6 +289,16 @@ func (m Marriage) CanDivorce() bool {
 	return m.status == StatusMarried
 }
 
+// CanFileDivorce returns true if a partner can file for divorce
+func (m Marriage) CanFileDivorce() bool {
+	return m.status == StatusMarried
+}
+
+// CanDelete returns true if the marriage can be ended by character deletion
+func (m Marriage) CanDelete() bool {
+	return m.status == StatusProposed || m.status == StatusEngaged || m.status == StatusMarried || m.status == StatusDivorcePending
+}
+
 // Builder returns a new builder for modifying the marriage
 func (m Marriage) Builder() *Builder {
 	return &Builder{
@@ -167,10 +309,25 @@ func (m Marriage) Builder() *Builder {
 		proposedAt:   m.proposedAt,
 		engagedAt:    m.engagedAt,
 		marriedAt:    m.marriedAt,
-		divorcedAt:   m.divorcedAt,
-		tenantId:     m.tenantId,
-		createdAt:    m.createdAt,
-		updatedAt:    m.updatedAt,
+		divorcedAt:     m.divorcedAt,
+		deletedAt:      m.deletedAt,
+		deletionReason: m.deletionReason,
+		tenantId:       m.tenantId,
+		createdAt:      m.createdAt,
+		updatedAt:      m.updatedAt,
+
+		ringItemId:  m.ringItemId,
+		ringSerial1: m.ringSerial1,
+		ringSerial2: m.ringSerial2,
+
+		divorceFiledBy:   m.divorceFiledBy,
+		divorceFiledAt:   m.divorceFiledAt,
+		divorceMaturesAt: m.divorceMaturesAt,
+		divorcePaymentId: m.divorcePaymentId,
+
+		bondPoints:     m.bondPoints,
+		bondLevel:      m.bondLevel,
+		bondActivityAt: m.bondActivityAt,
 	}
 }
 
@@ -194,6 +351,81 @@ func (m Marriage) Marry() (Marriage, error) {
 		Build()
 }
 
+// IssueRings creates a new marriage recording the matching wedding rings issued to both partners
+func (m Marriage) IssueRings(itemId uint32, serial1, serial2 uint64) (Marriage, error) {
+	if m.status != StatusMarried {
+		return Marriage{}, errors.New("wedding rings can only be issued to a married couple")
+	}
+	if m.HasRings() {
+		return Marriage{}, errors.New("wedding rings have already been issued")
+	}
+
+	return m.Builder().
+		SetRings(itemId, serial1, serial2).
+		SetUpdatedAt(time.Now()).
+		Build()
+}
+
+// AwardBond creates a new marriage with the given bond points added to the couple's bond, levelled against the
+// tenant's thresholds. Points saturate rather than overflow
+func (m Marriage) AwardBond(points uint32, thresholds []uint32) (Marriage, error) {
+	if !m.IsActive() {
+		return Marriage{}, errors.New("bond points can only be awarded to a married couple")
+	}
+	if points == 0 {
+		return Marriage{}, errors.New("bond award must be positive")
+	}
+
+	total := m.bondPoints + points
+	if total < m.bondPoints {
+		total = math.MaxUint32
+	}
+	now := time.Now()
+	return m.Builder().
+		SetBond(total, BondLevelFor(total, thresholds), &now).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// DecayBond creates a new marriage with the couple's bond decayed by decayPoints for every full interval without bond
+// activity, returning false when no interval has elapsed or there are no points to lose. The remainder of a partial
+// interval carries over to the next decay
+func (m Marriage) DecayBond(decayPoints uint32, interval time.Duration, thresholds []uint32, now time.Time) (Marriage, bool, error) {
+	if !m.IsActive() || m.bondActivityAt == nil || m.bondPoints == 0 || decayPoints == 0 || interval <= 0 {
+		return m, false, nil
+	}
+	intervals := now.Sub(*m.bondActivityAt) / interval
+	if intervals <= 0 {
+		return m, false, nil
+	}
+
+	remaining := uint32(0)
+	if lost := uint64(intervals) * uint64(decayPoints); lost < uint64(m.bondPoints) {
+		remaining = m.bondPoints - uint32(lost)
+	}
+	activityAt := m.bondActivityAt.Add(intervals * interval)
+	decayed, err := m.Builder().
+		SetBond(remaining, BondLevelFor(remaining, thresholds), &activityAt).
+		SetUpdatedAt(now).
+		Build()
+	if err != nil {
+		return Marriage{}, false, err
+	}
+	return decayed, true, nil
+}
+
+// BondLevelFor returns the bond level reached by the given points, being the number of ascending thresholds they meet
+func BondLevelFor(points uint32, thresholds []uint32) byte {
+	level := byte(0)
+	for _, threshold := range thresholds {
+		if points < threshold || level == math.MaxUint8 {
+			break
+		}
+		level++
+	}
+	return level
+}
+
 // Divorce creates a new marriage with divorced status
 func (m Marriage) Divorce() (Marriage, error) {
 	now := time.Now()
@@ -204,6 +436,60 @@ func (m Marriage) Divorce() (Marriage, error) {
 		Build()
 }
 
+// FileDivorce creates a new marriage with a divorce filed by the given partner, finalized at maturesAt unless the
+// other partner consents first or the filing is withdrawn
+func (m Marriage) FileDivorce(filedBy uint32, maturesAt time.Time, paymentId *uuid.UUID) (Marriage, error) {
+	if !m.CanFileDivorce() {
+		return Marriage{}, errors.New("divorce can only be filed for a married couple")
+	}
+	if !m.IsPartner(filedBy) {
+		return Marriage{}, errors.New("only a partner can file for divorce")
+	}
+
+	now := time.Now()
+	return m.Builder().
+		SetStatus(StatusDivorcePending).
+		SetDivorceFiling(filedBy, &now, &maturesAt, paymentId).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// WithdrawDivorce creates a new marriage with its pending divorce withdrawn, returning the couple to married
+func (m Marriage) WithdrawDivorce() (Marriage, error) {
+	if !m.IsDivorcePending() {
+		return Marriage{}, errors.New("no divorce is pending")
+	}
+
+	return m.Builder().
+		SetStatus(StatusMarried).
+		SetDivorceFiling(0, nil, nil, nil).
+		SetUpdatedAt(time.Now()).
+		Build()
+}
+
+// FinalizeDivorce creates a new marriage with its pending divorce finalized, keeping the filing as history
+func (m Marriage) FinalizeDivorce() (Marriage, error) {
+	if !m.IsDivorcePending() {
+		return Marriage{}, errors.New("no divorce is pending")
+	}
+	return m.Divorce()
+}
+
+// Delete creates a new marriage ended by character deletion, keeping the timestamps it reached
+func (m Marriage) Delete(reason string) (Marriage, error) {
+	if !m.CanDelete() {
+		return Marriage{}, errors.New("marriage cannot be deleted")
+	}
+
+	now := time.Now()
+	return m.Builder().
+		SetStatus(StatusDeleted).
+		SetDeletedAt(&now).
+		SetDeletionReason(reason).
+		SetUpdatedAt(now).
+		Build()
+}
+
 // Expire creates a new marriage with expired status
 func (m Marriage) Expire() (Marriage, error) {
 	now := time.Now()
@@ -256,13 +542,16 @@ type Proposal struct {
 	tenantId         uuid.UUID
 	createdAt        time.Time
 	updatedAt        time.Time
+
+	ringItemId        uint32     // Engagement ring item held for the proposal, or 0 when none was required
+	ringReservationId *uuid.UUID // Inventory reservation holding the ring while the proposal is pending
 }
 
-// Constants for proposal rules
+// Default proposal rules. Tenants may override these through their marriage rules configuration
 const (
-	ProposalExpiryDuration    = 24 * time.Hour  // 24 hours
-	GlobalCooldownDuration    = 4 * time.Hour   // 4 hours between any proposals
-	InitialPerTargetCooldown  = 24 * time.Hour  // 24 hours initial cooldown
+	ProposalExpiryDuration    = rules.DefaultProposalExpiry           // 24 hours
+	GlobalCooldownDuration    = rules.DefaultGlobalCooldown           // 4 hours between any proposals
+	InitialPerTargetCooldown  = rules.DefaultInitialPerTargetCooldown // 24 hours initial cooldown
 )
 
 // Id returns the proposal ID
@@ -325,6 +614,21 @@ func (p Proposal) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// RingItemId returns the engagement ring item held for the proposal, or 0 when none was required
+func (p Proposal) RingItemId() uint32 {
+	return p.ringItemId
+}
+
+// RingReservationId returns the inventory reservation holding the engagement ring
+func (p Proposal) RingReservationId() *uuid.UUID {
+	return p.ringReservationId
+}
+
+// HasRingReservation returns true if an engagement ring is reserved for the proposal
+func (p Proposal) HasRingReservation() bool {
+	return p.ringReservationId != nil
+}
+
 // IsExpired returns true if the proposal has expired
 func (p Proposal) IsExpired() bool {
 	return time.Now().After(p.expiresAt) || p.status == ProposalStatusExpired
@@ -362,8 +666,13 @@ func (p Proposal) CanCancel() bool {
 
 // CalculateNextCooldown calculates the next cooldown duration based on rejection count
 func (p Proposal) CalculateNextCooldown() time.Duration {
+	return p.CalculateNextCooldownFrom(InitialPerTargetCooldown)
+}
+
+// CalculateNextCooldownFrom calculates the next cooldown duration based on rejection count, starting from the given initial cooldown
+func (p Proposal) CalculateNextCooldownFrom(initialCooldown time.Duration) time.Duration {
 	if p.rejectionCount == 0 {
-		return InitialPerTargetCooldown
+		return initialCooldown
 	}
 	
 	// Exponential backoff: 24h, 48h, 96h, 192h, etc.
@@ -372,7 +681,7 @@ func (p Proposal) CalculateNextCooldown() time.Duration {
 		multiplier *= 2
 	}
 	
-	return time.Duration(multiplier) * InitialPerTargetCooldown
+	return time.Duration(multiplier) * initialCooldown
 }
 
 // Accept creates a new proposal with accepted status
@@ -391,12 +700,18 @@ func (p Proposal) Accept() (Proposal, error) {
 
 // Reject creates a new proposal with rejected status and updates cooldown
 func (p Proposal) Reject() (Proposal, error) {
+	return p.RejectWithInitialCooldown(InitialPerTargetCooldown)
+}
+
+// RejectWithInitialCooldown creates a new proposal with rejected status and updates cooldown, starting the
+// cooldown backoff from the given initial cooldown
+func (p Proposal) RejectWithInitialCooldown(initialCooldown time.Duration) (Proposal, error) {
 	if !p.CanRespond() {
 		return Proposal{}, errors.New("proposal cannot be rejected")
 	}
 	
 	now := time.Now()
-	nextCooldown := p.CalculateNextCooldown()
+	nextCooldown := p.CalculateNextCooldownFrom(initialCooldown)
 	cooldownUntil := now.Add(nextCooldown)
 	
 	return p.Builder().
@@ -449,6 +764,9 @@ func (p Proposal) Builder() *ProposalBuilder {
 		tenantId:       p.tenantId,
 		createdAt:      p.createdAt,
 		updatedAt:      p.updatedAt,
+
+		ringItemId:        p.ringItemId,
+		ringReservationId: p.ringReservationId,
 	}
 }
 
@@ -481,6 +799,26 @@ func (s CeremonyStatus) String() string {
 	}
 }
 
+// VenueTier identifies the class of venue a ceremony is held at, which determines its cost
+type VenueTier string
+
+const (
+	VenueTierStandard VenueTier = "STANDARD"
+	VenueTierPremium  VenueTier = "PREMIUM"
+)
+
+// ParseVenueTier parses a venue tier, defaulting to the standard tier when none is given
+func ParseVenueTier(value string) (VenueTier, error) {
+	switch VenueTier(value) {
+	case "", VenueTierStandard:
+		return VenueTierStandard, nil
+	case VenueTierPremium:
+		return VenueTierPremium, nil
+	default:
+		return "", ErrInvalidVenueTier
+	}
+}
+
 // Ceremony represents an immutable ceremony domain object
 type Ceremony struct {
 	id           uint32
@@ -494,15 +832,31 @@ type Ceremony struct {
 	cancelledAt  *time.Time
 	postponedAt  *time.Time
 	invitees     []uint32
+	rsvps        map[uint32]Rsvp
+	maxInvitees  int
 	tenantId     uuid.UUID
 	createdAt    time.Time
 	updatedAt    time.Time
+
+	venueTier VenueTier
+	cost      uint32
+	paymentId *uuid.UUID
+
+	remindedAt *time.Time
+
+	disconnectedAt1 *time.Time
+	disconnectedAt2 *time.Time
+
+	venueId uint32
+
+	stages []string
+	stage  string
 }
 
-// Constants for ceremony rules
+// Default ceremony rules. Tenants may override MaxInvitees and DisconnectionTimeout through their marriage rules configuration
 const (
-	MaxInvitees              = 15             // Maximum number of invitees
-	DisconnectionTimeout     = 5 * time.Minute // Timeout for disconnection before postponement
+	MaxInvitees              = rules.DefaultMaxInvitees          // Maximum number of invitees
+	DisconnectionTimeout     = rules.DefaultDisconnectionTimeout // Timeout for disconnection before postponement
 	CeremonyDurationUnlimited = true          // Ceremony duration is unlimited by default
 )
 
@@ -579,6 +933,68 @@ func (c Ceremony) UpdatedAt() time.Time {
 	return c.updatedAt
 }
 
+// VenueTier returns the class of venue the ceremony is held at
+func (c Ceremony) VenueTier() VenueTier {
+	return c.venueTier
+}
+
+// VenueId returns the venue the ceremony has booked, or zero if it holds no booking
+func (c Ceremony) VenueId() uint32 {
+	return c.venueId
+}
+
+// Stages returns the stages an officiant advances the active ceremony through, in order, fixed when it was scheduled
+func (c Ceremony) Stages() []string {
+	stages := make([]string, len(c.stages))
+	copy(stages, c.stages)
+	return stages
+}
+
+// Stage returns the stage the active ceremony has reached, or an empty string before its first stage
+func (c Ceremony) Stage() string {
+	return c.stage
+}
+
+// StageIndex returns the position of the current stage in the ceremony's stages, or -1 before its first stage
+func (c Ceremony) StageIndex() int {
+	for i, stage := range c.stages {
+		if stage == c.stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// NextStage returns the stage following the current one, and false if the ceremony has reached its final stage
+func (c Ceremony) NextStage() (string, bool) {
+	next := c.StageIndex() + 1
+	if next >= len(c.stages) {
+		return "", false
+	}
+	return c.stages[next], true
+}
+
+// StagesCompleted returns true if the ceremony has reached its final stage, or has no stages
+func (c Ceremony) StagesCompleted() bool {
+	_, ok := c.NextStage()
+	return !ok
+}
+
+// Cost returns the mesos the first partner paid to schedule the ceremony
+func (c Ceremony) Cost() uint32 {
+	return c.cost
+}
+
+// PaymentId returns the economy transaction the ceremony saga charges the ceremony under, if it is not free
+func (c Ceremony) PaymentId() *uuid.UUID {
+	return c.paymentId
+}
+
+// IsPaid returns true if scheduling the ceremony costs mesos, charged and refunded by its saga under PaymentId
+func (c Ceremony) IsPaid() bool {
+	return c.paymentId != nil
+}
+
 // IsScheduled returns true if the ceremony is scheduled
 func (c Ceremony) IsScheduled() bool {
 	return c.status == CeremonyStatusScheduled
@@ -609,6 +1025,49 @@ func (c Ceremony) IsFinished() bool {
 	return c.status == CeremonyStatusCompleted || c.status == CeremonyStatusCancelled
 }
 
+// RemindedAt returns when the couple and their invitees were reminded that the ceremony is about to start, or nil if
+// they have not been reminded
+func (c Ceremony) RemindedAt() *time.Time {
+	return c.remindedAt
+}
+
+// IsDue returns true if the ceremony is scheduled and its scheduled start has passed
+func (c Ceremony) IsDue(now time.Time) bool {
+	return c.status == CeremonyStatusScheduled && !now.Before(c.scheduledAt)
+}
+
+// IsMissed returns true if the ceremony is scheduled but was not started within the grace period after its scheduled
+// start
+func (c Ceremony) IsMissed(now time.Time, gracePeriod time.Duration) bool {
+	return c.status == CeremonyStatusScheduled && !now.Before(c.scheduledAt.Add(gracePeriod))
+}
+
+// DisconnectedAt returns when a partner logged out during the active ceremony, or nil if they are connected
+func (c Ceremony) DisconnectedAt(characterId uint32) *time.Time {
+	switch characterId {
+	case c.characterId1:
+		return c.disconnectedAt1
+	case c.characterId2:
+		return c.disconnectedAt2
+	default:
+		return nil
+	}
+}
+
+// IsDisconnectionTimedOut returns true if the ceremony is active and a partner has been disconnected for at least the
+// disconnection timeout
+func (c Ceremony) IsDisconnectionTimedOut(now time.Time, timeout time.Duration) bool {
+	if c.status != CeremonyStatusActive {
+		return false
+	}
+	for _, disconnectedAt := range []*time.Time{c.disconnectedAt1, c.disconnectedAt2} {
+		if disconnectedAt != nil && !now.Before(disconnectedAt.Add(timeout)) {
+			return true
+		}
+	}
+	return false
+}
+
 // CanStart returns true if the ceremony can be started
 func (c Ceremony) CanStart() bool {
 	return c.status == CeremonyStatusScheduled || c.status == CeremonyStatusPostponed
@@ -619,6 +1078,11 @@ func (c Ceremony) CanComplete() bool {
 	return c.status == CeremonyStatusActive
 }
 
+// CanAdvanceStage returns true if the ceremony is active and has a stage following the current one
+func (c Ceremony) CanAdvanceStage() bool {
+	return c.status == CeremonyStatusActive && !c.StagesCompleted()
+}
+
 // CanCancel returns true if the ceremony can be cancelled
 func (c Ceremony) CanCancel() bool {
 	return c.status == CeremonyStatusScheduled || c.status == CeremonyStatusActive || c.status == CeremonyStatusPostponed
@@ -654,9 +1118,54 @@ func (c Ceremony) InviteeCount() int {
 	return len(c.invitees)
 }
 
+// MaxInvitees returns the maximum number of invitees, fixed by the tenant's rules when the ceremony was scheduled
+func (c Ceremony) MaxInvitees() int {
+	return c.maxInvitees
+}
+
+// Rsvp returns an invitee's response to their invitation, or false if the character is not invited. Invitees who
+// have not responded are reported as invited
+func (c Ceremony) Rsvp(characterId uint32) (Rsvp, bool) {
+	if !c.IsInvited(characterId) {
+		return Rsvp{}, false
+	}
+	if rsvp, ok := c.rsvps[characterId]; ok {
+		return rsvp, true
+	}
+	return Rsvp{characterId: characterId, status: RsvpStatusInvited}, true
+}
+
+// Rsvps returns the response of every invitee, in the order they were invited
+func (c Ceremony) Rsvps() []Rsvp {
+	rsvps := make([]Rsvp, 0, len(c.invitees))
+	for _, invitee := range c.invitees {
+		rsvp, _ := c.Rsvp(invitee)
+		rsvps = append(rsvps, rsvp)
+	}
+	return rsvps
+}
+
+// CanRespond returns true if an invitee can change their response to the given status. Invitations are accepted or
+// declined before the ceremony starts, attendance is recorded while it is active, and an invitee who has attended
+// cannot respond again
+func (c Ceremony) CanRespond(characterId uint32, status RsvpStatus) bool {
+	rsvp, ok := c.Rsvp(characterId)
+	if !ok || rsvp.Status() == RsvpStatusAttended {
+		return false
+	}
+	switch status {
+	case RsvpStatusAccepted, RsvpStatusDeclined:
+		return c.status == CeremonyStatusScheduled || c.status == CeremonyStatusPostponed
+	case RsvpStatusAttended:
+		return c.status == CeremonyStatusActive
+	default:
+		return false
+	}
+}
+
 // CanAddInvitee returns true if a new invitee can be added
 func (c Ceremony) CanAddInvitee(characterId uint32) bool {
-	if c.InviteeCount() >= MaxInvitees {
+	if c.InviteeCount() >= c.maxInvitees {
 		return false
 	}
 	if c.IsPartner(characterId) {
@@ -686,6 +1195,9 @@ func (c Ceremony) Start() (Ceremony, error) {
 	return c.Builder().
 		SetStatus(CeremonyStatusActive).
 		SetStartedAt(&now).
+		SetDisconnectedAt1(nil).
+		SetDisconnectedAt2(nil).
+		SetStage("").
 		SetUpdatedAt(now).
 		Build()
 }
@@ -695,6 +1207,9 @@ func (c Ceremony) Complete() (Ceremony, error) {
 	if !c.CanComplete() {
 		return Ceremony{}, errors.New("ceremony cannot be completed")
 	}
+	if !c.StagesCompleted() {
+		return Ceremony{}, errors.New("ceremony has not reached its final stage")
+	}
 	
 	now := time.Now()
 	return c.Builder().
@@ -728,6 +1243,9 @@ func (c Ceremony) Postpone() (Ceremony, error) {
 	return c.Builder().
 		SetStatus(CeremonyStatusPostponed).
 		SetPostponedAt(&now).
+		SetDisconnectedAt1(nil).
+		SetDisconnectedAt2(nil).
+		SetStage("").
 		SetUpdatedAt(now).
 		Build()
 }
@@ -744,6 +1262,87 @@ func (c Ceremony) Reschedule(newScheduledAt time.Time) (Ceremony, error) {
 		SetScheduledAt(newScheduledAt).
 		SetStartedAt(nil).
 		SetPostponedAt(nil).
+		SetRemindedAt(nil).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// AdvanceStage creates a new ceremony at the stage following the current one
+func (c Ceremony) AdvanceStage() (Ceremony, error) {
+	if !c.CanAdvanceStage() {
+		return Ceremony{}, errors.New("ceremony stage cannot be advanced")
+	}
+
+	next, _ := c.NextStage()
+	return c.Builder().
+		SetStage(next).
+		SetUpdatedAt(time.Now()).
+		Build()
+}
+
+// Remind creates a new ceremony recording that the couple and their invitees were reminded that it is about to start
+func (c Ceremony) Remind() (Ceremony, error) {
+	if c.status != CeremonyStatusScheduled {
+		return Ceremony{}, errors.New("ceremony cannot be reminded")
+	}
+
+	now := time.Now()
+	return c.Builder().
+		SetRemindedAt(&now).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// Disconnect creates a new ceremony recording that a partner logged out during the active ceremony. A partner
+// disconnecting again keeps the time they first disconnected, so the disconnection timeout is not extended
+func (c Ceremony) Disconnect(characterId uint32) (Ceremony, error) {
+	if !c.IsPartner(characterId) || c.status != CeremonyStatusActive {
+		return Ceremony{}, errors.New("partner cannot disconnect")
+	}
+	if c.DisconnectedAt(characterId) != nil {
+		return c, nil
+	}
+
+	now := time.Now()
+	builder := c.Builder().SetUpdatedAt(now)
+	if characterId == c.characterId1 {
+		builder.SetDisconnectedAt1(&now)
+	} else {
+		builder.SetDisconnectedAt2(&now)
+	}
+	return builder.Build()
+}
+
+// Reconnect creates a new ceremony recording that a disconnected partner logged back in, cancelling their
+// disconnection timeout
+func (c Ceremony) Reconnect(characterId uint32) (Ceremony, error) {
+	if !c.IsPartner(characterId) {
+		return Ceremony{}, errors.New("character is not a partner")
+	}
+	if c.DisconnectedAt(characterId) == nil {
+		return c, nil
+	}
+
+	now := time.Now()
+	builder := c.Builder().SetUpdatedAt(now)
+	if characterId == c.characterId1 {
+		builder.SetDisconnectedAt1(nil)
+	} else {
+		builder.SetDisconnectedAt2(nil)
+	}
+	return builder.Build()
+}
+
+// Miss creates a new ceremony postponed because it was not started within the grace period after its scheduled start
+func (c Ceremony) Miss() (Ceremony, error) {
+	if c.status != CeremonyStatusScheduled {
+		return Ceremony{}, errors.New("ceremony cannot be missed")
+	}
+
+	now := time.Now()
+	return c.Builder().
+		SetStatus(CeremonyStatusPostponed).
+		SetPostponedAt(&now).
 		SetUpdatedAt(now).
 		Build()
 }
@@ -781,6 +1380,65 @@ func (c Ceremony) RemoveInvitee(characterId uint32) (Ceremony, error) {
 	now := time.Now()
 	return c.Builder().
 		SetInvitees(newInvitees).
+		SetRsvps(c.rsvpsExcept(characterId)).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// Respond creates a new ceremony recording an invitee's response to their invitation
+func (c Ceremony) Respond(characterId uint32, status RsvpStatus) (Ceremony, error) {
+	if !c.CanRespond(characterId, status) {
+		return Ceremony{}, errors.New("invitee cannot respond")
+	}
+
+	now := time.Now()
+	rsvps := append(c.rsvpsExcept(characterId), Rsvp{characterId: characterId, status: status, respondedAt: &now})
+	return c.Builder().
+		SetRsvps(rsvps).
+		SetUpdatedAt(now).
+		Build()
+}
+
+// respondedRsvps returns the responses of the invitees who have responded, in the order they were invited
+func (c Ceremony) respondedRsvps() []Rsvp {
+	rsvps := make([]Rsvp, 0, len(c.rsvps))
+	for _, invitee := range c.invitees {
+		if rsvp, ok := c.rsvps[invitee]; ok {
+			rsvps = append(rsvps, rsvp)
+		}
+	}
+	return rsvps
+}
+
+// rsvpsExcept returns the responses of the invitees other than the given character who have responded
+func (c Ceremony) rsvpsExcept(characterId uint32) []Rsvp {
+	rsvps := make([]Rsvp, 0, len(c.rsvps))
+	for _, rsvp := range c.respondedRsvps() {
+		if rsvp.CharacterId() != characterId {
+			rsvps = append(rsvps, rsvp)
+		}
+	}
+	return rsvps
+}
+
+// StripInvitee creates a new ceremony with an invitee removed regardless of the ceremony status, for invitees
+// whose character no longer exists
+func (c Ceremony) StripInvitee(characterId uint32) (Ceremony, error) {
+	if !c.IsInvited(characterId) {
+		return Ceremony{}, errors.New("character is not invited")
+	}
+	
+	newInvitees := make([]uint32, 0, len(c.invitees)-1)
+	for _, invitee := range c.invitees {
+		if invitee != characterId {
+			newInvitees = append(newInvitees, invitee)
+		}
+	}
+	
+	now := time.Now()
+	return c.Builder().
+		SetInvitees(newInvitees).
+		SetRsvps(c.rsvpsExcept(characterId)).
 		SetUpdatedAt(now).
 		Build()
 }
@@ -803,9 +1461,23 @@ func (c Ceremony) Builder() *CeremonyBuilder {
 		cancelledAt:  c.cancelledAt,
 		postponedAt:  c.postponedAt,
 		invitees:     invitees,
+		rsvps:        copyRsvps(c.rsvps),
+		maxInvitees:  c.maxInvitees,
 		tenantId:     c.tenantId,
 		createdAt:    c.createdAt,
 		updatedAt:    c.updatedAt,
+		venueTier:    c.venueTier,
+		cost:         c.cost,
+		paymentId:    c.paymentId,
+		remindedAt:   c.remindedAt,
+
+		disconnectedAt1: c.disconnectedAt1,
+		disconnectedAt2: c.disconnectedAt2,
+
+		venueId: c.venueId,
+
+		stages: c.Stages(),
+		stage:  c.stage,
 	}
 }
 
@@ -692,6 +692,107 @@ func TestMarriage_BusinessLogic(t *testing.T) {
 	}
 }
 
+func TestMarriage_Delete(t *testing.T) {
+	marriage, err := NewBuilder(1, 2, uuid.New()).Build()
+	if err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+	engaged, err := marriage.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept marriage: %v", err)
+	}
+
+	deleted, err := engaged.Delete("character_deleted")
+	if err != nil {
+		t.Fatalf("Failed to delete engaged marriage: %v", err)
+	}
+	if !deleted.IsDeleted() || deleted.IsDivorced() {
+		t.Errorf("Expected deleted marriage to be distinct from divorce, got %s", deleted.Status())
+	}
+	if deleted.DeletedAt() == nil || deleted.DeletionReason() != "character_deleted" {
+		t.Error("Expected deletion timestamp and reason to be set")
+	}
+	if deleted.MarriedAt() != nil || deleted.DivorcedAt() != nil {
+		t.Error("Expected deletion not to set marriage or divorce timestamps")
+	}
+	if deleted.Status().String() != "deleted" {
+		t.Errorf("Expected status string deleted, got %s", deleted.Status().String())
+	}
+
+	if _, err := deleted.Delete("character_deleted"); err == nil {
+		t.Error("Expected deleted marriage not to be deletable again")
+	}
+
+	now := time.Now()
+	if _, err := NewBuilder(1, 2, uuid.New()).SetStatus(StatusDivorced).SetEngagedAt(&now).SetMarriedAt(&now).SetDivorcedAt(&now).SetDeletedAt(&now).Build(); err == nil {
+		t.Error("Expected divorced marriage with deletion timestamp to fail validation")
+	}
+	if _, err := NewBuilder(1, 2, uuid.New()).SetStatus(StatusDeleted).Build(); err == nil {
+		t.Error("Expected deleted marriage without deletion timestamp to fail validation")
+	}
+}
+
+func TestMarriage_IssueRings(t *testing.T) {
+	marriage, err := NewBuilder(1, 2, uuid.New()).Build()
+	if err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+	engaged, err := marriage.Accept()
+	if err != nil {
+		t.Fatalf("Failed to accept marriage: %v", err)
+	}
+
+	if _, err := engaged.IssueRings(1112803, 100, 200); err == nil {
+		t.Error("Expected engaged marriage not to be issued wedding rings")
+	}
+
+	married, err := engaged.Marry()
+	if err != nil {
+		t.Fatalf("Failed to marry: %v", err)
+	}
+	if married.HasRings() {
+		t.Error("Expected marriage not to have wedding rings before they are issued")
+	}
+
+	withRings, err := married.IssueRings(1112803, 100, 200)
+	if err != nil {
+		t.Fatalf("Failed to issue wedding rings: %v", err)
+	}
+	if !withRings.HasRings() || withRings.RingItemId() != 1112803 {
+		t.Errorf("Expected wedding ring item 1112803, got %d", withRings.RingItemId())
+	}
+	if serial, ok := withRings.RingSerial(1); !ok || serial != 100 {
+		t.Errorf("Expected first partner ring serial 100, got %d", serial)
+	}
+	if serial, ok := withRings.RingSerial(2); !ok || serial != 200 {
+		t.Errorf("Expected second partner ring serial 200, got %d", serial)
+	}
+	if _, ok := withRings.RingSerial(3); ok {
+		t.Error("Expected no ring serial for a character outside the marriage")
+	}
+
+	if _, err := withRings.IssueRings(1112803, 300, 400); err == nil {
+		t.Error("Expected wedding rings not to be issued twice")
+	}
+	if _, err := married.IssueRings(1112803, 100, 100); err == nil {
+		t.Error("Expected matching ring serials to fail validation")
+	}
+	if _, err := married.IssueRings(1112803, 0, 200); err == nil {
+		t.Error("Expected missing ring serial to fail validation")
+	}
+	if _, err := NewBuilder(1, 2, uuid.New()).SetRings(0, 100, 200).Build(); err == nil {
+		t.Error("Expected ring serials without a ring item to fail validation")
+	}
+
+	divorced, err := withRings.Divorce()
+	if err != nil {
+		t.Fatalf("Failed to divorce: %v", err)
+	}
+	if divorced.RingSerial1() != 100 || divorced.RingSerial2() != 200 {
+		t.Error("Expected wedding rings to be retained in marriage history")
+	}
+}
+
 // Ceremony model tests
 func TestCeremony_Creation(t *testing.T) {
 	tenantId := uuid.New()
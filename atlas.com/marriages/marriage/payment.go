@@ -0,0 +1,100 @@
+package marriage
+
+import (
+	"atlas-marriages/economy"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Reasons recorded against the economy transactions paying for marriage operations
+const (
+	paymentReasonCeremony = "ceremony"
+	paymentReasonDivorce  = "divorce"
+)
+
+// ceremonyCost returns the mesos the tenant charges to schedule a ceremony at a venue of the given tier
+func (p *ProcessorImpl) ceremonyCost(venueTier VenueTier) uint32 {
+	if venueTier == VenueTierPremium {
+		return p.rules().PremiumCeremonyCost()
+	}
+	return p.rules().StandardCeremonyCost()
+}
+
+// verifyFunds reports an InsufficientFundsError when a character cannot afford a cost
+func (p *ProcessorImpl) verifyFunds(characterId uint32, cost uint32, reason string) error {
+	if cost == 0 {
+		return nil
+	}
+
+	mesos, err := p.economyProcessor.GetMesos(characterId)
+	if err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"characterId": characterId,
+			"reason":      reason,
+		}).Error("Failed to verify mesos")
+		return err
+	}
+	if mesos < cost {
+		return InsufficientFundsError{CharacterId: characterId, Required: cost, Available: mesos}
+	}
+	return nil
+}
+
+// paymentId returns the idempotency key of the payment a change makes for the given reason. The key is derived from
+// the transaction making the change, so a retried change and the saga charging a ceremony take the payment once
+func paymentId(transactionId uuid.UUID, reason string) uuid.UUID {
+	return uuid.NewSHA1(transactionId, []byte(reason))
+}
+
+// debit verifies a character can afford a cost and takes it from their wallet under the payment's idempotency key. It
+// is made before the state change it pays for, and is refunded if the processor's database transaction rolls back. It
+// returns nil when the cost is free
+func (p *ProcessorImpl) debit(paymentId uuid.UUID, characterId uint32, cost uint32, reason string) (*economy.Transaction, error) {
+	if err := p.verifyFunds(characterId, cost, reason); err != nil || cost == 0 {
+		return nil, err
+	}
+
+	// The debit may have been taken even if its outcome is lost, so it is compensated by id whatever the outcome
+	p.onRollback(func() {
+		p.releasePayment(characterId, paymentId)
+	})
+
+	payment, err := p.economyProcessor.Debit(paymentId, characterId, cost, reason)
+	if err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"characterId":   characterId,
+			"cost":          cost,
+			"reason":        reason,
+			"transactionId": paymentId,
+		}).Error("Failed to debit mesos")
+		return nil, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"characterId":   characterId,
+		"cost":          cost,
+		"reason":        reason,
+		"transactionId": payment.Id(),
+	}).Debug("Mesos debited")
+
+	return &payment, nil
+}
+
+// refundAfterCommit returns a payment to a character once the processor's database transaction commits, so a change
+// which rolls back or is retried never refunds it
+func (p *ProcessorImpl) refundAfterCommit(characterId uint32, transactionId uuid.UUID) {
+	p.afterCommit(func() {
+		p.releasePayment(characterId, transactionId)
+	})
+}
+
+// releasePayment refunds a payment to a character. Failures are logged, as the change it was refunded for stands
+func (p *ProcessorImpl) releasePayment(characterId uint32, transactionId uuid.UUID) {
+	if err := p.economyProcessor.Refund(characterId, transactionId); err != nil {
+		p.log.WithError(err).WithFields(logrus.Fields{
+			"characterId":   characterId,
+			"transactionId": transactionId,
+		}).Error("Failed to refund payment")
+	}
+}
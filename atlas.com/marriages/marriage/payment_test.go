@@ -0,0 +1,390 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-marriages/economy"
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	"atlas-marriages/rules"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	testStandardCeremonyCost = uint32(200)
+	testPremiumCeremonyCost  = uint32(1000)
+	testDivorceCost          = uint32(300)
+)
+
+// MockEconomyProcessor provides a mock implementation of the economy service for testing
+type MockEconomyProcessor struct {
+	mesos     map[uint32]uint32
+	debits    map[uuid.UUID]economy.Transaction
+	refunded  []uuid.UUID
+	refundErr error
+}
+
+func NewMockEconomyProcessor() *MockEconomyProcessor {
+	return &MockEconomyProcessor{
+		mesos:  make(map[uint32]uint32),
+		debits: make(map[uuid.UUID]economy.Transaction),
+	}
+}
+
+func (m *MockEconomyProcessor) WalletProvider(_ uint32) model.Provider[economy.Wallet] {
+	return model.ErrorProvider[economy.Wallet](errors.New("not implemented"))
+}
+
+func (m *MockEconomyProcessor) GetMesos(characterId uint32) (uint32, error) {
+	return m.mesos[characterId], nil
+}
+
+func (m *MockEconomyProcessor) Debit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) (economy.Transaction, error) {
+	if _, ok := m.debits[transactionId]; ok {
+		return m.debits[transactionId], nil
+	}
+	transaction := economy.NewTransaction(transactionId, characterId, amount, reason)
+	m.mesos[characterId] -= amount
+	m.debits[transaction.Id()] = transaction
+	return transaction, nil
+}
+
+func (m *MockEconomyProcessor) Refund(characterId uint32, transactionId uuid.UUID) error {
+	if m.refundErr != nil {
+		return m.refundErr
+	}
+	m.mesos[characterId] += m.debits[transactionId].Amount()
+	m.refunded = append(m.refunded, transactionId)
+	return nil
+}
+
+// setupPaymentTest creates a processor for a tenant charging for ceremonies and divorces, and a couple in the given status
+func setupPaymentTest(t *testing.T, status MarriageStatus) (*gorm.DB, Processor, *MockEconomyProcessor, Entity) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+
+	standard, premium, divorce := testStandardCeremonyCost, testPremiumCeremonyCost, testDivorceCost
+	if err := db.Create(&rules.Entity{TenantId: tenantId, StandardCeremonyCost: &standard, PremiumCeremonyCost: &premium, DivorceCost: &divorce, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	mockEconomyProcessor := NewMockEconomyProcessor()
+	processor := NewProcessor(log, setupTestContext(tenantId), db).
+		WithProducer(NewMockProducer().Provider).
+		WithEconomyProcessor(mockEconomyProcessor)
+
+	now := time.Now()
+	marriageEntity := Entity{
+		CharacterId1: 1,
+		CharacterId2: 2,
+		Status:       status,
+		ProposedAt:   now,
+		EngagedAt:    &now,
+		TenantId:     tenantId,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if status == StatusMarried {
+		marriageEntity.MarriedAt = &now
+	}
+	if err := db.Create(&marriageEntity).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+	return db, processor, mockEconomyProcessor, marriageEntity
+}
+
+// decodeRefundCommand decodes the only fee refund command, failing the test if there is not exactly one
+func decodeRefundCommand(t *testing.T, producer *MockProducer) sagaMsg.RefundFeeBody {
+	commands := messagesByType(t, producer)[sagaMsg.CommandRefundFee]
+	if len(commands) != 1 {
+		t.Fatalf("Expected one %s command, got %d", sagaMsg.CommandRefundFee, len(commands))
+	}
+	var command sagaMsg.Command[sagaMsg.RefundFeeBody]
+	if err := json.Unmarshal(commands[0], &command); err != nil {
+		t.Fatalf("Failed to decode %s command: %v", sagaMsg.CommandRefundFee, err)
+	}
+	return command.Body
+}
+
+func TestProcessor_ScheduleCeremony_ChargesVenueCost(t *testing.T) {
+	for _, tt := range []struct {
+		venueTier VenueTier
+		expected  VenueTier
+		cost      uint32
+	}{
+		{"", VenueTierStandard, testStandardCeremonyCost},
+		{VenueTierStandard, VenueTierStandard, testStandardCeremonyCost},
+		{VenueTierPremium, VenueTierPremium, testPremiumCeremonyCost},
+	} {
+		t.Run(string(tt.expected), func(t *testing.T) {
+			_, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+			econ.mesos[1] = 5000
+
+			transactionId := uuid.New()
+			ceremony, err := processor.ScheduleCeremonyAndEmit(transactionId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, tt.venueTier, 0)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if ceremony.VenueTier() != tt.expected || ceremony.Cost() != tt.cost || !ceremony.IsPaid() {
+				t.Fatalf("Expected a paid %s ceremony costing %d, got %s costing %d", tt.expected, tt.cost, ceremony.VenueTier(), ceremony.Cost())
+			}
+
+			// The proposer is debited when the ceremony is scheduled, under a payment keyed by the scheduling transaction
+			payment, ok := econ.debits[*ceremony.PaymentId()]
+			if len(econ.debits) != 1 || !ok || econ.mesos[1] != 5000-tt.cost {
+				t.Fatalf("Expected the proposer to be debited the ceremony's cost, got %v", econ.debits)
+			}
+			if payment.Id() != paymentId(transactionId, paymentReasonCeremony) || payment.CharacterId() != 1 || payment.Amount() != tt.cost || payment.Reason() != paymentReasonCeremony {
+				t.Errorf("Unexpected ceremony payment %+v", payment)
+			}
+		})
+	}
+}
+
+func TestProcessor_ScheduleCeremony_InsufficientFunds(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+	econ.mesos[1] = testPremiumCeremonyCost - 1
+
+	_, err := processor.ScheduleCeremonyAndEmit(uuid.New(), marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierPremium, 0)
+	var fundsErr InsufficientFundsError
+	if !errors.As(err, &fundsErr) || fundsErr.CharacterId != 1 || fundsErr.Required != testPremiumCeremonyCost || fundsErr.Available != testPremiumCeremonyCost-1 {
+		t.Fatalf("Expected insufficient funds error, got %v", err)
+	}
+
+	var count int64
+	db.Model(&CeremonyEntity{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no ceremony to be scheduled, found %d", count)
+	}
+	if len(econ.debits) != 0 {
+		t.Errorf("Expected no debit, found %d", len(econ.debits))
+	}
+}
+
+func TestProcessor_ScheduleCeremony_RolledBackScheduleRefunded(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+	econ.mesos[1] = 5000
+
+	transactionId := uuid.New()
+	err := processor.ProcessCommand(transactionId, 1, "ceremony_schedule", func(p Processor, transactionId uuid.UUID) error {
+		if _, err := p.ScheduleCeremonyAndEmit(transactionId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0); err != nil {
+			return err
+		}
+		return ErrNotMarriagePartner
+	})
+	if !errors.Is(err, ErrNotMarriagePartner) {
+		t.Fatalf("Expected the command to fail, got %v", err)
+	}
+	if len(econ.refunded) != 1 || econ.refunded[0] != paymentId(transactionId, paymentReasonCeremony) || econ.mesos[1] != 5000 {
+		t.Errorf("Expected the debit to be refunded, got %d debits and %v refunds", len(econ.debits), econ.refunded)
+	}
+
+	var count int64
+	db.Model(&CeremonyEntity{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected no ceremony to be scheduled, found %d", count)
+	}
+}
+
+func TestProcessor_ScheduleCeremony_InvalidVenueTier(t *testing.T) {
+	_, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+	econ.mesos[1] = 5000
+
+	_, err := processor.ScheduleCeremonyAndEmit(uuid.New(), marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, "ROYAL", 0)
+	if !errors.Is(err, ErrInvalidVenueTier) {
+		t.Fatalf("Expected invalid venue tier error, got %v", err)
+	}
+	if len(econ.debits) != 0 {
+		t.Errorf("Expected no debit, found %d", len(econ.debits))
+	}
+}
+
+func TestProcessor_CancelCeremony_RefundsChargedFee(t *testing.T) {
+	for name, cancel := range map[string]func(Processor, uint32) error{
+		"cancel command": func(p Processor, id uint32) error {
+			_, err := p.CancelCeremonyAndEmit(uuid.New(), id, 1, "changed_mind")
+			return err
+		},
+		"state advance": func(p Processor, id uint32) error {
+			_, err := p.AdvanceCeremonyStateAndEmit(uuid.New(), id, "cancelled")
+			return err
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+			producer := NewMockProducer()
+			processor = processor.WithProducer(producer.Provider)
+			econ.mesos[1] = 5000
+
+			sagaId := uuid.New()
+			ceremony, err := processor.ScheduleCeremonyAndEmit(sagaId, marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierPremium, 0)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err = processor.HandleSagaStepCompletedAndEmit(uuid.New(), sagaId, sagaMsg.CommandReserveChapel); err != nil {
+				t.Fatalf("Failed to complete chapel reservation: %v", err)
+			}
+
+			producer.ClearMessages()
+			if err = cancel(processor, ceremony.Id()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			refund := decodeRefundCommand(t, producer)
+			if refund.CharacterId != 1 || refund.Amount != testPremiumCeremonyCost || refund.TransactionId != *ceremony.PaymentId() {
+				t.Errorf("Expected the ceremony's payment to be refunded to the proposer, got %+v", refund)
+			}
+			if len(econ.refunded) != 0 {
+				t.Errorf("Expected the refund to be left to the saga, found %d refunds", len(econ.refunded))
+			}
+		})
+	}
+}
+
+func TestProcessor_CancelCeremony_RefundsBeforeChapelReserved(t *testing.T) {
+	_, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+	producer := NewMockProducer()
+	processor = processor.WithProducer(producer.Provider)
+	econ.mesos[1] = 5000
+
+	ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	producer.ClearMessages()
+	if _, err = processor.CancelCeremonyAndEmit(uuid.New(), ceremony.Id(), 1, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	refund := decodeRefundCommand(t, producer)
+	if refund.Amount != testStandardCeremonyCost || refund.TransactionId != *ceremony.PaymentId() {
+		t.Errorf("Expected the payment taken when the ceremony was scheduled to be refunded, got %+v", refund)
+	}
+	if releases := messagesByType(t, producer)[sagaMsg.CommandReleaseChapel]; len(releases) != 0 {
+		t.Errorf("Expected no release for a chapel which was not reserved, got %d", len(releases))
+	}
+}
+
+func TestProcessor_CancelCeremony_FreeCeremonyNotRefunded(t *testing.T) {
+	_, _, producer, processor, marriageEntity := setupSagaTest(t)
+	econ := NewMockEconomyProcessor()
+	processor = processor.WithEconomyProcessor(econ)
+
+	ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ceremony.IsPaid() || len(econ.debits) != 0 {
+		t.Fatalf("Expected a free ceremony to be scheduled without a debit")
+	}
+
+	producer.ClearMessages()
+	if _, err = processor.CancelCeremonyAndEmit(uuid.New(), ceremony.Id(), 1, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if refunds := messagesByType(t, producer)[sagaMsg.CommandRefundFee]; len(refunds) != 0 {
+		t.Errorf("Expected no refund for a free ceremony, got %d", len(refunds))
+	}
+}
+
+func TestProcessor_CancelCeremony_RolledBackCancellationDoesNotRefund(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusEngaged)
+	producer := NewMockProducer()
+	processor = processor.WithProducer(producer.Provider)
+	econ.mesos[1] = 5000
+
+	ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), marriageEntity.ID, time.Now().Add(time.Hour), []uint32{}, VenueTierStandard, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	producer.ClearMessages()
+	err = processor.ProcessCommand(uuid.New(), 1, "ceremony_cancel", func(p Processor, transactionId uuid.UUID) error {
+		if _, err := p.CancelCeremonyAndEmit(transactionId, ceremony.Id(), 1, ""); err != nil {
+			return err
+		}
+		return ErrNotMarriagePartner
+	})
+	if !errors.Is(err, ErrNotMarriagePartner) {
+		t.Fatalf("Expected the command to fail, got %v", err)
+	}
+	if refunds := messagesByType(t, producer)[sagaMsg.CommandRefundFee]; len(refunds) != 0 {
+		t.Errorf("Expected no refund for a cancellation which rolled back, got %d", len(refunds))
+	}
+
+	var stored CeremonyEntity
+	if err = db.First(&stored, ceremony.Id()).Error; err != nil || stored.Status != CeremonyStatusScheduled {
+		t.Errorf("Expected the ceremony to remain scheduled, got %v (%v)", stored.Status, err)
+	}
+}
+
+func TestProcessor_Divorce_ChargesInitiator(t *testing.T) {
+	_, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[2] = testDivorceCost
+
+	transactionId := uuid.New()
+	marriage, err := processor.DivorceAndEmit(transactionId, marriageEntity.ID, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if marriage.Status() != StatusDivorced {
+		t.Errorf("Expected the marriage to be divorced, got %s", marriage.Status())
+	}
+	if len(econ.debits) != 1 || econ.mesos[2] != 0 {
+		t.Fatalf("Expected the initiator to be debited the divorce cost, got %v", econ.debits)
+	}
+	for _, payment := range econ.debits {
+		if payment.Id() != paymentId(transactionId, paymentReasonDivorce) || payment.CharacterId() != 2 || payment.Amount() != testDivorceCost || payment.Reason() != paymentReasonDivorce {
+			t.Errorf("Unexpected divorce payment %+v", payment)
+		}
+	}
+}
+
+func TestProcessor_Divorce_InsufficientFunds(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[1] = testDivorceCost - 1
+
+	_, err := processor.DivorceAndEmit(uuid.New(), marriageEntity.ID, 1)
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("Expected insufficient funds error, got %v", err)
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusMarried {
+		t.Errorf("Expected the marriage to remain married, got %v (%v)", stored.Status, err)
+	}
+	if len(econ.debits) != 0 {
+		t.Errorf("Expected no debit, found %d", len(econ.debits))
+	}
+}
+
+func TestProcessor_Divorce_RolledBackDivorceRefunded(t *testing.T) {
+	db, processor, econ, marriageEntity := setupPaymentTest(t, StatusMarried)
+	econ.mesos[2] = testDivorceCost
+
+	err := processor.ProcessCommand(uuid.New(), 2, "marriage_divorce", func(p Processor, transactionId uuid.UUID) error {
+		if _, err := p.DivorceAndEmit(transactionId, marriageEntity.ID, 2); err != nil {
+			return err
+		}
+		return ErrNotMarriagePartner
+	})
+	if !errors.Is(err, ErrNotMarriagePartner) {
+		t.Fatalf("Expected the command to fail, got %v", err)
+	}
+	if len(econ.debits) != 1 || len(econ.refunded) != 1 || econ.mesos[2] != testDivorceCost {
+		t.Errorf("Expected the debit to be refunded, got %d debits and %d refunds", len(econ.debits), len(econ.refunded))
+	}
+
+	var stored Entity
+	if err = db.First(&stored, marriageEntity.ID).Error; err != nil || stored.Status != StatusMarried {
+		t.Errorf("Expected the marriage to remain married, got %v (%v)", stored.Status, err)
+	}
+}
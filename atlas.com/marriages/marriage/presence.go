@@ -0,0 +1,161 @@
+package marriage
+
+import (
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// DisconnectPartner records a character logging out, so a scheduled ceremony of theirs does not start without them, and
+// a partner logging out during their active ceremony starts the disconnection timeout. It returns nil if the character
+// is not one of the couple in an active ceremony, or is already disconnected
+func (p *ProcessorImpl) DisconnectPartner(characterId uint32) model.Provider[*Ceremony] {
+	return func() (*Ceremony, error) {
+		p.log.WithField("characterId", characterId).Debug("Disconnecting partner from active ceremony")
+
+		t := tenant.MustFromContext(p.ctx)
+
+		if err := RecordPresence(p.db, p.log)(characterId, false, t.Id()); err != nil {
+			return nil, err
+		}
+
+		ceremony, err := GetActiveCeremonyByPartnerProvider(p.db, p.log)(characterId, t.Id())()
+		if err != nil || ceremony == nil || ceremony.DisconnectedAt(characterId) != nil {
+			return nil, err
+		}
+
+		disconnected, err := ceremony.Disconnect(characterId)
+		if err != nil {
+			return nil, err
+		}
+		entity, err := UpdateCeremony(p.db, p.log)(ceremony.Id(), disconnected.ToEntity(), t.Id())()
+		if err != nil {
+			return nil, err
+		}
+		result, err := MakeCeremony(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"ceremonyId":  result.Id(),
+			"characterId": characterId,
+		}).Info("Partner disconnected from active ceremony")
+
+		return &result, nil
+	}
+}
+
+// DisconnectPartnerAndEmit records a partner logging out during their active ceremony and emits a PartnerDisconnected
+// event carrying when the ceremony will be postponed
+func (p *ProcessorImpl) DisconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (*Ceremony, error) {
+		ceremony, err := p.DisconnectPartner(characterId)()
+		if err != nil || ceremony == nil {
+			return nil, err
+		}
+
+		disconnectedAt := *ceremony.DisconnectedAt(characterId)
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := PartnerDisconnectedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				disconnectedAt,
+				disconnectedAt.Add(p.rules().DisconnectionTimeout()),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremony.Id(),
+			"characterId":   characterId,
+		}).Debug("PartnerDisconnected event emitted")
+
+		return ceremony, nil
+	})
+}
+
+// ReconnectPartner records a character logging in, which presents them for a scheduled ceremony of theirs, and a
+// disconnected partner logging back in during their active ceremony cancels the disconnection timeout. It returns nil
+// if the character is not a disconnected partner in an active ceremony
+func (p *ProcessorImpl) ReconnectPartner(characterId uint32) model.Provider[*Ceremony] {
+	return func() (*Ceremony, error) {
+		p.log.WithField("characterId", characterId).Debug("Reconnecting partner to active ceremony")
+
+		t := tenant.MustFromContext(p.ctx)
+
+		if err := RecordPresence(p.db, p.log)(characterId, true, t.Id()); err != nil {
+			return nil, err
+		}
+
+		ceremony, err := GetActiveCeremonyByPartnerProvider(p.db, p.log)(characterId, t.Id())()
+		if err != nil || ceremony == nil || ceremony.DisconnectedAt(characterId) == nil {
+			return nil, err
+		}
+
+		reconnected, err := ceremony.Reconnect(characterId)
+		if err != nil {
+			return nil, err
+		}
+		entity, err := UpdateCeremony(p.db, p.log)(ceremony.Id(), reconnected.ToEntity(), t.Id())()
+		if err != nil {
+			return nil, err
+		}
+		result, err := MakeCeremony(entity)
+		if err != nil {
+			return nil, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"ceremonyId":  result.Id(),
+			"characterId": characterId,
+		}).Info("Partner reconnected to active ceremony")
+
+		return &result, nil
+	}
+}
+
+// ReconnectPartnerAndEmit records a disconnected partner logging back in during their active ceremony and emits a
+// PartnerReconnected event
+func (p *ProcessorImpl) ReconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (*Ceremony, error) {
+		ceremony, err := p.ReconnectPartner(characterId)()
+		if err != nil || ceremony == nil {
+			return nil, err
+		}
+
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := PartnerReconnectedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				ceremony.UpdatedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremony.Id(),
+			"characterId":   characterId,
+		}).Debug("PartnerReconnected event emitted")
+
+		return ceremony, nil
+	})
+}
@@ -0,0 +1,181 @@
+package marriage
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupPresenceTest creates a processor emitting to a mock producer, and an active ceremony for characters 1 and 2
+func setupPresenceTest(t *testing.T) (*gorm.DB, Processor, *MockProducer, Ceremony) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	couple := createMarriedCouple(t, db, tenantId, 1, 2, StatusEngaged, 0)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).WithProducer(producer.Provider)
+
+	ceremony, err := processor.ScheduleCeremony(couple.ID, time.Now().Add(time.Hour), []uint32{3})()
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	ceremony, err = processor.StartCeremony(ceremony.Id())()
+	if err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	return db, processor, producer, ceremony
+}
+
+// disconnectPartnerAt records a partner as disconnected at the given time
+func disconnectPartnerAt(t *testing.T, db *gorm.DB, ceremonyId uint32, column string, disconnectedAt time.Time) {
+	if err := db.Model(&CeremonyEntity{}).Where("id = ?", ceremonyId).Update(column, disconnectedAt).Error; err != nil {
+		t.Fatalf("Failed to disconnect partner: %v", err)
+	}
+}
+
+func TestProcessor_DisconnectPartnerAndEmit(t *testing.T) {
+	_, processor, producer, ceremony := setupPresenceTest(t)
+
+	disconnected, err := processor.DisconnectPartnerAndEmit(uuid.New(), 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if disconnected == nil || disconnected.DisconnectedAt(2) == nil || disconnected.DisconnectedAt(1) != nil {
+		t.Fatal("Expected only the second partner to be disconnected")
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.PartnerDisconnectedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventPartnerDisconnected || event.Body.CeremonyId != ceremony.Id() || event.Body.CharacterId != 2 {
+		t.Errorf("Unexpected partner disconnected event %+v", event)
+	}
+	if timeout := event.Body.TimeoutAt.Sub(event.Body.DisconnectedAt); timeout != DisconnectionTimeout {
+		t.Errorf("Expected the ceremony to time out after %v, got %v", DisconnectionTimeout, timeout)
+	}
+
+	// Logging out again does not extend the timeout
+	producer.ClearMessages()
+	again, err := processor.DisconnectPartnerAndEmit(uuid.New(), 2)
+	if err != nil || again != nil {
+		t.Errorf("Expected no change for a partner already disconnected, got %v", err)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Errorf("Expected no messages, got %d", len(producer.GetProducedMessages()))
+	}
+
+	// Characters outside an active ceremony are ignored
+	if ignored, err := processor.DisconnectPartnerAndEmit(uuid.New(), 3); err != nil || ignored != nil {
+		t.Errorf("Expected an invitee logging out to be ignored, got %v", err)
+	}
+}
+
+func TestProcessor_ReconnectPartnerAndEmit(t *testing.T) {
+	_, processor, producer, ceremony := setupPresenceTest(t)
+
+	// Partners who never disconnected have nothing to reconnect
+	if ignored, err := processor.ReconnectPartnerAndEmit(uuid.New(), 1); err != nil || ignored != nil {
+		t.Errorf("Expected a connected partner logging in to be ignored, got %v", err)
+	}
+
+	if _, err := processor.DisconnectPartner(1)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reconnected, err := processor.ReconnectPartnerAndEmit(uuid.New(), 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reconnected == nil || reconnected.DisconnectedAt(1) != nil {
+		t.Fatal("Expected the partner to be reconnected")
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.PartnerReconnectedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventPartnerReconnected || event.Body.CeremonyId != ceremony.Id() || event.Body.CharacterId != 1 {
+		t.Errorf("Unexpected partner reconnected event %+v", event)
+	}
+}
+
+func TestProcessor_ProcessCeremonyTimeouts_OnlyDisconnectedPartners(t *testing.T) {
+	db, processor, producer, ceremony := setupPresenceTest(t)
+
+	// A long ceremony with both partners online is not postponed
+	if err := db.Model(&CeremonyEntity{}).Where("id = ?", ceremony.Id()).Update("started_at", time.Now().Add(-time.Hour)).Error; err != nil {
+		t.Fatalf("Failed to update ceremony: %v", err)
+	}
+	if err := processor.ProcessCeremonyTimeouts(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusActive {
+		t.Fatalf("Expected ceremony with both partners online to remain active, got %s", status)
+	}
+
+	// A partner disconnected within the timeout may still reconnect
+	disconnectPartnerAt(t, db, ceremony.Id(), "disconnected_at2", time.Now().Add(-2*time.Minute))
+	if err := processor.ProcessCeremonyTimeouts(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusActive {
+		t.Fatalf("Expected ceremony within the disconnection timeout to remain active, got %s", status)
+	}
+
+	disconnectPartnerAt(t, db, ceremony.Id(), "disconnected_at2", time.Now().Add(-10*time.Minute))
+	if err := processor.ProcessCeremonyTimeouts(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	postponed := getCeremony(t, processor, ceremony.Id())
+	if postponed.Status() != CeremonyStatusPostponed {
+		t.Fatalf("Expected timed out ceremony to be postponed, got %s", postponed.Status())
+	}
+	if postponed.DisconnectedAt(2) != nil {
+		t.Error("Expected the disconnection to be cleared when the ceremony is postponed")
+	}
+
+	var event marriageMsg.Event[marriageMsg.CeremonyPostponedBody]
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventCeremonyPostponed || event.Body.Reason != "timeout_disconnection" {
+		t.Errorf("Expected ceremony postponed by the disconnection timeout, got %+v", event)
+	}
+}
+
+func TestProcessor_ProcessCeremonyTimeouts_ReconnectCancelsTimeout(t *testing.T) {
+	db, processor, _, ceremony := setupPresenceTest(t)
+
+	disconnectPartnerAt(t, db, ceremony.Id(), "disconnected_at1", time.Now().Add(-10*time.Minute))
+	if _, err := processor.ReconnectPartner(1)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := processor.ProcessCeremonyTimeouts(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := getCeremony(t, processor, ceremony.Id()).Status(); status != CeremonyStatusActive {
+		t.Errorf("Expected ceremony to remain active after the partner reconnected, got %s", status)
+	}
+}
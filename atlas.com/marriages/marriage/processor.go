@@ -3,12 +3,21 @@ package marriage
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
 	"atlas-marriages/character"
+	"atlas-marriages/database"
+	"atlas-marriages/economy"
+	"atlas-marriages/inventory"
 	"atlas-marriages/kafka/message"
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
 	marriageMsg "atlas-marriages/kafka/message/marriage"
 	"atlas-marriages/kafka/producer"
+	"atlas-marriages/outbox"
+	"atlas-marriages/registry"
+	"atlas-marriages/rules"
+	"atlas-marriages/venue"
 
 	"github.com/Chronicle20/atlas-model/model"
 	"github.com/Chronicle20/atlas-tenant"
@@ -17,13 +26,16 @@ import (
 	"gorm.io/gorm"
 )
 
-// EligibilityRequirement represents minimum level requirement for marriage
-const EligibilityRequirement = 10
+// EligibilityRequirement represents the default minimum level requirement for marriage. Tenants may override it
+// through their marriage rules configuration
+const EligibilityRequirement = rules.DefaultEligibilityLevel
 
 // Processor interface defines the proposal and ceremony processing operations
 type Processor interface {
 	WithProducer(producer producer.Provider) Processor
 	WithCharacterProcessor(characterProcessor character.Processor) Processor
+	WithInventoryProcessor(inventoryProcessor inventory.Processor) Processor
+	WithEconomyProcessor(economyProcessor economy.Processor) Processor
 
 	// Proposal operations
 	Propose(proposerId, targetId uint32) model.Provider[Proposal]
@@ -39,6 +51,32 @@ type Processor interface {
 	Divorce(marriageId uint32, initiatedBy uint32) model.Provider[Marriage]
 	DivorceAndEmit(transactionId uuid.UUID, marriageId uint32, initiatedBy uint32) (Marriage, error)
 
+	// Divorce filing operations
+	FileDivorce(marriageId uint32, filedBy uint32) model.Provider[Marriage]
+	FileDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, filedBy uint32) (Marriage, error)
+	ConsentDivorce(marriageId uint32, consentedBy uint32) model.Provider[Marriage]
+	ConsentDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, consentedBy uint32) (Marriage, error)
+	WithdrawDivorce(marriageId uint32, withdrawnBy uint32) model.Provider[Marriage]
+	WithdrawDivorceAndEmit(transactionId uuid.UUID, marriageId uint32, withdrawnBy uint32) (Marriage, error)
+	FinalizeDivorceAndEmit(transactionId uuid.UUID, marriageId uint32) (Marriage, error)
+	ProcessMaturedDivorces() error
+
+	// Anniversary operations
+	RecordAnniversary(marriageId uint32, milestoneDays uint32) model.Provider[Anniversary]
+	RecordAnniversaryAndEmit(transactionId uuid.UUID, marriageId uint32, milestoneDays uint32) (Anniversary, error)
+	GetAnniversaries(marriageId uint32) model.Provider[[]Anniversary]
+	ProcessAnniversaries() error
+
+	// Bond operations
+	AwardBondPoints(characterId uint32, points uint32, source string) model.Provider[Marriage]
+	AwardBondPointsAndEmit(transactionId uuid.UUID, characterId uint32, points uint32, source string) (Marriage, error)
+	DecayBondAndEmit(transactionId uuid.UUID, marriageId uint32) (Marriage, error)
+	GetBond(characterId uint32) model.Provider[Bond]
+	ProcessBondDecay() error
+
+	// Couple skill operations
+	GetCoupleSkills(characterId uint32) model.Provider[[]CoupleSkill]
+
 	// Character deletion handling
 	HandleCharacterDeletion(characterId uint32) error
 	HandleCharacterDeletionAndEmit(transactionId uuid.UUID, characterId uint32) error
@@ -49,6 +87,7 @@ type Processor interface {
 	// Eligibility checks
 	CheckEligibility(characterId uint32) model.Provider[bool]
 	CheckProposalEligibility(proposerId, targetId uint32) model.Provider[bool]
+	EvaluateProposalEligibility(proposerId, targetId uint32) model.Provider[EligibilityVerdict]
 
 	// Cooldown operations
 	CheckGlobalCooldown(proposerId uint32) model.Provider[bool]
@@ -61,7 +100,8 @@ type Processor interface {
 
 	// Ceremony operations
 	ScheduleCeremony(marriageId uint32, scheduledAt time.Time, invitees []uint32) model.Provider[Ceremony]
-	ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32) (Ceremony, error)
+	ScheduleCeremonyAtVenue(marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, venueId uint32) model.Provider[Ceremony]
+	ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, venueId uint32) (Ceremony, error)
 	StartCeremony(ceremonyId uint32) model.Provider[Ceremony]
 	StartCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error)
 	CompleteCeremony(ceremonyId uint32) model.Provider[Ceremony]
@@ -78,6 +118,8 @@ type Processor interface {
 	AddInviteeAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, addedBy uint32) (Ceremony, error)
 	RemoveInvitee(ceremonyId uint32, characterId uint32) model.Provider[Ceremony]
 	RemoveInviteeAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, removedBy uint32) (Ceremony, error)
+	RespondToInvitation(ceremonyId uint32, characterId uint32, status RsvpStatus) model.Provider[Ceremony]
+	RespondToInvitationAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, status RsvpStatus) (Ceremony, error)
 
 	// Ceremony state management
 	AdvanceCeremonyState(ceremonyId uint32, nextState string) model.Provider[Ceremony]
@@ -92,6 +134,11 @@ type Processor interface {
 	GetCeremonyByMarriage(marriageId uint32) model.Provider[*Ceremony]
 	GetUpcomingCeremonies() model.Provider[[]Ceremony]
 	GetActiveCeremonies() model.Provider[[]Ceremony]
+	GetCeremonyAvailability(worldId byte, channelId byte, from time.Time, to time.Time) model.Provider[[]VenueAvailability]
+
+	// Venue catalogue operations
+	GetVenues(worldId byte, channelId byte) model.Provider[[]venue.Model]
+	CreateVenue(worldId byte, channelId byte, name string, venueType venue.Type, capacity int, slotLength time.Duration) model.Provider[venue.Model]
 
 	// Proposal expiry operations
 	ExpireProposal(proposalId uint32) model.Provider[Proposal]
@@ -100,6 +147,42 @@ type Processor interface {
 
 	// Ceremony timeout operations
 	ProcessCeremonyTimeouts() error
+
+	// Ceremony schedule operations
+	RemindCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error)
+	MissCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error)
+	ProcessCeremonySchedules() error
+
+	// Ceremony stage operations
+	AdvanceCeremonyStage(ceremonyId uint32, stage string) model.Provider[Ceremony]
+	AdvanceCeremonyStageAndEmit(transactionId uuid.UUID, ceremonyId uint32, stage string, advancedBy uint32) (Ceremony, error)
+
+	// Ceremony registry operations
+	GetCeremonyRegistry(ceremonyId uint32) model.Provider[registry.Model]
+	AddRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) model.Provider[registry.Item]
+	AddRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Item, error)
+	RemoveRegistryItem(ceremonyId uint32, characterId uint32, itemId uint32) model.Provider[registry.Item]
+	RemoveRegistryItemAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32) (registry.Item, error)
+	BlessCeremony(ceremonyId uint32, characterId uint32, text string) model.Provider[registry.Blessing]
+	BlessCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, text string) (registry.Blessing, error)
+	GiveGift(ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) model.Provider[registry.Gift]
+	GiveGiftAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32) (registry.Gift, error)
+	HandleGiftTransferFailedAndEmit(transactionId uuid.UUID, giftTransactionId uuid.UUID, reason string) error
+
+	// Partner presence operations
+	DisconnectPartner(characterId uint32) model.Provider[*Ceremony]
+	DisconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*Ceremony, error)
+	ReconnectPartner(characterId uint32) model.Provider[*Ceremony]
+	ReconnectPartnerAndEmit(transactionId uuid.UUID, characterId uint32) (*Ceremony, error)
+
+	// Ceremony saga operations
+	HandleSagaStepCompletedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string) error
+	HandleSagaStepFailedAndEmit(transactionId uuid.UUID, sagaId uuid.UUID, step string, reason string) error
+	ProcessSagaTimeouts() error
+
+	// Idempotency operations
+	ProcessCommand(transactionId uuid.UUID, characterId uint32, errorContext string, operation func(Processor, uuid.UUID) error) error
+	ReportErrorAndEmit(transactionId uuid.UUID, characterId uint32, cause error, errorContext string) error
 }
 
 // ProcessorImpl implements the Processor interface
@@ -109,6 +192,9 @@ type ProcessorImpl struct {
 	db                 *gorm.DB
 	producer           producer.Provider
 	characterProcessor character.Processor
+	inventoryProcessor inventory.Processor
+	economyProcessor   economy.Processor
+	scope              *transactionScope
 }
 
 type ProcessorProducer func(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor
@@ -121,6 +207,8 @@ func NewProcessor(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) Proc
 		db:                 db,
 		producer:           producer.ProviderImpl(log)(ctx),
 		characterProcessor: character.NewProcessor(log, ctx, db),
+		inventoryProcessor: inventory.NewProcessor(log, ctx),
+		economyProcessor:   economy.NewProcessor(log, ctx),
 	}
 }
 
@@ -131,6 +219,8 @@ func (p *ProcessorImpl) WithProducer(producer producer.Provider) Processor {
 		db:                 p.db,
 		producer:           producer,
 		characterProcessor: p.characterProcessor,
+		inventoryProcessor: p.inventoryProcessor,
+		economyProcessor:   p.economyProcessor,
 	}
 }
 
@@ -142,9 +232,43 @@ func (p *ProcessorImpl) WithCharacterProcessor(characterProcessor character.Proc
 		db:                 p.db,
 		producer:           p.producer,
 		characterProcessor: characterProcessor,
+		inventoryProcessor: p.inventoryProcessor,
+		economyProcessor:   p.economyProcessor,
+	}
+}
+
+// WithInventoryProcessor creates a new processor instance with a custom inventory processor for testing
+func (p *ProcessorImpl) WithInventoryProcessor(inventoryProcessor inventory.Processor) Processor {
+	return &ProcessorImpl{
+		log:                p.log,
+		ctx:                p.ctx,
+		db:                 p.db,
+		producer:           p.producer,
+		characterProcessor: p.characterProcessor,
+		inventoryProcessor: inventoryProcessor,
+		economyProcessor:   p.economyProcessor,
+	}
+}
+
+// WithEconomyProcessor creates a new processor instance with a custom economy processor for testing
+func (p *ProcessorImpl) WithEconomyProcessor(economyProcessor economy.Processor) Processor {
+	return &ProcessorImpl{
+		log:                p.log,
+		ctx:                p.ctx,
+		db:                 p.db,
+		producer:           p.producer,
+		characterProcessor: p.characterProcessor,
+		inventoryProcessor: p.inventoryProcessor,
+		economyProcessor:   economyProcessor,
 	}
 }
 
+// rules returns the marriage rules in effect for the tenant in context
+func (p *ProcessorImpl) rules() rules.Model {
+	t := tenant.MustFromContext(p.ctx)
+	return rules.ForTenant(p.log, p.db)(t.Id())
+}
+
 // Propose creates a new marriage proposal with all eligibility checks
 func (p *ProcessorImpl) Propose(proposerId, targetId uint32) model.Provider[Proposal] {
 	return func() (Proposal, error) {
@@ -153,46 +277,43 @@ func (p *ProcessorImpl) Propose(proposerId, targetId uint32) model.Provider[Prop
 			"targetId":   targetId,
 		}).Debug("Processing marriage proposal")
 
-		// Check basic eligibility
-		eligible, err := p.CheckProposalEligibility(proposerId, targetId)()
+		// Check eligibility and cooldowns
+		verdict, err := p.EvaluateProposalEligibility(proposerId, targetId)()
 		if err != nil {
 			return Proposal{}, err
 		}
-		if !eligible {
-			return Proposal{}, errors.New("proposal eligibility check failed")
-		}
-
-		// Check global cooldown
-		canPropose, err := p.CheckGlobalCooldown(proposerId)()
-		if err != nil {
+		if err = verdict.Err(); err != nil {
 			return Proposal{}, err
 		}
-		if !canPropose {
-			return Proposal{}, errors.New("proposer is in global cooldown period")
-		}
 
-		// Check per-target cooldown
-		canProposeToTarget, err := p.CheckPerTargetCooldown(proposerId, targetId)()
+		// Get tenant from context
+		t := tenant.MustFromContext(p.ctx)
+
+		// Reserve the engagement ring for as long as the proposal is pending
+		ring, err := p.reserveEngagementRing(proposerId)
 		if err != nil {
 			return Proposal{}, err
 		}
-		if !canProposeToTarget {
-			return Proposal{}, errors.New("proposer is in cooldown period for this target")
-		}
-
-		// Get tenant from context
-		t := tenant.MustFromContext(p.ctx)
 
 		// Create proposal using administrator
-		entityProvider := CreateProposal(p.db, p.log)(proposerId, targetId, t.Id())
+		var ringItemId uint32
+		var ringReservationId *uuid.UUID
+		if ring != nil {
+			ringItemId = ring.ItemId()
+			reservationId := ring.Id()
+			ringReservationId = &reservationId
+		}
+		entityProvider := CreateProposalWithRing(p.db, p.log)(proposerId, targetId, t.Id(), ringItemId, ringReservationId)
 		entity, err := entityProvider()
 		if err != nil {
+			p.releaseReservation(proposerId, ring)
 			return Proposal{}, err
 		}
 
 		// Transform entity to domain model
 		proposal, err := MakeProposal(entity)
 		if err != nil {
+			p.releaseReservation(proposerId, ring)
 			return Proposal{}, err
 		}
 
@@ -208,32 +329,34 @@ func (p *ProcessorImpl) Propose(proposerId, targetId uint32) model.Provider[Prop
 
 // ProposeAndEmit creates a proposal and emits events
 func (p *ProcessorImpl) ProposeAndEmit(transactionId uuid.UUID, proposerId, targetId uint32) (Proposal, error) {
-	proposal, err := p.Propose(proposerId, targetId)()
-	if err != nil {
-		return Proposal{}, err
-	}
-
-	// Emit ProposalCreated event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		eventProvider := ProposalCreatedEventProvider(
-			proposal.Id(),
-			proposerId,
-			targetId,
-			proposal.ProposedAt(),
-			proposal.ExpiresAt(),
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Proposal{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Proposal, error) {
+		proposal, err := p.Propose(proposerId, targetId)()
+		if err != nil {
+			return Proposal{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"proposalId":    proposal.Id(),
-	}).Debug("ProposalCreated event emitted")
+		// Emit ProposalCreated event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := ProposalCreatedEventProvider(
+				proposal.Id(),
+				proposerId,
+				targetId,
+				proposal.ProposedAt(),
+				proposal.ExpiresAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Proposal{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"proposalId":    proposal.Id(),
+		}).Debug("ProposalCreated event emitted")
 
-	return proposal, nil
+		return proposal, nil
+	})
 }
 
 // AcceptProposal accepts a proposal and creates a marriage
@@ -253,7 +376,7 @@ func (p *ProcessorImpl) AcceptProposal(proposalId uint32) model.Provider[Marriag
 
 		// Check if proposal can be accepted
 		if !proposal.CanRespond() {
-			return Marriage{}, errors.New("proposal cannot be accepted")
+			return Marriage{}, proposalTransitionError(proposal, ProposalStatusAccepted)
 		}
 
 		// Accept the proposal
@@ -301,6 +424,9 @@ func (p *ProcessorImpl) AcceptProposal(proposalId uint32) model.Provider[Marriag
 			return Marriage{}, err
 		}
 
+		// The ring is consumed once the acceptance commits, so a rolled back acceptance leaves it reserved
+		p.consumeEngagementRing(proposal)
+
 		p.log.WithFields(logrus.Fields{
 			"proposalId": proposalId,
 			"marriageId": result.Id(),
@@ -312,49 +438,56 @@ func (p *ProcessorImpl) AcceptProposal(proposalId uint32) model.Provider[Marriag
 
 // AcceptProposalAndEmit accepts a proposal and emits events
 func (p *ProcessorImpl) AcceptProposalAndEmit(transactionId uuid.UUID, proposalId uint32) (Marriage, error) {
-	marriage, err := p.AcceptProposal(proposalId)()
-	if err != nil {
-		return Marriage{}, err
-	}
-
-	// Emit both ProposalAccepted and MarriageCreated events in a single transaction
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		// Add ProposalAccepted event to buffer
-		acceptedAt := time.Now()
-		proposalAcceptedProvider := ProposalAcceptedEventProvider(
-			proposalId,
-			marriage.CharacterId1(),
-			marriage.CharacterId2(),
-			acceptedAt,
-		)
-		if err := buf.Put(marriageMsg.EnvEventTopicStatus, proposalAcceptedProvider); err != nil {
-			return err
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		marriage, err := p.AcceptProposal(proposalId)()
+		if err != nil {
+			return Marriage{}, err
 		}
 
-		// Add MarriageCreated event to buffer
-		marriedAt := time.Now()
-		if marriage.EngagedAt() != nil {
-			marriedAt = *marriage.EngagedAt()
+		// Emit both ProposalAccepted and MarriageCreated events in a single transaction
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			// Add ProposalAccepted event to buffer
+			acceptedAt := time.Now()
+			proposalAcceptedProvider := ProposalAcceptedEventProvider(
+				proposalId,
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				acceptedAt,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, proposalAcceptedProvider); err != nil {
+				return err
+			}
+
+			// Add MarriageCreated event to buffer
+			marriedAt := time.Now()
+			if marriage.EngagedAt() != nil {
+				marriedAt = *marriage.EngagedAt()
+			}
+			marriageCreatedProvider := MarriageCreatedEventProvider(
+				marriage.Id(),
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				marriedAt,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, marriageCreatedProvider); err != nil {
+				return err
+			}
+
+			// Grant couple skills unlocked from engagement
+			return p.putCoupleSkillChanges(buf, marriage, StatusProposed, 0)
+		})
+		if err != nil {
+			return Marriage{}, err
 		}
-		marriageCreatedProvider := MarriageCreatedEventProvider(
-			marriage.Id(),
-			marriage.CharacterId1(),
-			marriage.CharacterId2(),
-			marriedAt,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, marriageCreatedProvider)
-	})
-	if err != nil {
-		return Marriage{}, err
-	}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"proposalId":    proposalId,
-		"marriageId":    marriage.Id(),
-	}).Debug("ProposalAccepted and MarriageCreated events emitted")
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"proposalId":    proposalId,
+			"marriageId":    marriage.Id(),
+		}).Debug("ProposalAccepted and MarriageCreated events emitted")
 
-	return marriage, nil
+		return marriage, nil
+	})
 }
 
 // DeclineProposal declines a proposal and updates cooldown
@@ -374,11 +507,11 @@ func (p *ProcessorImpl) DeclineProposal(proposalId uint32) model.Provider[Propos
 
 		// Check if proposal can be declined
 		if !proposal.CanRespond() {
-			return Proposal{}, errors.New("proposal cannot be declined")
+			return Proposal{}, proposalTransitionError(proposal, ProposalStatusRejected)
 		}
 
 		// Decline the proposal
-		declinedProposal, err := proposal.Reject()
+		declinedProposal, err := proposal.RejectWithInitialCooldown(p.rules().InitialPerTargetCooldown())
 		if err != nil {
 			return Proposal{}, err
 		}
@@ -390,6 +523,9 @@ func (p *ProcessorImpl) DeclineProposal(proposalId uint32) model.Provider[Propos
 			return Proposal{}, err
 		}
 
+		// Return the engagement ring once the decline commits
+		p.returnEngagementRing(declinedProposal)
+
 		p.log.WithFields(logrus.Fields{
 			"proposalId":     proposalId,
 			"rejectionCount": declinedProposal.RejectionCount(),
@@ -401,41 +537,43 @@ func (p *ProcessorImpl) DeclineProposal(proposalId uint32) model.Provider[Propos
 
 // DeclineProposalAndEmit declines a proposal and emits events
 func (p *ProcessorImpl) DeclineProposalAndEmit(transactionId uuid.UUID, proposalId uint32) (Proposal, error) {
-	proposal, err := p.DeclineProposal(proposalId)()
-	if err != nil {
-		return Proposal{}, err
-	}
-
-	// Emit ProposalDeclined event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		declinedAt := time.Now()
-		if proposal.RespondedAt() != nil {
-			declinedAt = *proposal.RespondedAt()
-		}
-		cooldownUntil := time.Now()
-		if proposal.CooldownUntil() != nil {
-			cooldownUntil = *proposal.CooldownUntil()
-		}
-		eventProvider := ProposalDeclinedEventProvider(
-			proposalId,
-			proposal.ProposerId(),
-			proposal.TargetId(),
-			declinedAt,
-			proposal.RejectionCount(),
-			cooldownUntil,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Proposal{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Proposal, error) {
+		proposal, err := p.DeclineProposal(proposalId)()
+		if err != nil {
+			return Proposal{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"proposalId":    proposalId,
-	}).Debug("ProposalDeclined event emitted")
+		// Emit ProposalDeclined event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			declinedAt := time.Now()
+			if proposal.RespondedAt() != nil {
+				declinedAt = *proposal.RespondedAt()
+			}
+			cooldownUntil := time.Now()
+			if proposal.CooldownUntil() != nil {
+				cooldownUntil = *proposal.CooldownUntil()
+			}
+			eventProvider := ProposalDeclinedEventProvider(
+				proposalId,
+				proposal.ProposerId(),
+				proposal.TargetId(),
+				declinedAt,
+				proposal.RejectionCount(),
+				cooldownUntil,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Proposal{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"proposalId":    proposalId,
+		}).Debug("ProposalDeclined event emitted")
 
-	return proposal, nil
+		return proposal, nil
+	})
 }
 
 // CancelProposal cancels a proposal by the proposer
@@ -455,7 +593,7 @@ func (p *ProcessorImpl) CancelProposal(proposalId uint32) model.Provider[Proposa
 
 		// Check if proposal can be cancelled
 		if !proposal.CanCancel() {
-			return Proposal{}, errors.New("proposal cannot be cancelled")
+			return Proposal{}, proposalTransitionError(proposal, ProposalStatusCancelled)
 		}
 
 		// Cancel the proposal
@@ -471,6 +609,9 @@ func (p *ProcessorImpl) CancelProposal(proposalId uint32) model.Provider[Proposa
 			return Proposal{}, err
 		}
 
+		// Return the engagement ring once the cancellation commits
+		p.returnEngagementRing(cancelledProposal)
+
 		p.log.WithField("proposalId", proposalId).Info("Proposal cancelled")
 
 		return cancelledProposal, nil
@@ -479,32 +620,34 @@ func (p *ProcessorImpl) CancelProposal(proposalId uint32) model.Provider[Proposa
 
 // CancelProposalAndEmit cancels a proposal and emits events
 func (p *ProcessorImpl) CancelProposalAndEmit(transactionId uuid.UUID, proposalId uint32) (Proposal, error) {
-	proposal, err := p.CancelProposal(proposalId)()
-	if err != nil {
-		return Proposal{}, err
-	}
-
-	// Emit ProposalCancelled event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		cancelledAt := time.Now()
-		eventProvider := ProposalCancelledEventProvider(
-			proposalId,
-			proposal.ProposerId(),
-			proposal.TargetId(),
-			cancelledAt,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Proposal{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Proposal, error) {
+		proposal, err := p.CancelProposal(proposalId)()
+		if err != nil {
+			return Proposal{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"proposalId":    proposalId,
-	}).Debug("ProposalCancelled event emitted")
+		// Emit ProposalCancelled event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			cancelledAt := time.Now()
+			eventProvider := ProposalCancelledEventProvider(
+				proposalId,
+				proposal.ProposerId(),
+				proposal.TargetId(),
+				cancelledAt,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Proposal{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"proposalId":    proposalId,
+		}).Debug("ProposalCancelled event emitted")
 
-	return proposal, nil
+		return proposal, nil
+	})
 }
 
 // CheckEligibility checks if a character meets the minimum level requirement
@@ -520,11 +663,12 @@ func (p *ProcessorImpl) CheckEligibility(characterId uint32) model.Provider[bool
 		}
 
 		// Check if character meets minimum level requirement
-		if char.Level() < byte(EligibilityRequirement) {
+		required := p.rules().EligibilityLevel()
+		if char.Level() < required {
 			p.log.WithFields(logrus.Fields{
 				"characterId": characterId,
 				"level":       char.Level(),
-				"required":    EligibilityRequirement,
+				"required":    required,
 			}).Debug("Character level too low for marriage")
 			return false, nil
 		}
@@ -533,60 +677,99 @@ func (p *ProcessorImpl) CheckEligibility(characterId uint32) model.Provider[bool
 	}
 }
 
-// CheckProposalEligibility performs comprehensive eligibility checks for a proposal
+// CheckProposalEligibility performs comprehensive eligibility checks for a proposal, excluding cooldowns
 func (p *ProcessorImpl) CheckProposalEligibility(proposerId, targetId uint32) model.Provider[bool] {
 	return func() (bool, error) {
+		verdict, err := p.EvaluateProposalEligibility(proposerId, targetId)()
+		if err != nil {
+			return false, err
+		}
+		return verdict.CharactersEligible(), nil
+	}
+}
+
+// EvaluateProposalEligibility evaluates every proposal eligibility rule, including proposal and remarriage cooldowns, and returns a verdict listing each one which fails
+func (p *ProcessorImpl) EvaluateProposalEligibility(proposerId, targetId uint32) model.Provider[EligibilityVerdict] {
+	return func() (EligibilityVerdict, error) {
 		// Get tenant from context
 		t := tenant.MustFromContext(p.ctx)
 
+		var violations []EligibilityViolation
+
 		// Check basic character eligibility
-		proposerEligible, err := p.CheckEligibility(proposerId)()
-		if err != nil {
-			return false, err
+		required := p.rules().EligibilityLevel()
+		for _, characterId := range []uint32{proposerId, targetId} {
+			char, err := p.characterProcessor.GetById(characterId)
+			if err != nil {
+				p.log.WithError(err).WithField("characterId", characterId).Error("Failed to retrieve character")
+				return EligibilityVerdict{}, err
+			}
+			if char.Level() < required {
+				violations = append(violations, newEligibilityViolation(ErrCharacterTooLowLevel, characterId, strconv.Itoa(int(char.Level()))))
+			}
 		}
-		if !proposerEligible {
-			return false, nil
+
+		// Check if either character is already married or engaged
+		for _, characterId := range []uint32{proposerId, targetId} {
+			marriageProvider := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())
+			marriage, err := marriageProvider()
+			if err != nil {
+				return EligibilityVerdict{}, err
+			}
+			if marriage != nil {
+				violations = append(violations, newEligibilityViolation(ErrCharacterAlreadyMarried, characterId, marriage.Status().String()))
+			}
 		}
 
-		targetEligible, err := p.CheckEligibility(targetId)()
+		// Check if there's already a pending proposal between these characters
+		existingProposalProvider := GetActiveProposalProvider(p.db, p.log)(proposerId, targetId, t.Id())
+		existingProposal, err := existingProposalProvider()
 		if err != nil {
-			return false, err
+			return EligibilityVerdict{}, err
 		}
-		if !targetEligible {
-			return false, nil
+		if existingProposal != nil {
+			violations = append(violations, newEligibilityViolation(ErrTargetAlreadyEngaged, targetId, strconv.Itoa(int(existingProposal.Id()))))
 		}
 
-		// Check if proposer is already married or engaged
-		proposerMarriageProvider := GetActiveMarriageByCharacterProvider(p.db, p.log)(proposerId, t.Id())
-		proposerMarriage, err := proposerMarriageProvider()
+		// Check global cooldown
+		globalRemaining, err := GetGlobalCooldownRemainingProvider(p.db, p.log)(proposerId, t.Id())()
 		if err != nil {
-			return false, err
+			return EligibilityVerdict{}, err
 		}
-		if proposerMarriage != nil {
-			return false, nil
+		if globalRemaining > 0 {
+			violations = append(violations, newCooldownViolation(CooldownError{Scope: CooldownScopeGlobal, Remaining: globalRemaining}, proposerId))
 		}
 
-		// Check if target is already married or engaged
-		targetMarriageProvider := GetActiveMarriageByCharacterProvider(p.db, p.log)(targetId, t.Id())
-		targetMarriage, err := targetMarriageProvider()
+		// Check per-target cooldown
+		targetRemaining, err := GetPerTargetCooldownRemainingProvider(p.db, p.log)(proposerId, targetId, t.Id())()
 		if err != nil {
-			return false, err
+			return EligibilityVerdict{}, err
 		}
-		if targetMarriage != nil {
-			return false, nil
+		if targetRemaining > 0 {
+			violations = append(violations, newCooldownViolation(CooldownError{Scope: CooldownScopeTarget, Remaining: targetRemaining}, proposerId))
 		}
 
-		// Check if there's already a pending proposal between these characters
-		existingProposalProvider := GetActiveProposalProvider(p.db, p.log)(proposerId, targetId, t.Id())
-		existingProposal, err := existingProposalProvider()
+		// Check the remarriage cooldown of both characters after a divorce
+		for _, characterId := range []uint32{proposerId, targetId} {
+			remarriageRemaining, err := GetRemarriageCooldownRemainingProvider(p.db, p.log)(characterId, t.Id())()
+			if err != nil {
+				return EligibilityVerdict{}, err
+			}
+			if remarriageRemaining > 0 {
+				violations = append(violations, newCooldownViolation(CooldownError{Scope: CooldownScopeRemarriage, Remaining: remarriageRemaining}, characterId))
+			}
+		}
+
+		// Check the cooldown between former partners
+		exPartnerRemaining, err := GetExPartnerCooldownRemainingProvider(p.db, p.log)(proposerId, targetId, t.Id())()
 		if err != nil {
-			return false, err
+			return EligibilityVerdict{}, err
 		}
-		if existingProposal != nil {
-			return false, nil
+		if exPartnerRemaining > 0 {
+			violations = append(violations, newCooldownViolation(CooldownError{Scope: CooldownScopeExPartner, Remaining: exPartnerRemaining}, proposerId))
 		}
 
-		return true, nil
+		return NewEligibilityVerdict(proposerId, targetId, violations), nil
 	}
 }
 
@@ -640,130 +823,160 @@ func (p *ProcessorImpl) GetProposalHistory(proposerId, targetId uint32) model.Pr
 	}
 }
 
-// EligibilityError represents specific eligibility validation errors
-type EligibilityError struct {
-	Code    string
-	Message string
+// Ceremony-related processor methods
+
+// ScheduleCeremony creates a new ceremony at a standard venue for an engaged marriage
+func (p *ProcessorImpl) ScheduleCeremony(marriageId uint32, scheduledAt time.Time, invitees []uint32) model.Provider[Ceremony] {
+	return p.ScheduleCeremonyAtVenue(marriageId, scheduledAt, invitees, VenueTierStandard, 0)
 }
 
-func (e EligibilityError) Error() string {
-	return e.Message
+// ScheduleCeremonyAtVenue creates a new ceremony for an engaged marriage at a venue of the given tier, defaulting to
+// a standard venue. The tenant's cost for the tier is debited from the first partner before the ceremony is created,
+// and the ceremony records the economy transaction paying for it. A non-zero venue id books that venue's slot starting at the scheduled time, failing if another ceremony holds it.
+// A venue id is required once the tenant has a venue catalogue.
+// The ceremony is scheduled in its own transaction, so the cost is refunded if it cannot be recorded
+func (p *ProcessorImpl) ScheduleCeremonyAtVenue(marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, venueId uint32) model.Provider[Ceremony] {
+	return func() (Ceremony, error) {
+		transactionId := uuid.New()
+		return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+			return p.scheduleCeremony(transactionId, marriageId, scheduledAt, invitees, venueTier, venueId)
+		})
+	}
 }
 
-// Predefined eligibility errors
-var (
-	ErrCharacterTooLowLevel = EligibilityError{
-		Code:    "CHARACTER_TOO_LOW_LEVEL",
-		Message: "character level is too low for marriage",
+// scheduleCeremony creates a new ceremony, debiting its cost from the first partner under a payment keyed by the
+// scheduling transaction. The debit is refunded if the processor's transaction rolls back
+func (p *ProcessorImpl) scheduleCeremony(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, venueId uint32) (Ceremony, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId":  marriageId,
+		"scheduledAt": scheduledAt,
+		"invitees":    len(invitees),
+		"venueTier":   venueTier,
+		"venueId":     venueId,
+	}).Debug("Scheduling ceremony")
+
+	venueTier, err := ParseVenueTier(string(venueTier))
+	if err != nil {
+		return Ceremony{}, err
+	}
+
+	// Verify the venue is free at the scheduled time
+	var booked *venue.Model
+	if venueId != 0 {
+		v, err := p.bookableVenue(venueId, 0, scheduledAt)
+		if err != nil {
+			return Ceremony{}, err
+		}
+		booked = &v
+	} else if err = p.verifyVenueOptional(); err != nil {
+		return Ceremony{}, err
 	}
-	ErrCharacterAlreadyMarried = EligibilityError{
-		Code:    "CHARACTER_ALREADY_MARRIED",
-		Message: "character is already married or engaged",
+
+	// Validate invitees limit
+	limit := p.inviteeLimit(booked)
+	if len(invitees) > limit {
+		return Ceremony{}, InviteeLimitError{Limit: limit, Requested: len(invitees)}
 	}
-	ErrTargetAlreadyEngaged = EligibilityError{
-		Code:    "TARGET_ALREADY_ENGAGED",
-		Message: "target character already has a pending proposal",
+
+	// Get tenant from context
+	t := tenant.MustFromContext(p.ctx)
+
+	// Verify marriage exists and is engaged
+	marriageProvider := GetMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())
+	marriage, err := marriageProvider()
+	if err != nil {
+		return Ceremony{}, err
 	}
-	ErrGlobalCooldownActive = EligibilityError{
-		Code:    "GLOBAL_COOLDOWN_ACTIVE",
-		Message: "proposer is in global cooldown period",
+	if marriage == nil {
+		return Ceremony{}, ErrMarriageNotFound
 	}
-	ErrTargetCooldownActive = EligibilityError{
-		Code:    "TARGET_COOLDOWN_ACTIVE",
-		Message: "proposer is in cooldown period for this target",
+	if marriage.Status() != StatusEngaged {
+		return Ceremony{}, marriageTransitionError(*marriage, StatusMarried)
 	}
-)
 
-// Ceremony-related processor methods
+	// The proposer pays for the ceremony before it is created
+	cost := p.ceremonyCost(venueTier)
+	payment, err := p.debit(paymentId(transactionId, paymentReasonCeremony), marriage.CharacterId1(), cost, paymentReasonCeremony)
+	if err != nil {
+		return Ceremony{}, err
+	}
+	var ceremonyPaymentId *uuid.UUID
+	if payment != nil {
+		id := payment.Id()
+		ceremonyPaymentId = &id
+	}
 
-// ScheduleCeremony creates a new ceremony for an engaged marriage
-func (p *ProcessorImpl) ScheduleCeremony(marriageId uint32, scheduledAt time.Time, invitees []uint32) model.Provider[Ceremony] {
-	return func() (Ceremony, error) {
-		p.log.WithFields(logrus.Fields{
-			"marriageId":  marriageId,
-			"scheduledAt": scheduledAt,
-			"invitees":    len(invitees),
-		}).Debug("Scheduling ceremony")
+	// Create ceremony using administrator
+	entityProvider := CreateCeremonyWithPayment(p.db, p.log)(marriageId, marriage.CharacterId1(), marriage.CharacterId2(), scheduledAt, invitees, venueTier, cost, ceremonyPaymentId, venueId, limit, t.Id())
+	entity, err := entityProvider()
+	if err != nil {
+		return Ceremony{}, err
+	}
 
-		// Validate invitees limit
-		if len(invitees) > MaxInvitees {
-			return Ceremony{}, errors.New("too many invitees, maximum is 15")
+	if booked != nil {
+		if err = p.bookVenue(*booked, entity.ID, scheduledAt); err != nil {
+			return Ceremony{}, err
 		}
+	}
 
-		// Get tenant from context
-		t := tenant.MustFromContext(p.ctx)
+	// Transform entity to domain model
+	ceremony, err := MakeCeremony(entity)
+	if err != nil {
+		return Ceremony{}, err
+	}
 
-		// Verify marriage exists and is engaged
-		marriageProvider := GetMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())
-		marriage, err := marriageProvider()
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId": ceremony.Id(),
+		"marriageId": marriageId,
+	}).Info("Ceremony scheduled successfully")
+
+	return ceremony, nil
+}
+
+// ScheduleCeremonyAndEmit schedules a ceremony, starts the saga orchestrating the services involved in it and emits
+// events. The saga settles the ceremony's payment once the chapel is reserved, and refunds it if the ceremony is
+// cancelled before the saga finishes
+func (p *ProcessorImpl) ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32, venueTier VenueTier, venueId uint32) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.scheduleCeremony(transactionId, marriageId, scheduledAt, invitees, venueTier, venueId)
 		if err != nil {
 			return Ceremony{}, err
 		}
-		if marriage == nil {
-			return Ceremony{}, errors.New("marriage not found")
-		}
-		if marriage.Status() != StatusEngaged {
-			return Ceremony{}, errors.New("marriage must be engaged to schedule ceremony")
-		}
 
-		// Create ceremony using administrator
-		entityProvider := CreateCeremony(p.db, p.log)(marriageId, marriage.CharacterId1(), marriage.CharacterId2(), scheduledAt, invitees, t.Id())
-		entity, err := entityProvider()
-		if err != nil {
+		if _, err = p.beginCeremonySaga(transactionId, ceremony); err != nil {
 			return Ceremony{}, err
 		}
 
-		// Transform entity to domain model
-		ceremony, err := MakeCeremony(entity)
+		// Use enhanced message buffering for potential future expansion
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			// Primary ceremony scheduled event
+			eventProvider := CeremonyScheduledEventProvider(
+				ceremony.Id(),
+				marriageId,
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				scheduledAt,
+				invitees,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+				return err
+			}
+
+			// Potential for additional events (invitation notifications, etc.)
+			// This pattern ensures all related events are emitted together
+			return nil
+		})
 		if err != nil {
 			return Ceremony{}, err
 		}
 
 		p.log.WithFields(logrus.Fields{
-			"ceremonyId": ceremony.Id(),
-			"marriageId": marriageId,
-		}).Info("Ceremony scheduled successfully")
+			"transactionId": transactionId,
+			"ceremonyId":    ceremony.Id(),
+		}).Debug("CeremonyScheduled event emitted")
 
 		return ceremony, nil
-	}
-}
-
-// ScheduleCeremonyAndEmit schedules a ceremony and emits events
-func (p *ProcessorImpl) ScheduleCeremonyAndEmit(transactionId uuid.UUID, marriageId uint32, scheduledAt time.Time, invitees []uint32) (Ceremony, error) {
-	ceremony, err := p.ScheduleCeremony(marriageId, scheduledAt, invitees)()
-	if err != nil {
-		return Ceremony{}, err
-	}
-
-	// Use enhanced message buffering for potential future expansion
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		// Primary ceremony scheduled event
-		eventProvider := CeremonyScheduledEventProvider(
-			ceremony.Id(),
-			marriageId,
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			scheduledAt,
-			invitees,
-		)
-		if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
-			return err
-		}
-
-		// Potential for additional events (invitation notifications, etc.)
-		// This pattern ensures all related events are emitted together
-		return nil
 	})
-	if err != nil {
-		return Ceremony{}, err
-	}
-
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremony.Id(),
-	}).Debug("CeremonyScheduled event emitted")
-
-	return ceremony, nil
 }
 
 // StartCeremony transitions a ceremony to active state
@@ -781,12 +994,12 @@ func (p *ProcessorImpl) StartCeremony(ceremonyId uint32) model.Provider[Ceremony
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate state transition
 		if !ceremony.CanStart() {
-			return Ceremony{}, errors.New("ceremony cannot be started in current state")
+			return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusActive)
 		}
 
 		// Start ceremony
@@ -814,120 +1027,255 @@ func (p *ProcessorImpl) StartCeremony(ceremonyId uint32) model.Provider[Ceremony
 	}
 }
 
-// StartCeremonyAndEmit starts a ceremony and emits events
+// StartCeremonyAndEmit starts a ceremony, resumes its saga and emits events
 func (p *ProcessorImpl) StartCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error) {
-	ceremony, err := p.StartCeremony(ceremonyId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
-
-	// Emit CeremonyStarted event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		startedAt := time.Now()
-		if ceremony.StartedAt() != nil {
-			startedAt = *ceremony.StartedAt()
-		}
-		eventProvider := CeremonyStartedEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			startedAt,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Ceremony{}, err
-	}
-
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-	}).Debug("CeremonyStarted event emitted")
-
-	return ceremony, nil
-}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.StartCeremony(ceremonyId)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-// CompleteCeremony transitions a ceremony to completed state
-func (p *ProcessorImpl) CompleteCeremony(ceremonyId uint32) model.Provider[Ceremony] {
-	return func() (Ceremony, error) {
-		p.log.WithField("ceremonyId", ceremonyId).Debug("Completing ceremony")
+		if err = p.resumeCeremonySaga(ceremony); err != nil {
+			return Ceremony{}, err
+		}
 
-		// Get tenant from context
-		t := tenant.MustFromContext(p.ctx)
+		// Emit CeremonyStarted event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			startedAt := time.Now()
+			if ceremony.StartedAt() != nil {
+				startedAt = *ceremony.StartedAt()
+			}
+			eventProvider := CeremonyStartedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				startedAt,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+		}).Debug("CeremonyStarted event emitted")
 
+		return ceremony, nil
+	})
+}
+
+// CompleteCeremony transitions a ceremony to completed state and marries the couple
+func (p *ProcessorImpl) CompleteCeremony(ceremonyId uint32) model.Provider[Ceremony] {
+	return func() (Ceremony, error) {
+		ceremony, _, err := p.completeCeremony(ceremonyId)
+		if err != nil {
+			return Ceremony{}, err
+		}
+		return ceremony, nil
+	}
+}
+
+// completeCeremony completes a ceremony and transitions the linked marriage to married status, recording the
+// wedding rings issued to the couple when the tenant issues them. Both rows are persisted within a single database
+// transaction.
+func (p *ProcessorImpl) completeCeremony(ceremonyId uint32) (Ceremony, Marriage, error) {
+	p.log.WithField("ceremonyId", ceremonyId).Debug("Completing ceremony")
+
+	// Get tenant from context
+	t := tenant.MustFromContext(p.ctx)
+	ringItemId := p.rules().WeddingRingItemId()
+
+	var result Ceremony
+	var married Marriage
+	err := database.ExecuteTransaction(p.db, func(tx *gorm.DB) error {
 		// Get ceremony
-		ceremonyProvider := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())
+		ceremonyProvider := GetCeremonyByIdProvider(tx, p.log)(ceremonyId, t.Id())
 		ceremony, err := ceremonyProvider()
 		if err != nil {
-			return Ceremony{}, err
+			return err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return ErrCeremonyNotFound
 		}
 
 		// Validate state transition
 		if !ceremony.CanComplete() {
-			return Ceremony{}, errors.New("ceremony cannot be completed in current state")
+			return ceremonyTransitionError(*ceremony, CeremonyStatusCompleted)
+		}
+		if !ceremony.StagesCompleted() {
+			return ErrCeremonyStagesPending
+		}
+
+		// Get the marriage linked to the ceremony
+		marriageProvider := GetMarriageByIdProvider(tx, p.log)(ceremony.MarriageId(), t.Id())
+		marriage, err := marriageProvider()
+		if err != nil {
+			return err
+		}
+		if marriage == nil {
+			return ErrMarriageNotFound
+		}
+		if !marriage.CanMarry() {
+			return marriageTransitionError(*marriage, StatusMarried)
 		}
 
 		// Complete ceremony
 		updatedCeremony, err := ceremony.Complete()
 		if err != nil {
-			return Ceremony{}, err
+			return err
 		}
 
 		// Update ceremony using administrator
-		entityProvider := UpdateCeremony(p.db, p.log)(ceremonyId, updatedCeremony.ToEntity(), t.Id())
+		entityProvider := UpdateCeremony(tx, p.log)(ceremonyId, updatedCeremony.ToEntity(), t.Id())
 		entity, err := entityProvider()
 		if err != nil {
-			return Ceremony{}, err
+			return err
 		}
 
 		// Transform entity to domain model
-		result, err := MakeCeremony(entity)
+		result, err = MakeCeremony(entity)
 		if err != nil {
-			return Ceremony{}, err
+			return err
 		}
 
-		p.log.WithField("ceremonyId", ceremonyId).Info("Ceremony completed successfully")
+		// Marry the couple
+		marriedMarriage, err := marriage.Marry()
+		if err != nil {
+			return err
+		}
 
-		return result, nil
-	}
-}
+		// Record the matching wedding rings issued to the couple
+		if ringItemId != 0 {
+			serial1, serial2, err := newRingSerials()
+			if err != nil {
+				return err
+			}
+			marriedMarriage, err = marriedMarriage.IssueRings(ringItemId, serial1, serial2)
+			if err != nil {
+				return err
+			}
+		}
 
-// CompleteCeremonyAndEmit completes a ceremony and emits events
-func (p *ProcessorImpl) CompleteCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error) {
-	ceremony, err := p.CompleteCeremony(ceremonyId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+		// Update the marriage in the database
+		updateMarriageProvider := UpdateMarriage(tx, p.log)(marriedMarriage)
+		updatedEntity, err := updateMarriageProvider()
+		if err != nil {
+			return err
+		}
 
-	// Emit CeremonyCompleted event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		completedAt := time.Now()
-		if ceremony.CompletedAt() != nil {
-			completedAt = *ceremony.CompletedAt()
-		}
-		eventProvider := CeremonyCompletedEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			completedAt,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		// Transform entity to domain model
+		married, err = Make(updatedEntity)
+		return err
 	})
 	if err != nil {
-		return Ceremony{}, err
+		return Ceremony{}, Marriage{}, err
 	}
 
 	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-	}).Debug("CeremonyCompleted event emitted")
+		"ceremonyId": ceremonyId,
+		"marriageId": married.Id(),
+	}).Info("Ceremony completed successfully and couple married")
 
-	return ceremony, nil
+	return result, married, nil
+}
+
+// CompleteCeremonyAndEmit completes a ceremony, marries the couple, finishes the ceremony saga, awards the bond points
+// earned by the blessings and gifts received at the ceremony and emits events
+func (p *ProcessorImpl) CompleteCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		var ceremony Ceremony
+		var marriage Marriage
+
+		// Emit CeremonyCompleted and MarriageCreated events in a single buffer
+		err := message.Emit(p.producer)(func(buf *message.Buffer) error {
+			var err error
+			ceremony, marriage, err = p.completeCeremony(ceremonyId)
+			if err != nil {
+				return err
+			}
+			if err = p.finishCeremonySaga(ceremonyId); err != nil {
+				return err
+			}
+
+			completedAt := time.Now()
+			if ceremony.CompletedAt() != nil {
+				completedAt = *ceremony.CompletedAt()
+			}
+			ceremonyCompletedProvider := CeremonyCompletedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				completedAt,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, ceremonyCompletedProvider); err != nil {
+				return err
+			}
+
+			marriedAt := completedAt
+			if marriage.MarriedAt() != nil {
+				marriedAt = *marriage.MarriedAt()
+			}
+			marriageCreatedProvider := MarriageCreatedEventProvider(
+				marriage.Id(),
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				marriedAt,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, marriageCreatedProvider); err != nil {
+				return err
+			}
+			if err := p.putCoupleSkillChanges(buf, marriage, StatusEngaged, marriage.BondLevel()); err != nil {
+				return err
+			}
+
+			if !marriage.HasRings() {
+				return nil
+			}
+			ring1Provider := CreateWeddingRingCommandProvider(
+				transactionId,
+				marriage.Id(),
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				marriage.RingItemId(),
+				marriage.RingSerial1(),
+				marriage.RingSerial2(),
+			)
+			if err := buf.Put(inventoryMsg.EnvCommandTopic, ring1Provider); err != nil {
+				return err
+			}
+			ring2Provider := CreateWeddingRingCommandProvider(
+				transactionId,
+				marriage.Id(),
+				marriage.CharacterId2(),
+				marriage.CharacterId1(),
+				marriage.RingItemId(),
+				marriage.RingSerial2(),
+				marriage.RingSerial1(),
+			)
+			return buf.Put(inventoryMsg.EnvCommandTopic, ring2Provider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		// The blessings and gifts received at the ceremony strengthen the newly married couple's bond
+		if marriage, err = p.awardRegistryBondAndEmit(transactionId, ceremony, marriage); err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"marriageId":    marriage.Id(),
+			"ringsIssued":   marriage.HasRings(),
+		}).Debug("CeremonyCompleted and MarriageCreated events emitted")
+
+		return ceremony, nil
+	})
 }
 
 // CancelCeremony transitions a ceremony to cancelled state
@@ -945,12 +1293,12 @@ func (p *ProcessorImpl) CancelCeremony(ceremonyId uint32) model.Provider[Ceremon
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate state transition
 		if !ceremony.CanCancel() {
-			return Ceremony{}, errors.New("ceremony cannot be cancelled in current state")
+			return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusCancelled)
 		}
 
 		// Cancel ceremony
@@ -966,6 +1314,10 @@ func (p *ProcessorImpl) CancelCeremony(ceremonyId uint32) model.Provider[Ceremon
 			return Ceremony{}, err
 		}
 
+		if err = p.releaseVenue(*ceremony); err != nil {
+			return Ceremony{}, err
+		}
+
 		// Transform entity to domain model
 		result, err := MakeCeremony(entity)
 		if err != nil {
@@ -978,40 +1330,50 @@ func (p *ProcessorImpl) CancelCeremony(ceremonyId uint32) model.Provider[Ceremon
 	}
 }
 
-// CancelCeremonyAndEmit cancels a ceremony and emits events
+// CancelCeremonyAndEmit cancels a ceremony, rolls back its saga and emits events
 func (p *ProcessorImpl) CancelCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, cancelledBy uint32, reason string) (Ceremony, error) {
-	ceremony, err := p.CancelCeremony(ceremonyId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.CancelCeremony(ceremonyId)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	// Emit CeremonyCancelled event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		cancelledAt := time.Now()
-		if ceremony.CancelledAt() != nil {
-			cancelledAt = *ceremony.CancelledAt()
-		}
-		eventProvider := CeremonyCancelledEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			cancelledAt,
-			cancelledBy,
-			reason,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Ceremony{}, err
-	}
+		sagaReason := reason
+		if sagaReason == "" {
+			sagaReason = "ceremony_cancelled"
+		}
+		if err = p.abortCeremonySaga(ceremonyId, sagaReason); err != nil {
+			return Ceremony{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-	}).Debug("CeremonyCancelled event emitted")
+		// Emit CeremonyCancelled event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			cancelledAt := time.Now()
+			if ceremony.CancelledAt() != nil {
+				cancelledAt = *ceremony.CancelledAt()
+			}
+			eventProvider := CeremonyCancelledEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				cancelledAt,
+				cancelledBy,
+				reason,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	return ceremony, nil
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+		}).Debug("CeremonyCancelled event emitted")
+
+		return ceremony, nil
+	})
 }
 
 // PostponeCeremony transitions a ceremony to postponed state
@@ -1029,12 +1391,12 @@ func (p *ProcessorImpl) PostponeCeremony(ceremonyId uint32) model.Provider[Cerem
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate state transition
 		if !ceremony.CanPostpone() {
-			return Ceremony{}, errors.New("ceremony cannot be postponed in current state")
+			return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusPostponed)
 		}
 
 		// Postpone ceremony
@@ -1050,6 +1412,11 @@ func (p *ProcessorImpl) PostponeCeremony(ceremonyId uint32) model.Provider[Cerem
 			return Ceremony{}, err
 		}
 
+		// The venue is booked again when the ceremony is rescheduled
+		if err = p.releaseVenue(*ceremony); err != nil {
+			return Ceremony{}, err
+		}
+
 		// Transform entity to domain model
 		result, err := MakeCeremony(entity)
 		if err != nil {
@@ -1062,39 +1429,46 @@ func (p *ProcessorImpl) PostponeCeremony(ceremonyId uint32) model.Provider[Cerem
 	}
 }
 
-// PostponeCeremonyAndEmit postpones a ceremony and emits events
+// PostponeCeremonyAndEmit postpones a ceremony, releases the chapel its saga reserved and emits events
 func (p *ProcessorImpl) PostponeCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, reason string) (Ceremony, error) {
-	ceremony, err := p.PostponeCeremony(ceremonyId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.PostponeCeremony(ceremonyId)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	// Emit CeremonyPostponed event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		postponedAt := time.Now()
-		if ceremony.PostponedAt() != nil {
-			postponedAt = *ceremony.PostponedAt()
-		}
-		eventProvider := CeremonyPostponedEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			postponedAt,
-			reason,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Ceremony{}, err
-	}
+		// The chapel is reserved again, and the guests warped again, once the ceremony is rescheduled and started
+		if err = p.rewindCeremonySaga(ceremony); err != nil {
+			return Ceremony{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-	}).Debug("CeremonyPostponed event emitted")
+		// Emit CeremonyPostponed event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			postponedAt := time.Now()
+			if ceremony.PostponedAt() != nil {
+				postponedAt = *ceremony.PostponedAt()
+			}
+			eventProvider := CeremonyPostponedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				postponedAt,
+				reason,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	return ceremony, nil
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+		}).Debug("CeremonyPostponed event emitted")
+
+		return ceremony, nil
+	})
 }
 
 // RescheduleCeremony reschedules a ceremony to a new time
@@ -1115,12 +1489,12 @@ func (p *ProcessorImpl) RescheduleCeremony(ceremonyId uint32, newScheduledAt tim
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate state transition
 		if !ceremony.CanReschedule() {
-			return Ceremony{}, errors.New("ceremony cannot be rescheduled in current state")
+			return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusScheduled)
 		}
 
 		// Reschedule ceremony
@@ -1129,6 +1503,10 @@ func (p *ProcessorImpl) RescheduleCeremony(ceremonyId uint32, newScheduledAt tim
 			return Ceremony{}, err
 		}
 
+		if err = p.rebookVenue(*ceremony, newScheduledAt); err != nil {
+			return Ceremony{}, err
+		}
+
 		// Update ceremony using administrator
 		entityProvider := UpdateCeremony(p.db, p.log)(ceremonyId, updatedCeremony.ToEntity(), t.Id())
 		entity, err := entityProvider()
@@ -1148,37 +1526,47 @@ func (p *ProcessorImpl) RescheduleCeremony(ceremonyId uint32, newScheduledAt tim
 	}
 }
 
-// RescheduleCeremonyAndEmit reschedules a ceremony and emits events
+// RescheduleCeremonyAndEmit reschedules a ceremony, has its saga reserve the chapel for the new time and emits events
 func (p *ProcessorImpl) RescheduleCeremonyAndEmit(transactionId uuid.UUID, ceremonyId uint32, newScheduledAt time.Time, rescheduledBy uint32) (Ceremony, error) {
-	ceremony, err := p.RescheduleCeremony(ceremonyId, newScheduledAt)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.RescheduleCeremony(ceremonyId, newScheduledAt)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	// Emit CeremonyRescheduled event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		rescheduledAt := time.Now()
-		eventProvider := CeremonyRescheduledEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			rescheduledAt,
-			newScheduledAt,
-			rescheduledBy,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Ceremony{}, err
-	}
+		// A chapel reserved for the previous time is released before it is reserved for the new one
+		if err = p.rewindCeremonySaga(ceremony); err != nil {
+			return Ceremony{}, err
+		}
+		if err = p.resumeCeremonySaga(ceremony); err != nil {
+			return Ceremony{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-	}).Debug("CeremonyRescheduled event emitted")
+		// Emit CeremonyRescheduled event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			rescheduledAt := time.Now()
+			eventProvider := CeremonyRescheduledEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				rescheduledAt,
+				newScheduledAt,
+				rescheduledBy,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	return ceremony, nil
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+		}).Debug("CeremonyRescheduled event emitted")
+
+		return ceremony, nil
+	})
 }
 
 // AddInvitee adds an invitee to a ceremony
@@ -1199,12 +1587,12 @@ func (p *ProcessorImpl) AddInvitee(ceremonyId uint32, characterId uint32) model.
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate invitee addition
-		if !ceremony.CanAddInvitee(characterId) {
-			return Ceremony{}, errors.New("invitee cannot be added to ceremony")
+		if err := inviteeAdditionError(*ceremony, characterId); err != nil {
+			return Ceremony{}, err
 		}
 
 		// Add invitee
@@ -1237,25 +1625,27 @@ func (p *ProcessorImpl) AddInvitee(ceremonyId uint32, characterId uint32) model.
 
 // AddInviteeAndEmit adds an invitee and emits events
 func (p *ProcessorImpl) AddInviteeAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, addedBy uint32) (Ceremony, error) {
-	ceremony, err := p.AddInvitee(ceremonyId, characterId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.AddInvitee(ceremonyId, characterId)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	// Emit InviteeAdded event
-	return ceremony, message.Emit(p.producer)(func(mb *message.Buffer) error {
-		now := time.Now()
-		inviteeAddedProvider := InviteeAddedEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			characterId,
-			now,
-			addedBy,
-		)
-
-		return mb.Put(marriageMsg.EnvEventTopicStatus, inviteeAddedProvider)
+		// Emit InviteeAdded event
+		return ceremony, message.Emit(p.producer)(func(mb *message.Buffer) error {
+			now := time.Now()
+			inviteeAddedProvider := InviteeAddedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				now,
+				addedBy,
+			)
+
+			return mb.Put(marriageMsg.EnvEventTopicStatus, inviteeAddedProvider)
+		})
 	})
 }
 
@@ -1277,12 +1667,12 @@ func (p *ProcessorImpl) RemoveInvitee(ceremonyId uint32, characterId uint32) mod
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Validate invitee removal
-		if !ceremony.CanRemoveInvitee(characterId) {
-			return Ceremony{}, errors.New("invitee cannot be removed from ceremony")
+		if err := inviteeRemovalError(*ceremony, characterId); err != nil {
+			return Ceremony{}, err
 		}
 
 		// Remove invitee
@@ -1315,25 +1705,27 @@ func (p *ProcessorImpl) RemoveInvitee(ceremonyId uint32, characterId uint32) mod
 
 // RemoveInviteeAndEmit removes an invitee and emits events
 func (p *ProcessorImpl) RemoveInviteeAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, removedBy uint32) (Ceremony, error) {
-	ceremony, err := p.RemoveInvitee(ceremonyId, characterId)()
-	if err != nil {
-		return Ceremony{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.RemoveInvitee(ceremonyId, characterId)()
+		if err != nil {
+			return Ceremony{}, err
+		}
 
-	// Emit InviteeRemoved event
-	return ceremony, message.Emit(p.producer)(func(mb *message.Buffer) error {
-		now := time.Now()
-		inviteeRemovedProvider := InviteeRemovedEventProvider(
-			ceremony.Id(),
-			ceremony.MarriageId(),
-			ceremony.CharacterId1(),
-			ceremony.CharacterId2(),
-			characterId,
-			now,
-			removedBy,
-		)
-
-		return mb.Put(marriageMsg.EnvEventTopicStatus, inviteeRemovedProvider)
+		// Emit InviteeRemoved event
+		return ceremony, message.Emit(p.producer)(func(mb *message.Buffer) error {
+			now := time.Now()
+			inviteeRemovedProvider := InviteeRemovedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				now,
+				removedBy,
+			)
+
+			return mb.Put(marriageMsg.EnvEventTopicStatus, inviteeRemovedProvider)
+		})
 	})
 }
 
@@ -1377,98 +1769,124 @@ func (p *ProcessorImpl) GetActiveCeremonies() model.Provider[[]Ceremony] {
 	}
 }
 
-// Divorce divorces a marriage
+// Divorce divorces a marriage immediately, debiting the tenant's divorce cost from the initiating partner. Tenants
+// requiring divorces to be filed reject it in favour of FileDivorce. The divorce is made in its own transaction, so the
+// cost is refunded if it cannot be recorded
 func (p *ProcessorImpl) Divorce(marriageId uint32, initiatedBy uint32) model.Provider[Marriage] {
 	return func() (Marriage, error) {
-		p.log.WithFields(logrus.Fields{
-			"marriageId":  marriageId,
-			"initiatedBy": initiatedBy,
-		}).Debug("Processing divorce")
-
-		// Get tenant from context
-		t := tenant.MustFromContext(p.ctx)
+		transactionId := uuid.New()
+		return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+			return p.divorce(transactionId, marriageId, initiatedBy)
+		})
+	}
+}
 
-		// Get the marriage
-		marriageProvider := GetMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())
-		marriage, err := marriageProvider()
-		if err != nil {
-			return Marriage{}, err
-		}
-		if marriage == nil {
-			return Marriage{}, errors.New("marriage not found")
-		}
+// divorce divorces a marriage, debiting the divorce cost from the initiating partner before the marriage is changed. The
+// debit is keyed by the divorcing transaction, and refunded if the processor's transaction rolls back
+func (p *ProcessorImpl) divorce(transactionId uuid.UUID, marriageId uint32, initiatedBy uint32) (Marriage, error) {
+	p.log.WithFields(logrus.Fields{
+		"marriageId":  marriageId,
+		"initiatedBy": initiatedBy,
+	}).Debug("Processing divorce")
 
-		// Check if marriage can be divorced
-		if !marriage.CanDivorce() {
-			return Marriage{}, errors.New("marriage cannot be divorced")
-		}
+	// Get tenant from context
+	t := tenant.MustFromContext(p.ctx)
 
-		// Verify that the initiatedBy character is one of the partners
-		if !marriage.IsPartner(initiatedBy) {
-			return Marriage{}, errors.New("only married partners can initiate divorce")
-		}
+	// Get the marriage, locked so a divorce filed meanwhile is seen
+	marriageProvider := LockMarriageByIdProvider(p.db, p.log)(marriageId, t.Id())
+	marriage, err := marriageProvider()
+	if err != nil {
+		return Marriage{}, err
+	}
+	if marriage == nil {
+		return Marriage{}, ErrMarriageNotFound
+	}
 
-		// Divorce the marriage
-		divorcedMarriage, err := marriage.Divorce()
-		if err != nil {
-			return Marriage{}, err
-		}
+	// Check if marriage can be divorced
+	if !marriage.CanDivorce() {
+		return Marriage{}, marriageTransitionError(*marriage, StatusDivorced)
+	}
 
-		// Update the marriage in the database
-		updateMarriageProvider := UpdateMarriage(p.db, p.log)(divorcedMarriage)
-		updatedEntity, err := updateMarriageProvider()
-		if err != nil {
-			return Marriage{}, err
-		}
+	// Verify that the initiatedBy character is one of the partners
+	if !marriage.IsPartner(initiatedBy) {
+		return Marriage{}, ErrNotMarriagePartner
+	}
 
-		// Transform entity to domain model
-		result, err := Make(updatedEntity)
-		if err != nil {
-			return Marriage{}, err
-		}
+	// Tenants requiring divorces to be filed do not grant them unilaterally
+	if p.rules().DivorceFilingRequired() {
+		return Marriage{}, ErrDivorceFilingRequired
+	}
 
-		p.log.WithFields(logrus.Fields{
-			"marriageId":  marriageId,
-			"initiatedBy": initiatedBy,
-		}).Info("Marriage divorced successfully")
+	// The initiating partner pays for the divorce
+	if _, err = p.debit(paymentId(transactionId, paymentReasonDivorce), initiatedBy, p.rules().DivorceCost(), paymentReasonDivorce); err != nil {
+		return Marriage{}, err
+	}
 
-		return result, nil
+	// Divorce the marriage
+	divorcedMarriage, err := marriage.Divorce()
+	if err != nil {
+		return Marriage{}, err
 	}
-}
 
-// DivorceAndEmit divorces a marriage and emits events
-func (p *ProcessorImpl) DivorceAndEmit(transactionId uuid.UUID, marriageId uint32, initiatedBy uint32) (Marriage, error) {
-	marriage, err := p.Divorce(marriageId, initiatedBy)()
+	// Update the marriage in the database
+	updateMarriageProvider := UpdateMarriage(p.db, p.log)(divorcedMarriage)
+	updatedEntity, err := updateMarriageProvider()
 	if err != nil {
 		return Marriage{}, err
 	}
 
-	// Emit MarriageDivorced event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		divorcedAt := time.Now()
-		if marriage.DivorcedAt() != nil {
-			divorcedAt = *marriage.DivorcedAt()
-		}
-		eventProvider := MarriageDivorcedEventProvider(
-			marriageId,
-			marriage.CharacterId1(),
-			marriage.CharacterId2(),
-			divorcedAt,
-			initiatedBy,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
+	// Transform entity to domain model
+	result, err := Make(updatedEntity)
 	if err != nil {
 		return Marriage{}, err
 	}
 
 	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"marriageId":    marriageId,
-		"initiatedBy":   initiatedBy,
-	}).Debug("MarriageDivorced event emitted")
+		"marriageId":  marriageId,
+		"initiatedBy": initiatedBy,
+	}).Info("Marriage divorced successfully")
+
+	return result, nil
+}
+
+// DivorceAndEmit divorces a marriage and emits events
+func (p *ProcessorImpl) DivorceAndEmit(transactionId uuid.UUID, marriageId uint32, initiatedBy uint32) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Marriage, error) {
+		marriage, err := p.divorce(transactionId, marriageId, initiatedBy)
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		// Emit MarriageDivorced event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			divorcedAt := time.Now()
+			if marriage.DivorcedAt() != nil {
+				divorcedAt = *marriage.DivorcedAt()
+			}
+			eventProvider := MarriageDivorcedEventProvider(
+				marriageId,
+				marriage.CharacterId1(),
+				marriage.CharacterId2(),
+				divorcedAt,
+				initiatedBy,
+			)
+			if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+				return err
+			}
+			return p.putCoupleSkillChanges(buf, marriage, StatusMarried, marriage.BondLevel())
+		})
+		if err != nil {
+			return Marriage{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"marriageId":    marriageId,
+			"initiatedBy":   initiatedBy,
+		}).Debug("MarriageDivorced event emitted")
 
-	return marriage, nil
+		return marriage, nil
+	})
 }
 
 // AdvanceCeremonyState advances a ceremony to the next state
@@ -1489,7 +1907,7 @@ func (p *ProcessorImpl) AdvanceCeremonyState(ceremonyId uint32, nextState string
 			return Ceremony{}, err
 		}
 		if ceremony == nil {
-			return Ceremony{}, errors.New("ceremony not found")
+			return Ceremony{}, ErrCeremonyNotFound
 		}
 
 		// Apply state transition based on nextState
@@ -1497,24 +1915,28 @@ func (p *ProcessorImpl) AdvanceCeremonyState(ceremonyId uint32, nextState string
 		switch nextState {
 		case "active":
 			if !ceremony.CanStart() {
-				return Ceremony{}, errors.New("ceremony cannot be started")
+				return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusActive)
 			}
 			updatedCeremony, err = ceremony.Start()
 		case "completed":
-			if !ceremony.CanComplete() {
-				return Ceremony{}, errors.New("ceremony cannot be completed")
-			}
-			updatedCeremony, err = ceremony.Complete()
+			// Completion also marries the couple, so it follows the dedicated flow
+			return p.CompleteCeremony(ceremonyId)()
 		case "cancelled":
 			if !ceremony.CanCancel() {
-				return Ceremony{}, errors.New("ceremony cannot be cancelled")
+				return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusCancelled)
 			}
 			updatedCeremony, err = ceremony.Cancel()
+			if err == nil {
+				err = p.releaseVenue(*ceremony)
+			}
 		case "postponed":
 			if !ceremony.CanPostpone() {
-				return Ceremony{}, errors.New("ceremony cannot be postponed")
+				return Ceremony{}, ceremonyTransitionError(*ceremony, CeremonyStatusPostponed)
 			}
 			updatedCeremony, err = ceremony.Postpone()
+			if err == nil {
+				err = p.releaseVenue(*ceremony)
+			}
 		default:
 			return Ceremony{}, errors.New("invalid ceremony state: " + nextState)
 		}
@@ -1548,85 +1970,92 @@ func (p *ProcessorImpl) AdvanceCeremonyState(ceremonyId uint32, nextState string
 
 // AdvanceCeremonyStateAndEmit advances a ceremony state and emits appropriate events
 func (p *ProcessorImpl) AdvanceCeremonyStateAndEmit(transactionId uuid.UUID, ceremonyId uint32, nextState string) (Ceremony, error) {
-	ceremony, err := p.AdvanceCeremonyState(ceremonyId, nextState)()
-	if err != nil {
-		return Ceremony{}, err
+	// Completion emits both CeremonyCompleted and MarriageCreated events
+	if nextState == "completed" {
+		return p.CompleteCeremonyAndEmit(transactionId, ceremonyId)
 	}
 
-	// Emit appropriate event based on the new state
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		ceremony, err := p.AdvanceCeremonyState(ceremonyId, nextState)()
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		// Keep the ceremony saga in step with the ceremony
 		switch nextState {
 		case "active":
-			startedAt := time.Now()
-			if ceremony.StartedAt() != nil {
-				startedAt = *ceremony.StartedAt()
-			}
-			eventProvider := CeremonyStartedEventProvider(
-				ceremony.Id(),
-				ceremony.MarriageId(),
-				ceremony.CharacterId1(),
-				ceremony.CharacterId2(),
-				startedAt,
-			)
-			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-		case "completed":
-			completedAt := time.Now()
-			if ceremony.CompletedAt() != nil {
-				completedAt = *ceremony.CompletedAt()
-			}
-			eventProvider := CeremonyCompletedEventProvider(
-				ceremony.Id(),
-				ceremony.MarriageId(),
-				ceremony.CharacterId1(),
-				ceremony.CharacterId2(),
-				completedAt,
-			)
-			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+			err = p.resumeCeremonySaga(ceremony)
 		case "cancelled":
-			cancelledAt := time.Now()
-			if ceremony.CancelledAt() != nil {
-				cancelledAt = *ceremony.CancelledAt()
-			}
-			eventProvider := CeremonyCancelledEventProvider(
-				ceremony.Id(),
-				ceremony.MarriageId(),
-				ceremony.CharacterId1(),
-				ceremony.CharacterId2(),
-				cancelledAt,
-				0,                    // No specific character ID for state transitions
-				"ceremony_cancelled", // Default reason for state transitions
-			)
-			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+			err = p.abortCeremonySaga(ceremonyId, "ceremony_cancelled")
 		case "postponed":
-			postponedAt := time.Now()
-			if ceremony.PostponedAt() != nil {
-				postponedAt = *ceremony.PostponedAt()
+			err = p.rewindCeremonySaga(ceremony)
+		}
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		// Emit appropriate event based on the new state
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			switch nextState {
+			case "active":
+				startedAt := time.Now()
+				if ceremony.StartedAt() != nil {
+					startedAt = *ceremony.StartedAt()
+				}
+				eventProvider := CeremonyStartedEventProvider(
+					ceremony.Id(),
+					ceremony.MarriageId(),
+					ceremony.CharacterId1(),
+					ceremony.CharacterId2(),
+					startedAt,
+				)
+				return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+			case "cancelled":
+				cancelledAt := time.Now()
+				if ceremony.CancelledAt() != nil {
+					cancelledAt = *ceremony.CancelledAt()
+				}
+				eventProvider := CeremonyCancelledEventProvider(
+					ceremony.Id(),
+					ceremony.MarriageId(),
+					ceremony.CharacterId1(),
+					ceremony.CharacterId2(),
+					cancelledAt,
+					0,                    // No specific character ID for state transitions
+					"ceremony_cancelled", // Default reason for state transitions
+				)
+				return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+			case "postponed":
+				postponedAt := time.Now()
+				if ceremony.PostponedAt() != nil {
+					postponedAt = *ceremony.PostponedAt()
+				}
+				eventProvider := CeremonyPostponedEventProvider(
+					ceremony.Id(),
+					ceremony.MarriageId(),
+					ceremony.CharacterId1(),
+					ceremony.CharacterId2(),
+					postponedAt,
+					"ceremony_postponed", // Default reason for state transitions
+				)
+				return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+			default:
+				// No event to emit for unknown states
+				return nil
 			}
-			eventProvider := CeremonyPostponedEventProvider(
-				ceremony.Id(),
-				ceremony.MarriageId(),
-				ceremony.CharacterId1(),
-				ceremony.CharacterId2(),
-				postponedAt,
-				"ceremony_postponed", // Default reason for state transitions
-			)
-			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-		default:
-			// No event to emit for unknown states
-			return nil
+		})
+		if err != nil {
+			return Ceremony{}, err
 		}
-	})
-	if err != nil {
-		return Ceremony{}, err
-	}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"ceremonyId":    ceremonyId,
-		"nextState":     nextState,
-	}).Debug("Ceremony state advanced and event emitted")
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"nextState":     nextState,
+		}).Debug("Ceremony state advanced and event emitted")
 
-	return ceremony, nil
+		return ceremony, nil
+	})
 }
 
 // AcceptProposalWithTransactionAndEmit provides full transactional consistency for proposal acceptance
@@ -1634,7 +2063,7 @@ func (p *ProcessorImpl) AdvanceCeremonyStateAndEmit(transactionId uuid.UUID, cer
 // changes and event emissions that must all succeed or fail together
 func (p *ProcessorImpl) AcceptProposalWithTransactionAndEmit(transactionId uuid.UUID, proposalId uint32) (Marriage, error) {
 	// Execute the entire operation within a database transaction
-	return p.executeInTransaction(func(txProcessor *ProcessorImpl) (Marriage, error) {
+	return emitTransactionally(p, transactionId, func(txProcessor *ProcessorImpl) (Marriage, error) {
 		// Get tenant from context
 		t := tenant.MustFromContext(p.ctx)
 
@@ -1647,7 +2076,7 @@ func (p *ProcessorImpl) AcceptProposalWithTransactionAndEmit(transactionId uuid.
 
 		// Check if proposal can be accepted
 		if !proposal.CanRespond() {
-			return Marriage{}, errors.New("proposal cannot be accepted")
+			return Marriage{}, proposalTransitionError(proposal, ProposalStatusAccepted)
 		}
 
 		// Accept the proposal
@@ -1698,6 +2127,9 @@ func (p *ProcessorImpl) AcceptProposalWithTransactionAndEmit(transactionId uuid.
 					return Marriage{}, err
 				}
 
+				// The ring is consumed once the acceptance commits, so a rolled back acceptance leaves it reserved
+				txProcessor.consumeEngagementRing(proposal)
+
 				// Buffer ProposalAccepted event
 				acceptedAt := time.Now()
 				proposalAcceptedProvider := ProposalAcceptedEventProvider(
@@ -1725,6 +2157,11 @@ func (p *ProcessorImpl) AcceptProposalWithTransactionAndEmit(transactionId uuid.
 					return Marriage{}, err
 				}
 
+				// Buffer grants of couple skills unlocked from engagement
+				if err := txProcessor.putCoupleSkillChanges(buf, result, StatusProposed, 0); err != nil {
+					return Marriage{}, err
+				}
+
 				p.log.WithFields(logrus.Fields{
 					"transactionId": transactionId,
 					"proposalId":    proposalId,
@@ -1737,37 +2174,62 @@ func (p *ProcessorImpl) AcceptProposalWithTransactionAndEmit(transactionId uuid.
 	})
 }
 
-// executeInTransaction wraps business logic in a database transaction
-// This ensures both database operations and message emission are transactionally consistent
-func (p *ProcessorImpl) executeInTransaction(operation func(*ProcessorImpl) (Marriage, error)) (Marriage, error) {
-	// Begin database transaction
-	tx := p.db.Begin()
-	if tx.Error != nil {
-		return Marriage{}, tx.Error
+// emitInTransaction runs an operation within a database transaction, staging every event it emits in the
+// outbox under the transaction id alongside its database changes. Once the transaction commits the staged
+// events are dispatched; anything that fails to publish is retried by the outbox relay. An operation nested in
+// a transaction the processor began joins it, staging its events in the same batch. Calls to other services deferred
+// with afterCommit are made once the transaction commits, or once the operation succeeds when it joins a
+// transaction the processor did not begin. Compensations registered with onRollback are made when it rolls back
+func (p *ProcessorImpl) emitInTransaction(transactionId uuid.UUID, operation func(*ProcessorImpl) error) error {
+	if p.scope != nil {
+		return operation(p)
+	}
+
+	scope := &transactionScope{}
+	err := database.ExecuteTransaction(p.db, func(tx *gorm.DB) error {
+		scope.batch = outbox.NewBatch(p.log, p.ctx, tx, transactionId)
+
+		// Create a new processor with the transaction DB which emits to the outbox
+		txProcessor := &ProcessorImpl{
+			log:                p.log,
+			ctx:                p.ctx,
+			db:                 tx,
+			producer:           scope.batch.Provider,
+			characterProcessor: p.characterProcessor,
+			inventoryProcessor: p.inventoryProcessor,
+			economyProcessor:   p.economyProcessor,
+			scope:              scope,
+		}
+		return operation(txProcessor)
+	})
+	if err != nil {
+		scope.runRolledBack()
+		return err
 	}
 
-	// Create a new processor with the transaction DB
-	txProcessor := &ProcessorImpl{
-		log:                p.log,
-		ctx:                p.ctx,
-		db:                 tx,
-		producer:           p.producer,
-		characterProcessor: p.characterProcessor,
+	// An enclosing transaction may still roll back, so leave dispatch to the relay
+	if database.IsTransaction(p.db) {
+		scope.runCommitted()
+		return nil
 	}
 
-	// Execute the operation
-	result, err := operation(txProcessor)
-	if err != nil {
-		// Rollback on error
-		tx.Rollback()
-		return Marriage{}, err
-	}
+	scope.batch.Dispatch(p.db, p.producer)
+	scope.runCommitted()
+	return nil
+}
 
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return Marriage{}, err
+// emitTransactionally runs an operation producing a result via emitInTransaction
+func emitTransactionally[M any](p *ProcessorImpl, transactionId uuid.UUID, operation func(*ProcessorImpl) (M, error)) (M, error) {
+	var result M
+	err := p.emitInTransaction(transactionId, func(txProcessor *ProcessorImpl) error {
+		var err error
+		result, err = operation(txProcessor)
+		return err
+	})
+	if err != nil {
+		var zero M
+		return zero, err
 	}
-
 	return result, nil
 }
 
@@ -1808,7 +2270,7 @@ func (p *ProcessorImpl) ExpireProposal(proposalId uint32) model.Provider[Proposa
 
 		// Check if proposal can be expired
 		if proposal.Status() != ProposalStatusPending {
-			return Proposal{}, errors.New("only pending proposals can be expired")
+			return Proposal{}, proposalTransitionError(proposal, ProposalStatusExpired)
 		}
 
 		// Expire the proposal
@@ -1824,6 +2286,9 @@ func (p *ProcessorImpl) ExpireProposal(proposalId uint32) model.Provider[Proposa
 			return Proposal{}, err
 		}
 
+		// Return the engagement ring once the expiry commits
+		p.returnEngagementRing(expiredProposal)
+
 		p.log.WithField("proposalId", proposalId).Info("Proposal expired successfully")
 
 		return expiredProposal, nil
@@ -1832,32 +2297,34 @@ func (p *ProcessorImpl) ExpireProposal(proposalId uint32) model.Provider[Proposa
 
 // ExpireProposalAndEmit expires a proposal and emits events
 func (p *ProcessorImpl) ExpireProposalAndEmit(transactionId uuid.UUID, proposalId uint32) (Proposal, error) {
-	proposal, err := p.ExpireProposal(proposalId)()
-	if err != nil {
-		return Proposal{}, err
-	}
-
-	// Emit ProposalExpired event
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		expiredAt := time.Now()
-		eventProvider := ProposalExpiredEventProvider(
-			proposalId,
-			proposal.ProposerId(),
-			proposal.TargetId(),
-			expiredAt,
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
-	if err != nil {
-		return Proposal{}, err
-	}
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Proposal, error) {
+		proposal, err := p.ExpireProposal(proposalId)()
+		if err != nil {
+			return Proposal{}, err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"proposalId":    proposalId,
-	}).Debug("ProposalExpired event emitted")
+		// Emit ProposalExpired event
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			expiredAt := time.Now()
+			eventProvider := ProposalExpiredEventProvider(
+				proposalId,
+				proposal.ProposerId(),
+				proposal.TargetId(),
+				expiredAt,
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Proposal{}, err
+		}
 
-	return proposal, nil
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"proposalId":    proposalId,
+		}).Debug("ProposalExpired event emitted")
+
+		return proposal, nil
+	})
 }
 
 // ProcessExpiredProposals processes all expired proposals for all tenants
@@ -1902,7 +2369,8 @@ func (p *ProcessorImpl) ProcessExpiredProposals() error {
 	return nil
 }
 
-// ProcessCeremonyTimeouts processes all active ceremonies that may have timed out
+// ProcessCeremonyTimeouts postpones all active ceremonies in which a partner has been disconnected for longer than the
+// disconnection timeout
 func (p *ProcessorImpl) ProcessCeremonyTimeouts() error {
 	p.log.Debug("Processing ceremony timeouts")
 
@@ -1948,143 +2416,240 @@ func (p *ProcessorImpl) ProcessCeremonyTimeouts() error {
 	return nil
 }
 
-// HandleCharacterDeletion handles automatic divorce when a character is deleted
-func (p *ProcessorImpl) HandleCharacterDeletion(characterId uint32) error {
-	p.log.WithField("characterId", characterId).Debug("Processing character deletion for marriage cleanup")
-
-	// Get tenant from context
-	t := tenant.MustFromContext(p.ctx)
+// characterDeletionReason is the reason recorded on events caused by a character being deleted
+const characterDeletionReason = "character_deleted"
+
+// characterDeletion records the changes made when cleaning up after a deleted character
+type characterDeletion struct {
+	deletedAt          time.Time
+	marriage           *Marriage
+	previousStatus     MarriageStatus
+	cancelledCeremony  *Ceremony
+	cancelledProposals []Proposal
+	strippedCeremonies []Ceremony
+}
 
-	// Get any active marriage for this character
-	marriageProvider := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())
-	marriage, err := marriageProvider()
+// HandleCharacterDeletion ends the marriage of a deleted character, cancels its ceremony and pending proposals, and
+// removes the character from every ceremony it is invited to. The rings of the cancelled proposals are returned once
+// the transaction commits
+func (p *ProcessorImpl) HandleCharacterDeletion(characterId uint32) error {
+	scope := &transactionScope{}
+	err := database.ExecuteTransaction(p.db, func(tx *gorm.DB) error {
+		txProcessor := &ProcessorImpl{
+			log:                p.log,
+			ctx:                p.ctx,
+			db:                 tx,
+			producer:           p.producer,
+			characterProcessor: p.characterProcessor,
+			inventoryProcessor: p.inventoryProcessor,
+			economyProcessor:   p.economyProcessor,
+			scope:              scope,
+		}
+		_, err := txProcessor.deleteCharacter(characterId)
+		return err
+	})
 	if err != nil {
-		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to retrieve active marriage for character deletion")
+		scope.runRolledBack()
 		return err
 	}
+	scope.runCommitted()
+	return nil
+}
 
-	// If no active marriage, nothing to do
-	if marriage == nil {
-		p.log.WithField("characterId", characterId).Debug("No active marriage found for deleted character")
-		return nil
-	}
+// HandleCharacterDeletionAndEmit handles character deletion and emits an event for every change in one transaction
+func (p *ProcessorImpl) HandleCharacterDeletionAndEmit(transactionId uuid.UUID, characterId uint32) error {
+	return p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		p.log.WithFields(logrus.Fields{
+			"characterId":   characterId,
+			"transactionId": transactionId,
+		}).Debug("Processing character deletion with event emission")
 
-	// Mark the marriage as deleted due to character deletion
-	now := time.Now()
-	builder := marriage.Builder().
-		SetStatus(StatusDivorced). // Use divorced status as there's no separate deleted status
-		SetDivorcedAt(&now).
-		SetUpdatedAt(now)
+		deletion, err := p.deleteCharacter(characterId)
+		if err != nil {
+			return err
+		}
+		if ceremony := deletion.cancelledCeremony; ceremony != nil {
+			if err = p.abortCeremonySaga(ceremony.Id(), characterDeletionReason); err != nil {
+				return err
+			}
+		}
 
-	// If marriage is not yet married (e.g., still engaged), set a married timestamp
-	// This is required by business rules for divorced status
-	if marriage.Status() == StatusEngaged && marriage.MarriedAt() == nil {
-		builder = builder.SetMarriedAt(&now) // Set to same time as divorce for deleted characters
-	}
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			for _, proposal := range deletion.cancelledProposals {
+				eventProvider := ProposalCancelledEventProvider(
+					proposal.Id(),
+					proposal.ProposerId(),
+					proposal.TargetId(),
+					deletion.deletedAt,
+				)
+				if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+					return err
+				}
+			}
 
-	deletedMarriage, err := builder.Build()
+			if ceremony := deletion.cancelledCeremony; ceremony != nil {
+				eventProvider := CeremonyCancelledEventProvider(
+					ceremony.Id(),
+					ceremony.MarriageId(),
+					ceremony.CharacterId1(),
+					ceremony.CharacterId2(),
+					deletion.deletedAt,
+					characterId,
+					characterDeletionReason,
+				)
+				if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+					return err
+				}
+			}
 
-	if err != nil {
-		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to build deleted marriage")
-		return err
-	}
+			for _, ceremony := range deletion.strippedCeremonies {
+				eventProvider := InviteeRemovedEventProvider(
+					ceremony.Id(),
+					ceremony.MarriageId(),
+					ceremony.CharacterId1(),
+					ceremony.CharacterId2(),
+					characterId,
+					deletion.deletedAt,
+					characterId,
+				)
+				if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+					return err
+				}
+			}
 
-	// Update the marriage in the database
-	updateMarriageProvider := UpdateMarriage(p.db, p.log)(deletedMarriage)
-	_, err = updateMarriageProvider()
-	if err != nil {
-		p.log.WithError(err).WithFields(logrus.Fields{
-			"marriageId":  marriage.Id(),
-			"characterId": characterId,
-		}).Error("Failed to update marriage for character deletion")
-		return err
-	}
+			if marriage := deletion.marriage; marriage != nil {
+				eventProvider := MarriageDeletedEventProvider(
+					marriage.Id(),
+					marriage.CharacterId1(),
+					marriage.CharacterId2(),
+					*marriage.DeletedAt(),
+					characterId, // The deleted character initiated the deletion
+					characterDeletionReason,
+				)
+				if err := buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider); err != nil {
+					return err
+				}
+				if err := p.putCoupleSkillChanges(buf, *marriage, deletion.previousStatus, marriage.BondLevel()); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			p.log.WithError(err).WithField("characterId", characterId).Error("Failed to process character deletion")
+			return err
+		}
 
-	p.log.WithFields(logrus.Fields{
-		"marriageId":  marriage.Id(),
-		"characterId": characterId,
-	}).Info("Marriage automatically ended due to character deletion")
+		p.log.WithFields(logrus.Fields{
+			"transactionId":      transactionId,
+			"characterId":        characterId,
+			"proposalsCancelled": len(deletion.cancelledProposals),
+			"ceremonyCancelled":  deletion.cancelledCeremony != nil,
+			"invitationsRemoved": len(deletion.strippedCeremonies),
+			"marriageEnded":      deletion.marriage != nil,
+		}).Info("Character deletion processed successfully with events")
 
-	return nil
+		return nil
+	})
 }
 
-// HandleCharacterDeletionAndEmit handles character deletion and emits appropriate events
-func (p *ProcessorImpl) HandleCharacterDeletionAndEmit(transactionId uuid.UUID, characterId uint32) error {
-	p.log.WithFields(logrus.Fields{
-		"characterId":   characterId,
-		"transactionId": transactionId,
-	}).Debug("Processing character deletion with event emission")
+// deleteCharacter performs the clean up for a deleted character and reports what was changed
+func (p *ProcessorImpl) deleteCharacter(characterId uint32) (characterDeletion, error) {
+	p.log.WithField("characterId", characterId).Debug("Processing character deletion for marriage cleanup")
 
 	// Get tenant from context
 	t := tenant.MustFromContext(p.ctx)
 
+	deletion := characterDeletion{deletedAt: time.Now()}
+
+	// Cancel every pending proposal to or from the character
+	proposals, err := GetPendingProposalsByCharacterProvider(p.db, p.log)(characterId, t.Id())()
+	if err != nil {
+		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to retrieve pending proposals for character deletion")
+		return characterDeletion{}, err
+	}
+	for _, proposal := range proposals {
+		cancelledProposal, err := proposal.Cancel()
+		if err != nil {
+			return characterDeletion{}, err
+		}
+		if _, err = UpdateProposal(p.db, p.log)(cancelledProposal)(); err != nil {
+			return characterDeletion{}, err
+		}
+		// The ring is returned once the deletion commits, which the inventory service never blocks
+		p.returnEngagementRing(cancelledProposal)
+		deletion.cancelledProposals = append(deletion.cancelledProposals, cancelledProposal)
+	}
+
+	// Remove the character from other couples' ceremonies
+	ceremonies, err := GetOpenCeremoniesByInviteeProvider(p.db, p.log)(characterId, t.Id())()
+	if err != nil {
+		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to retrieve ceremonies for character deletion")
+		return characterDeletion{}, err
+	}
+	for _, ceremony := range ceremonies {
+		strippedCeremony, err := ceremony.StripInvitee(characterId)
+		if err != nil {
+			return characterDeletion{}, err
+		}
+		if _, err = UpdateCeremony(p.db, p.log)(ceremony.Id(), strippedCeremony.ToEntity(), t.Id())(); err != nil {
+			return characterDeletion{}, err
+		}
+		deletion.strippedCeremonies = append(deletion.strippedCeremonies, strippedCeremony)
+	}
+
 	// Get any active marriage for this character
-	marriageProvider := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())
-	marriage, err := marriageProvider()
+	marriage, err := GetActiveMarriageByCharacterProvider(p.db, p.log)(characterId, t.Id())()
 	if err != nil {
 		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to retrieve active marriage for character deletion")
-		return err
+		return characterDeletion{}, err
 	}
-
-	// If no active marriage, nothing to do
 	if marriage == nil {
 		p.log.WithField("characterId", characterId).Debug("No active marriage found for deleted character")
-		return nil
+		return deletion, nil
 	}
 
-	// Process the deletion with events
-	err = message.Emit(p.producer)(func(buf *message.Buffer) error {
-		// Mark the marriage as deleted due to character deletion
-		now := time.Now()
-		builder := marriage.Builder().
-			SetStatus(StatusDivorced). // Use divorced status as there's no separate deleted status
-			SetDivorcedAt(&now).
-			SetUpdatedAt(now)
-
-		// If marriage is not yet married (e.g., still engaged), set a married timestamp
-		// This is required by business rules for divorced status
-		if marriage.Status() == StatusEngaged && marriage.MarriedAt() == nil {
-			builder = builder.SetMarriedAt(&now) // Set to same time as divorce for deleted characters
-		}
-
-		deletedMarriage, err := builder.Build()
-
+	// Cancel the couple's ceremony if it has not finished
+	ceremony, err := GetCeremonyByMarriageProvider(p.db, p.log)(marriage.Id(), t.Id())()
+	if err != nil {
+		return characterDeletion{}, err
+	}
+	if ceremony != nil && ceremony.CanCancel() {
+		cancelledCeremony, err := ceremony.Cancel()
 		if err != nil {
-			return err
+			return characterDeletion{}, err
 		}
-
-		// Update the marriage in the database
-		updateMarriageProvider := UpdateMarriage(p.db, p.log)(deletedMarriage)
-		_, err = updateMarriageProvider()
-		if err != nil {
-			return err
+		if _, err = UpdateCeremony(p.db, p.log)(ceremony.Id(), cancelledCeremony.ToEntity(), t.Id())(); err != nil {
+			return characterDeletion{}, err
 		}
+		if err = p.releaseVenue(*ceremony); err != nil {
+			return characterDeletion{}, err
+		}
+		deletion.cancelledCeremony = &cancelledCeremony
+	}
 
-		// Emit MarriageDeleted event for character deletion
-		deletedAt := now
-		eventProvider := MarriageDeletedEventProvider(
-			marriage.Id(),
-			marriage.CharacterId1(),
-			marriage.CharacterId2(),
-			deletedAt,
-			characterId, // The deleted character initiated the deletion
-			"character_deleted",
-		)
-		return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
-	})
+	// Mark the marriage as deleted due to character deletion
+	deletedMarriage, err := marriage.Delete(characterDeletionReason)
 	if err != nil {
+		p.log.WithError(err).WithField("characterId", characterId).Error("Failed to build deleted marriage")
+		return characterDeletion{}, err
+	}
+
+	// Update the marriage in the database
+	if _, err = UpdateMarriage(p.db, p.log)(deletedMarriage)(); err != nil {
 		p.log.WithError(err).WithFields(logrus.Fields{
 			"marriageId":  marriage.Id(),
 			"characterId": characterId,
-		}).Error("Failed to process character deletion")
-		return err
+		}).Error("Failed to update marriage for character deletion")
+		return characterDeletion{}, err
 	}
+	deletion.marriage = &deletedMarriage
+	deletion.previousStatus = marriage.Status()
 
 	p.log.WithFields(logrus.Fields{
-		"transactionId": transactionId,
-		"marriageId":    marriage.Id(),
-		"characterId":   characterId,
-	}).Info("Character deletion processed successfully with events")
+		"marriageId":  marriage.Id(),
+		"characterId": characterId,
+	}).Info("Marriage automatically ended due to character deletion")
 
-	return nil
+	return deletion, nil
 }
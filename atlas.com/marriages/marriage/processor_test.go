@@ -2,11 +2,21 @@ package marriage
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"atlas-marriages/character"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/outbox"
+	"atlas-marriages/registry"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+	"atlas-marriages/transaction"
+	"atlas-marriages/venue"
 	kafkaProducer "github.com/Chronicle20/atlas-kafka/producer"
 	"github.com/Chronicle20/atlas-model/model"
 	"github.com/Chronicle20/atlas-tenant"
@@ -20,7 +30,48 @@ import (
 
 // setupTestDB creates an in-memory SQLite database for testing
 func setupTestDB(t *testing.T) *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+	return openTestDB(t, ":memory:")
+}
+
+// setupConcurrentTestDB creates a file backed test database shared by concurrent connections. Write transactions
+// begin immediately and wait for one another, standing in for the row locks taken in production
+func setupConcurrentTestDB(t *testing.T) *gorm.DB {
+	return openTestDB(t, filepath.Join(t.TempDir(), "marriages.db")+"?_busy_timeout=10000&_txlock=immediate")
+}
+
+// runConcurrently runs an operation from several processors at once, each emitting to its own mock producer, and
+// returns the types of the events emitted by every run
+func runConcurrently(t *testing.T, db *gorm.DB, tenantId uuid.UUID, runs int, operation func(Processor) error) []string {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	producers := make([]*MockProducer, runs)
+	errs := make([]error, runs)
+	var wg sync.WaitGroup
+	for i := range producers {
+		producers[i] = NewMockProducer()
+		processor := NewProcessor(log, setupTestContext(tenantId), db).WithProducer(producers[i].Provider)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = operation(processor)
+		}(i)
+	}
+	wg.Wait()
+
+	types := make([]string, 0)
+	for i, producer := range producers {
+		if errs[i] != nil {
+			t.Errorf("Run %d failed: %v", i, errs[i])
+		}
+		types = append(types, producedEventTypes(t, producer)...)
+	}
+	return types
+}
+
+// openTestDB opens and migrates the test database at the given data source
+func openTestDB(t *testing.T, dsn string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
 		Logger: logger.New(
 			logrus.StandardLogger(),
 			logger.Config{
@@ -35,7 +86,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	}
 
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &AnniversaryEntity{}, &PresenceEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{}, &venue.Entity{}, &venue.BookingEntity{}, &registry.ItemEntity{}, &registry.GiftEntity{}, &registry.BlessingEntity{}, &transaction.Entity{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -93,6 +144,7 @@ func (m *MockCharacterProcessor) ByIdProvider(characterId uint32) model.Provider
 // MockProducer provides a mock implementation for Kafka producer testing
 type MockProducer struct {
 	messagesProduced []kafka.Message
+	topicsProduced   []string
 	shouldError      bool
 	errorMessage     string
 }
@@ -113,8 +165,14 @@ func (m *MockProducer) GetProducedMessages() []kafka.Message {
 	return m.messagesProduced
 }
 
+// GetProducedTopics returns the topic each produced message was sent to, in the order of the messages
+func (m *MockProducer) GetProducedTopics() []string {
+	return m.topicsProduced
+}
+
 func (m *MockProducer) ClearMessages() {
 	m.messagesProduced = make([]kafka.Message, 0)
+	m.topicsProduced = nil
 }
 
 func (m *MockProducer) Provider(token string) kafkaProducer.MessageProducer {
@@ -129,6 +187,9 @@ func (m *MockProducer) Provider(token string) kafkaProducer.MessageProducer {
 		}
 
 		m.messagesProduced = append(m.messagesProduced, messages...)
+		for range messages {
+			m.topicsProduced = append(m.topicsProduced, token)
+		}
 		return nil
 	}
 }
@@ -864,6 +925,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 		proposerId  uint32
 		targetId    uint32
 		expectError bool
+		expectedErr error
 		description string
 	}{
 		{
@@ -871,6 +933,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			proposerId:  100, // This character is married in test data
 			targetId:    2,
 			expectError: true,
+			expectedErr: ErrCharacterAlreadyMarried,
 			description: "Should fail when proposer is already married",
 		},
 		{
@@ -878,6 +941,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			proposerId:  1,
 			targetId:    101, // This character is married in test data
 			expectError: true,
+			expectedErr: ErrCharacterAlreadyMarried,
 			description: "Should fail when target is already married",
 		},
 		{
@@ -885,6 +949,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			proposerId:  500, // This character has pending proposal in test data
 			targetId:    501,
 			expectError: true,
+			expectedErr: ErrTargetAlreadyEngaged,
 			description: "Should fail when active proposal already exists",
 		},
 		{
@@ -892,6 +957,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			proposerId:  200, // This character has proposal 2 hours ago (within 4-hour cooldown)
 			targetId:    2,
 			expectError: true,
+			expectedErr: ErrGlobalCooldownActive,
 			description: "Should fail when proposer is in global cooldown",
 		},
 		{
@@ -899,6 +965,7 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			proposerId:  300, // This character has rejected proposal to 301 with cooldown
 			targetId:    301,
 			expectError: true,
+			expectedErr: ErrTargetCooldownActive,
 			description: "Should fail when proposer is in per-target cooldown",
 		},
 	}
@@ -910,6 +977,9 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 				if err == nil {
 					t.Errorf("Expected error for %s, but got none", tt.description)
 				}
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Expected %v for %s, got %v", tt.expectedErr, tt.description, err)
+				}
 			} else {
 				if err != nil {
 					t.Errorf("Unexpected error for %s: %v", tt.description, err)
@@ -917,6 +987,78 @@ func TestProcessor_Propose_EligibilityFailures(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("cooldown reports remaining duration", func(t *testing.T) {
+		_, err := processor.Propose(200, 2)()
+		var cooldownErr CooldownError
+		if !errors.As(err, &cooldownErr) {
+			t.Fatalf("Expected cooldown error, got %v", err)
+		}
+		if cooldownErr.Remaining <= 0 || cooldownErr.Remaining > GlobalCooldownDuration {
+			t.Errorf("Expected remaining global cooldown within (0, %v], got %v", GlobalCooldownDuration, cooldownErr.Remaining)
+		}
+	})
+}
+
+func TestProcessor_EvaluateProposalEligibility(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	setupTestData(t, db, tenantId)
+
+	mockCharacterProcessor := NewMockCharacterProcessor()
+	mockCharacterProcessor.AddCharacter(1, "Character1", 15)
+	mockCharacterProcessor.AddCharacter(2, "Character2", 15)
+	mockCharacterProcessor.AddCharacter(7, "LowLevelChar", 5)
+	mockCharacterProcessor.AddCharacter(100, "Character100", 15) // Already married
+
+	processor := NewProcessor(log, ctx, db).WithCharacterProcessor(mockCharacterProcessor)
+
+	t.Run("eligible proposal has no violations", func(t *testing.T) {
+		verdict, err := processor.EvaluateProposalEligibility(1, 2)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !verdict.Eligible() || len(verdict.Violations()) != 0 {
+			t.Errorf("Expected eligible verdict, got violations %v", verdict.Violations())
+		}
+	})
+
+	t.Run("every failing rule is listed", func(t *testing.T) {
+		verdict, err := processor.EvaluateProposalEligibility(7, 100)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if verdict.Eligible() {
+			t.Fatal("Expected ineligible verdict")
+		}
+
+		violations := verdict.Violations()
+		if len(violations) != 2 {
+			t.Fatalf("Expected 2 violations, got %d: %v", len(violations), violations)
+		}
+		if violations[0].Rule != ErrCharacterTooLowLevel.Code || violations[0].CharacterId != 7 || violations[0].Value != "5" {
+			t.Errorf("Unexpected level violation: %+v", violations[0])
+		}
+		if violations[1].Rule != ErrCharacterAlreadyMarried.Code || violations[1].CharacterId != 100 || violations[1].Value != "married" {
+			t.Errorf("Unexpected marriage violation: %+v", violations[1])
+		}
+	})
+
+	t.Run("propose reports every violation", func(t *testing.T) {
+		_, err := processor.Propose(7, 100)()
+		var eligibilityErr EligibilityError
+		if !errors.As(err, &eligibilityErr) {
+			t.Fatalf("Expected eligibility error, got %v", err)
+		}
+		if len(eligibilityErr.Violations) != 2 {
+			t.Errorf("Expected 2 violations, got %d", len(eligibilityErr.Violations))
+		}
+		if !errors.Is(err, ErrCharacterTooLowLevel) || !errors.Is(err, ErrCharacterAlreadyMarried) {
+			t.Errorf("Expected error to match both failing rules, got %v", err)
+		}
+	})
 }
 
 func TestProcessor_ProposeAndEmit(t *testing.T) {
@@ -1019,6 +1161,84 @@ func TestProcessor_EligibilityConstants(t *testing.T) {
 	}
 }
 
+func TestProcessor_TenantRules(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	eligibilityLevel := byte(20)
+	maxInvitees := 2
+	initialCooldown := int64(3600)
+	if err := db.Create(&rules.Entity{
+		TenantId:                        tenantId,
+		EligibilityLevel:                &eligibilityLevel,
+		MaxInvitees:                     &maxInvitees,
+		InitialPerTargetCooldownSeconds: &initialCooldown,
+		UpdatedAt:                       time.Now(),
+	}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	mockCharacterProcessor := NewMockCharacterProcessor()
+	mockCharacterProcessor.AddCharacter(1, "DefaultEligible", 15)
+	processor := NewProcessor(log, ctx, db).WithCharacterProcessor(mockCharacterProcessor)
+
+	t.Run("eligibility level", func(t *testing.T) {
+		eligible, err := processor.CheckEligibility(1)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if eligible {
+			t.Error("Expected level 15 character to be ineligible under a level 20 requirement")
+		}
+	})
+
+	t.Run("invitee limit", func(t *testing.T) {
+		now := time.Now()
+		marriage := Entity{CharacterId1: 1, CharacterId2: 2, Status: StatusEngaged, ProposedAt: now, EngagedAt: &now, TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+		if err := db.Create(&marriage).Error; err != nil {
+			t.Fatalf("Failed to create marriage: %v", err)
+		}
+
+		_, err := processor.ScheduleCeremony(marriage.ID, now.Add(time.Hour), []uint32{10, 11, 12})()
+		var limitErr InviteeLimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != maxInvitees {
+			t.Fatalf("Expected invitee limit error with limit %d, got %v", maxInvitees, err)
+		}
+
+		ceremony, err := processor.ScheduleCeremony(marriage.ID, now.Add(time.Hour), []uint32{10, 11})()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if ceremony.MaxInvitees() != maxInvitees {
+			t.Errorf("Expected ceremony invitee limit %d, got %d", maxInvitees, ceremony.MaxInvitees())
+		}
+		if !errors.Is(inviteeAdditionError(ceremony, 13), ErrTooManyInvitees) {
+			t.Error("Expected ceremony at its tenant limit to reject further invitees")
+		}
+	})
+
+	t.Run("initial per-target cooldown", func(t *testing.T) {
+		now := time.Now()
+		proposal := ProposalEntity{ProposerId: 3, TargetId: 4, Status: ProposalStatusPending, ProposedAt: now, ExpiresAt: now.Add(time.Hour), TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+		if err := db.Create(&proposal).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+
+		declined, err := processor.DeclineProposal(proposal.ID)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		remaining := time.Until(*declined.CooldownUntil())
+		if remaining > time.Hour || remaining < 59*time.Minute {
+			t.Errorf("Expected a one hour cooldown, got %s", remaining)
+		}
+	})
+}
+
 func TestProcessor_ContextTenantExtraction(t *testing.T) {
 	db := setupTestDB(t)
 	tenantId := uuid.New()
@@ -1100,7 +1320,7 @@ func TestProcessor_EligibilityErrors(t *testing.T) {
 	// Test the predefined eligibility errors
 	tests := []struct {
 		name     string
-		err      EligibilityError
+		err      error
 		expected string
 	}{
 		{
@@ -1231,13 +1451,29 @@ func TestProcessor_WithMockedProducer(t *testing.T) {
 		mockCharacterProcessor.AddCharacter(3, "Character3", 15)
 		mockCharacterProcessor.AddCharacter(4, "Character4", 15)
 
-		// Attempt to create proposal with emit - should fail
-		_, err := processor.ProposeAndEmit(transactionId, 3, 4)
-		if err == nil {
-			t.Error("Expected error when producer fails")
+		// Attempt to create proposal with emit - the proposal is committed and the event is left in the outbox
+		proposal, err := processor.ProposeAndEmit(transactionId, 3, 4)
+		if err != nil {
+			t.Fatalf("Expected producer failure to be deferred to the outbox, got: %v", err)
 		}
-		if !contains(err.Error(), "kafka connection failed") {
-			t.Errorf("Expected error to contain 'kafka connection failed', got: %v", err)
+
+		var entries []outbox.Entity
+		if err := db.Where("sent_at IS NULL").Find(&entries).Error; err != nil {
+			t.Fatalf("Failed to query outbox: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("Expected 1 pending outbox entry, got %d", len(entries))
+		}
+		if entries[0].Attempts != 1 {
+			t.Errorf("Expected 1 publication attempt, got %d", entries[0].Attempts)
+		}
+		if !contains(entries[0].LastError, "kafka connection failed") {
+			t.Errorf("Expected last error to contain 'kafka connection failed', got: %v", entries[0].LastError)
+		}
+
+		stored, err := processor.GetActiveProposal(3, 4)()
+		if err != nil || stored == nil || stored.Id() != proposal.Id() {
+			t.Errorf("Expected proposal %d to be persisted despite producer failure", proposal.Id())
 		}
 
 		// Reset producer error state
@@ -1370,10 +1606,13 @@ func TestProcessor_ErrorScenarios(t *testing.T) {
 			WithCharacterProcessor(mockCharacterProcessor).
 			WithProducer(mockProducer.Provider)
 
-		// First attempt should fail
+		// First attempt is committed but its event stays in the outbox
 		_, err := processor.ProposeAndEmit(uuid.New(), 1, 2)
-		if err == nil {
-			t.Error("Expected error due to producer failure")
+		if err != nil {
+			t.Errorf("Expected producer failure to be deferred to the outbox, got error: %v", err)
+		}
+		if len(mockProducer.GetProducedMessages()) != 0 {
+			t.Error("Expected no Kafka message to be produced while the producer is failing")
 		}
 
 		// Fix the producer
@@ -1418,8 +1657,12 @@ func TestProcessor_LevelRequirementEnforcement(t *testing.T) {
 		if err == nil {
 			t.Error("Expected error for low level proposer")
 		}
-		if !contains(err.Error(), "eligibility check failed") {
-			t.Errorf("Expected eligibility check failure, got: %v", err)
+		var eligibilityErr EligibilityError
+		if !errors.As(err, &eligibilityErr) || !errors.Is(err, ErrCharacterTooLowLevel) {
+			t.Fatalf("Expected level eligibility failure, got: %v", err)
+		}
+		if eligibilityErr.CharacterId != 1 {
+			t.Errorf("Expected failing character 1, got %d", eligibilityErr.CharacterId)
 		}
 	})
 
@@ -1429,8 +1672,12 @@ func TestProcessor_LevelRequirementEnforcement(t *testing.T) {
 		if err == nil {
 			t.Error("Expected error for low level target")
 		}
-		if !contains(err.Error(), "eligibility check failed") {
-			t.Errorf("Expected eligibility check failure, got: %v", err)
+		var eligibilityErr EligibilityError
+		if !errors.As(err, &eligibilityErr) || !errors.Is(err, ErrCharacterTooLowLevel) {
+			t.Fatalf("Expected level eligibility failure, got: %v", err)
+		}
+		if eligibilityErr.CharacterId != 1 {
+			t.Errorf("Expected failing character 1, got %d", eligibilityErr.CharacterId)
 		}
 	})
 
@@ -1914,16 +2161,22 @@ func TestProcessor_CharacterDeletion(t *testing.T) {
 			t.Fatalf("Failed to handle character deletion: %v", err)
 		}
 
-		// Verify marriage is marked as divorced
+		// Verify marriage is marked as deleted rather than divorced
 		var updatedEntity Entity
 		db.First(&updatedEntity, 1)
-		if updatedEntity.Status != StatusDivorced {
-			t.Errorf("Expected marriage status to be %v after character deletion, got %v", StatusDivorced, updatedEntity.Status)
+		if updatedEntity.Status != StatusDeleted {
+			t.Errorf("Expected marriage status to be %v after character deletion, got %v", StatusDeleted, updatedEntity.Status)
 		}
 
-		// Verify divorced timestamp is set
-		if updatedEntity.DivorcedAt == nil {
-			t.Error("Expected divorced at timestamp to be set after character deletion")
+		// Verify deletion timestamp and reason are set
+		if updatedEntity.DeletedAt == nil {
+			t.Error("Expected deleted at timestamp to be set after character deletion")
+		}
+		if updatedEntity.DivorcedAt != nil {
+			t.Error("Expected divorced at timestamp not to be set after character deletion")
+		}
+		if updatedEntity.DeletionReason != characterDeletionReason {
+			t.Errorf("Expected deletion reason %s, got %s", characterDeletionReason, updatedEntity.DeletionReason)
 		}
 	})
 
@@ -1956,11 +2209,14 @@ func TestProcessor_CharacterDeletion(t *testing.T) {
 			t.Fatalf("Failed to handle character deletion for engaged couple: %v", err)
 		}
 
-		// Verify marriage is marked as divorced
+		// Verify marriage is marked as deleted without a fabricated marriage timestamp
 		var updatedEntity Entity
 		db.First(&updatedEntity, 2)
-		if updatedEntity.Status != StatusDivorced {
-			t.Errorf("Expected marriage status to be %v after character deletion, got %v", StatusDivorced, updatedEntity.Status)
+		if updatedEntity.Status != StatusDeleted {
+			t.Errorf("Expected marriage status to be %v after character deletion, got %v", StatusDeleted, updatedEntity.Status)
+		}
+		if updatedEntity.MarriedAt != nil {
+			t.Error("Expected engaged marriage to have no marriage timestamp after character deletion")
 		}
 	})
 }
@@ -2187,6 +2443,105 @@ func TestProcessor_HandleCharacterDeletionAndEmit(t *testing.T) {
 	}
 }
 
+func TestProcessor_HandleCharacterDeletionAndEmit_CleansUpProposalsAndCeremonies(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	mockProducer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).
+		WithCharacterProcessor(NewMockCharacterProcessor()).
+		WithProducer(mockProducer.Provider)
+
+	now := time.Now()
+	ownMarriage := Entity{CharacterId1: 1, CharacterId2: 2, Status: StatusEngaged, ProposedAt: now, EngagedAt: &now, TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	otherMarriage := Entity{CharacterId1: 6, CharacterId2: 7, Status: StatusEngaged, ProposedAt: now, EngagedAt: &now, TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	for _, m := range []*Entity{&ownMarriage, &otherMarriage} {
+		if err := db.Create(m).Error; err != nil {
+			t.Fatalf("Failed to create marriage: %v", err)
+		}
+	}
+
+	ownCeremony := CeremonyEntity{MarriageId: ownMarriage.ID, CharacterId1: 1, CharacterId2: 2, Status: CeremonyStatusScheduled, ScheduledAt: now.Add(time.Hour), Invitees: "[5]", TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	otherCeremony := CeremonyEntity{MarriageId: otherMarriage.ID, CharacterId1: 6, CharacterId2: 7, Status: CeremonyStatusScheduled, ScheduledAt: now.Add(time.Hour), Invitees: "[1,8]", TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	for _, c := range []*CeremonyEntity{&ownCeremony, &otherCeremony} {
+		if err := db.Create(c).Error; err != nil {
+			t.Fatalf("Failed to create ceremony: %v", err)
+		}
+	}
+
+	received := ProposalEntity{ProposerId: 3, TargetId: 1, Status: ProposalStatusPending, ProposedAt: now, ExpiresAt: now.Add(time.Hour), TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	sent := ProposalEntity{ProposerId: 1, TargetId: 4, Status: ProposalStatusPending, ProposedAt: now, ExpiresAt: now.Add(time.Hour), TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	unrelated := ProposalEntity{ProposerId: 3, TargetId: 4, Status: ProposalStatusPending, ProposedAt: now, ExpiresAt: now.Add(time.Hour), TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+	for _, pe := range []*ProposalEntity{&received, &sent, &unrelated} {
+		if err := db.Create(pe).Error; err != nil {
+			t.Fatalf("Failed to create proposal: %v", err)
+		}
+	}
+
+	if err := processor.HandleCharacterDeletionAndEmit(uuid.New(), 1); err != nil {
+		t.Fatalf("Failed to handle character deletion: %v", err)
+	}
+
+	for id, expected := range map[uint32]ProposalStatus{received.ID: ProposalStatusCancelled, sent.ID: ProposalStatusCancelled, unrelated.ID: ProposalStatusPending} {
+		proposal, err := GetProposalByIdProvider(db, log)(id, tenantId)()
+		if err != nil {
+			t.Fatalf("Failed to retrieve proposal: %v", err)
+		}
+		if proposal.Status() != expected {
+			t.Errorf("Expected proposal %d to be %s, got %s", id, expected, proposal.Status())
+		}
+	}
+
+	ceremony, err := GetCeremonyByIdProvider(db, log)(ownCeremony.ID, tenantId)()
+	if err != nil {
+		t.Fatalf("Failed to retrieve ceremony: %v", err)
+	}
+	if !ceremony.IsCancelled() {
+		t.Errorf("Expected the couple's ceremony to be cancelled, got %s", ceremony.Status())
+	}
+
+	ceremony, err = GetCeremonyByIdProvider(db, log)(otherCeremony.ID, tenantId)()
+	if err != nil {
+		t.Fatalf("Failed to retrieve ceremony: %v", err)
+	}
+	if ceremony.IsInvited(1) || !ceremony.IsInvited(8) || !ceremony.IsScheduled() {
+		t.Errorf("Expected only the deleted character to be removed from the other ceremony, got invitees %v", ceremony.Invitees())
+	}
+
+	marriage, err := GetMarriageByIdProvider(db, log)(ownMarriage.ID, tenantId)()
+	if err != nil {
+		t.Fatalf("Failed to retrieve marriage: %v", err)
+	}
+	if marriage.Status() != StatusDeleted {
+		t.Errorf("Expected marriage to be deleted, got %s", marriage.Status())
+	}
+
+	eventTypes := make(map[string]int)
+	for _, msg := range mockProducer.GetProducedMessages() {
+		var event struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			t.Fatalf("Failed to unmarshal event: %v", err)
+		}
+		eventTypes[event.Type]++
+	}
+	expected := map[string]int{
+		marriageMsg.EventProposalCancelled: 2,
+		marriageMsg.EventCeremonyCancelled: 1,
+		marriageMsg.EventInviteeRemoved:    1,
+		marriageMsg.EventMarriageDeleted:   1,
+	}
+	for eventType, count := range expected {
+		if eventTypes[eventType] != count {
+			t.Errorf("Expected %d %s events, got %d", count, eventType, eventTypes[eventType])
+		}
+	}
+}
+
 func TestProcessor_ExpireProposalAndEmit(t *testing.T) {
 	db := setupTestDB(t)
 	tenantId := uuid.New()
@@ -2227,3 +2582,44 @@ func TestProcessor_ExpireProposalAndEmit(t *testing.T) {
 	}
 }
 
+
+func TestGetOpenCeremoniesByInviteeProvider_MatchesWholeIds(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	now := time.Now()
+	ceremonies := map[string]CeremonyStatus{
+		"[12]":       CeremonyStatusScheduled,
+		"[12,40]":    CeremonyStatusActive,
+		"[40,12,41]": CeremonyStatusPostponed,
+		"[40,12]":    CeremonyStatusScheduled,
+		"[112,120]":  CeremonyStatusScheduled,
+		"[1,2]":      CeremonyStatusScheduled,
+		"[41,12]":    CeremonyStatusCompleted,
+	}
+	for invitees, status := range ceremonies {
+		entity := CeremonyEntity{CharacterId1: 1, CharacterId2: 2, Status: status, ScheduledAt: now, Invitees: invitees, TenantId: tenantId, CreatedAt: now, UpdatedAt: now}
+		if err := db.Create(&entity).Error; err != nil {
+			t.Fatalf("Failed to create ceremony: %v", err)
+		}
+	}
+	other := CeremonyEntity{CharacterId1: 1, CharacterId2: 2, Status: CeremonyStatusScheduled, ScheduledAt: now, Invitees: "[12]", TenantId: uuid.New(), CreatedAt: now, UpdatedAt: now}
+	if err := db.Create(&other).Error; err != nil {
+		t.Fatalf("Failed to create ceremony: %v", err)
+	}
+
+	found, err := GetOpenCeremoniesByInviteeProvider(db, log)(12, tenantId)()
+	if err != nil {
+		t.Fatalf("Failed to retrieve ceremonies: %v", err)
+	}
+	if len(found) != 4 {
+		t.Fatalf("Expected the 4 open ceremonies inviting character 12, got %d", len(found))
+	}
+	for _, c := range found {
+		if !c.IsInvited(12) || c.IsCompleted() {
+			t.Errorf("Expected only open ceremonies inviting character 12, got %v (%s)", c.Invitees(), c.Status())
+		}
+	}
+}
@@ -1,11 +1,15 @@
 package marriage
 
 import (
+	"errors"
 	"time"
 
+	"atlas-marriages/kafka/message/inventory"
 	"atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/kafka/message/saga"
 	"github.com/Chronicle20/atlas-kafka/producer"
 	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -112,6 +116,71 @@ func MarriageCreatedEventProvider(marriageId uint32, characterId1 uint32, charac
 	return producer.SingleMessageProvider(key, value)
 }
 
+// MarriageAnniversaryEventProvider creates a provider for marriage anniversary events
+func MarriageAnniversaryEventProvider(marriageId uint32, characterId1 uint32, characterId2 uint32, marriedAt time.Time, milestoneDays uint32, reachedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.MarriageAnniversaryBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventMarriageAnniversary,
+		Body: marriage.MarriageAnniversaryBody{
+			MarriageId:    marriageId,
+			CharacterId1:  characterId1,
+			CharacterId2:  characterId2,
+			MarriedAt:     marriedAt,
+			MilestoneDays: milestoneDays,
+			ReachedAt:     reachedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// BondLevelChangedEventProvider creates a provider for bond level changed events
+func BondLevelChangedEventProvider(marriageId uint32, characterId1 uint32, characterId2 uint32, previousLevel byte, level byte, bondPoints uint32, reason string, source string, changedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.BondLevelChangedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventBondLevelChanged,
+		Body: marriage.BondLevelChangedBody{
+			MarriageId:    marriageId,
+			CharacterId1:  characterId1,
+			CharacterId2:  characterId2,
+			PreviousLevel: previousLevel,
+			Level:         level,
+			BondPoints:    bondPoints,
+			Reason:        reason,
+			Source:        source,
+			ChangedAt:     changedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CoupleSkillsGrantedEventProvider creates a provider for events granting couple skills to a character
+func CoupleSkillsGrantedEventProvider(marriageId uint32, characterId uint32, partnerId uint32, skillIds []uint32) model.Provider[[]kafka.Message] {
+	return coupleSkillsEventProvider(marriage.EventCoupleSkillsGranted, marriageId, characterId, partnerId, skillIds)
+}
+
+// CoupleSkillsRevokedEventProvider creates a provider for events revoking couple skills from a character
+func CoupleSkillsRevokedEventProvider(marriageId uint32, characterId uint32, partnerId uint32, skillIds []uint32) model.Provider[[]kafka.Message] {
+	return coupleSkillsEventProvider(marriage.EventCoupleSkillsRevoked, marriageId, characterId, partnerId, skillIds)
+}
+
+// coupleSkillsEventProvider creates a provider for couple skill events, keyed by the character receiving them
+func coupleSkillsEventProvider(eventType string, marriageId uint32, characterId uint32, partnerId uint32, skillIds []uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := &marriage.Event[marriage.CoupleSkillsBody]{
+		CharacterId: characterId,
+		Type:        eventType,
+		Body: marriage.CoupleSkillsBody{
+			MarriageId:  marriageId,
+			CharacterId: characterId,
+			PartnerId:   partnerId,
+			SkillIds:    skillIds,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
 // MarriageDivorcedEventProvider creates a provider for marriage divorced events
 func MarriageDivorcedEventProvider(marriageId uint32, characterId1 uint32, characterId2 uint32, divorcedAt time.Time, initiatedBy uint32) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId1))
@@ -147,6 +216,41 @@ func MarriageDeletedEventProvider(marriageId uint32, characterId1 uint32, charac
 	return producer.SingleMessageProvider(key, value)
 }
 
+// DivorceFiledEventProvider creates a provider for divorce filed events
+func DivorceFiledEventProvider(marriageId uint32, characterId1 uint32, characterId2 uint32, filedBy uint32, filedAt time.Time, maturesAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.DivorceFiledBody]{
+		CharacterId: filedBy,
+		Type:        marriage.EventDivorceFiled,
+		Body: marriage.DivorceFiledBody{
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			FiledBy:      filedBy,
+			FiledAt:      filedAt,
+			MaturesAt:    maturesAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// DivorceWithdrawnEventProvider creates a provider for divorce withdrawn events
+func DivorceWithdrawnEventProvider(marriageId uint32, characterId1 uint32, characterId2 uint32, filedBy uint32, withdrawnAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.DivorceWithdrawnBody]{
+		CharacterId: filedBy,
+		Type:        marriage.EventDivorceWithdrawn,
+		Body: marriage.DivorceWithdrawnBody{
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			FiledBy:      filedBy,
+			WithdrawnAt:  withdrawnAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
 // Ceremony Event Producers
 
 // CeremonyScheduledEventProvider creates a provider for ceremony scheduled events
@@ -297,6 +401,210 @@ func InviteeRemovedEventProvider(ceremonyId uint32, marriageId uint32, character
 	return producer.SingleMessageProvider(key, value)
 }
 
+// InviteeRsvpChangedEventProvider creates a provider for invitee RSVP changed events
+func InviteeRsvpChangedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, inviteeId uint32, previousStatus string, status string, changedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.InviteeRsvpChangedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventInviteeRsvpChanged,
+		Body: marriage.InviteeRsvpChangedBody{
+			CeremonyId:     ceremonyId,
+			MarriageId:     marriageId,
+			CharacterId1:   characterId1,
+			CharacterId2:   characterId2,
+			InviteeId:      inviteeId,
+			PreviousStatus: previousStatus,
+			Status:         status,
+			ChangedAt:      changedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CeremonyReminderEventProvider creates a provider for events reminding the couple and their invitees that a
+// ceremony is about to start
+func CeremonyReminderEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, scheduledAt time.Time, invitees []uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.CeremonyReminderBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventCeremonyReminder,
+		Body: marriage.CeremonyReminderBody{
+			CeremonyId:   ceremonyId,
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			ScheduledAt:  scheduledAt,
+			Invitees:     invitees,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// PartnerDisconnectedEventProvider creates a provider for partner disconnected events, keyed by the first partner
+func PartnerDisconnectedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, characterId uint32, disconnectedAt time.Time, timeoutAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.PartnerDisconnectedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventPartnerDisconnected,
+		Body: marriage.PartnerDisconnectedBody{
+			CeremonyId:     ceremonyId,
+			MarriageId:     marriageId,
+			CharacterId1:   characterId1,
+			CharacterId2:   characterId2,
+			CharacterId:    characterId,
+			DisconnectedAt: disconnectedAt,
+			TimeoutAt:      timeoutAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// PartnerReconnectedEventProvider creates a provider for partner reconnected events, keyed by the first partner
+func PartnerReconnectedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, characterId uint32, reconnectedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.PartnerReconnectedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventPartnerReconnected,
+		Body: marriage.PartnerReconnectedBody{
+			CeremonyId:    ceremonyId,
+			MarriageId:    marriageId,
+			CharacterId1:  characterId1,
+			CharacterId2:  characterId2,
+			CharacterId:   characterId,
+			ReconnectedAt: reconnectedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CeremonyStageChangedEventProvider creates a provider for events in which an officiant advances an active ceremony to
+// its next stage, keyed by the first partner
+func CeremonyStageChangedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, previousStage string, stage string, stageIndex int, finalStage bool, advancedBy uint32, changedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.CeremonyStageChangedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventCeremonyStageChanged,
+		Body: marriage.CeremonyStageChangedBody{
+			CeremonyId:    ceremonyId,
+			MarriageId:    marriageId,
+			CharacterId1:  characterId1,
+			CharacterId2:  characterId2,
+			PreviousStage: previousStage,
+			Stage:         stage,
+			StageIndex:    stageIndex,
+			FinalStage:    finalStage,
+			AdvancedBy:    advancedBy,
+			ChangedAt:     changedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// RegistryItemAddedEventProvider creates a provider for registry item added events
+func RegistryItemAddedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, itemId uint32, quantity uint32, addedBy uint32, addedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.RegistryItemAddedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventRegistryItemAdded,
+		Body: marriage.RegistryItemAddedBody{
+			CeremonyId:   ceremonyId,
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			ItemId:       itemId,
+			Quantity:     quantity,
+			AddedBy:      addedBy,
+			AddedAt:      addedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// RegistryItemRemovedEventProvider creates a provider for registry item removed events
+func RegistryItemRemovedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, itemId uint32, removedBy uint32, removedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.RegistryItemRemovedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventRegistryItemRemoved,
+		Body: marriage.RegistryItemRemovedBody{
+			CeremonyId:   ceremonyId,
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			ItemId:       itemId,
+			RemovedBy:    removedBy,
+			RemovedAt:    removedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CeremonyBlessedEventProvider creates a provider for ceremony blessed events
+func CeremonyBlessedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, characterId uint32, message string, blessingCount uint32, blessedAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.CeremonyBlessedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventCeremonyBlessed,
+		Body: marriage.CeremonyBlessedBody{
+			CeremonyId:    ceremonyId,
+			MarriageId:    marriageId,
+			CharacterId1:  characterId1,
+			CharacterId2:  characterId2,
+			CharacterId:   characterId,
+			Message:       message,
+			BlessingCount: blessingCount,
+			BlessedAt:     blessedAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// GiftGivenEventProvider creates a provider for gift given events
+func GiftGivenEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, characterId uint32, itemId uint32, quantity uint32, received uint32, remaining uint32, giftCount uint32, givenAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.GiftGivenBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventGiftGiven,
+		Body: marriage.GiftGivenBody{
+			CeremonyId:   ceremonyId,
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			CharacterId:  characterId,
+			ItemId:       itemId,
+			Quantity:     quantity,
+			Received:     received,
+			Remaining:    remaining,
+			GiftCount:    giftCount,
+			GivenAt:      givenAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// GiftReturnedEventProvider creates a provider for gift returned events
+func GiftReturnedEventProvider(ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32, characterId uint32, itemId uint32, quantity uint32, received uint32, remaining uint32, giftCount uint32, reason string) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId1))
+	value := &marriage.Event[marriage.GiftReturnedBody]{
+		CharacterId: characterId1,
+		Type:        marriage.EventGiftReturned,
+		Body: marriage.GiftReturnedBody{
+			CeremonyId:   ceremonyId,
+			MarriageId:   marriageId,
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			CharacterId:  characterId,
+			ItemId:       itemId,
+			Quantity:     quantity,
+			Received:     received,
+			Remaining:    remaining,
+			GiftCount:    giftCount,
+			Reason:       reason,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
 // MarriageErrorEventProvider creates a provider for marriage error events
 func MarriageErrorEventProvider(characterId uint32, errorType string, errorCode string, message string, context string) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
@@ -313,4 +621,174 @@ func MarriageErrorEventProvider(characterId uint32, errorType string, errorCode
 		},
 	}
 	return producer.SingleMessageProvider(key, value)
-}
\ No newline at end of file
+}
+
+// DomainErrorEventProvider creates a provider for a marriage error event whose type and code are derived from the error
+func DomainErrorEventProvider(characterId uint32, err error, context string) model.Provider[[]kafka.Message] {
+	errorType, errorCode := ClassifyError(err)
+	key := producer.CreateKey(int(characterId))
+	value := &marriage.Event[marriage.MarriageErrorBody]{
+		CharacterId: characterId,
+		Type:        marriage.EventMarriageError,
+		Body: marriage.MarriageErrorBody{
+			ErrorType:   errorType,
+			ErrorCode:   errorCode,
+			Message:     err.Error(),
+			CharacterId: characterId,
+			Context:     context,
+			Timestamp:   time.Now(),
+			Violations:  eligibilityViolationBodies(err),
+			Funds:       fundsShortfallBody(err),
+			Cooldown:    cooldownRemainingBody(err),
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// fundsShortfallBody returns the mesos shortfall carried by an error, if any
+func fundsShortfallBody(err error) *marriage.FundsShortfall {
+	var fundsErr InsufficientFundsError
+	if !errors.As(err, &fundsErr) {
+		return nil
+	}
+	return &marriage.FundsShortfall{
+		Required:  fundsErr.Required,
+		Available: fundsErr.Available,
+	}
+}
+
+// cooldownRemainingBody returns the cooldown carried by an error, if any
+func cooldownRemainingBody(err error) *marriage.CooldownRemaining {
+	var cooldownErr CooldownError
+	if !errors.As(err, &cooldownErr) || cooldownErr.Remaining <= 0 {
+		return nil
+	}
+	return &marriage.CooldownRemaining{
+		Scope:            string(cooldownErr.Scope),
+		RemainingSeconds: ceilSeconds(cooldownErr.Remaining),
+		Until:            time.Now().Add(cooldownErr.Remaining),
+	}
+}
+
+// eligibilityViolationBodies returns the eligibility violations carried by an error, if any
+func eligibilityViolationBodies(err error) []marriage.EligibilityViolation {
+	var eligibilityErr EligibilityError
+	if !errors.As(err, &eligibilityErr) || len(eligibilityErr.Violations) == 0 {
+		return nil
+	}
+
+	bodies := make([]marriage.EligibilityViolation, 0, len(eligibilityErr.Violations))
+	for _, violation := range eligibilityErr.Violations {
+		bodies = append(bodies, marriage.EligibilityViolation{
+			Rule:             violation.Rule,
+			CharacterId:      violation.CharacterId,
+			Value:            violation.Value,
+			Message:          violation.Message,
+			RemainingSeconds: violation.RemainingSeconds(),
+		})
+	}
+	return bodies
+}
+
+// Inventory Command Producers
+
+// CreateWeddingRingCommandProvider creates a provider for the command issuing a character's wedding ring, paired with
+// their partner's ring
+func CreateWeddingRingCommandProvider(transactionId uuid.UUID, marriageId uint32, characterId uint32, partnerCharacterId uint32, itemId uint32, serial uint64, partnerSerial uint64) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := &inventory.Command[inventory.CreateRingCommandBody]{
+		TransactionId: transactionId,
+		CharacterId:   characterId,
+		Type:          inventory.CommandCreateRing,
+		Body: inventory.CreateRingCommandBody{
+			ItemId:             itemId,
+			Serial:             serial,
+			PartnerCharacterId: partnerCharacterId,
+			PartnerSerial:      partnerSerial,
+			MarriageId:         marriageId,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// TransferGiftCommandProvider creates a provider for the command moving the items an invitee gave at a ceremony from
+// their inventory to the couple's first partner
+func TransferGiftCommandProvider(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, recipientCharacterId uint32, itemId uint32, quantity uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := &inventory.Command[inventory.TransferItemCommandBody]{
+		TransactionId: transactionId,
+		CharacterId:   characterId,
+		Type:          inventory.CommandTransferItem,
+		Body: inventory.TransferItemCommandBody{
+			ItemId:               itemId,
+			Quantity:             quantity,
+			RecipientCharacterId: recipientCharacterId,
+			CeremonyId:           ceremonyId,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// Saga Command Producers
+
+// ReserveChapelCommandProvider creates a provider for commands reserving the chapel for a ceremony
+func ReserveChapelCommandProvider(sagaId uuid.UUID, ceremonyId uint32, characterId1 uint32, characterId2 uint32, scheduledAt time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(ceremonyId))
+	value := &saga.Command[saga.ReserveChapelBody]{
+		SagaId:     sagaId,
+		CeremonyId: ceremonyId,
+		Type:       saga.CommandReserveChapel,
+		Body: saga.ReserveChapelBody{
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+			ScheduledAt:  scheduledAt,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// ReleaseChapelCommandProvider creates a provider for commands releasing the chapel reserved for a ceremony
+func ReleaseChapelCommandProvider(sagaId uuid.UUID, ceremonyId uint32, characterId1 uint32, characterId2 uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(ceremonyId))
+	value := &saga.Command[saga.ReleaseChapelBody]{
+		SagaId:     sagaId,
+		CeremonyId: ceremonyId,
+		Type:       saga.CommandReleaseChapel,
+		Body: saga.ReleaseChapelBody{
+			CharacterId1: characterId1,
+			CharacterId2: characterId2,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// RefundFeeCommandProvider creates a provider for commands returning the ceremony fee debited under an economy
+// transaction id to a character
+func RefundFeeCommandProvider(sagaId uuid.UUID, ceremonyId uint32, characterId uint32, amount uint32, transactionId uuid.UUID) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(ceremonyId))
+	value := &saga.Command[saga.RefundFeeBody]{
+		SagaId:     sagaId,
+		CeremonyId: ceremonyId,
+		Type:       saga.CommandRefundFee,
+		Body: saga.RefundFeeBody{
+			CharacterId:   characterId,
+			Amount:        amount,
+			TransactionId: transactionId,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
+
+// WarpGuestsCommandProvider creates a provider for commands warping the couple and their invitees to the chapel
+func WarpGuestsCommandProvider(sagaId uuid.UUID, ceremonyId uint32, characterIds []uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(ceremonyId))
+	value := &saga.Command[saga.WarpGuestsBody]{
+		SagaId:     sagaId,
+		CeremonyId: ceremonyId,
+		Type:       saga.CommandWarpGuests,
+		Body: saga.WarpGuestsBody{
+			CharacterIds: characterIds,
+		},
+	}
+	return producer.SingleMessageProvider(key, value)
+}
@@ -1,9 +1,11 @@
 package marriage
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"atlas-marriages/kafka/message/marriage"
 	"github.com/Chronicle20/atlas-kafka/producer"
 )
 
@@ -210,6 +212,266 @@ func TestMarriageDeletedEventProvider(t *testing.T) {
 	}
 }
 
+func TestDivorceFiledEventProvider(t *testing.T) {
+	filedAt := time.Now()
+	maturesAt := filedAt.Add(168 * time.Hour)
+
+	messages, err := DivorceFiledEventProvider(1, 100, 200, 200, filedAt, maturesAt)()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	var event marriage.Event[marriage.DivorceFiledBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventDivorceFiled || event.CharacterId != 200 {
+		t.Errorf("Expected a divorce filed event for character 200, got %s for %d", event.Type, event.CharacterId)
+	}
+	if event.Body.FiledBy != 200 || !event.Body.MaturesAt.Equal(maturesAt) {
+		t.Errorf("Unexpected divorce filed body %+v", event.Body)
+	}
+}
+
+func TestDivorceWithdrawnEventProvider(t *testing.T) {
+	messages, err := DivorceWithdrawnEventProvider(1, 100, 200, 100, time.Now())()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	var event marriage.Event[marriage.DivorceWithdrawnBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventDivorceWithdrawn || event.Body.FiledBy != 100 || event.Body.MarriageId != 1 {
+		t.Errorf("Unexpected divorce withdrawn event %+v", event)
+	}
+}
+
+func TestMarriageAnniversaryEventProvider(t *testing.T) {
+	marriedAt := time.Now().Add(-30 * 24 * time.Hour)
+	messages, err := MarriageAnniversaryEventProvider(1, 100, 200, marriedAt, 30, marriedAt.Add(30*24*time.Hour))()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	var event marriage.Event[marriage.MarriageAnniversaryBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventMarriageAnniversary || event.Body.MilestoneDays != 30 || event.Body.CharacterId2 != 200 {
+		t.Errorf("Unexpected marriage anniversary event %+v", event)
+	}
+}
+
+func TestBondLevelChangedEventProvider(t *testing.T) {
+	messages, err := BondLevelChangedEventProvider(1, 100, 200, 1, 2, 350, marriage.BondReasonAward, "QUEST", time.Now())()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	var event marriage.Event[marriage.BondLevelChangedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventBondLevelChanged || event.Body.PreviousLevel != 1 || event.Body.Level != 2 || event.Body.BondPoints != 350 {
+		t.Errorf("Unexpected bond level changed event %+v", event)
+	}
+	if event.Body.Reason != marriage.BondReasonAward || event.Body.Source != "QUEST" {
+		t.Errorf("Expected an award from a quest, got %s from %s", event.Body.Reason, event.Body.Source)
+	}
+}
+
+func TestCoupleSkillsEventProviders(t *testing.T) {
+	messages, err := CoupleSkillsGrantedEventProvider(1, 100, 200, []uint32{1000, 1001})()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the receiving character, got %s", messages[0].Key)
+	}
+
+	var event marriage.Event[marriage.CoupleSkillsBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventCoupleSkillsGranted || event.CharacterId != 100 || event.Body.PartnerId != 200 || len(event.Body.SkillIds) != 2 {
+		t.Errorf("Unexpected couple skills granted event %+v", event)
+	}
+
+	messages, err = CoupleSkillsRevokedEventProvider(1, 200, 100, []uint32{1000})()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventCoupleSkillsRevoked || event.Body.CharacterId != 200 || event.Body.PartnerId != 100 {
+		t.Errorf("Unexpected couple skills revoked event %+v", event)
+	}
+}
+
+func TestInviteeRsvpChangedEventProvider(t *testing.T) {
+	messages, err := InviteeRsvpChangedEventProvider(1, 2, 100, 200, 300, "INVITED", "ACCEPTED", time.Now())()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the first partner, got %s", messages[0].Key)
+	}
+
+	var event marriage.Event[marriage.InviteeRsvpChangedBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Type != marriage.EventInviteeRsvpChanged || event.Body.InviteeId != 300 || event.Body.PreviousStatus != "INVITED" || event.Body.Status != "ACCEPTED" {
+		t.Errorf("Unexpected invitee RSVP changed event %+v", event)
+	}
+}
+
+func TestCeremonyReminderEventProvider(t *testing.T) {
+	scheduledAt := time.Now().Add(15 * time.Minute)
+	messages, err := CeremonyReminderEventProvider(1, 2, 100, 200, scheduledAt, []uint32{300, 400})()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var reminder marriage.Event[marriage.CeremonyReminderBody]
+	if err = json.Unmarshal(messages[0].Value, &reminder); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if reminder.Type != marriage.EventCeremonyReminder || reminder.CharacterId != 100 || len(reminder.Body.Invitees) != 2 || !reminder.Body.ScheduledAt.Equal(scheduledAt) {
+		t.Errorf("Unexpected ceremony reminder event %+v", reminder)
+	}
+
+}
+
+func TestPartnerPresenceEventProviders(t *testing.T) {
+	disconnectedAt := time.Now()
+	timeoutAt := disconnectedAt.Add(5 * time.Minute)
+	messages, err := PartnerDisconnectedEventProvider(1, 2, 100, 200, 200, disconnectedAt, timeoutAt)()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the first partner, got %s", messages[0].Key)
+	}
+	var disconnected marriage.Event[marriage.PartnerDisconnectedBody]
+	if err = json.Unmarshal(messages[0].Value, &disconnected); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if disconnected.Type != marriage.EventPartnerDisconnected || disconnected.Body.CharacterId != 200 || !disconnected.Body.TimeoutAt.Equal(timeoutAt) {
+		t.Errorf("Unexpected partner disconnected event %+v", disconnected)
+	}
+
+	messages, err = PartnerReconnectedEventProvider(1, 2, 100, 200, 200, time.Now())()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var reconnected marriage.Event[marriage.PartnerReconnectedBody]
+	if err = json.Unmarshal(messages[0].Value, &reconnected); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if reconnected.Type != marriage.EventPartnerReconnected || reconnected.CharacterId != 100 || reconnected.Body.CharacterId != 200 {
+		t.Errorf("Unexpected partner reconnected event %+v", reconnected)
+	}
+}
+
+func TestCeremonyStageChangedEventProvider(t *testing.T) {
+	changedAt := time.Now()
+	messages, err := CeremonyStageChangedEventProvider(1, 2, 100, 200, "VOWS", "RING_EXCHANGE", 2, false, 300, changedAt)()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the first partner, got %s", messages[0].Key)
+	}
+	var changed marriage.Event[marriage.CeremonyStageChangedBody]
+	if err = json.Unmarshal(messages[0].Value, &changed); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if changed.Type != marriage.EventCeremonyStageChanged || changed.Body.PreviousStage != "VOWS" || changed.Body.Stage != "RING_EXCHANGE" {
+		t.Errorf("Unexpected ceremony stage changed event %+v", changed)
+	}
+	if changed.Body.StageIndex != 2 || changed.Body.FinalStage || changed.Body.AdvancedBy != 300 || !changed.Body.ChangedAt.Equal(changedAt) {
+		t.Errorf("Unexpected ceremony stage changed event %+v", changed)
+	}
+}
+
+func TestCeremonyBlessedEventProvider(t *testing.T) {
+	blessedAt := time.Now()
+	messages, err := CeremonyBlessedEventProvider(1, 2, 100, 200, 300, "Congratulations!", 4, blessedAt)()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the first partner, got %s", messages[0].Key)
+	}
+	var blessed marriage.Event[marriage.CeremonyBlessedBody]
+	if err = json.Unmarshal(messages[0].Value, &blessed); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if blessed.Type != marriage.EventCeremonyBlessed || blessed.Body.CharacterId != 300 || blessed.Body.Message != "Congratulations!" {
+		t.Errorf("Unexpected ceremony blessed event %+v", blessed)
+	}
+	if blessed.Body.BlessingCount != 4 || !blessed.Body.BlessedAt.Equal(blessedAt) {
+		t.Errorf("Unexpected ceremony blessed event %+v", blessed)
+	}
+}
+
+func TestGiftGivenEventProvider(t *testing.T) {
+	givenAt := time.Now()
+	messages, err := GiftGivenEventProvider(1, 2, 100, 200, 300, 2000000, 2, 3, 1, 5, givenAt)()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	if expectedKey := producer.CreateKey(100); string(messages[0].Key) != string(expectedKey) {
+		t.Errorf("Expected the event to be keyed by the first partner, got %s", messages[0].Key)
+	}
+	var given marriage.Event[marriage.GiftGivenBody]
+	if err = json.Unmarshal(messages[0].Value, &given); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if given.Type != marriage.EventGiftGiven || given.Body.CharacterId != 300 || given.Body.ItemId != 2000000 || given.Body.Quantity != 2 {
+		t.Errorf("Unexpected gift given event %+v", given)
+	}
+	if given.Body.Received != 3 || given.Body.Remaining != 1 || given.Body.GiftCount != 5 || !given.Body.GivenAt.Equal(givenAt) {
+		t.Errorf("Unexpected gift given event %+v", given)
+	}
+}
+
 func TestCeremonyScheduledEventProvider(t *testing.T) {
 	ceremonyId := uint32(1)
 	marriageId := uint32(1)
@@ -443,4 +705,76 @@ func TestMarriageErrorEventProvider(t *testing.T) {
 	if string(msg.Key) != string(expectedKey) {
 		t.Errorf("Expected key %s, got %s", expectedKey, msg.Key)
 	}
-}
\ No newline at end of file
+}
+
+func TestDomainErrorEventProvider_IncludesViolations(t *testing.T) {
+	verdict := NewEligibilityVerdict(1, 2, []EligibilityViolation{
+		newEligibilityViolation(ErrCharacterTooLowLevel, 1, "5"),
+		newEligibilityViolation(ErrCharacterAlreadyMarried, 2, "married"),
+	})
+
+	messages, err := DomainErrorEventProvider(1, verdict.Err(), "marriage_proposal")()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+
+	var event marriage.Event[marriage.MarriageErrorBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Body.ErrorType != marriage.ErrorTypeEligibility || event.Body.ErrorCode != marriage.ErrorCodeInsufficientLevel {
+		t.Errorf("Unexpected error type and code: %s %s", event.Body.ErrorType, event.Body.ErrorCode)
+	}
+	if len(event.Body.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %d", len(event.Body.Violations))
+	}
+	if event.Body.Violations[1].Rule != marriage.ErrorCodeAlreadyMarried || event.Body.Violations[1].CharacterId != 2 || event.Body.Violations[1].Value != "married" {
+		t.Errorf("Unexpected violation: %+v", event.Body.Violations[1])
+	}
+}
+
+func TestDomainErrorEventProvider_IncludesFundsShortfall(t *testing.T) {
+	messages, err := DomainErrorEventProvider(1, InsufficientFundsError{CharacterId: 1, Required: 500, Available: 120}, "ceremony_schedule")()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var event marriage.Event[marriage.MarriageErrorBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Body.ErrorType != marriage.ErrorTypeInsufficientFunds || event.Body.ErrorCode != marriage.ErrorCodeInsufficientFunds {
+		t.Errorf("Unexpected error type and code: %s %s", event.Body.ErrorType, event.Body.ErrorCode)
+	}
+	if event.Body.Funds == nil || event.Body.Funds.Required != 500 || event.Body.Funds.Available != 120 {
+		t.Errorf("Unexpected funds shortfall: %+v", event.Body.Funds)
+	}
+}
+
+func TestDomainErrorEventProvider_IncludesCooldown(t *testing.T) {
+	verdict := NewEligibilityVerdict(1, 2, []EligibilityViolation{
+		newCooldownViolation(CooldownError{Scope: CooldownScopeRemarriage, Remaining: 90 * time.Minute}, 2),
+	})
+
+	messages, err := DomainErrorEventProvider(1, verdict.Err(), "marriage_proposal")()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var event marriage.Event[marriage.MarriageErrorBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Body.ErrorType != marriage.ErrorTypeCooldown || event.Body.ErrorCode != marriage.ErrorCodeRemarriageCooldown {
+		t.Errorf("Unexpected error type and code: %s %s", event.Body.ErrorType, event.Body.ErrorCode)
+	}
+	if event.Body.Cooldown == nil || event.Body.Cooldown.Scope != string(CooldownScopeRemarriage) || event.Body.Cooldown.RemainingSeconds != 5400 {
+		t.Fatalf("Unexpected cooldown: %+v", event.Body.Cooldown)
+	}
+	if event.Body.Cooldown.Until.Before(time.Now().Add(89 * time.Minute)) {
+		t.Errorf("Expected the cooldown to end in 90 minutes, got %v", event.Body.Cooldown.Until)
+	}
+}
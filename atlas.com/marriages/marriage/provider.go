@@ -2,12 +2,16 @@ package marriage
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
+	"atlas-marriages/rules"
+
 	"github.com/Chronicle20/atlas-model/model"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // GetProposalByIdProvider retrieves a proposal by ID
@@ -23,7 +27,7 @@ func GetProposalByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(proposalI
 			err := db.Where("id = ? AND tenant_id = ?", proposalId, tenantId).First(&entity).Error
 			if err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
-					return Proposal{}, errors.New("proposal not found")
+					return Proposal{}, ErrProposalNotFound
 				}
 				return Proposal{}, err
 			}
@@ -215,7 +219,7 @@ func GetActiveMarriageByCharacterProvider(db *gorm.DB, log logrus.FieldLogger) f
 
 			var entity Entity
 			err := db.Where("(character_id1 = ? OR character_id2 = ?) AND tenant_id = ? AND status IN (?)",
-				characterId, characterId, tenantId, []MarriageStatus{StatusProposed, StatusEngaged, StatusMarried}).
+				characterId, characterId, tenantId, []MarriageStatus{StatusProposed, StatusEngaged, StatusMarried, StatusDivorcePending}).
 				First(&entity).Error
 
 			if err != nil {
@@ -263,6 +267,37 @@ func GetMarriageByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(marriageI
 	}
 }
 
+// LockMarriageByIdProvider retrieves a marriage by ID, returning nil when it does not exist. The marriage's row stays
+// locked until the database transaction ends, so concurrent changes to the marriage are checked and made one at a time
+func LockMarriageByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(marriageId uint32, tenantId uuid.UUID) model.Provider[*Marriage] {
+	return func(marriageId uint32, tenantId uuid.UUID) model.Provider[*Marriage] {
+		return func() (*Marriage, error) {
+			log.WithFields(logrus.Fields{
+				"marriageId": marriageId,
+				"tenantId":   tenantId,
+			}).Debug("Locking marriage by ID")
+
+			var entity Entity
+			err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND tenant_id = ?", marriageId, tenantId).
+				First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			marriage, err := Make(entity)
+			if err != nil {
+				return nil, err
+			}
+
+			return &marriage, nil
+		}
+	}
+}
+
 // GetMarriageHistoryByCharacterProvider retrieves marriage history for a character
 func GetMarriageHistoryByCharacterProvider(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32, tenantId uuid.UUID) model.Provider[[]Marriage] {
 	return func(characterId uint32, tenantId uuid.UUID) model.Provider[[]Marriage] {
@@ -355,7 +390,14 @@ func GetCeremonyByMarriageIdProvider(db *gorm.DB, log logrus.FieldLogger) func(m
 // CheckGlobalCooldownProvider checks if a character is in global cooldown
 func CheckGlobalCooldownProvider(db *gorm.DB, log logrus.FieldLogger) func(proposerId uint32, tenantId uuid.UUID) model.Provider[bool] {
 	return func(proposerId uint32, tenantId uuid.UUID) model.Provider[bool] {
-		return func() (bool, error) {
+		return cooldownElapsed(GetGlobalCooldownRemainingProvider(db, log)(proposerId, tenantId))
+	}
+}
+
+// GetGlobalCooldownRemainingProvider returns how long remains of a character's global cooldown, or zero when none is active
+func GetGlobalCooldownRemainingProvider(db *gorm.DB, log logrus.FieldLogger) func(proposerId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+	return func(proposerId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+		return func() (time.Duration, error) {
 			log.WithFields(logrus.Fields{
 				"proposerId": proposerId,
 				"tenantId":   tenantId,
@@ -364,16 +406,16 @@ func CheckGlobalCooldownProvider(db *gorm.DB, log logrus.FieldLogger) func(propo
 			lastProposalProvider := GetLastProposalByProposerProvider(db, log)(proposerId, tenantId)
 			lastProposal, err := lastProposalProvider()
 			if err != nil {
-				return false, err
+				return 0, err
 			}
 
 			if lastProposal == nil {
-				return true, nil // No previous proposals
+				return 0, nil // No previous proposals
 			}
 
 			// Check if global cooldown period has passed
-			cooldownEnd := lastProposal.CreatedAt().Add(GlobalCooldownDuration)
-			return time.Now().After(cooldownEnd), nil
+			cooldownEnd := lastProposal.CreatedAt().Add(rules.ForTenant(log, db)(tenantId).GlobalCooldown())
+			return remainingUntil(cooldownEnd), nil
 		}
 	}
 }
@@ -381,7 +423,14 @@ func CheckGlobalCooldownProvider(db *gorm.DB, log logrus.FieldLogger) func(propo
 // CheckPerTargetCooldownProvider checks if a character is in per-target cooldown
 func CheckPerTargetCooldownProvider(db *gorm.DB, log logrus.FieldLogger) func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[bool] {
 	return func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[bool] {
-		return func() (bool, error) {
+		return cooldownElapsed(GetPerTargetCooldownRemainingProvider(db, log)(proposerId, targetId, tenantId))
+	}
+}
+
+// GetPerTargetCooldownRemainingProvider returns how long remains of a character's cooldown for a target, or zero when none is active
+func GetPerTargetCooldownRemainingProvider(db *gorm.DB, log logrus.FieldLogger) func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+	return func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+		return func() (time.Duration, error) {
 			log.WithFields(logrus.Fields{
 				"proposerId": proposerId,
 				"targetId":   targetId,
@@ -391,26 +440,237 @@ func CheckPerTargetCooldownProvider(db *gorm.DB, log logrus.FieldLogger) func(pr
 			lastProposalProvider := GetLastProposalToTargetProvider(db, log)(proposerId, targetId, tenantId)
 			lastProposal, err := lastProposalProvider()
 			if err != nil {
-				return false, err
+				return 0, err
 			}
 
 			if lastProposal == nil {
-				return true, nil // No previous proposals to this target
+				return 0, nil // No previous proposals to this target
 			}
 
 			// If last proposal was rejected, check cooldown
 			if lastProposal.Status() == ProposalStatusRejected && lastProposal.CooldownUntil() != nil {
-				return time.Now().After(*lastProposal.CooldownUntil()), nil
+				return remainingUntil(*lastProposal.CooldownUntil()), nil
 			}
 
 			// If last proposal expired, apply initial cooldown
 			if lastProposal.Status() == ProposalStatusExpired {
-				cooldownEnd := lastProposal.UpdatedAt().Add(InitialPerTargetCooldown)
-				return time.Now().After(cooldownEnd), nil
+				cooldownEnd := lastProposal.UpdatedAt().Add(rules.ForTenant(log, db)(tenantId).InitialPerTargetCooldown())
+				return remainingUntil(cooldownEnd), nil
+			}
+
+			return 0, nil
+		}
+	}
+}
+
+// GetLastDivorceByCharacterProvider retrieves the most recent divorce of a character, optionally from a specific
+// former partner. A partnerId of 0 matches any partner
+func GetLastDivorceByCharacterProvider(db *gorm.DB, log logrus.FieldLogger) func(characterId, partnerId uint32, tenantId uuid.UUID) model.Provider[*Marriage] {
+	return func(characterId, partnerId uint32, tenantId uuid.UUID) model.Provider[*Marriage] {
+		return func() (*Marriage, error) {
+			log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"partnerId":   partnerId,
+				"tenantId":    tenantId,
+			}).Debug("Retrieving last divorce for character")
+
+			query := db.Where("tenant_id = ? AND status = ? AND divorced_at IS NOT NULL", tenantId, StatusDivorced)
+			if partnerId == 0 {
+				query = query.Where("character_id1 = ? OR character_id2 = ?", characterId, characterId)
+			} else {
+				query = query.Where("(character_id1 = ? AND character_id2 = ?) OR (character_id1 = ? AND character_id2 = ?)",
+					characterId, partnerId, partnerId, characterId)
+			}
+
+			var entity Entity
+			err := query.Order("divorced_at DESC").First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			marriage, err := Make(entity)
+			if err != nil {
+				return nil, err
+			}
+
+			return &marriage, nil
+		}
+	}
+}
+
+// GetRemarriageCooldownRemainingProvider returns how long remains of a character's cooldown after their last divorce,
+// or zero when none is active
+func GetRemarriageCooldownRemainingProvider(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+	return func(characterId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+		return divorceCooldownRemaining(GetLastDivorceByCharacterProvider(db, log)(characterId, 0, tenantId), rules.ForTenant(log, db)(tenantId).RemarriageCooldown())
+	}
+}
+
+// GetExPartnerCooldownRemainingProvider returns how long remains of the cooldown before two former partners may
+// propose to each other again, or zero when none is active
+func GetExPartnerCooldownRemainingProvider(db *gorm.DB, log logrus.FieldLogger) func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+	return func(proposerId, targetId uint32, tenantId uuid.UUID) model.Provider[time.Duration] {
+		return divorceCooldownRemaining(GetLastDivorceByCharacterProvider(db, log)(proposerId, targetId, tenantId), rules.ForTenant(log, db)(tenantId).ExPartnerCooldown())
+	}
+}
+
+// divorceCooldownRemaining returns how long remains of a cooldown starting when the provided divorce was granted
+func divorceCooldownRemaining(divorceProvider model.Provider[*Marriage], cooldown time.Duration) model.Provider[time.Duration] {
+	return func() (time.Duration, error) {
+		if cooldown <= 0 {
+			return 0, nil
+		}
+
+		divorce, err := divorceProvider()
+		if err != nil {
+			return 0, err
+		}
+		if divorce == nil || divorce.DivorcedAt() == nil {
+			return 0, nil // No previous divorce
+		}
+
+		return remainingUntil(divorce.DivorcedAt().Add(cooldown)), nil
+	}
+}
+
+// GetAnniversaryProvider retrieves the record of a marriage reaching an anniversary milestone, or nil if it has not been celebrated
+func GetAnniversaryProvider(db *gorm.DB, log logrus.FieldLogger) func(marriageId, milestoneDays uint32, tenantId uuid.UUID) model.Provider[*Anniversary] {
+	return func(marriageId, milestoneDays uint32, tenantId uuid.UUID) model.Provider[*Anniversary] {
+		return func() (*Anniversary, error) {
+			log.WithFields(logrus.Fields{
+				"marriageId":    marriageId,
+				"milestoneDays": milestoneDays,
+				"tenantId":      tenantId,
+			}).Debug("Retrieving anniversary")
+
+			var entity AnniversaryEntity
+			err := db.Where("marriage_id = ? AND milestone_days = ? AND tenant_id = ?", marriageId, milestoneDays, tenantId).
+				First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			anniversary := MakeAnniversary(entity)
+			return &anniversary, nil
+		}
+	}
+}
+
+// GetAnniversariesByMarriageProvider retrieves every anniversary milestone a marriage has celebrated, earliest first
+func GetAnniversariesByMarriageProvider(db *gorm.DB, log logrus.FieldLogger) func(marriageId uint32, tenantId uuid.UUID) model.Provider[[]Anniversary] {
+	return func(marriageId uint32, tenantId uuid.UUID) model.Provider[[]Anniversary] {
+		return func() ([]Anniversary, error) {
+			log.WithFields(logrus.Fields{
+				"marriageId": marriageId,
+				"tenantId":   tenantId,
+			}).Debug("Retrieving anniversaries for marriage")
+
+			var entities []AnniversaryEntity
+			err := db.Where("marriage_id = ? AND tenant_id = ?", marriageId, tenantId).
+				Order("milestone_days ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			anniversaries := make([]Anniversary, 0, len(entities))
+			for _, entity := range entities {
+				anniversaries = append(anniversaries, MakeAnniversary(entity))
+			}
+			return anniversaries, nil
+		}
+	}
+}
+
+// GetMarriagesReachingAnniversaryProvider retrieves active marriages which have lasted the given number of days but
+// have not yet celebrated that milestone
+func GetMarriagesReachingAnniversaryProvider(db *gorm.DB, log logrus.FieldLogger) func(milestoneDays uint32, tenantId uuid.UUID) model.Provider[[]Marriage] {
+	return func(milestoneDays uint32, tenantId uuid.UUID) model.Provider[[]Marriage] {
+		return func() ([]Marriage, error) {
+			log.WithFields(logrus.Fields{
+				"milestoneDays": milestoneDays,
+				"tenantId":      tenantId,
+			}).Debug("Retrieving marriages reaching anniversary")
+
+			marriedBefore := time.Now().Add(-time.Duration(milestoneDays) * 24 * time.Hour)
+
+			var entities []Entity
+			err := db.Where("tenant_id = ? AND status IN (?) AND married_at <= ?",
+				tenantId, []MarriageStatus{StatusMarried, StatusDivorcePending}, marriedBefore).
+				Where("NOT EXISTS (SELECT 1 FROM marriage_anniversaries WHERE marriage_anniversaries.marriage_id = marriages.id AND marriage_anniversaries.tenant_id = marriages.tenant_id AND marriage_anniversaries.milestone_days = ?)", milestoneDays).
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			marriages := make([]Marriage, 0, len(entities))
+			for _, entity := range entities {
+				marriage, err := Make(entity)
+				if err != nil {
+					return nil, err
+				}
+				marriages = append(marriages, marriage)
+			}
+			return marriages, nil
+		}
+	}
+}
+
+// GetMarriagesWithDecayingBondProvider retrieves active marriages holding bond points which have not been awarded or
+// decayed since the given time
+func GetMarriagesWithDecayingBondProvider(db *gorm.DB, log logrus.FieldLogger) func(inactiveSince time.Time, tenantId uuid.UUID) model.Provider[[]Marriage] {
+	return func(inactiveSince time.Time, tenantId uuid.UUID) model.Provider[[]Marriage] {
+		return func() ([]Marriage, error) {
+			log.WithFields(logrus.Fields{
+				"inactiveSince": inactiveSince,
+				"tenantId":      tenantId,
+			}).Debug("Retrieving marriages with decaying bonds")
+
+			var entities []Entity
+			err := db.Where("tenant_id = ? AND status IN (?) AND bond_points > 0 AND bond_activity_at <= ?",
+				tenantId, []MarriageStatus{StatusMarried, StatusDivorcePending}, inactiveSince).
+				Order("bond_activity_at ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			marriages := make([]Marriage, 0, len(entities))
+			for _, entity := range entities {
+				marriage, err := Make(entity)
+				if err != nil {
+					return nil, err
+				}
+				marriages = append(marriages, marriage)
 			}
+			return marriages, nil
+		}
+	}
+}
 
-			return true, nil
+// remainingUntil returns the time left until end, or zero once it has passed
+func remainingUntil(end time.Time) time.Duration {
+	remaining := time.Until(end)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// cooldownElapsed adapts a remaining cooldown provider into one reporting whether the cooldown has passed
+func cooldownElapsed(remainingProvider model.Provider[time.Duration]) model.Provider[bool] {
+	return func() (bool, error) {
+		remaining, err := remainingProvider()
+		if err != nil {
+			return false, err
 		}
+		return remaining <= 0, nil
 	}
 }
 
@@ -448,6 +708,43 @@ func GetUpcomingCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(ten
 	}
 }
 
+// GetOpenCeremoniesByInviteeProvider retrieves all scheduled, active and postponed ceremonies a character is invited to
+func GetOpenCeremoniesByInviteeProvider(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32, tenantId uuid.UUID) model.Provider[[]Ceremony] {
+	return func(characterId uint32, tenantId uuid.UUID) model.Provider[[]Ceremony] {
+		return func() ([]Ceremony, error) {
+			log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"tenantId":    tenantId,
+			}).Debug("Retrieving open ceremonies for invitee")
+
+			// Invitees are stored as a compact JSON array, so membership is matched on the id's position in the array
+			id := strconv.FormatUint(uint64(characterId), 10)
+			var entities []CeremonyEntity
+			err := db.Where("tenant_id = ? AND status IN ?", tenantId,
+				[]CeremonyStatus{CeremonyStatusScheduled, CeremonyStatusActive, CeremonyStatusPostponed}).
+				Where("(invitees = ? OR invitees LIKE ? OR invitees LIKE ? OR invitees LIKE ?)",
+					"["+id+"]", "["+id+",%", "%,"+id+",%", "%,"+id+"]").
+				Order("id ASC").
+				Find(&entities).Error
+
+			if err != nil {
+				return nil, err
+			}
+
+			ceremonies := make([]Ceremony, 0, len(entities))
+			for _, entity := range entities {
+				ceremony, err := MakeCeremony(entity)
+				if err != nil {
+					return nil, err
+				}
+				ceremonies = append(ceremonies, ceremony)
+			}
+
+			return ceremonies, nil
+		}
+	}
+}
+
 // GetActiveCeremoniesProvider retrieves all active ceremonies
 func GetActiveCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
 	return func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
@@ -477,16 +774,47 @@ func GetActiveCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(tenan
 	}
 }
 
-// GetTimeoutCeremoniesProvider retrieves all active ceremonies that have been running longer than the disconnection timeout
+// GetActiveCeremonyByPartnerProvider retrieves the active ceremony in which a character is one of the couple
+func GetActiveCeremonyByPartnerProvider(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32, tenantId uuid.UUID) model.Provider[*Ceremony] {
+	return func(characterId uint32, tenantId uuid.UUID) model.Provider[*Ceremony] {
+		return func() (*Ceremony, error) {
+			log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"tenantId":    tenantId,
+			}).Debug("Retrieving active ceremony for partner")
+
+			var entity CeremonyEntity
+			err := db.Where("tenant_id = ? AND status = ? AND (character_id1 = ? OR character_id2 = ?)",
+				tenantId, CeremonyStatusActive, characterId, characterId).
+				First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			ceremony, err := MakeCeremony(entity)
+			if err != nil {
+				return nil, err
+			}
+
+			return &ceremony, nil
+		}
+	}
+}
+
+// GetTimeoutCeremoniesProvider retrieves all active ceremonies in which a partner has been disconnected for longer than
+// the disconnection timeout
 func GetTimeoutCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
 	return func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
 		return func() ([]Ceremony, error) {
 			log.WithField("tenantId", tenantId).Debug("Retrieving ceremonies that may have timed out")
 
 			var entities []CeremonyEntity
-			timeoutThreshold := time.Now().Add(-DisconnectionTimeout)
-			err := db.Where("tenant_id = ? AND status = ? AND started_at < ?", 
-				tenantId, CeremonyStatusActive, timeoutThreshold).
+			timeoutThreshold := time.Now().Add(-rules.ForTenant(log, db)(tenantId).DisconnectionTimeout())
+			err := db.Where("tenant_id = ? AND status = ? AND (disconnected_at1 <= ? OR disconnected_at2 <= ?)",
+				tenantId, CeremonyStatusActive, timeoutThreshold, timeoutThreshold).
 				Order("started_at ASC").
 				Find(&entities).Error
 
@@ -513,6 +841,64 @@ func GetTimeoutCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(tena
 	}
 }
 
+// GetCeremoniesAwaitingReminderProvider retrieves all scheduled ceremonies starting within the lead time whose couple
+// and invitees have not been reminded
+func GetCeremoniesAwaitingReminderProvider(db *gorm.DB, log logrus.FieldLogger) func(leadTime time.Duration, tenantId uuid.UUID) model.Provider[[]Ceremony] {
+	return func(leadTime time.Duration, tenantId uuid.UUID) model.Provider[[]Ceremony] {
+		return func() ([]Ceremony, error) {
+			log.WithField("tenantId", tenantId).Debug("Retrieving ceremonies awaiting a reminder")
+
+			var entities []CeremonyEntity
+			now := time.Now()
+			err := db.Where("tenant_id = ? AND status = ? AND reminded_at IS NULL AND scheduled_at > ? AND scheduled_at <= ?",
+				tenantId, CeremonyStatusScheduled, now, now.Add(leadTime)).
+				Order("scheduled_at ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			ceremonies := make([]Ceremony, 0, len(entities))
+			for _, entity := range entities {
+				ceremony, err := MakeCeremony(entity)
+				if err != nil {
+					return nil, err
+				}
+				ceremonies = append(ceremonies, ceremony)
+			}
+			return ceremonies, nil
+		}
+	}
+}
+
+// GetDueCeremoniesProvider retrieves all scheduled ceremonies whose scheduled start has passed
+func GetDueCeremoniesProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
+	return func(tenantId uuid.UUID) model.Provider[[]Ceremony] {
+		return func() ([]Ceremony, error) {
+			log.WithField("tenantId", tenantId).Debug("Retrieving due ceremonies")
+
+			var entities []CeremonyEntity
+			err := db.Where("tenant_id = ? AND status = ? AND scheduled_at <= ?",
+				tenantId, CeremonyStatusScheduled, time.Now()).
+				Order("scheduled_at ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			ceremonies := make([]Ceremony, 0, len(entities))
+			for _, entity := range entities {
+				ceremony, err := MakeCeremony(entity)
+				if err != nil {
+					return nil, err
+				}
+				ceremonies = append(ceremonies, ceremony)
+			}
+			return ceremonies, nil
+		}
+	}
+}
+
 // GetExpiredProposalsProvider retrieves all proposals that have expired but not yet been marked as expired
 func GetExpiredProposalsProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[[]Proposal] {
 	return func(tenantId uuid.UUID) model.Provider[[]Proposal] {
@@ -547,4 +933,61 @@ func GetExpiredProposalsProvider(db *gorm.DB, log logrus.FieldLogger) func(tenan
 			return proposals, nil
 		}
 	}
-}
\ No newline at end of file
+}
+
+// GetMaturedDivorcesProvider retrieves all marriages whose filed divorce has reached the end of its waiting period
+func GetMaturedDivorcesProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[[]Marriage] {
+	return func(tenantId uuid.UUID) model.Provider[[]Marriage] {
+		return func() ([]Marriage, error) {
+			log.WithField("tenantId", tenantId).Debug("Retrieving matured divorces")
+
+			var entities []Entity
+			err := db.Where("tenant_id = ? AND status = ? AND divorce_matures_at <= ?",
+				tenantId, StatusDivorcePending, time.Now()).
+				Order("divorce_matures_at ASC").
+				Find(&entities).Error
+
+			if err != nil {
+				return nil, err
+			}
+
+			marriages := make([]Marriage, 0, len(entities))
+			for _, entity := range entities {
+				marriage, err := Make(entity)
+				if err != nil {
+					return nil, err
+				}
+				marriages = append(marriages, marriage)
+			}
+
+			log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"count":    len(marriages),
+			}).Debug("Found matured divorces")
+
+			return marriages, nil
+		}
+	}
+}
+
+// GetPartnersOnlineProvider reports whether both of a ceremony's couple are logged in, as their recorded presences
+// report. A partner without a recorded presence has not logged in since presence was first tracked
+func GetPartnersOnlineProvider(db *gorm.DB, log logrus.FieldLogger) func(ceremony Ceremony, tenantId uuid.UUID) model.Provider[bool] {
+	return func(ceremony Ceremony, tenantId uuid.UUID) model.Provider[bool] {
+		return func() (bool, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId": ceremony.Id(),
+				"tenantId":   tenantId,
+			}).Debug("Retrieving presence of ceremony partners")
+
+			var online int64
+			err := db.Model(&PresenceEntity{}).
+				Where("tenant_id = ? AND character_id IN ? AND online = ?", tenantId, []uint32{ceremony.CharacterId1(), ceremony.CharacterId2()}, true).
+				Count(&online).Error
+			if err != nil {
+				return false, err
+			}
+			return online == 2, nil
+		}
+	}
+}
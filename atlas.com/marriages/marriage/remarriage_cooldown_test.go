@@ -0,0 +1,121 @@
+package marriage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupRemarriageCooldownTest creates characters 1, 2 and 3, with 1 and 2 divorced an hour ago
+func setupRemarriageCooldownTest(t *testing.T) (*gorm.DB, uuid.UUID, Processor) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	rules.GetRegistry().Invalidate(tenantId)
+
+	now := time.Now()
+	marriedAt := now.Add(-30 * 24 * time.Hour)
+	divorcedAt := now.Add(-time.Hour)
+	if err := db.Create(&Entity{
+		CharacterId1: 1,
+		CharacterId2: 2,
+		Status:       StatusDivorced,
+		ProposedAt:   marriedAt,
+		EngagedAt:    &marriedAt,
+		MarriedAt:    &marriedAt,
+		DivorcedAt:   &divorcedAt,
+		TenantId:     tenantId,
+		CreatedAt:    marriedAt,
+		UpdatedAt:    divorcedAt,
+	}).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+
+	mockCharacterProcessor := NewMockCharacterProcessor()
+	mockCharacterProcessor.AddCharacter(1, "Character1", 15)
+	mockCharacterProcessor.AddCharacter(2, "Character2", 15)
+	mockCharacterProcessor.AddCharacter(3, "Character3", 15)
+
+	processor := NewProcessor(log, ctx, db).
+		WithProducer(NewMockProducer().Provider).
+		WithCharacterProcessor(mockCharacterProcessor)
+	return db, tenantId, processor
+}
+
+func TestProcessor_Propose_RemarriageCooldown(t *testing.T) {
+	_, _, processor := setupRemarriageCooldownTest(t)
+
+	_, err := processor.Propose(1, 3)()
+	var cooldownErr CooldownError
+	if !errors.As(err, &cooldownErr) || !errors.Is(err, ErrRemarriageCooldownActive) {
+		t.Fatalf("Expected remarriage cooldown error, got %v", err)
+	}
+	remaining := rules.DefaultRemarriageCooldown - time.Hour
+	if cooldownErr.Remaining <= remaining-time.Minute || cooldownErr.Remaining > remaining {
+		t.Errorf("Expected about %v of the remarriage cooldown to remain, got %v", remaining, cooldownErr.Remaining)
+	}
+
+	verdict, err := processor.EvaluateProposalEligibility(3, 2)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	violations := verdict.Violations()
+	if len(violations) != 1 || violations[0].Rule != ErrRemarriageCooldownActive.ErrorCode() || violations[0].CharacterId != 2 {
+		t.Fatalf("Expected the divorced target to be in remarriage cooldown, got %v", violations)
+	}
+	if violations[0].RemainingSeconds() <= 0 {
+		t.Errorf("Expected the violation to carry the remaining time, got %d", violations[0].RemainingSeconds())
+	}
+	if !verdict.CharactersEligible() {
+		t.Error("Expected the remarriage cooldown not to make the characters ineligible")
+	}
+}
+
+func TestProcessor_Propose_ExPartnerCooldown(t *testing.T) {
+	db, tenantId, processor := setupRemarriageCooldownTest(t)
+
+	remarriage := int64(0)
+	exPartner := int64(48 * 60 * 60)
+	if err := db.Create(&rules.Entity{TenantId: tenantId, RemarriageCooldownSeconds: &remarriage, ExPartnerCooldownSeconds: &exPartner, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	verdict, err := processor.EvaluateProposalEligibility(1, 3)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !verdict.Eligible() {
+		t.Errorf("Expected a divorced character to propose to someone new, got %v", verdict.Violations())
+	}
+
+	_, err = processor.Propose(2, 1)()
+	if !errors.Is(err, ErrExPartnerCooldownActive) {
+		t.Fatalf("Expected ex-partner cooldown error, got %v", err)
+	}
+}
+
+func TestProcessor_Propose_DeletionStartsNoRemarriageCooldown(t *testing.T) {
+	db, _, processor := setupRemarriageCooldownTest(t)
+
+	deletedAt := time.Now()
+	if err := db.Model(&Entity{}).Where("character_id1 = ?", 1).Updates(map[string]interface{}{
+		"status":      StatusDeleted,
+		"divorced_at": nil,
+		"deleted_at":  &deletedAt,
+	}).Error; err != nil {
+		t.Fatalf("Failed to delete marriage: %v", err)
+	}
+
+	if _, err := processor.Propose(2, 3)(); err != nil {
+		t.Fatalf("Expected the surviving partner to propose, got %v", err)
+	}
+}
@@ -1,45 +1,161 @@
 package marriage
 
 import (
+	"atlas-marriages/character"
 	"atlas-marriages/rest"
+	"atlas-marriages/venue"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Chronicle20/atlas-rest/server"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/jtumidanski/api2go/jsonapi"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+// maxAvailabilityWindow is the longest period venue availability may be requested for
+const maxAvailabilityWindow = 7 * 24 * time.Hour
+
 // InitializeRoutes initializes marriage-related REST routes
 func InitializeRoutes(db *gorm.DB) func(serverInfo jsonapi.ServerInformation) func(router *mux.Router, logger logrus.FieldLogger) {
+	return initializeRoutes(NewProcessor, character.NewProcessor, db)
+}
+
+// initializeRoutes initializes marriage-related REST routes whose handlers create processors with the given producer,
+// resolving the characters shown in marriage and proposal responses with the given character processor producer
+func initializeRoutes(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) func(serverInfo jsonapi.ServerInformation) func(router *mux.Router, logger logrus.FieldLogger) {
 	return func(serverInfo jsonapi.ServerInformation) func(router *mux.Router, logger logrus.FieldLogger) {
 		return func(router *mux.Router, logger logrus.FieldLogger) {
 			// GET /api/characters/{characterId}/marriage
 			router.HandleFunc("/characters/{characterId}/marriage",
-				rest.RegisterHandler(logger)(serverInfo)("get_character_marriage", getMarriageHandler(db))).
+				rest.RegisterHandler(logger)(serverInfo)("get_character_marriage", getMarriageHandler(pp, cp, db))).
 				Methods(http.MethodGet)
 
 			// GET /api/characters/{characterId}/marriage/history
 			router.HandleFunc("/characters/{characterId}/marriage/history",
-				rest.RegisterHandler(logger)(serverInfo)("get_marriage_history", getMarriageHistoryHandler(db))).
+				rest.RegisterHandler(logger)(serverInfo)("get_marriage_history", getMarriageHistoryHandler(pp, cp, db))).
+				Methods(http.MethodGet)
+
+			// GET /api/characters/{characterId}/marriage/bond
+			router.HandleFunc("/characters/{characterId}/marriage/bond",
+				rest.RegisterHandler(logger)(serverInfo)("get_marriage_bond", getBondHandler(pp, db))).
+				Methods(http.MethodGet)
+
+			// GET /api/characters/{characterId}/marriage/skills
+			router.HandleFunc("/characters/{characterId}/marriage/skills",
+				rest.RegisterHandler(logger)(serverInfo)("get_couple_skills", getCoupleSkillsHandler(pp, db))).
 				Methods(http.MethodGet)
 
 			// GET /api/characters/{characterId}/marriage/proposals
 			router.HandleFunc("/characters/{characterId}/marriage/proposals",
-				rest.RegisterHandler(logger)(serverInfo)("get_character_proposals", getProposalsHandler(db))).
+				rest.RegisterHandler(logger)(serverInfo)("get_character_proposals", getProposalsHandler(pp, cp, db))).
+				Methods(http.MethodGet)
+
+			// GET /api/characters/{characterId}/marriage/eligibility?target={targetId}
+			router.HandleFunc("/characters/{characterId}/marriage/eligibility",
+				rest.RegisterHandler(logger)(serverInfo)("get_proposal_eligibility", getEligibilityHandler(pp, db))).
+				Methods(http.MethodGet)
+
+			// POST /api/characters/{characterId}/marriage/proposals
+			router.HandleFunc("/characters/{characterId}/marriage/proposals",
+				rest.RegisterInputHandler[ProposalInputRestModel](logger)(serverInfo)("create_proposal", createProposalHandler(pp, cp, db))).
+				Methods(http.MethodPost)
+
+			// DELETE /api/characters/{characterId}/marriage
+			router.HandleFunc("/characters/{characterId}/marriage",
+				rest.RegisterHandler(logger)(serverInfo)("divorce", divorceHandler(pp, db))).
+				Methods(http.MethodDelete)
+
+			// POST /api/characters/{characterId}/marriage/divorce
+			router.HandleFunc("/characters/{characterId}/marriage/divorce",
+				rest.RegisterHandler(logger)(serverInfo)("file_divorce", fileDivorceHandler(pp, cp, db))).
+				Methods(http.MethodPost)
+
+			// POST /api/characters/{characterId}/marriage/divorce/consent
+			router.HandleFunc("/characters/{characterId}/marriage/divorce/consent",
+				rest.RegisterHandler(logger)(serverInfo)("consent_divorce", consentDivorceHandler(pp, cp, db))).
+				Methods(http.MethodPost)
+
+			// DELETE /api/characters/{characterId}/marriage/divorce
+			router.HandleFunc("/characters/{characterId}/marriage/divorce",
+				rest.RegisterHandler(logger)(serverInfo)("withdraw_divorce", withdrawDivorceHandler(pp, cp, db))).
+				Methods(http.MethodDelete)
+
+			// POST /api/proposals/{proposalId}/accept
+			router.HandleFunc("/proposals/{proposalId}/accept",
+				rest.RegisterHandler(logger)(serverInfo)("accept_proposal", acceptProposalHandler(pp, cp, db))).
+				Methods(http.MethodPost)
+
+			// POST /api/proposals/{proposalId}/decline
+			router.HandleFunc("/proposals/{proposalId}/decline",
+				rest.RegisterHandler(logger)(serverInfo)("decline_proposal", declineProposalHandler(pp, cp, db))).
+				Methods(http.MethodPost)
+
+			// DELETE /api/proposals/{proposalId}
+			router.HandleFunc("/proposals/{proposalId}",
+				rest.RegisterHandler(logger)(serverInfo)("cancel_proposal", cancelProposalHandler(pp, db))).
+				Methods(http.MethodDelete)
+
+			// GET /api/ceremonies/availability
+			router.HandleFunc("/ceremonies/availability",
+				rest.RegisterHandler(logger)(serverInfo)("get_ceremony_availability", getCeremonyAvailabilityHandler(pp, db))).
+				Methods(http.MethodGet)
+
+			// GET /api/venues
+			router.HandleFunc("/venues",
+				rest.RegisterHandler(logger)(serverInfo)("get_venues", getVenuesHandler(pp, db))).
+				Methods(http.MethodGet)
+
+			// POST /api/venues
+			router.HandleFunc("/venues",
+				rest.RegisterInputHandler[VenueInputRestModel](logger)(serverInfo)("create_venue", createVenueHandler(pp, db))).
+				Methods(http.MethodPost)
+
+			// POST /api/ceremonies
+			router.HandleFunc("/ceremonies",
+				rest.RegisterInputHandler[CeremonyInputRestModel](logger)(serverInfo)("schedule_ceremony", scheduleCeremonyHandler(pp, db))).
+				Methods(http.MethodPost)
+
+			// PATCH /api/ceremonies/{ceremonyId}
+			router.HandleFunc("/ceremonies/{ceremonyId}",
+				rest.RegisterInputHandler[CeremonyInputRestModel](logger)(serverInfo)("update_ceremony", updateCeremonyHandler(pp, db))).
+				Methods(http.MethodPatch)
+
+			// DELETE /api/ceremonies/{ceremonyId}
+			router.HandleFunc("/ceremonies/{ceremonyId}",
+				rest.RegisterHandler(logger)(serverInfo)("cancel_ceremony", cancelCeremonyHandler(pp, db))).
+				Methods(http.MethodDelete)
+
+			// POST /api/ceremonies/{ceremonyId}/invitees
+			router.HandleFunc("/ceremonies/{ceremonyId}/invitees",
+				rest.RegisterInputHandler[InviteeInputRestModel](logger)(serverInfo)("add_invitee", addInviteeHandler(pp, db))).
+				Methods(http.MethodPost)
+
+			// DELETE /api/ceremonies/{ceremonyId}/invitees/{characterId}
+			router.HandleFunc("/ceremonies/{ceremonyId}/invitees/{characterId}",
+				rest.RegisterHandler(logger)(serverInfo)("remove_invitee", removeInviteeHandler(pp, db))).
+				Methods(http.MethodDelete)
+
+			// GET /api/ceremonies/{ceremonyId}/registry
+			router.HandleFunc("/ceremonies/{ceremonyId}/registry",
+				rest.RegisterHandler(logger)(serverInfo)("get_ceremony_registry", getCeremonyRegistryHandler(pp, db))).
 				Methods(http.MethodGet)
 		}
 	}
 }
 
 // getMarriageHandler returns the current marriage for a character
-func getMarriageHandler(db *gorm.DB) rest.GetHandler {
+func getMarriageHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
 	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
 		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
-				processor := NewProcessor(d.Logger(), d.Context(), db)
+				processor := pp(d.Logger(), d.Context(), db)
 				marriage, err := processor.GetMarriageByCharacter(characterId)()
 				if err != nil {
 					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
@@ -60,7 +176,7 @@ func getMarriageHandler(db *gorm.DB) rest.GetHandler {
 				}
 
 				// Get ceremony information if marriage is engaged or married
-				if marriage.Status() == StatusEngaged || marriage.Status() == StatusMarried {
+				if marriage.Status() == StatusEngaged || marriage.IsActive() {
 					ceremony, err := processor.GetCeremonyByMarriage(marriage.Id())()
 					if err == nil && ceremony != nil {
 						restMarriage, err = TransformMarriageComplete(*marriage, characterId, ceremony)
@@ -70,6 +186,9 @@ func getMarriageHandler(db *gorm.DB) rest.GetHandler {
 					}
 				}
 
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restMarriage = lookup.WithPartner(restMarriage, characterId)
+
 				query := r.URL.Query()
 				queryParams := jsonapi.ParseQueryFields(&query)
 				server.MarshalResponse[RestMarriage](d.Logger())(w)(c.ServerInformation())(queryParams)(restMarriage)
@@ -79,11 +198,11 @@ func getMarriageHandler(db *gorm.DB) rest.GetHandler {
 }
 
 // getMarriageHistoryHandler returns marriage history for a character
-func getMarriageHistoryHandler(db *gorm.DB) rest.GetHandler {
+func getMarriageHistoryHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
 	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
 		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
-				processor := NewProcessor(d.Logger(), d.Context(), db)
+				processor := pp(d.Logger(), d.Context(), db)
 				marriages, err := processor.GetMarriageHistory(characterId)()
 				if err != nil {
 					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
@@ -97,6 +216,9 @@ func getMarriageHistoryHandler(db *gorm.DB) rest.GetHandler {
 					return
 				}
 
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restMarriages = lookup.WithPartners(restMarriages, characterId)
+
 				query := r.URL.Query()
 				queryParams := jsonapi.ParseQueryFields(&query)
 				server.MarshalResponse[[]RestMarriage](d.Logger())(w)(c.ServerInformation())(queryParams)(restMarriages)
@@ -105,12 +227,64 @@ func getMarriageHistoryHandler(db *gorm.DB) rest.GetHandler {
 	}
 }
 
+// getBondHandler returns the bond level and progress of a character's marriage
+func getBondHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				bond, err := processor.GetBond(characterId)()
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restBond, err := TransformBond(bond)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform bond data")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestBond](d.Logger())(w)(c.ServerInformation())(queryParams)(restBond)
+			}
+		})
+	}
+}
+
+// getCoupleSkillsHandler returns the tenant's couple skills and which of them a character holds
+func getCoupleSkillsHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				skills, err := processor.GetCoupleSkills(characterId)()
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restSkills, err := TransformCoupleSkills(skills)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform couple skill data")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[[]RestCoupleSkill](d.Logger())(w)(c.ServerInformation())(queryParams)(restSkills)
+			}
+		})
+	}
+}
+
 // getProposalsHandler returns pending proposals for a character
-func getProposalsHandler(db *gorm.DB) rest.GetHandler {
+func getProposalsHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
 	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
 		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
-				processor := NewProcessor(d.Logger(), d.Context(), db)
+				processor := pp(d.Logger(), d.Context(), db)
 				proposals, err := processor.GetPendingProposalsByCharacter(characterId)()
 				if err != nil {
 					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
@@ -124,6 +298,9 @@ func getProposalsHandler(db *gorm.DB) rest.GetHandler {
 					return
 				}
 
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restProposals = lookup.WithParticipants(restProposals)
+
 				query := r.URL.Query()
 				queryParams := jsonapi.ParseQueryFields(&query)
 				server.MarshalResponse[[]RestProposal](d.Logger())(w)(c.ServerInformation())(queryParams)(restProposals)
@@ -132,6 +309,553 @@ func getProposalsHandler(db *gorm.DB) rest.GetHandler {
 	}
 }
 
+// getEligibilityHandler evaluates whether a character may propose to the target given in the query string
+func getEligibilityHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				targetId, err := parseOptionalCharacterId(r, "target")
+				if err != nil || targetId == 0 {
+					writeErrorResponse(w, http.StatusBadRequest, "target must be a valid character id")
+					return
+				}
+
+				processor := pp(d.Logger(), d.Context(), db)
+				verdict, err := processor.EvaluateProposalEligibility(characterId, targetId)()
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restEligibility, err := TransformEligibility(verdict)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform eligibility data")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestEligibility](d.Logger())(w)(c.ServerInformation())(queryParams)(restEligibility)
+			}
+		})
+	}
+}
+
+// createProposalHandler creates a proposal from a character to the target character
+func createProposalHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.InputHandler[ProposalInputRestModel] {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input ProposalInputRestModel) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				proposal, err := processor.ProposeAndEmit(uuid.New(), characterId, input.TargetCharacterId)
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restProposal, err := TransformProposal(proposal)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform proposal data")
+					return
+				}
+
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restProposal = lookup.WithParticipants([]RestProposal{restProposal})[0]
+
+				w.WriteHeader(http.StatusCreated)
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestProposal](d.Logger())(w)(c.ServerInformation())(queryParams)(restProposal)
+			}
+		})
+	}
+}
+
+// acceptProposalHandler accepts a proposal, returning the resulting engagement
+func acceptProposalHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseProposalId(d.Logger(), func(proposalId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				marriage, err := processor.AcceptProposalAndEmit(uuid.New(), proposalId)
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restMarriage, err := TransformMarriage(marriage)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform marriage data")
+					return
+				}
+
+				// The engagement is shown from the accepting target's side, with the proposer as their partner
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restMarriage = lookup.WithPartner(restMarriage, marriage.CharacterId2())
+
+				w.WriteHeader(http.StatusCreated)
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestMarriage](d.Logger())(w)(c.ServerInformation())(queryParams)(restMarriage)
+			}
+		})
+	}
+}
+
+// declineProposalHandler declines a proposal
+func declineProposalHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseProposalId(d.Logger(), func(proposalId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				proposal, err := processor.DeclineProposalAndEmit(uuid.New(), proposalId)
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restProposal, err := TransformProposal(proposal)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform proposal data")
+					return
+				}
+
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restProposal = lookup.WithParticipants([]RestProposal{restProposal})[0]
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestProposal](d.Logger())(w)(c.ServerInformation())(queryParams)(restProposal)
+			}
+		})
+	}
+}
+
+// cancelProposalHandler cancels a pending proposal
+func cancelProposalHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseProposalId(d.Logger(), func(proposalId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				if _, err := processor.CancelProposalAndEmit(uuid.New(), proposalId); err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+	}
+}
+
+// divorceHandler divorces a character from their current partner
+func divorceHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				marriage, err := processor.GetMarriageByCharacter(characterId)()
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+				if marriage == nil {
+					writeErrorResponse(w, http.StatusNotFound, "Character is not married")
+					return
+				}
+
+				if _, err = processor.DivorceAndEmit(uuid.New(), marriage.Id(), characterId); err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+	}
+}
+
+// fileDivorceHandler files for divorce on behalf of a character, returning the marriage with its pending divorce
+func fileDivorceHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return characterMarriageHandler(pp, cp, db, func(processor Processor, marriageId uint32, characterId uint32) (Marriage, error) {
+		return processor.FileDivorceAndEmit(uuid.New(), marriageId, characterId)
+	})
+}
+
+// consentDivorceHandler consents to the divorce filed by a character's partner, finalizing it
+func consentDivorceHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return characterMarriageHandler(pp, cp, db, func(processor Processor, marriageId uint32, characterId uint32) (Marriage, error) {
+		return processor.ConsentDivorceAndEmit(uuid.New(), marriageId, characterId)
+	})
+}
+
+// withdrawDivorceHandler withdraws the divorce filed by a character, restoring the marriage
+func withdrawDivorceHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return characterMarriageHandler(pp, cp, db, func(processor Processor, marriageId uint32, characterId uint32) (Marriage, error) {
+		return processor.WithdrawDivorceAndEmit(uuid.New(), marriageId, characterId)
+	})
+}
+
+// characterMarriageHandler applies an operation to the active marriage of the character in the path on their
+// behalf, writing the resulting marriage
+func characterMarriageHandler(pp ProcessorProducer, cp character.ProcessorProducer, db *gorm.DB, operation func(processor Processor, marriageId uint32, characterId uint32) (Marriage, error)) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				marriage, err := processor.GetMarriageByCharacter(characterId)()
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, err.Error())
+					return
+				}
+				if marriage == nil {
+					writeErrorResponse(w, http.StatusNotFound, "Character is not married")
+					return
+				}
+
+				result, err := operation(processor, marriage.Id(), characterId)
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restMarriage, err := TransformMarriageWithPartner(result, characterId)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform marriage data")
+					return
+				}
+
+				lookup := newCharacterLookup(d.Logger(), cp(d.Logger(), d.Context(), db))
+				restMarriage = lookup.WithPartner(restMarriage, characterId)
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestMarriage](d.Logger())(w)(c.ServerInformation())(queryParams)(restMarriage)
+			}
+		})
+	}
+}
+
+// getCeremonyAvailabilityHandler returns the free slots of the venues in the world and channel given in the query
+// string. The window defaults to the day from now and may span at most a week
+func getCeremonyAvailabilityHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			worldId, err := strconv.ParseUint(query.Get("worldId"), 10, 8)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "worldId must be a valid world id")
+				return
+			}
+			channelId, err := strconv.ParseUint(query.Get("channelId"), 10, 8)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "channelId must be a valid channel id")
+				return
+			}
+			from, err := parseOptionalTime(r, "from", time.Now())
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "from must be an RFC 3339 timestamp")
+				return
+			}
+			to, err := parseOptionalTime(r, "to", from.Add(24*time.Hour))
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "to must be an RFC 3339 timestamp")
+				return
+			}
+			if !to.After(from) || to.Sub(from) > maxAvailabilityWindow {
+				writeErrorResponse(w, http.StatusBadRequest, "to must be after from and at most a week later")
+				return
+			}
+
+			processor := pp(d.Logger(), d.Context(), db)
+			availability, err := processor.GetCeremonyAvailability(byte(worldId), byte(channelId), from, to)()
+			if err != nil {
+				writeProcessorError(d.Logger(), w, err)
+				return
+			}
+
+			restAvailability, err := TransformVenueAvailability(availability)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform venue availability data")
+				return
+			}
+
+			queryParams := jsonapi.ParseQueryFields(&query)
+			server.MarshalResponse[[]RestVenueAvailability](d.Logger())(w)(c.ServerInformation())(queryParams)(restAvailability)
+		}
+	}
+}
+
+// getVenuesHandler returns the tenant's catalogue of venues in the world and channel given in the query string
+func getVenuesHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			worldId, err := strconv.ParseUint(query.Get("worldId"), 10, 8)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "worldId must be a valid world id")
+				return
+			}
+			channelId, err := strconv.ParseUint(query.Get("channelId"), 10, 8)
+			if err != nil {
+				writeErrorResponse(w, http.StatusBadRequest, "channelId must be a valid channel id")
+				return
+			}
+
+			processor := pp(d.Logger(), d.Context(), db)
+			venues, err := processor.GetVenues(byte(worldId), byte(channelId))()
+			if err != nil {
+				writeProcessorError(d.Logger(), w, err)
+				return
+			}
+
+			restVenues, err := TransformVenues(venues)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform venue data")
+				return
+			}
+
+			queryParams := jsonapi.ParseQueryFields(&query)
+			server.MarshalResponse[[]RestVenue](d.Logger())(w)(c.ServerInformation())(queryParams)(restVenues)
+		}
+	}
+}
+
+// createVenueHandler adds a venue to the tenant's catalogue
+func createVenueHandler(pp ProcessorProducer, db *gorm.DB) rest.InputHandler[VenueInputRestModel] {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input VenueInputRestModel) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if input.Name == "" || input.SlotLengthSeconds <= 0 {
+				writeErrorResponse(w, http.StatusBadRequest, "name and slotLengthSeconds are required")
+				return
+			}
+			venueType := venue.TypeChapel
+			if input.VenueType != "" {
+				venueType = venue.Type(input.VenueType)
+			}
+
+			processor := pp(d.Logger(), d.Context(), db)
+			created, err := processor.CreateVenue(input.WorldId, input.ChannelId, input.Name, venueType, input.Capacity, time.Duration(input.SlotLengthSeconds)*time.Second)()
+			if err != nil {
+				writeProcessorError(d.Logger(), w, err)
+				return
+			}
+
+			restVenue, err := TransformVenue(created)
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform venue data")
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			query := r.URL.Query()
+			queryParams := jsonapi.ParseQueryFields(&query)
+			server.MarshalResponse[RestVenue](d.Logger())(w)(c.ServerInformation())(queryParams)(restVenue)
+		}
+	}
+}
+
+// scheduleCeremonyHandler schedules a ceremony for an engaged marriage
+func scheduleCeremonyHandler(pp ProcessorProducer, db *gorm.DB) rest.InputHandler[CeremonyInputRestModel] {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input CeremonyInputRestModel) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if input.MarriageId == 0 || input.ScheduledAt == nil {
+				writeErrorResponse(w, http.StatusBadRequest, "marriageId and scheduledAt are required")
+				return
+			}
+
+			processor := pp(d.Logger(), d.Context(), db)
+			ceremony, err := processor.ScheduleCeremonyAndEmit(uuid.New(), input.MarriageId, *input.ScheduledAt, input.Invitees, VenueTier(input.VenueTier), input.VenueId)
+			if err != nil {
+				writeProcessorError(d.Logger(), w, err)
+				return
+			}
+
+			writeCeremonyResponse(d, c, w, r, http.StatusCreated, ceremony)
+		}
+	}
+}
+
+// updateCeremonyHandler starts, completes, postpones or reschedules a ceremony
+func updateCeremonyHandler(pp ProcessorProducer, db *gorm.DB) rest.InputHandler[CeremonyInputRestModel] {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input CeremonyInputRestModel) http.HandlerFunc {
+		return rest.ParseCeremonyId(d.Logger(), func(ceremonyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				transactionId := uuid.New()
+
+				var ceremony Ceremony
+				var err error
+				switch {
+				case input.Status == CeremonyStatusActive.String():
+					ceremony, err = processor.StartCeremonyAndEmit(transactionId, ceremonyId)
+				case input.Status == CeremonyStatusCompleted.String():
+					ceremony, err = processor.CompleteCeremonyAndEmit(transactionId, ceremonyId)
+				case input.Status == CeremonyStatusPostponed.String():
+					ceremony, err = processor.PostponeCeremonyAndEmit(transactionId, ceremonyId, input.Reason)
+				case input.Status == "" && input.ScheduledAt != nil:
+					ceremony, err = processor.RescheduleCeremonyAndEmit(transactionId, ceremonyId, *input.ScheduledAt, input.CharacterId)
+				default:
+					writeErrorResponse(w, http.StatusBadRequest, "status must be one of active, completed or postponed, or scheduledAt must be provided")
+					return
+				}
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				writeCeremonyResponse(d, c, w, r, http.StatusOK, ceremony)
+			}
+		})
+	}
+}
+
+// cancelCeremonyHandler cancels a ceremony. The cancelling character and reason are taken from the query string
+func cancelCeremonyHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCeremonyId(d.Logger(), func(ceremonyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				cancelledBy, err := parseOptionalCharacterId(r, "characterId")
+				if err != nil {
+					writeErrorResponse(w, http.StatusBadRequest, "characterId must be a valid character id")
+					return
+				}
+
+				processor := pp(d.Logger(), d.Context(), db)
+				if _, err = processor.CancelCeremonyAndEmit(uuid.New(), ceremonyId, cancelledBy, r.URL.Query().Get("reason")); err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}
+		})
+	}
+}
+
+// addInviteeHandler adds an invitee to a ceremony
+func addInviteeHandler(pp ProcessorProducer, db *gorm.DB) rest.InputHandler[InviteeInputRestModel] {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input InviteeInputRestModel) http.HandlerFunc {
+		return rest.ParseCeremonyId(d.Logger(), func(ceremonyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.CharacterId == 0 {
+					writeErrorResponse(w, http.StatusBadRequest, "characterId is required")
+					return
+				}
+
+				processor := pp(d.Logger(), d.Context(), db)
+				ceremony, err := processor.AddInviteeAndEmit(uuid.New(), ceremonyId, input.CharacterId, input.AddedBy)
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				writeCeremonyResponse(d, c, w, r, http.StatusCreated, ceremony)
+			}
+		})
+	}
+}
+
+// removeInviteeHandler removes an invitee from a ceremony. The removing character is taken from the query string
+func removeInviteeHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCeremonyId(d.Logger(), func(ceremonyId uint32) http.HandlerFunc {
+			return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					removedBy, err := parseOptionalCharacterId(r, "removedBy")
+					if err != nil {
+						writeErrorResponse(w, http.StatusBadRequest, "removedBy must be a valid character id")
+						return
+					}
+
+					processor := pp(d.Logger(), d.Context(), db)
+					if _, err = processor.RemoveInviteeAndEmit(uuid.New(), ceremonyId, characterId, removedBy); err != nil {
+						writeProcessorError(d.Logger(), w, err)
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})
+		})
+	}
+}
+
+// getCeremonyRegistryHandler returns a ceremony's wish list, who gave what from it and the blessings it received
+func getCeremonyRegistryHandler(pp ProcessorProducer, db *gorm.DB) rest.GetHandler {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCeremonyId(d.Logger(), func(ceremonyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				processor := pp(d.Logger(), d.Context(), db)
+				ceremonyRegistry, err := processor.GetCeremonyRegistry(ceremonyId)()
+				if err != nil {
+					writeProcessorError(d.Logger(), w, err)
+					return
+				}
+
+				restRegistry, err := TransformRegistry(ceremonyRegistry)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform registry data")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestRegistry](d.Logger())(w)(c.ServerInformation())(queryParams)(restRegistry)
+			}
+		})
+	}
+}
+
+// writeCeremonyResponse writes a ceremony as a JSON:API response with the given status code
+func writeCeremonyResponse(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, statusCode int, ceremony Ceremony) {
+	restCeremony, err := TransformCeremony(ceremony)
+	if err != nil {
+		writeErrorResponse(w, http.StatusInternalServerError, "Failed to transform ceremony data")
+		return
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[RestCeremony](d.Logger())(w)(c.ServerInformation())(queryParams)(restCeremony)
+}
+
+// parseOptionalCharacterId parses an optional character id query parameter, defaulting to 0 when absent
+func parseOptionalCharacterId(r *http.Request, name string) (uint32, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(id), nil
+}
+
+// parseOptionalTime parses an optional RFC 3339 query parameter, defaulting to fallback when absent
+func parseOptionalTime(r *http.Request, name string, fallback time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// writeProcessorError writes the error response for a failed processor operation. Cooldown failures
+// advertise when the proposer may retry
+func writeProcessorError(l logrus.FieldLogger, w http.ResponseWriter, err error) {
+	statusCode := ErrorStatus(err)
+	if statusCode == http.StatusInternalServerError {
+		l.WithError(err).Error("Failed to process marriage request")
+	}
+
+	var cooldownErr CooldownError
+	if errors.As(err, &cooldownErr) && cooldownErr.Remaining > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(cooldownErr.Remaining.Seconds()))))
+	}
+	writeErrorResponse(w, statusCode, err.Error())
+}
+
 // writeErrorResponse writes a JSON error response
 func writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
@@ -1,11 +1,21 @@
 package marriage
 
 import (
+	"atlas-marriages/character"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/outbox"
+	"atlas-marriages/registry"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+	"atlas-marriages/transaction"
+	"atlas-marriages/venue"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -43,6 +53,18 @@ func TestMarriageResourceIntegration(t *testing.T) {
 		testGetProposalsEndpoint(t, testServer, tenantId)
 	})
 
+	t.Run("GetBondEndpoint", func(t *testing.T) {
+		testGetBondEndpoint(t, testServer, tenantId)
+	})
+
+	t.Run("GetCoupleSkillsEndpoint", func(t *testing.T) {
+		testGetCoupleSkillsEndpoint(t, testServer, db, tenantId)
+	})
+
+	t.Run("GetCeremonyAvailabilityEndpoint", func(t *testing.T) {
+		testGetCeremonyAvailabilityEndpoint(t, testServer, db, tenantId)
+	})
+
 	t.Run("ErrorHandling", func(t *testing.T) {
 		testErrorHandling(t, testServer, tenantId)
 	})
@@ -73,6 +95,18 @@ func setupResourceTestDB(t *testing.T) *gorm.DB {
 	// Run migrations
 	err = Migration(db)
 	require.NoError(t, err)
+	err = outbox.Migration(db)
+	require.NoError(t, err)
+	err = rules.Migration(db)
+	require.NoError(t, err)
+	err = saga.Migration(db)
+	require.NoError(t, err)
+	err = venue.Migration(db)
+	require.NoError(t, err)
+	err = registry.Migration(db)
+	require.NoError(t, err)
+	err = transaction.Migration(db)
+	require.NoError(t, err)
 
 	return db
 }
@@ -212,6 +246,149 @@ func createRequestWithTenant(method, url string, body []byte, tenantId uuid.UUID
 }
 
 // testGetMarriageEndpoint tests GET /characters/{characterId}/marriage
+func testGetBondEndpoint(t *testing.T, testServer *httptest.Server, tenantId uuid.UUID) {
+	t.Run("GetBondOfActiveMarriage", func(t *testing.T) {
+		url := fmt.Sprintf("%s/characters/101/marriage/bond", testServer.URL)
+		req := createRequestWithTenant("GET", url, nil, tenantId)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "bonds", data["type"])
+		assert.Equal(t, "1", data["id"])
+
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, float64(0), attributes["level"])
+		assert.Equal(t, float64(0), attributes["points"])
+		assert.Equal(t, float64(rules.DefaultBondLevelThresholds[0]), attributes["nextThreshold"])
+		assert.Equal(t, float64(0), attributes["progress"])
+		assert.Equal(t, false, attributes["maxLevel"])
+	})
+
+	t.Run("GetBondNotMarried", func(t *testing.T) {
+		url := fmt.Sprintf("%s/characters/999/marriage/bond", testServer.URL)
+		req := createRequestWithTenant("GET", url, nil, tenantId)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func testGetCoupleSkillsEndpoint(t *testing.T, testServer *httptest.Server, db *gorm.DB, tenantId uuid.UUID) {
+	skills := "1000:engaged,1001,1002:2"
+	require.NoError(t, db.Create(&rules.Entity{TenantId: tenantId, CoupleSkills: &skills, UpdatedAt: time.Now()}).Error)
+	rules.GetRegistry().Invalidate(tenantId)
+
+	getSkills := func(characterId uint32) []interface{} {
+		url := fmt.Sprintf("%s/characters/%d/marriage/skills", testServer.URL, characterId)
+		req := createRequestWithTenant("GET", url, nil, tenantId)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+		return response["data"].([]interface{})
+	}
+
+	t.Run("GetCoupleSkillsOfMarriedCharacter", func(t *testing.T) {
+		data := getSkills(100)
+		require.Len(t, data, 3)
+
+		unlocked := map[string]bool{}
+		for _, item := range data {
+			skill := item.(map[string]interface{})
+			assert.Equal(t, "coupleSkills", skill["type"])
+			attributes := skill["attributes"].(map[string]interface{})
+			unlocked[skill["id"].(string)] = attributes["unlocked"].(bool)
+		}
+		assert.Equal(t, map[string]bool{"1000": true, "1001": true, "1002": false}, unlocked)
+
+		attributes := data[0].(map[string]interface{})["attributes"].(map[string]interface{})
+		assert.Equal(t, "engaged", attributes["requiredStatus"])
+		attributes = data[2].(map[string]interface{})["attributes"].(map[string]interface{})
+		assert.Equal(t, "married", attributes["requiredStatus"])
+		assert.Equal(t, float64(2), attributes["requiredBondLevel"])
+	})
+
+	t.Run("GetCoupleSkillsNotMarried", func(t *testing.T) {
+		data := getSkills(999)
+		require.Len(t, data, 3)
+		for _, item := range data {
+			attributes := item.(map[string]interface{})["attributes"].(map[string]interface{})
+			assert.Equal(t, false, attributes["unlocked"])
+		}
+	})
+}
+
+func testGetCeremonyAvailabilityEndpoint(t *testing.T, testServer *httptest.Server, db *gorm.DB, tenantId uuid.UUID) {
+	cathedral := createVenue(t, db, tenantId, 10)
+	from := nextSlot()
+
+	getAvailability := func(query string) *http.Response {
+		url := fmt.Sprintf("%s/ceremonies/availability?%s", testServer.URL, query)
+		req := createRequestWithTenant("GET", url, nil, tenantId)
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("GetFreeSlots", func(t *testing.T) {
+		resp := getAvailability(fmt.Sprintf("worldId=0&channelId=1&from=%s&to=%s", from.Format(time.RFC3339), from.Add(3*time.Hour).Format(time.RFC3339)))
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		err := json.NewDecoder(resp.Body).Decode(&response)
+		require.NoError(t, err)
+
+		data := response["data"].([]interface{})
+		require.Len(t, data, 1)
+		resource := data[0].(map[string]interface{})
+		assert.Equal(t, "venueAvailability", resource["type"])
+		assert.Equal(t, strconv.Itoa(int(cathedral.ID)), resource["id"])
+		attributes := resource["attributes"].(map[string]interface{})
+		assert.Equal(t, "CATHEDRAL", attributes["venueType"])
+		assert.Equal(t, float64(3600), attributes["slotLengthSeconds"])
+		assert.Len(t, attributes["slots"], 3)
+	})
+
+	t.Run("WindowTooLong", func(t *testing.T) {
+		resp := getAvailability(fmt.Sprintf("worldId=0&channelId=1&from=%s&to=%s", from.Format(time.RFC3339), from.Add(8*24*time.Hour).Format(time.RFC3339)))
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("MissingWorld", func(t *testing.T) {
+		resp := getAvailability("channelId=1")
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
 func testGetMarriageEndpoint(t *testing.T, testServer *httptest.Server, tenantId uuid.UUID) {
 	t.Run("GetActiveMarriage", func(t *testing.T) {
 		url := fmt.Sprintf("%s/characters/100/marriage", testServer.URL)
@@ -509,6 +686,389 @@ func testErrorHandling(t *testing.T, testServer *httptest.Server, tenantId uuid.
 	})
 }
 
+// TestMarriageResourceWriteEndpoints tests the error mapping of the REST write endpoints
+func TestMarriageResourceWriteEndpoints(t *testing.T) {
+	db := setupResourceTestDB(t)
+	tenantId := uuid.New()
+	setupTestMarriageData(t, db, tenantId)
+
+	// Create a completed ceremony whose invitees exclude the partners
+	now := time.Now()
+	completedCeremony := CeremonyEntity{
+		ID:           2,
+		MarriageId:   1,
+		CharacterId1: 100,
+		CharacterId2: 101,
+		Status:       CeremonyStatusCompleted,
+		ScheduledAt:  now.Add(-24 * time.Hour),
+		StartedAt:    &now,
+		CompletedAt:  &now,
+		Invitees:     `[102]`,
+		TenantId:     tenantId,
+		CreatedAt:    now.Add(-24 * time.Hour),
+		UpdatedAt:    now,
+	}
+	require.NoError(t, db.Create(&completedCeremony).Error)
+
+	router := setupTestRouter(db)
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "ProposeWithMalformedBody",
+			method:         http.MethodPost,
+			path:           "/characters/100/marriage/proposals",
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "EligibilityWithoutTarget",
+			method:         http.MethodGet,
+			path:           "/characters/100/marriage/eligibility",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "EligibilityWithInvalidTarget",
+			method:         http.MethodGet,
+			path:           "/characters/100/marriage/eligibility?target=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "AcceptUnknownProposal",
+			method:         http.MethodPost,
+			path:           "/proposals/999/accept",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "AcceptRejectedProposal",
+			method:         http.MethodPost,
+			path:           "/proposals/2/accept",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "DeclineRejectedProposal",
+			method:         http.MethodPost,
+			path:           "/proposals/2/decline",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "CancelRejectedProposal",
+			method:         http.MethodDelete,
+			path:           "/proposals/2",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "DivorceUnmarriedCharacter",
+			method:         http.MethodDelete,
+			path:           "/characters/999/marriage",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "FileDivorceUnmarriedCharacter",
+			method:         http.MethodPost,
+			path:           "/characters/999/marriage/divorce",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "ConsentToUnfiledDivorce",
+			method:         http.MethodPost,
+			path:           "/characters/101/marriage/divorce/consent",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "WithdrawUnfiledDivorce",
+			method:         http.MethodDelete,
+			path:           "/characters/100/marriage/divorce",
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "ScheduleCeremonyForMarriedCouple",
+			method:         http.MethodPost,
+			path:           "/ceremonies",
+			body:           `{"data":{"type":"ceremonies","attributes":{"marriageId":1,"scheduledAt":"2030-01-01T00:00:00Z","invitees":[]}}}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "ScheduleCeremonyWithoutMarriage",
+			method:         http.MethodPost,
+			path:           "/ceremonies",
+			body:           `{"data":{"type":"ceremonies","attributes":{"scheduledAt":"2030-01-01T00:00:00Z"}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "StartCompletedCeremony",
+			method:         http.MethodPatch,
+			path:           "/ceremonies/2",
+			body:           `{"data":{"type":"ceremonies","id":"2","attributes":{"status":"active"}}}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "UpdateCeremonyWithUnknownStatus",
+			method:         http.MethodPatch,
+			path:           "/ceremonies/2",
+			body:           `{"data":{"type":"ceremonies","id":"2","attributes":{"status":"bogus"}}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "CancelUnknownCeremony",
+			method:         http.MethodDelete,
+			path:           "/ceremonies/999?characterId=100",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "AddInviteeToCompletedCeremony",
+			method:         http.MethodPost,
+			path:           "/ceremonies/2/invitees",
+			body:           `{"data":{"type":"invitees","attributes":{"characterId":500,"addedBy":100}}}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "RemoveInviteeWithInvalidRemovedBy",
+			method:         http.MethodDelete,
+			path:           "/ceremonies/2/invitees/102?removedBy=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.body != "" {
+				body = []byte(tt.body)
+			}
+			req := createRequestWithTenant(tt.method, testServer.URL+tt.path, body, tenantId)
+
+			client := &http.Client{}
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+
+	t.Run("FailedWritesDoNotStageEvents", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&outbox.Entity{}).Count(&count).Error)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+// TestMarriageResourceWriteEndpointsSucceed walks a couple from proposal to divorce through the REST write endpoints,
+// checking each response and the events it stages
+func TestMarriageResourceWriteEndpointsSucceed(t *testing.T) {
+	db := setupResourceTestDB(t)
+	tenantId := uuid.New()
+
+	characters := NewMockCharacterProcessor()
+	for _, id := range []uint32{400, 401, 402, 403} {
+		characters.AddCharacter(id, fmt.Sprintf("Character%d", id), 50)
+	}
+	producer := NewMockProducer()
+	pp := func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+		return NewProcessor(l, ctx, db).WithProducer(producer.Provider).WithCharacterProcessor(characters)
+	}
+
+	router := mux.NewRouter()
+	l := logrus.New()
+	l.SetLevel(logrus.ErrorLevel)
+	cp := func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) character.Processor {
+		return characters
+	}
+	initializeRoutes(pp, cp, db)(testServerInfo{})(router, l)
+	testServer := httptest.NewServer(router)
+	defer testServer.Close()
+
+	// send issues a request, asserting its status, and returns the JSON:API resource in the response body, if any
+	send := func(t *testing.T, method string, path string, body string, expectedStatus int) map[string]interface{} {
+		var payload []byte
+		if body != "" {
+			payload = []byte(body)
+		}
+		resp, err := http.DefaultClient.Do(createRequestWithTenant(method, testServer.URL+path, payload, tenantId))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedStatus, resp.StatusCode)
+		if expectedStatus == http.StatusNoContent {
+			return nil
+		}
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		data, ok := response["data"].(map[string]interface{})
+		require.True(t, ok, "expected a JSON:API resource in the response")
+		return data
+	}
+
+	// staged returns the types of the events staged in the outbox since the last call, in order
+	staged := func(t *testing.T) []string {
+		var entities []outbox.Entity
+		require.NoError(t, db.Order("id").Find(&entities).Error)
+		require.NoError(t, db.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&outbox.Entity{}).Error)
+
+		types := make([]string, 0, len(entities))
+		for _, e := range entities {
+			var event marriageMsg.Event[json.RawMessage]
+			require.NoError(t, json.Unmarshal(e.Value, &event))
+			types = append(types, event.Type)
+		}
+		return types
+	}
+
+	var proposalId, marriageId, ceremonyId string
+
+	t.Run("Propose", func(t *testing.T) {
+		data := send(t, http.MethodPost, "/characters/400/marriage/proposals", `{"data":{"type":"proposals","attributes":{"targetCharacterId":401}}}`, http.StatusCreated)
+		assert.Equal(t, "restProposals", data["type"])
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, float64(400), attributes["proposerId"])
+		assert.Equal(t, float64(401), attributes["targetId"])
+		assert.Equal(t, "pending", attributes["status"])
+		assert.Equal(t, map[string]interface{}{"characterId": float64(400), "name": "Character400", "level": float64(50)}, attributes["proposer"])
+		assert.Equal(t, map[string]interface{}{"characterId": float64(401), "name": "Character401", "level": float64(50)}, attributes["target"])
+		assert.Equal(t, []string{marriageMsg.EventProposalCreated}, staged(t))
+		proposalId = data["id"].(string)
+	})
+
+	t.Run("Accept", func(t *testing.T) {
+		data := send(t, http.MethodPost, "/proposals/"+proposalId+"/accept", "", http.StatusCreated)
+		assert.Equal(t, "restMarriages", data["type"])
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "engaged", attributes["status"])
+		assert.Equal(t, map[string]interface{}{"characterId": float64(400), "name": "Character400", "level": float64(50)}, attributes["partner"])
+		assert.Contains(t, staged(t), marriageMsg.EventProposalAccepted)
+		marriageId = data["id"].(string)
+	})
+
+	t.Run("ScheduleCeremony", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		body := fmt.Sprintf(`{"data":{"type":"ceremonies","attributes":{"marriageId":%s,"scheduledAt":"%s","invitees":[402]}}}`, marriageId, scheduledAt)
+		data := send(t, http.MethodPost, "/ceremonies", body, http.StatusCreated)
+		assert.Equal(t, "ceremonies", data["type"])
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "scheduled", attributes["status"])
+		assert.Equal(t, []interface{}{float64(402)}, attributes["invitees"])
+		assert.Contains(t, staged(t), marriageMsg.EventCeremonyScheduled)
+		ceremonyId = data["id"].(string)
+	})
+
+	t.Run("AddInvitee", func(t *testing.T) {
+		data := send(t, http.MethodPost, "/ceremonies/"+ceremonyId+"/invitees", `{"data":{"type":"invitees","attributes":{"characterId":403,"addedBy":400}}}`, http.StatusCreated)
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, []interface{}{float64(402), float64(403)}, attributes["invitees"])
+		assert.Equal(t, []string{marriageMsg.EventInviteeAdded}, staged(t))
+	})
+
+	t.Run("RemoveInvitee", func(t *testing.T) {
+		send(t, http.MethodDelete, "/ceremonies/"+ceremonyId+"/invitees/403?removedBy=400", "", http.StatusNoContent)
+		assert.Equal(t, []string{marriageMsg.EventInviteeRemoved}, staged(t))
+	})
+
+	t.Run("StartCeremony", func(t *testing.T) {
+		body := fmt.Sprintf(`{"data":{"type":"ceremonies","id":"%s","attributes":{"status":"active"}}}`, ceremonyId)
+		data := send(t, http.MethodPatch, "/ceremonies/"+ceremonyId, body, http.StatusOK)
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "active", attributes["status"])
+		assert.Contains(t, staged(t), marriageMsg.EventCeremonyStarted)
+	})
+
+	t.Run("CompleteCeremony", func(t *testing.T) {
+		id, err := strconv.ParseUint(ceremonyId, 10, 32)
+		require.NoError(t, err)
+		advanceToFinalStage(t, pp(l, setupTestContext(tenantId), db), uint32(id))
+		staged(t)
+
+		body := fmt.Sprintf(`{"data":{"type":"ceremonies","id":"%s","attributes":{"status":"completed"}}}`, ceremonyId)
+		data := send(t, http.MethodPatch, "/ceremonies/"+ceremonyId, body, http.StatusOK)
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "completed", attributes["status"])
+		assert.Contains(t, staged(t), marriageMsg.EventCeremonyCompleted)
+	})
+
+	t.Run("Divorce", func(t *testing.T) {
+		send(t, http.MethodDelete, "/characters/400/marriage", "", http.StatusNoContent)
+		assert.Contains(t, staged(t), marriageMsg.EventMarriageDivorced)
+
+		var entity Entity
+		require.NoError(t, db.Where("tenant_id = ? AND character_id1 = ?", tenantId, 400).First(&entity).Error)
+		assert.Equal(t, StatusDivorced, entity.Status)
+	})
+}
+
+// TestVenueCatalogueEndpoints tests adding venues to the tenant's catalogue and listing them
+func TestVenueCatalogueEndpoints(t *testing.T) {
+	db := setupResourceTestDB(t)
+	tenantId := uuid.New()
+	testServer := httptest.NewServer(setupTestRouter(db))
+	defer testServer.Close()
+
+	send := func(t *testing.T, method string, path string, body string) *http.Response {
+		var payload []byte
+		if body != "" {
+			payload = []byte(body)
+		}
+		resp, err := http.DefaultClient.Do(createRequestWithTenant(method, testServer.URL+path, payload, tenantId))
+		require.NoError(t, err)
+		return resp
+	}
+
+	var venueId string
+
+	t.Run("CreateVenue", func(t *testing.T) {
+		resp := send(t, http.MethodPost, "/venues", `{"data":{"type":"venues","attributes":{"name":"Cathedral","venueType":"CATHEDRAL","worldId":0,"channelId":1,"capacity":20,"slotLengthSeconds":3600}}}`)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		data := response["data"].(map[string]interface{})
+		assert.Equal(t, "venues", data["type"])
+		attributes := data["attributes"].(map[string]interface{})
+		assert.Equal(t, "CATHEDRAL", attributes["venueType"])
+		assert.Equal(t, float64(20), attributes["capacity"])
+		assert.Equal(t, float64(3600), attributes["slotLengthSeconds"])
+		venueId = data["id"].(string)
+	})
+
+	t.Run("InvalidSlotLength", func(t *testing.T) {
+		resp := send(t, http.MethodPost, "/venues", `{"data":{"type":"venues","attributes":{"name":"Chapel","worldId":0,"channelId":1,"slotLengthSeconds":25200}}}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+
+	t.Run("MissingName", func(t *testing.T) {
+		resp := send(t, http.MethodPost, "/venues", `{"data":{"type":"venues","attributes":{"worldId":0,"channelId":1,"slotLengthSeconds":3600}}}`)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("GetVenues", func(t *testing.T) {
+		resp := send(t, http.MethodGet, "/venues?worldId=0&channelId=1", "")
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		data := response["data"].([]interface{})
+		require.Len(t, data, 1)
+		assert.Equal(t, venueId, data[0].(map[string]interface{})["id"])
+
+		// Other channels have no venues
+		other := send(t, http.MethodGet, "/venues?worldId=0&channelId=2", "")
+		defer other.Body.Close()
+		require.Equal(t, http.StatusOK, other.StatusCode)
+		require.NoError(t, json.NewDecoder(other.Body).Decode(&response))
+		assert.Empty(t, response["data"])
+	})
+}
+
 // testTenantIsolation tests that tenant isolation works correctly
 func testTenantIsolation(t *testing.T, testServer *httptest.Server, originalTenantId uuid.UUID) {
 	t.Run("DifferentTenantNoAccess", func(t *testing.T) {
@@ -710,6 +1270,28 @@ func TestResourceTransformations(t *testing.T) {
 		assert.Equal(t, proposal.RejectionCount(), restProposal.RejectionCount)
 	})
 
+	t.Run("TransformBond", func(t *testing.T) {
+		now := time.Now()
+		marriage, err := NewBuilder(100, 101, uuid.New()).
+			SetId(7).
+			SetStatus(StatusMarried).
+			SetEngagedAt(&now).
+			SetMarriedAt(&now).
+			SetBond(400, 2, &now).
+			Build()
+		require.NoError(t, err)
+
+		restBond, err := TransformBond(MakeBond(marriage, []uint32{100, 300, 500}))
+		require.NoError(t, err)
+
+		assert.Equal(t, "7", restBond.GetID())
+		assert.Equal(t, byte(2), restBond.Level)
+		assert.Equal(t, uint32(300), restBond.CurrentThreshold)
+		assert.Equal(t, uint32(500), restBond.NextThreshold)
+		assert.InDelta(t, 0.5, restBond.Progress, 0.0001)
+		assert.False(t, restBond.MaxLevel)
+	})
+
 	t.Run("JSONAPIResourceInterface", func(t *testing.T) {
 		// Test that RestMarriage implements JSON:API resource interface
 		restMarriage := RestMarriage{ID: 123}
@@ -3,6 +3,9 @@ package marriage
 import (
 	"strconv"
 	"time"
+
+	"atlas-marriages/registry"
+	"atlas-marriages/venue"
 )
 
 // RestMarriage represents the REST API model for marriage responses
@@ -15,28 +18,64 @@ type RestMarriage struct {
 	EngagedAt        *time.Time       `json:"engagedAt,omitempty"`
 	MarriedAt        *time.Time       `json:"marriedAt,omitempty"`
 	DivorcedAt       *time.Time       `json:"divorcedAt,omitempty"`
+	DeletedAt        *time.Time       `json:"deletedAt,omitempty"`
+	DeletionReason   string           `json:"deletionReason,omitempty"`
+	DivorceFiledBy   uint32           `json:"divorceFiledBy,omitempty"`
+	DivorceFiledAt   *time.Time       `json:"divorceFiledAt,omitempty"`
+	DivorceMaturesAt *time.Time       `json:"divorceMaturesAt,omitempty"`
 	CreatedAt        time.Time        `json:"createdAt"`
 	UpdatedAt        time.Time        `json:"updatedAt"`
 	Partner          *RestPartner     `json:"partner,omitempty"`
 	Ceremony         *RestCeremony    `json:"ceremony,omitempty"`
+	Rings            []RestWeddingRing `json:"rings,omitempty"`
+}
+
+// RestWeddingRing represents the wedding ring held by one partner, paired with their partner's ring. The partner
+// name is omitted when the character service cannot resolve the partner
+type RestWeddingRing struct {
+	CharacterId        uint32 `json:"characterId"`
+	ItemId             uint32 `json:"itemId"`
+	Serial             uint64 `json:"serial"`
+	PartnerCharacterId uint32 `json:"partnerCharacterId"`
+	PartnerSerial      uint64 `json:"partnerSerial"`
+	PartnerName        string `json:"partnerName,omitempty"`
 }
 
-// RestPartner represents partner information in marriage response
+// RestPartner represents a partner, proposer or target character. Name and level are omitted when the
+// character service cannot resolve the character
 type RestPartner struct {
 	CharacterID uint32 `json:"characterId"`
-	// Note: Character name and other details would be populated by external service calls
+	Name        string `json:"name,omitempty"`
+	Level       byte   `json:"level,omitempty"`
 }
 
-// RestCeremony represents ceremony information in marriage response
+// RestCeremony represents ceremony information in marriage and ceremony responses
 type RestCeremony struct {
 	ID           uint32      `json:"id"`
+	MarriageID   uint32      `json:"marriageId,omitempty"`
+	CharacterId1 uint32      `json:"characterId1,omitempty"`
+	CharacterId2 uint32      `json:"characterId2,omitempty"`
 	Status       string      `json:"status"`
 	ScheduledAt  time.Time   `json:"scheduledAt"`
 	StartedAt    *time.Time  `json:"startedAt,omitempty"`
 	CompletedAt  *time.Time  `json:"completedAt,omitempty"`
 	CancelledAt  *time.Time  `json:"cancelledAt,omitempty"`
 	PostponedAt  *time.Time  `json:"postponedAt,omitempty"`
+	Invitees     []uint32    `json:"invitees,omitempty"`
 	InviteeCount int         `json:"inviteeCount"`
+	Rsvps        []RestRsvp  `json:"rsvps,omitempty"`
+	VenueTier    string      `json:"venueTier,omitempty"`
+	VenueId      uint32      `json:"venueId,omitempty"`
+	Cost         uint32      `json:"cost"`
+	Stages       []string    `json:"stages,omitempty"`
+	Stage        string      `json:"stage,omitempty"`
+}
+
+// RestRsvp represents an invitee's response to their invitation to a ceremony
+type RestRsvp struct {
+	CharacterId uint32     `json:"characterId"`
+	Status      string     `json:"status"`
+	RespondedAt *time.Time `json:"respondedAt,omitempty"`
 }
 
 // RestProposal represents a proposal in REST API responses
@@ -49,9 +88,111 @@ type RestProposal struct {
 	RespondedAt    *time.Time `json:"respondedAt,omitempty"`
 	ExpiresAt      time.Time  `json:"expiresAt"`
 	RejectionCount uint32     `json:"rejectionCount"`
-	CooldownUntil  *time.Time `json:"cooldownUntil,omitempty"`
-	CreatedAt      time.Time  `json:"createdAt"`
-	UpdatedAt      time.Time  `json:"updatedAt"`
+	CooldownUntil  *time.Time   `json:"cooldownUntil,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt"`
+	Proposer       *RestPartner `json:"proposer,omitempty"`
+	Target         *RestPartner `json:"target,omitempty"`
+}
+
+// RestEligibility represents the eligibility of a character to propose to a target
+type RestEligibility struct {
+	ID         string                     `json:"-"`
+	ProposerId uint32                     `json:"proposerId"`
+	TargetId   uint32                     `json:"targetId"`
+	Eligible   bool                       `json:"eligible"`
+	Violations []RestEligibilityViolation `json:"violations"`
+}
+
+// RestEligibilityViolation represents a failing eligibility rule
+type RestEligibilityViolation struct {
+	Rule             string `json:"rule"`
+	CharacterId      uint32 `json:"characterId"`
+	Value            string `json:"value"`
+	Message          string `json:"message"`
+	RemainingSeconds int64  `json:"remainingSeconds,omitempty"`
+}
+
+// RestBond represents a couple's bond level and their progress towards the next level
+type RestBond struct {
+	ID               uint32     `json:"-"`
+	Level            byte       `json:"level"`
+	Points           uint32     `json:"points"`
+	CurrentThreshold uint32     `json:"currentThreshold"`
+	NextThreshold    uint32     `json:"nextThreshold"`
+	Progress         float64    `json:"progress"`
+	MaxLevel         bool       `json:"maxLevel"`
+	LastActivityAt   *time.Time `json:"lastActivityAt"`
+}
+
+// RestCoupleSkill represents a couple skill from the tenant's catalogue and whether the character holds it
+type RestCoupleSkill struct {
+	ID                uint32 `json:"-"`
+	RequiredStatus    string `json:"requiredStatus"`
+	RequiredBondLevel byte   `json:"requiredBondLevel"`
+	Unlocked          bool   `json:"unlocked"`
+}
+
+// RestVenueAvailability represents a venue and the slots it is free to be booked for
+type RestVenueAvailability struct {
+	ID                uint32          `json:"-"`
+	Name              string          `json:"name"`
+	VenueType         string          `json:"venueType"`
+	WorldId           byte            `json:"worldId"`
+	ChannelId         byte            `json:"channelId"`
+	Capacity          int             `json:"capacity"`
+	SlotLengthSeconds int64           `json:"slotLengthSeconds"`
+	Slots             []RestVenueSlot `json:"slots"`
+}
+
+// RestVenueSlot represents a period a venue is free to be booked for
+type RestVenueSlot struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+// RestVenue represents a venue in the tenant's catalogue
+type RestVenue struct {
+	ID                uint32 `json:"-"`
+	Name              string `json:"name"`
+	VenueType         string `json:"venueType"`
+	WorldId           byte   `json:"worldId"`
+	ChannelId         byte   `json:"channelId"`
+	Capacity          int    `json:"capacity"`
+	SlotLengthSeconds int64  `json:"slotLengthSeconds"`
+}
+
+// RestRegistry represents a ceremony's wish list, the gifts invitees gave from it and the blessings they sent
+type RestRegistry struct {
+	ID            uint32             `json:"-"`
+	Items         []RestRegistryItem `json:"items"`
+	Gifts         []RestGift         `json:"gifts"`
+	Blessings     []RestBlessing     `json:"blessings"`
+	GiftCount     uint32             `json:"giftCount"`
+	BlessingCount uint32             `json:"blessingCount"`
+}
+
+// RestRegistryItem represents an item on a couple's wish list and how many of it invitees have given
+type RestRegistryItem struct {
+	ItemId    uint32 `json:"itemId"`
+	Quantity  uint32 `json:"quantity"`
+	Received  uint32 `json:"received"`
+	Remaining uint32 `json:"remaining"`
+}
+
+// RestGift represents items an invitee gave from a couple's wish list
+type RestGift struct {
+	CharacterId uint32    `json:"characterId"`
+	ItemId      uint32    `json:"itemId"`
+	Quantity    uint32    `json:"quantity"`
+	GivenAt     time.Time `json:"givenAt"`
+}
+
+// RestBlessing represents an invitee's blessing of a couple
+type RestBlessing struct {
+	CharacterId uint32    `json:"characterId"`
+	Message     string    `json:"message,omitempty"`
+	BlessedAt   time.Time `json:"blessedAt"`
 }
 
 // GetType returns the JSON:API resource type for marriage
@@ -64,6 +205,81 @@ func (rm RestMarriage) GetID() string {
 	return strconv.Itoa(int(rm.ID))
 }
 
+// GetType returns the JSON:API resource type for ceremony
+func (rc RestCeremony) GetType() string {
+	return "ceremony"
+}
+
+// GetName returns the JSON:API resource name for ceremony
+func (rc RestCeremony) GetName() string {
+	return "ceremonies"
+}
+
+// GetID returns the JSON:API resource ID for ceremony
+func (rc RestCeremony) GetID() string {
+	return strconv.Itoa(int(rc.ID))
+}
+
+// GetName returns the JSON:API resource name for eligibility
+func (re RestEligibility) GetName() string {
+	return "eligibility"
+}
+
+// GetID returns the JSON:API resource ID for eligibility
+func (re RestEligibility) GetID() string {
+	return re.ID
+}
+
+// GetName returns the JSON:API resource name for bond
+func (rb RestBond) GetName() string {
+	return "bonds"
+}
+
+// GetID returns the JSON:API resource ID for bond, which is the ID of the marriage it belongs to
+func (rb RestBond) GetID() string {
+	return strconv.Itoa(int(rb.ID))
+}
+
+// GetName returns the JSON:API resource name for couple skill
+func (rs RestCoupleSkill) GetName() string {
+	return "coupleSkills"
+}
+
+// GetID returns the JSON:API resource ID for couple skill, which is the ID of the skill
+func (rs RestCoupleSkill) GetID() string {
+	return strconv.Itoa(int(rs.ID))
+}
+
+// GetName returns the JSON:API resource name for venue availability
+func (rv RestVenueAvailability) GetName() string {
+	return "venueAvailability"
+}
+
+// GetID returns the JSON:API resource ID for venue availability, which is the ID of the venue
+func (rv RestVenueAvailability) GetID() string {
+	return strconv.Itoa(int(rv.ID))
+}
+
+// GetName returns the JSON:API resource name for venue
+func (rv RestVenue) GetName() string {
+	return "venues"
+}
+
+// GetID returns the JSON:API resource ID for venue
+func (rv RestVenue) GetID() string {
+	return strconv.Itoa(int(rv.ID))
+}
+
+// GetName returns the JSON:API resource name for registry
+func (rr RestRegistry) GetName() string {
+	return "registries"
+}
+
+// GetID returns the JSON:API resource ID for registry, which is the ID of the ceremony it belongs to
+func (rr RestRegistry) GetID() string {
+	return strconv.Itoa(int(rr.ID))
+}
+
 // GetType returns the JSON:API resource type for proposal
 func (rp RestProposal) GetType() string {
 	return "proposal"
@@ -84,12 +300,41 @@ func TransformMarriage(m Marriage) (RestMarriage, error) {
 		ProposedAt:   m.ProposedAt(),
 		EngagedAt:    m.EngagedAt(),
 		MarriedAt:    m.MarriedAt(),
-		DivorcedAt:   m.DivorcedAt(),
+		DivorcedAt:     m.DivorcedAt(),
+		DeletedAt:      m.DeletedAt(),
+		DeletionReason: m.DeletionReason(),
+		DivorceFiledBy:   m.DivorceFiledBy(),
+		DivorceFiledAt:   m.DivorceFiledAt(),
+		DivorceMaturesAt: m.DivorceMaturesAt(),
 		CreatedAt:    m.CreatedAt(),
 		UpdatedAt:    m.UpdatedAt(),
+		Rings:        transformWeddingRings(m),
 	}, nil
 }
 
+// transformWeddingRings converts the wedding rings issued for a marriage to REST representation
+func transformWeddingRings(m Marriage) []RestWeddingRing {
+	if !m.HasRings() {
+		return nil
+	}
+	return []RestWeddingRing{
+		{
+			CharacterId:        m.CharacterId1(),
+			ItemId:             m.RingItemId(),
+			Serial:             m.RingSerial1(),
+			PartnerCharacterId: m.CharacterId2(),
+			PartnerSerial:      m.RingSerial2(),
+		},
+		{
+			CharacterId:        m.CharacterId2(),
+			ItemId:             m.RingItemId(),
+			Serial:             m.RingSerial2(),
+			PartnerCharacterId: m.CharacterId1(),
+			PartnerSerial:      m.RingSerial1(),
+		},
+	}
+}
+
 // TransformMarriageWithPartner converts a domain Marriage model to REST representation with partner info
 func TransformMarriageWithPartner(m Marriage, characterId uint32) (RestMarriage, error) {
 	marriage, err := TransformMarriage(m)
@@ -126,6 +371,12 @@ func TransformMarriageWithCeremony(m Marriage, ceremony *Ceremony) (RestMarriage
 			CancelledAt:  ceremony.CancelledAt(),
 			PostponedAt:  ceremony.PostponedAt(),
 			InviteeCount: ceremony.InviteeCount(),
+			Rsvps:        TransformRsvps(ceremony.Rsvps()),
+			VenueTier:    string(ceremony.VenueTier()),
+			VenueId:      ceremony.VenueId(),
+			Cost:         ceremony.Cost(),
+			Stages:       ceremony.Stages(),
+			Stage:        ceremony.Stage(),
 		}
 	}
 
@@ -158,12 +409,192 @@ func TransformMarriageComplete(m Marriage, characterId uint32, ceremony *Ceremon
 			CancelledAt:  ceremony.CancelledAt(),
 			PostponedAt:  ceremony.PostponedAt(),
 			InviteeCount: ceremony.InviteeCount(),
+			Rsvps:        TransformRsvps(ceremony.Rsvps()),
+			VenueTier:    string(ceremony.VenueTier()),
+			VenueId:      ceremony.VenueId(),
+			Cost:         ceremony.Cost(),
+			Stages:       ceremony.Stages(),
+			Stage:        ceremony.Stage(),
 		}
 	}
 
 	return marriage, nil
 }
 
+// TransformCeremony converts a domain Ceremony model to REST representation
+func TransformCeremony(c Ceremony) (RestCeremony, error) {
+	return RestCeremony{
+		ID:           c.Id(),
+		MarriageID:   c.MarriageId(),
+		CharacterId1: c.CharacterId1(),
+		CharacterId2: c.CharacterId2(),
+		Status:       c.Status().String(),
+		ScheduledAt:  c.ScheduledAt(),
+		StartedAt:    c.StartedAt(),
+		CompletedAt:  c.CompletedAt(),
+		CancelledAt:  c.CancelledAt(),
+		PostponedAt:  c.PostponedAt(),
+		Invitees:     c.Invitees(),
+		InviteeCount: c.InviteeCount(),
+		Rsvps:        TransformRsvps(c.Rsvps()),
+		VenueTier:    string(c.VenueTier()),
+		VenueId:      c.VenueId(),
+		Cost:         c.Cost(),
+		Stages:       c.Stages(),
+		Stage:        c.Stage(),
+	}, nil
+}
+
+// TransformRsvps converts a ceremony's invitee responses to REST representation
+func TransformRsvps(rsvps []Rsvp) []RestRsvp {
+	result := make([]RestRsvp, 0, len(rsvps))
+	for _, rsvp := range rsvps {
+		result = append(result, RestRsvp{
+			CharacterId: rsvp.CharacterId(),
+			Status:      string(rsvp.Status()),
+			RespondedAt: rsvp.RespondedAt(),
+		})
+	}
+	return result
+}
+
+// TransformEligibility converts an eligibility verdict to REST representation
+func TransformEligibility(v EligibilityVerdict) (RestEligibility, error) {
+	violations := make([]RestEligibilityViolation, 0, len(v.Violations()))
+	for _, violation := range v.Violations() {
+		violations = append(violations, RestEligibilityViolation{
+			Rule:             violation.Rule,
+			CharacterId:      violation.CharacterId,
+			Value:            violation.Value,
+			Message:          violation.Message,
+			RemainingSeconds: violation.RemainingSeconds(),
+		})
+	}
+
+	return RestEligibility{
+		ID:         strconv.Itoa(int(v.ProposerId())) + "-" + strconv.Itoa(int(v.TargetId())),
+		ProposerId: v.ProposerId(),
+		TargetId:   v.TargetId(),
+		Eligible:   v.Eligible(),
+		Violations: violations,
+	}, nil
+}
+
+// TransformBond converts a couple's bond to REST representation
+func TransformBond(b Bond) (RestBond, error) {
+	return RestBond{
+		ID:               b.MarriageId(),
+		Level:            b.Level(),
+		Points:           b.Points(),
+		CurrentThreshold: b.CurrentThreshold(),
+		NextThreshold:    b.NextThreshold(),
+		Progress:         b.Progress(),
+		MaxLevel:         b.IsMaxLevel(),
+		LastActivityAt:   b.ActivityAt(),
+	}, nil
+}
+
+// TransformCoupleSkills converts a character's view of the couple skill catalogue to REST representation
+func TransformCoupleSkills(skills []CoupleSkill) ([]RestCoupleSkill, error) {
+	restSkills := make([]RestCoupleSkill, 0, len(skills))
+	for _, skill := range skills {
+		restSkills = append(restSkills, RestCoupleSkill{
+			ID:                skill.SkillId(),
+			RequiredStatus:    skill.RequiredStatus().String(),
+			RequiredBondLevel: skill.RequiredBondLevel(),
+			Unlocked:          skill.Unlocked(),
+		})
+	}
+	return restSkills, nil
+}
+
+// TransformVenueAvailability converts the free slots of venues to REST representation
+func TransformVenueAvailability(availability []VenueAvailability) ([]RestVenueAvailability, error) {
+	result := make([]RestVenueAvailability, 0, len(availability))
+	for _, a := range availability {
+		v := a.Venue()
+		slots := make([]RestVenueSlot, 0, len(a.Slots()))
+		for _, s := range a.Slots() {
+			slots = append(slots, RestVenueSlot{StartsAt: s.StartsAt(), EndsAt: s.EndsAt()})
+		}
+		result = append(result, RestVenueAvailability{
+			ID:                v.Id(),
+			Name:              v.Name(),
+			VenueType:         string(v.Type()),
+			WorldId:           v.WorldId(),
+			ChannelId:         v.ChannelId(),
+			Capacity:          v.Capacity(),
+			SlotLengthSeconds: int64(v.SlotLength().Seconds()),
+			Slots:             slots,
+		})
+	}
+	return result, nil
+}
+
+// TransformVenue converts a venue to REST representation
+func TransformVenue(v venue.Model) (RestVenue, error) {
+	return RestVenue{
+		ID:                v.Id(),
+		Name:              v.Name(),
+		VenueType:         string(v.Type()),
+		WorldId:           v.WorldId(),
+		ChannelId:         v.ChannelId(),
+		Capacity:          v.Capacity(),
+		SlotLengthSeconds: int64(v.SlotLength().Seconds()),
+	}, nil
+}
+
+// TransformVenues converts venues to REST representation
+func TransformVenues(venues []venue.Model) ([]RestVenue, error) {
+	result := make([]RestVenue, 0, len(venues))
+	for _, v := range venues {
+		restVenue, err := TransformVenue(v)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, restVenue)
+	}
+	return result, nil
+}
+
+// TransformRegistry converts a ceremony's registry to REST representation
+func TransformRegistry(r registry.Model) (RestRegistry, error) {
+	items := make([]RestRegistryItem, 0, len(r.Items()))
+	for _, i := range r.Items() {
+		items = append(items, RestRegistryItem{
+			ItemId:    i.ItemId(),
+			Quantity:  i.Quantity(),
+			Received:  i.Received(),
+			Remaining: i.Remaining(),
+		})
+	}
+	gifts := make([]RestGift, 0, len(r.Gifts()))
+	for _, g := range r.Gifts() {
+		gifts = append(gifts, RestGift{
+			CharacterId: g.CharacterId(),
+			ItemId:      g.ItemId(),
+			Quantity:    g.Quantity(),
+			GivenAt:     g.GivenAt(),
+		})
+	}
+	blessings := make([]RestBlessing, 0, len(r.Blessings()))
+	for _, b := range r.Blessings() {
+		blessings = append(blessings, RestBlessing{
+			CharacterId: b.CharacterId(),
+			Message:     b.Message(),
+			BlessedAt:   b.BlessedAt(),
+		})
+	}
+	return RestRegistry{
+		ID:            r.CeremonyId(),
+		Items:         items,
+		Gifts:         gifts,
+		Blessings:     blessings,
+		GiftCount:     r.GiftCount(),
+		BlessingCount: r.BlessingCount(),
+	}, nil
+}
+
 // TransformProposal converts a domain Proposal model to REST representation
 func TransformProposal(p Proposal) (RestProposal, error) {
 	return RestProposal{
@@ -209,4 +640,90 @@ func TransformMarriages(marriages []Marriage) ([]RestMarriage, error) {
 	}
 	
 	return restMarriages, nil
-}
\ No newline at end of file
+}
+
+// ProposalInputRestModel represents the JSON:API input for creating a proposal
+type ProposalInputRestModel struct {
+	Id                string `json:"-"`
+	TargetCharacterId uint32 `json:"targetCharacterId"`
+}
+
+// GetName returns the JSON:API resource name for proposal input
+func (r ProposalInputRestModel) GetName() string {
+	return "proposals"
+}
+
+// SetID sets the JSON:API resource ID for proposal input
+func (r *ProposalInputRestModel) SetID(id string) error {
+	r.Id = id
+	return nil
+}
+
+// CeremonyInputRestModel represents the JSON:API input for scheduling or updating a ceremony.
+// When scheduling, VenueTier selects a standard or premium venue and defaults to standard, and
+// VenueId books a venue's slot starting at ScheduledAt. When updating, Status requests a
+// transition to active, completed or postponed, while a ScheduledAt without a Status reschedules
+// the ceremony
+type CeremonyInputRestModel struct {
+	Id          string     `json:"-"`
+	MarriageId  uint32     `json:"marriageId"`
+	Status      string     `json:"status,omitempty"`
+	ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+	Invitees    []uint32   `json:"invitees,omitempty"`
+	VenueTier   string     `json:"venueTier,omitempty"`
+	VenueId     uint32     `json:"venueId,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	CharacterId uint32     `json:"characterId,omitempty"`
+}
+
+// GetName returns the JSON:API resource name for ceremony input
+func (r CeremonyInputRestModel) GetName() string {
+	return "ceremonies"
+}
+
+// SetID sets the JSON:API resource ID for ceremony input
+func (r *CeremonyInputRestModel) SetID(id string) error {
+	r.Id = id
+	return nil
+}
+
+// InviteeInputRestModel represents the JSON:API input for adding a ceremony invitee
+type InviteeInputRestModel struct {
+	Id          string `json:"-"`
+	CharacterId uint32 `json:"characterId"`
+	AddedBy     uint32 `json:"addedBy"`
+}
+
+// GetName returns the JSON:API resource name for invitee input
+func (r InviteeInputRestModel) GetName() string {
+	return "invitees"
+}
+
+// SetID sets the JSON:API resource ID for invitee input
+func (r *InviteeInputRestModel) SetID(id string) error {
+	r.Id = id
+	return nil
+}
+
+// VenueInputRestModel represents the JSON:API input for adding a venue to the tenant's catalogue. VenueType is a
+// CATHEDRAL or a CHAPEL and defaults to a chapel, while a Capacity of zero leaves only the tenant's invitee limit
+type VenueInputRestModel struct {
+	Id                string `json:"-"`
+	Name              string `json:"name"`
+	VenueType         string `json:"venueType,omitempty"`
+	WorldId           byte   `json:"worldId"`
+	ChannelId         byte   `json:"channelId"`
+	Capacity          int    `json:"capacity,omitempty"`
+	SlotLengthSeconds int64  `json:"slotLengthSeconds"`
+}
+
+// GetName returns the JSON:API resource name for venue input
+func (r VenueInputRestModel) GetName() string {
+	return "venues"
+}
+
+// SetID sets the JSON:API resource ID for venue input
+func (r *VenueInputRestModel) SetID(id string) error {
+	r.Id = id
+	return nil
+}
@@ -0,0 +1,152 @@
+package marriage
+
+import (
+	"time"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RsvpStatus is an invitee's response to their invitation to a ceremony
+type RsvpStatus string
+
+const (
+	RsvpStatusInvited  RsvpStatus = "INVITED"
+	RsvpStatusAccepted RsvpStatus = "ACCEPTED"
+	RsvpStatusDeclined RsvpStatus = "DECLINED"
+	RsvpStatusAttended RsvpStatus = "ATTENDED"
+)
+
+// IsResponse returns true if the status is one an invitee can respond with, rather than the status of an invitee
+// who has not responded
+func (s RsvpStatus) IsResponse() bool {
+	return s == RsvpStatusAccepted || s == RsvpStatusDeclined || s == RsvpStatusAttended
+}
+
+// Rsvp is the immutable record of an invitee's response to their invitation to a ceremony
+type Rsvp struct {
+	characterId uint32
+	status      RsvpStatus
+	respondedAt *time.Time
+}
+
+// CharacterId returns the invitee's character ID
+func (r Rsvp) CharacterId() uint32 {
+	return r.characterId
+}
+
+// Status returns the invitee's response
+func (r Rsvp) Status() RsvpStatus {
+	return r.status
+}
+
+// RespondedAt returns when the invitee last responded, or nil if they have not responded
+func (r Rsvp) RespondedAt() *time.Time {
+	return r.respondedAt
+}
+
+// copyRsvps returns a copy of a ceremony's invitee responses, so ceremonies never share them
+func copyRsvps(rsvps map[uint32]Rsvp) map[uint32]Rsvp {
+	copied := make(map[uint32]Rsvp, len(rsvps))
+	for characterId, rsvp := range rsvps {
+		copied[characterId] = rsvp
+	}
+	return copied
+}
+
+// RespondToInvitation records an invitee accepting or declining their invitation to a ceremony which has not started,
+// or attending a ceremony which is active
+func (p *ProcessorImpl) RespondToInvitation(ceremonyId uint32, characterId uint32, status RsvpStatus) model.Provider[Ceremony] {
+	return func() (Ceremony, error) {
+		_, ceremony, err := p.respondToInvitation(ceremonyId, characterId, status)
+		return ceremony, err
+	}
+}
+
+// respondToInvitation records an invitee's response, returning the invitee's previous status alongside the ceremony
+func (p *ProcessorImpl) respondToInvitation(ceremonyId uint32, characterId uint32, status RsvpStatus) (RsvpStatus, Ceremony, error) {
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":  ceremonyId,
+		"characterId": characterId,
+		"status":      status,
+	}).Debug("Responding to ceremony invitation")
+
+	t := tenant.MustFromContext(p.ctx)
+
+	ceremony, err := GetCeremonyByIdProvider(p.db, p.log)(ceremonyId, t.Id())()
+	if err != nil {
+		return "", Ceremony{}, err
+	}
+	if ceremony == nil {
+		return "", Ceremony{}, ErrCeremonyNotFound
+	}
+
+	if err = rsvpError(*ceremony, characterId, status); err != nil {
+		return "", Ceremony{}, err
+	}
+	previous, _ := ceremony.Rsvp(characterId)
+
+	responded, err := ceremony.Respond(characterId, status)
+	if err != nil {
+		return "", Ceremony{}, err
+	}
+
+	entity, err := UpdateCeremony(p.db, p.log)(ceremonyId, responded.ToEntity(), t.Id())()
+	if err != nil {
+		return "", Ceremony{}, err
+	}
+	result, err := MakeCeremony(entity)
+	if err != nil {
+		return "", Ceremony{}, err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"ceremonyId":     ceremonyId,
+		"characterId":    characterId,
+		"previousStatus": previous.Status(),
+		"status":         status,
+	}).Info("Ceremony invitation response recorded")
+
+	return previous.Status(), result, nil
+}
+
+// RespondToInvitationAndEmit records an invitee's response and emits an InviteeRsvpChanged event notifying the couple
+func (p *ProcessorImpl) RespondToInvitationAndEmit(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, status RsvpStatus) (Ceremony, error) {
+	return emitTransactionally(p, transactionId, func(p *ProcessorImpl) (Ceremony, error) {
+		previousStatus, ceremony, err := p.respondToInvitation(ceremonyId, characterId, status)
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		rsvp, _ := ceremony.Rsvp(characterId)
+		err = message.Emit(p.producer)(func(buf *message.Buffer) error {
+			eventProvider := InviteeRsvpChangedEventProvider(
+				ceremony.Id(),
+				ceremony.MarriageId(),
+				ceremony.CharacterId1(),
+				ceremony.CharacterId2(),
+				characterId,
+				string(previousStatus),
+				string(rsvp.Status()),
+				*rsvp.RespondedAt(),
+			)
+			return buf.Put(marriageMsg.EnvEventTopicStatus, eventProvider)
+		})
+		if err != nil {
+			return Ceremony{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"transactionId": transactionId,
+			"ceremonyId":    ceremonyId,
+			"characterId":   characterId,
+		}).Debug("InviteeRsvpChanged event emitted")
+
+		return ceremony, nil
+	})
+}
@@ -0,0 +1,204 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// setupRsvpTest creates a processor emitting to a mock producer, and an engaged couple whose ceremony invites
+// characters 3, 4 and 5
+func setupRsvpTest(t *testing.T) (Processor, *MockProducer, Ceremony) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	couple := createMarriedCouple(t, db, tenantId, 1, 2, StatusEngaged, 0)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, ctx, db).WithProducer(producer.Provider)
+
+	ceremony, err := processor.ScheduleCeremony(couple.ID, time.Now().Add(time.Hour), []uint32{3, 4, 5})()
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	return processor, producer, ceremony
+}
+
+// rsvpStatuses returns each invitee's status, keyed by character ID
+func rsvpStatuses(ceremony Ceremony) map[uint32]RsvpStatus {
+	statuses := map[uint32]RsvpStatus{}
+	for _, rsvp := range ceremony.Rsvps() {
+		statuses[rsvp.CharacterId()] = rsvp.Status()
+	}
+	return statuses
+}
+
+func TestCeremony_Rsvps_DefaultToInvited(t *testing.T) {
+	_, _, ceremony := setupRsvpTest(t)
+
+	rsvps := ceremony.Rsvps()
+	if len(rsvps) != 3 {
+		t.Fatalf("Expected 3 responses, got %d", len(rsvps))
+	}
+	for i, rsvp := range rsvps {
+		if rsvp.CharacterId() != ceremony.Invitees()[i] {
+			t.Errorf("Expected responses in invitee order, got %d at %d", rsvp.CharacterId(), i)
+		}
+		if rsvp.Status() != RsvpStatusInvited || rsvp.RespondedAt() != nil {
+			t.Errorf("Expected invitee %d to be invited without a response, got %s", rsvp.CharacterId(), rsvp.Status())
+		}
+	}
+	if _, ok := ceremony.Rsvp(6); ok {
+		t.Error("Expected no response for a character who is not invited")
+	}
+}
+
+func TestProcessor_RespondToInvitation(t *testing.T) {
+	processor, _, ceremony := setupRsvpTest(t)
+
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 3, RsvpStatusAccepted)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 4, RsvpStatusDeclined)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Invitees can change their minds before the ceremony starts
+	updated, err := processor.RespondToInvitation(ceremony.Id(), 4, RsvpStatusAccepted)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	statuses := rsvpStatuses(updated)
+	expected := map[uint32]RsvpStatus{3: RsvpStatusAccepted, 4: RsvpStatusAccepted, 5: RsvpStatusInvited}
+	for characterId, status := range expected {
+		if statuses[characterId] != status {
+			t.Errorf("Expected invitee %d to be %s, got %s", characterId, status, statuses[characterId])
+		}
+	}
+
+	// Responses are persisted with the ceremony
+	stored, err := processor.GetCeremonyById(ceremony.Id())()
+	if err != nil || stored == nil {
+		t.Fatalf("Failed to get ceremony: %v", err)
+	}
+	rsvp, _ := stored.Rsvp(3)
+	if rsvp.Status() != RsvpStatusAccepted || rsvp.RespondedAt() == nil {
+		t.Errorf("Expected stored response to be accepted with a response time, got %s", rsvp.Status())
+	}
+}
+
+func TestProcessor_RespondToInvitation_Validation(t *testing.T) {
+	processor, _, ceremony := setupRsvpTest(t)
+
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 6, RsvpStatusAccepted)(); !errors.Is(err, ErrInviteeNotInvited) {
+		t.Errorf("Expected not invited error, got %v", err)
+	}
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 3, RsvpStatusInvited)(); !errors.Is(err, ErrInvalidRsvpStatus) {
+		t.Errorf("Expected invalid status error, got %v", err)
+	}
+	if _, err := processor.RespondToInvitation(ceremony.Id()+1, 3, RsvpStatusAccepted)(); !errors.Is(err, ErrCeremonyNotFound) {
+		t.Errorf("Expected ceremony not found error, got %v", err)
+	}
+
+	// Attendance is only recorded once the ceremony starts
+	var transitionErr StateTransitionError
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 3, RsvpStatusAttended)(); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected state transition error, got %v", err)
+	}
+}
+
+func TestProcessor_RespondToInvitation_Attendance(t *testing.T) {
+	processor, _, ceremony := setupRsvpTest(t)
+
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 4, RsvpStatusDeclined)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+
+	// Invitations can no longer be accepted once the ceremony is active
+	var transitionErr StateTransitionError
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 3, RsvpStatusAccepted)(); !errors.As(err, &transitionErr) {
+		t.Errorf("Expected state transition error, got %v", err)
+	}
+
+	// An invitee who declined can still attend
+	updated, err := processor.RespondToInvitation(ceremony.Id(), 4, RsvpStatusAttended)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := rsvpStatuses(updated)[4]; status != RsvpStatusAttended {
+		t.Errorf("Expected invitee 4 to have attended, got %s", status)
+	}
+
+	// Attendance is final
+	if _, err = processor.RespondToInvitation(ceremony.Id(), 4, RsvpStatusAttended)(); !errors.Is(err, ErrInviteeAttended) {
+		t.Errorf("Expected attended error, got %v", err)
+	}
+}
+
+func TestProcessor_RemoveInvitee_DropsRsvp(t *testing.T) {
+	processor, _, ceremony := setupRsvpTest(t)
+
+	if _, err := processor.RespondToInvitation(ceremony.Id(), 3, RsvpStatusAccepted)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := processor.RemoveInvitee(ceremony.Id(), 3)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A character invited again starts without a response
+	updated, err := processor.AddInvitee(ceremony.Id(), 3)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if status := rsvpStatuses(updated)[3]; status != RsvpStatusInvited {
+		t.Errorf("Expected re-invited character to be invited, got %s", status)
+	}
+}
+
+func TestProcessor_RespondToInvitationAndEmit(t *testing.T) {
+	processor, producer, ceremony := setupRsvpTest(t)
+
+	if _, err := processor.RespondToInvitationAndEmit(uuid.New(), ceremony.Id(), 5, RsvpStatusDeclined); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.InviteeRsvpChangedBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode message: %v", err)
+	}
+	if event.Type != marriageMsg.EventInviteeRsvpChanged {
+		t.Errorf("Expected %s event, got %s", marriageMsg.EventInviteeRsvpChanged, event.Type)
+	}
+	if event.CharacterId != 1 || event.Body.CharacterId2 != 2 || event.Body.InviteeId != 5 {
+		t.Errorf("Expected the couple to be notified of invitee 5, got %+v", event)
+	}
+	if event.Body.PreviousStatus != string(RsvpStatusInvited) || event.Body.Status != string(RsvpStatusDeclined) {
+		t.Errorf("Expected change from INVITED to DECLINED, got %s to %s", event.Body.PreviousStatus, event.Body.Status)
+	}
+
+	// Failed responses emit nothing
+	producer.ClearMessages()
+	if _, err := processor.RespondToInvitationAndEmit(uuid.New(), ceremony.Id(), 6, RsvpStatusAccepted); err == nil {
+		t.Error("Expected error for a character who is not invited")
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Errorf("Expected no messages, got %d", len(producer.GetProducedMessages()))
+	}
+}
@@ -14,6 +14,10 @@ const (
 	StateDivorced
 	// StateExpired represents a proposal that has expired without acceptance
 	StateExpired
+	// StateDeleted represents a relationship ended because one of the characters was deleted
+	StateDeleted
+	// StateDivorcePending represents a marriage where one partner has filed for divorce and it has not been finalized
+	StateDivorcePending
 )
 
 // String returns the string representation of MarriageState
@@ -29,6 +33,10 @@ func (s MarriageState) String() string {
 		return "divorced"
 	case StateExpired:
 		return "expired"
+	case StateDeleted:
+		return "deleted"
+	case StateDivorcePending:
+		return "divorce_pending"
 	default:
 		return "unknown"
 	}
@@ -36,24 +44,26 @@ func (s MarriageState) String() string {
 
 // IsActive returns true if the marriage state represents an active relationship
 func (s MarriageState) IsActive() bool {
-	return s == StateMarried
+	return s == StateMarried || s == StateDivorcePending
 }
 
 // IsTerminated returns true if the marriage state represents a terminated relationship
 func (s MarriageState) IsTerminated() bool {
-	return s == StateDivorced || s == StateExpired
+	return s == StateDivorced || s == StateExpired || s == StateDeleted
 }
 
 // CanTransitionTo returns true if the marriage can transition to the target state
 func (s MarriageState) CanTransitionTo(target MarriageState) bool {
 	switch s {
 	case StateProposed:
-		return target == StateEngaged || target == StateExpired
+		return target == StateEngaged || target == StateExpired || target == StateDeleted
 	case StateEngaged:
-		return target == StateMarried
+		return target == StateMarried || target == StateDeleted
 	case StateMarried:
-		return target == StateDivorced
-	case StateDivorced, StateExpired:
+		return target == StateDivorced || target == StateDivorcePending || target == StateDeleted
+	case StateDivorcePending:
+		return target == StateMarried || target == StateDivorced || target == StateDeleted
+	case StateDivorced, StateExpired, StateDeleted:
 		return false // Terminal states
 	default:
 		return false
@@ -64,12 +74,14 @@ func (s MarriageState) CanTransitionTo(target MarriageState) bool {
 func (s MarriageState) ValidTransitions() []MarriageState {
 	switch s {
 	case StateProposed:
-		return []MarriageState{StateEngaged, StateExpired}
+		return []MarriageState{StateEngaged, StateExpired, StateDeleted}
 	case StateEngaged:
-		return []MarriageState{StateMarried}
+		return []MarriageState{StateMarried, StateDeleted}
 	case StateMarried:
-		return []MarriageState{StateDivorced}
-	case StateDivorced, StateExpired:
+		return []MarriageState{StateDivorced, StateDivorcePending, StateDeleted}
+	case StateDivorcePending:
+		return []MarriageState{StateMarried, StateDivorced, StateDeleted}
+	case StateDivorced, StateExpired, StateDeleted:
 		return []MarriageState{} // Terminal states
 	default:
 		return []MarriageState{}
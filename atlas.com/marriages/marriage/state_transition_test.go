@@ -1,6 +1,10 @@
 package marriage
 
 import (
+	"atlas-marriages/outbox"
+	"atlas-marriages/rules"
+	"atlas-marriages/saga"
+
 	"context"
 	"errors"
 	"strings"
@@ -99,6 +103,73 @@ func TestMarriageStateTransitions(t *testing.T) {
 			description:   "Cannot revert to proposal",
 		},
 
+		// Divorce filing transitions
+		{
+			name:          "married to divorce pending",
+			currentState:  StateMarried,
+			targetState:   StateDivorcePending,
+			canTransition: true,
+			description:   "Filing for divorce should be allowed",
+		},
+		{
+			name:          "divorce pending to divorced",
+			currentState:  StateDivorcePending,
+			targetState:   StateDivorced,
+			canTransition: true,
+			description:   "Consent or a matured filing finalizes the divorce",
+		},
+		{
+			name:          "divorce pending to married",
+			currentState:  StateDivorcePending,
+			targetState:   StateMarried,
+			canTransition: true,
+			description:   "Withdrawing the filing restores the marriage",
+		},
+		{
+			name:          "divorce pending to deleted",
+			currentState:  StateDivorcePending,
+			targetState:   StateDeleted,
+			canTransition: true,
+			description:   "Character deletion ends a marriage with a pending divorce",
+		},
+		{
+			name:          "engaged to divorce pending - invalid",
+			currentState:  StateEngaged,
+			targetState:   StateDivorcePending,
+			canTransition: false,
+			description:   "Only a married couple can file for divorce",
+		},
+
+		// Deletion transitions
+		{
+			name:          "engaged to deleted",
+			currentState:  StateEngaged,
+			targetState:   StateDeleted,
+			canTransition: true,
+			description:   "Character deletion ends an engagement",
+		},
+		{
+			name:          "married to deleted",
+			currentState:  StateMarried,
+			targetState:   StateDeleted,
+			canTransition: true,
+			description:   "Character deletion ends a marriage",
+		},
+		{
+			name:          "divorced to deleted - invalid",
+			currentState:  StateDivorced,
+			targetState:   StateDeleted,
+			canTransition: false,
+			description:   "Divorced is terminal state",
+		},
+		{
+			name:          "deleted to married - invalid",
+			currentState:  StateDeleted,
+			targetState:   StateMarried,
+			canTransition: false,
+			description:   "Deleted is terminal state",
+		},
+
 		// Terminal state transitions
 		{
 			name:          "divorced to married - invalid",
@@ -293,6 +364,13 @@ func TestMarriageStateHelperMethods(t *testing.T) {
 			isTerminated: false,
 			description:  "Marriage is active",
 		},
+		{
+			name:         "divorce pending state",
+			state:        StateDivorcePending,
+			isActive:     true,
+			isTerminated: false,
+			description:  "Marriage remains active until the divorce is finalized",
+		},
 		{
 			name:         "divorced state",
 			state:        StateDivorced,
@@ -307,6 +385,13 @@ func TestMarriageStateHelperMethods(t *testing.T) {
 			isTerminated: true,
 			description:  "Expiry is terminated",
 		},
+		{
+			name:         "deleted state",
+			state:        StateDeleted,
+			isActive:     false,
+			isTerminated: true,
+			description:  "Deletion is terminated",
+		},
 	}
 
 	for _, tt := range tests {
@@ -396,7 +481,7 @@ func TestInvalidStateTransitionErrors(t *testing.T) {
 	}
 
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
@@ -561,7 +646,7 @@ func TestProcessorErrorHandling(t *testing.T) {
 	}
 
 	// Run migrations
-	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{})
+	err = db.AutoMigrate(&Entity{}, &ProposalEntity{}, &CeremonyEntity{}, &outbox.Entity{}, &rules.Entity{}, &saga.Entity{})
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
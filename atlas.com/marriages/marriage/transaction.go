@@ -0,0 +1,176 @@
+package marriage
+
+import (
+	"errors"
+
+	"atlas-marriages/kafka/message"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/outbox"
+	"atlas-marriages/transaction"
+
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// transactionScope holds what the processors sharing one database transaction stage for after it commits or rolls back
+type transactionScope struct {
+	batch      *outbox.Batch
+	committed  []func()
+	rolledBack []func()
+}
+
+// afterCommit defers a call to another service until the processor's database transaction commits, so a rolled back
+// change is never acted on elsewhere. Outside of a transaction the call is made immediately
+func (p *ProcessorImpl) afterCommit(hook func()) {
+	if p.scope == nil {
+		hook()
+		return
+	}
+	p.scope.committed = append(p.scope.committed, hook)
+}
+
+// runCommitted makes the calls deferred until the scope's transaction committed, in the order they were deferred
+func (s *transactionScope) runCommitted() {
+	for _, hook := range s.committed {
+		hook()
+	}
+}
+
+// onRollback registers a call to another service compensating one already made, for when the processor's database
+// transaction rolls back. Outside of a transaction there is nothing to roll back, so the compensation is never made
+func (p *ProcessorImpl) onRollback(hook func()) {
+	if p.scope == nil {
+		return
+	}
+	p.scope.rolledBack = append(p.scope.rolledBack, hook)
+}
+
+// runRolledBack makes the compensations registered for the scope's rolled back transaction, most recent first
+func (s *transactionScope) runRolledBack() {
+	for i := len(s.rolledBack) - 1; i >= 0; i-- {
+		s.rolledBack[i]()
+	}
+}
+
+// ProcessCommand executes a command's operation at most once for the tenant's transaction id, generating an id when
+// the caller supplied none. The transaction is recorded as processed in the same database transaction as the changes
+// the operation makes, so a redelivered command replays the recorded outcome instead of executing again and returns
+// ErrTransactionProcessed. A failed operation's error event is emitted and recorded as the transaction's outcome.
+// When the recorded outcome cannot be read the operation is not executed
+func (p *ProcessorImpl) ProcessCommand(transactionId uuid.UUID, characterId uint32, errorContext string, operation func(Processor, uuid.UUID) error) error {
+	t := tenant.MustFromContext(p.ctx)
+	if transactionId == uuid.Nil {
+		transactionId = uuid.New()
+	}
+	l := p.log.WithFields(logrus.Fields{
+		"transactionId": transactionId,
+		"characterId":   characterId,
+	})
+
+	processed, err := transaction.GetByIdProvider(p.db, p.log)(transactionId, t.Id())()
+	if err != nil {
+		l.WithError(err).Error("Failed to look up processed transaction, not executing command")
+		return err
+	}
+	if processed != nil {
+		if err = p.replayTransaction(*processed); err != nil {
+			l.WithError(err).Error("Failed to replay processed transaction")
+			return err
+		}
+		l.WithField("status", processed.Status()).Info("Duplicate transaction detected, replayed its outcome")
+		return ErrTransactionProcessed
+	}
+
+	err = p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		m, err := transaction.NewBuilder(t.Id(), transactionId, characterId).Build()
+		if err != nil {
+			return err
+		}
+		recorded, err := transaction.Record(p.db, p.log)(m)
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			return ErrTransactionProcessed
+		}
+		return operation(p, transactionId)
+	})
+	if errors.Is(err, ErrTransactionProcessed) {
+		l.Info("Duplicate transaction processed concurrently, not executing command")
+		return err
+	}
+	if err != nil {
+		p.recordFailedTransaction(transactionId, characterId, errorContext, err)
+		return err
+	}
+	return nil
+}
+
+// recordFailedTransaction records a command's failure as the outcome of its transaction and emits its error event
+// with the record. Nothing is recorded or emitted when a concurrent delivery has processed the transaction. When the
+// failure cannot be recorded the error event is still emitted
+func (p *ProcessorImpl) recordFailedTransaction(transactionId uuid.UUID, characterId uint32, errorContext string, cause error) {
+	t := tenant.MustFromContext(p.ctx)
+	errorType, errorCode := ClassifyError(cause)
+	errorProvider := DomainErrorEventProvider(characterId, cause, errorContext)
+
+	err := p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		m, err := transaction.NewBuilder(t.Id(), transactionId, characterId).
+			SetFailure(errorType, errorCode, cause.Error(), errorContext).
+			Build()
+		if err != nil {
+			return err
+		}
+		recorded, err := transaction.Record(p.db, p.log)(m)
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			return ErrTransactionProcessed
+		}
+		return message.Emit(p.producer)(func(buf *message.Buffer) error {
+			return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
+		})
+	})
+	if err == nil || errors.Is(err, ErrTransactionProcessed) {
+		return
+	}
+
+	p.log.WithError(err).WithField("transactionId", transactionId).Error("Failed to record failed transaction")
+	if emitErr := message.Emit(p.producer)(func(buf *message.Buffer) error {
+		return buf.Put(marriageMsg.EnvEventTopicStatus, errorProvider)
+	}); emitErr != nil {
+		p.log.WithError(emitErr).WithField("transactionId", transactionId).Error("Failed to emit error event for failed transaction")
+	}
+}
+
+// ReportErrorAndEmit emits the error event for a failure handling an event on behalf of a character. Unlike a failed
+// command, the failure is not recorded against a transaction, as the event carries none to be redelivered under
+func (p *ProcessorImpl) ReportErrorAndEmit(transactionId uuid.UUID, characterId uint32, cause error, errorContext string) error {
+	return p.emitInTransaction(transactionId, func(p *ProcessorImpl) error {
+		return message.Emit(p.producer)(func(buf *message.Buffer) error {
+			return buf.Put(marriageMsg.EnvEventTopicStatus, DomainErrorEventProvider(characterId, cause, errorContext))
+		})
+	})
+}
+
+// replayTransaction re-emits the outcome of a processed transaction. A failure's error event is rebuilt from the
+// record, while the events of a success are republished for as long as the outbox retains them. The commands a
+// success sent to other services are not, so a redelivery never issues rings, moves gifts or charges fees again
+func (p *ProcessorImpl) replayTransaction(m transaction.Model) error {
+	if !m.Succeeded() {
+		return message.Emit(p.producer)(func(buf *message.Buffer) error {
+			return buf.Put(marriageMsg.EnvEventTopicStatus, MarriageErrorEventProvider(m.CharacterId(), m.ErrorType(), m.ErrorCode(), m.ErrorMessage(), m.ErrorContext()))
+		})
+	}
+
+	replayed, err := outbox.Replay(p.log, p.db)(m.TenantId(), m.TransactionId(), marriageMsg.EnvEventTopicStatus, p.producer)
+	if err != nil {
+		return err
+	}
+	if !replayed {
+		p.log.WithField("transactionId", m.TransactionId()).Debug("Events of processed transaction are no longer retained")
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package marriage
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	sagaMsg "atlas-marriages/kafka/message/saga"
+	"atlas-marriages/transaction"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// scheduleOperation returns a command operation scheduling the couple's ceremony, counting its executions
+func scheduleOperation(marriageId uint32, executions *int, cause error) func(Processor, uuid.UUID) error {
+	return func(p Processor, transactionId uuid.UUID) error {
+		*executions++
+		if _, err := p.ScheduleCeremonyAndEmit(transactionId, marriageId, time.Now().Add(time.Hour), []uint32{3}, VenueTierStandard, 0); err != nil {
+			return err
+		}
+		return cause
+	}
+}
+
+func TestProcessCommand_SuccessReplayedWithoutExecuting(t *testing.T) {
+	db, tenantId, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+
+	executions := 0
+	transactionId := uuid.New()
+	if err := processor.ProcessCommand(transactionId, 1, "ceremony_schedule", scheduleOperation(marriageId, &executions, nil)); err != nil {
+		t.Fatalf("Failed to process command: %v", err)
+	}
+	produced := len(producer.GetProducedMessages())
+	if produced == 0 {
+		t.Fatalf("Expected the command's events to be emitted")
+	}
+
+	recorded, err := transaction.GetByIdProvider(db, logrus.New())(transactionId, tenantId)()
+	if err != nil || recorded == nil || !recorded.Succeeded() {
+		t.Fatalf("Expected the transaction to be recorded as succeeded, got %v (%v)", recorded, err)
+	}
+
+	// A redelivery replays the recorded events without scheduling again
+	err = processor.ProcessCommand(transactionId, 1, "ceremony_schedule", scheduleOperation(marriageId, &executions, nil))
+	if !errors.Is(err, ErrTransactionProcessed) {
+		t.Fatalf("Expected the redelivery to be reported as processed, got %v", err)
+	}
+	if executions != 1 {
+		t.Fatalf("Expected the command to execute once, executed %d times", executions)
+	}
+	if len(producer.GetProducedMessages()) != 2*produced {
+		t.Fatalf("Expected the %d events to be replayed, got %d messages", produced, len(producer.GetProducedMessages()))
+	}
+}
+
+func TestProcessCommand_FailureRolledBackAndReplayed(t *testing.T) {
+	db, tenantId, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+
+	executions := 0
+	transactionId := uuid.New()
+	err := processor.ProcessCommand(transactionId, 1, "ceremony_schedule", scheduleOperation(marriageId, &executions, ErrNotMarriagePartner))
+	if !errors.Is(err, ErrNotMarriagePartner) {
+		t.Fatalf("Expected the operation's error, got %v", err)
+	}
+
+	// The ceremony scheduled before the operation failed is rolled back with it
+	if ceremony, err := processor.GetCeremonyByMarriage(marriageId)(); err != nil || ceremony != nil {
+		t.Fatalf("Expected no ceremony after the failed command, got %v (%v)", ceremony, err)
+	}
+	if types := producedEventTypes(t, producer); len(types) != 1 || types[0] != marriageMsg.EventMarriageError {
+		t.Fatalf("Expected only the error event, got %v", types)
+	}
+
+	recorded, err := transaction.GetByIdProvider(db, logrus.New())(transactionId, tenantId)()
+	if err != nil || recorded == nil || recorded.Succeeded() || recorded.ErrorCode() != marriageMsg.ErrorCodeNotPartner {
+		t.Fatalf("Expected the failure to be recorded, got %v (%v)", recorded, err)
+	}
+
+	// A redelivery replays the error event without executing again
+	producer.ClearMessages()
+	err = processor.ProcessCommand(transactionId, 1, "ceremony_schedule", scheduleOperation(marriageId, &executions, nil))
+	if !errors.Is(err, ErrTransactionProcessed) {
+		t.Fatalf("Expected the redelivery to be reported as processed, got %v", err)
+	}
+	if executions != 1 {
+		t.Fatalf("Expected the command to execute once, executed %d times", executions)
+	}
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected the error event to be replayed, got %d messages", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.MarriageErrorBody]
+	if err = json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode error event: %v", err)
+	}
+	if event.Body.ErrorCode != marriageMsg.ErrorCodeNotPartner || event.Body.Context != "ceremony_schedule" {
+		t.Fatalf("Expected the recorded error to be replayed, got %+v", event.Body)
+	}
+}
+
+func TestProcessCommand_ReplayDoesNotResendCommands(t *testing.T) {
+	_, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+
+	// The couple's ceremony is scheduled, started and completed under one transaction, which starts the ceremony
+	// saga and issues the wedding rings
+	executions := 0
+	operation := func(p Processor, transactionId uuid.UUID) error {
+		executions++
+		ceremony, err := p.ScheduleCeremonyAndEmit(transactionId, marriageId, time.Now().Add(time.Hour), []uint32{3}, VenueTierStandard, 0)
+		if err != nil {
+			return err
+		}
+		if _, err = p.StartCeremonyAndEmit(transactionId, ceremony.Id()); err != nil {
+			return err
+		}
+		_, err = p.CompleteCeremonyAndEmit(transactionId, ceremony.Id())
+		return err
+	}
+
+	transactionId := uuid.New()
+	if err := processor.ProcessCommand(transactionId, 1, "ceremony_complete", operation); err != nil {
+		t.Fatalf("Failed to process command: %v", err)
+	}
+	topics := producer.GetProducedTopics()
+	if !slices.Contains(topics, inventoryMsg.EnvCommandTopic) || !slices.Contains(topics, sagaMsg.EnvCommandTopic) {
+		t.Fatalf("Expected the command to send inventory and saga commands, got topics %v", topics)
+	}
+	events := 0
+	for _, topic := range topics {
+		if topic == marriageMsg.EnvEventTopicStatus {
+			events++
+		}
+	}
+
+	producer.ClearMessages()
+	if err := processor.ProcessCommand(transactionId, 1, "ceremony_complete", operation); !errors.Is(err, ErrTransactionProcessed) {
+		t.Fatalf("Expected the redelivery to be reported as processed, got %v", err)
+	}
+	if executions != 1 {
+		t.Fatalf("Expected the command to execute once, executed %d times", executions)
+	}
+	replayed := producer.GetProducedTopics()
+	if len(replayed) != events {
+		t.Errorf("Expected the %d events to be replayed, got %d messages", events, len(replayed))
+	}
+	for _, topic := range replayed {
+		if topic != marriageMsg.EnvEventTopicStatus {
+			t.Errorf("Expected only events to be replayed, got a message on %s", topic)
+		}
+	}
+}
+
+func TestProcessCommand_LookupFailureDoesNotExecute(t *testing.T) {
+	db, _, processor, producer, marriageId := setupCeremonyScheduleTest(t)
+	if err := db.Migrator().DropTable(&transaction.Entity{}); err != nil {
+		t.Fatalf("Failed to drop processed transactions: %v", err)
+	}
+
+	executions := 0
+	err := processor.ProcessCommand(uuid.New(), 1, "ceremony_schedule", scheduleOperation(marriageId, &executions, nil))
+	if err == nil {
+		t.Fatalf("Expected the failed lookup to be reported")
+	}
+	if executions != 0 {
+		t.Fatalf("Expected the command not to execute, executed %d times", executions)
+	}
+	if len(producer.GetProducedMessages()) != 0 {
+		t.Fatalf("Expected no messages, got %d", len(producer.GetProducedMessages()))
+	}
+}
+
+func TestReportErrorAndEmit(t *testing.T) {
+	db, tenantId, processor, producer, _ := setupCeremonyScheduleTest(t)
+
+	transactionId := uuid.New()
+	if err := processor.ReportErrorAndEmit(transactionId, 1, ErrCeremonyNotFound, "character_logout"); err != nil {
+		t.Fatalf("Failed to report error: %v", err)
+	}
+
+	messages := producer.GetProducedMessages()
+	if len(messages) != 1 {
+		t.Fatalf("Expected 1 message, got %d", len(messages))
+	}
+	var event marriageMsg.Event[marriageMsg.MarriageErrorBody]
+	if err := json.Unmarshal(messages[0].Value, &event); err != nil {
+		t.Fatalf("Failed to decode error event: %v", err)
+	}
+	if event.Body.ErrorCode != marriageMsg.ErrorCodeCeremonyNotFound || event.Body.Context != "character_logout" {
+		t.Fatalf("Expected the ceremony not found error, got %+v", event.Body)
+	}
+
+	// The failure is not recorded against the transaction
+	recorded, err := transaction.GetByIdProvider(db, logrus.New())(transactionId, tenantId)()
+	if err != nil || recorded != nil {
+		t.Fatalf("Expected no transaction to be recorded, got %v (%v)", recorded, err)
+	}
+}
@@ -0,0 +1,196 @@
+package marriage
+
+import (
+	"errors"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/venue"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/sirupsen/logrus"
+)
+
+// VenueAvailability represents a venue and the slots it is free to be booked for within a window
+type VenueAvailability struct {
+	venue venue.Model
+	slots []venue.Slot
+}
+
+// Venue returns the venue
+func (a VenueAvailability) Venue() venue.Model {
+	return a.venue
+}
+
+// Slots returns the slots the venue is free for
+func (a VenueAvailability) Slots() []venue.Slot {
+	return a.slots
+}
+
+// GetCeremonyAvailability retrieves the free slots of each venue in a world and channel starting within [from, to)
+func (p *ProcessorImpl) GetCeremonyAvailability(worldId byte, channelId byte, from time.Time, to time.Time) model.Provider[[]VenueAvailability] {
+	return func() ([]VenueAvailability, error) {
+		p.log.WithFields(logrus.Fields{
+			"worldId":   worldId,
+			"channelId": channelId,
+			"from":      from,
+			"to":        to,
+		}).Debug("Retrieving ceremony availability")
+
+		t := tenant.MustFromContext(p.ctx)
+
+		venues, err := venue.GetByLocationProvider(p.db, p.log)(worldId, channelId, t.Id())()
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]VenueAvailability, 0, len(venues))
+		for _, v := range venues {
+			// Bookings starting before the window may still hold its first slots
+			bookings, err := venue.GetBookingsProvider(p.db, p.log)(v.Id(), from, v.SlotEnd(to), t.Id())()
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, VenueAvailability{venue: v, slots: v.FreeSlots(bookings, from, to)})
+		}
+		return results, nil
+	}
+}
+
+// GetVenues retrieves the tenant's venues in a world and channel
+func (p *ProcessorImpl) GetVenues(worldId byte, channelId byte) model.Provider[[]venue.Model] {
+	t := tenant.MustFromContext(p.ctx)
+	return venue.GetByLocationProvider(p.db, p.log)(worldId, channelId, t.Id())
+}
+
+// CreateVenue adds a venue to the tenant's catalogue for a world and channel
+func (p *ProcessorImpl) CreateVenue(worldId byte, channelId byte, name string, venueType venue.Type, capacity int, slotLength time.Duration) model.Provider[venue.Model] {
+	return func() (venue.Model, error) {
+		t := tenant.MustFromContext(p.ctx)
+
+		m, err := venue.NewBuilder(t.Id(), 0).
+			SetLocation(worldId, channelId).
+			SetName(name).
+			SetType(venueType).
+			SetCapacity(capacity).
+			SetSlotLength(slotLength).
+			Build()
+		if err != nil {
+			return venue.Model{}, ValidationError{Code: marriageMsg.ErrorCodeInvalidVenue, Message: err.Error()}
+		}
+
+		created, err := venue.Create(p.db, p.log)(m)()
+		if err != nil {
+			return venue.Model{}, err
+		}
+
+		p.log.WithFields(logrus.Fields{
+			"venueId":   created.Id(),
+			"worldId":   worldId,
+			"channelId": channelId,
+			"name":      name,
+		}).Info("Venue added to catalogue")
+		return created, nil
+	}
+}
+
+// bookableVenue retrieves a venue, verifying startsAt is the start of one of its slots and no other ceremony has
+// booked it for any part of that slot. A ceremony's own booking is ignored so it can be moved. The venue stays locked
+// until the database transaction ends, so a concurrent booking of it waits for this one
+func (p *ProcessorImpl) bookableVenue(venueId uint32, ceremonyId uint32, startsAt time.Time) (venue.Model, error) {
+	t := tenant.MustFromContext(p.ctx)
+
+	v, err := venue.LockByIdProvider(p.db, p.log)(venueId, t.Id())()
+	if err != nil {
+		return venue.Model{}, err
+	}
+	if v == nil {
+		return venue.Model{}, ErrVenueNotFound
+	}
+	if !v.IsSlotStart(startsAt) {
+		return venue.Model{}, ErrInvalidVenueSlot
+	}
+
+	bookings, err := venue.GetBookingsProvider(p.db, p.log)(venueId, startsAt, v.SlotEnd(startsAt), t.Id())()
+	if err != nil {
+		return venue.Model{}, err
+	}
+	for _, b := range bookings {
+		if ceremonyId == 0 || b.CeremonyId() != ceremonyId {
+			return venue.Model{}, ErrVenueSlotUnavailable
+		}
+	}
+	return *v, nil
+}
+
+// verifyVenueOptional reports ErrVenueRequired when the tenant has a venue catalogue, as a ceremony scheduled without a
+// venue would hold no booking for other ceremonies to be checked against
+func (p *ProcessorImpl) verifyVenueOptional() error {
+	t := tenant.MustFromContext(p.ctx)
+
+	catalogued, err := venue.HasCatalogueProvider(p.db, p.log)(t.Id())()
+	if err != nil {
+		return err
+	}
+	if catalogued {
+		return ErrVenueRequired
+	}
+	return nil
+}
+
+// inviteeLimit returns the most invitees a ceremony at the venue may have, the lesser of the tenant's limit and the
+// venue's capacity
+func (p *ProcessorImpl) inviteeLimit(v *venue.Model) int {
+	limit := p.rules().MaxInvitees()
+	if v != nil && v.Capacity() > 0 && v.Capacity() < limit {
+		return v.Capacity()
+	}
+	return limit
+}
+
+// bookVenue books the slot starting at startsAt for a ceremony
+func (p *ProcessorImpl) bookVenue(v venue.Model, ceremonyId uint32, startsAt time.Time) error {
+	t := tenant.MustFromContext(p.ctx)
+
+	_, err := venue.CreateBooking(p.db, p.log)(v.Id(), ceremonyId, startsAt, v.SlotEnd(startsAt), t.Id())()
+	if errors.Is(err, venue.ErrSlotBooked) {
+		return ErrVenueSlotUnavailable
+	}
+	if err != nil {
+		return err
+	}
+
+	p.log.WithFields(logrus.Fields{
+		"venueId":    v.Id(),
+		"ceremonyId": ceremonyId,
+		"startsAt":   startsAt,
+	}).Info("Venue booked for ceremony")
+	return nil
+}
+
+// rebookVenue moves a ceremony's booking to the slot starting at startsAt, failing if another ceremony holds it
+func (p *ProcessorImpl) rebookVenue(ceremony Ceremony, startsAt time.Time) error {
+	if ceremony.VenueId() == 0 {
+		return nil
+	}
+
+	v, err := p.bookableVenue(ceremony.VenueId(), ceremony.Id(), startsAt)
+	if err != nil {
+		return err
+	}
+	if err = p.releaseVenue(ceremony); err != nil {
+		return err
+	}
+	return p.bookVenue(v, ceremony.Id(), startsAt)
+}
+
+// releaseVenue frees the venue booked by a ceremony which will no longer be held at its scheduled time
+func (p *ProcessorImpl) releaseVenue(ceremony Ceremony) error {
+	if ceremony.VenueId() == 0 {
+		return nil
+	}
+
+	t := tenant.MustFromContext(p.ctx)
+	return venue.DeleteBookingByCeremonyId(p.db, p.log)(ceremony.Id(), t.Id())
+}
@@ -0,0 +1,255 @@
+package marriage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	marriageMsg "atlas-marriages/kafka/message/marriage"
+	"atlas-marriages/venue"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// setupVenueBookingTest creates a processor, a cathedral with one hour slots holding two invitees, and two engaged
+// couples, characters 1 and 2, and characters 3 and 4
+func setupVenueBookingTest(t *testing.T) (Processor, uint32, uint32, uint32) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	ctx := setupTestContext(tenantId)
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	cathedral := createVenue(t, db, tenantId, 2)
+	couple1 := createMarriedCouple(t, db, tenantId, 1, 2, StatusEngaged, 0)
+	couple2 := createMarriedCouple(t, db, tenantId, 3, 4, StatusEngaged, 0)
+
+	processor := NewProcessor(log, ctx, db).WithProducer(NewMockProducer().Provider)
+	return processor, cathedral.ID, couple1.ID, couple2.ID
+}
+
+// createVenue adds a cathedral with one hour slots to the tenant's catalogue for world 0, channel 1
+func createVenue(t *testing.T, db *gorm.DB, tenantId uuid.UUID, capacity int) venue.Entity {
+	now := time.Now()
+	entity := venue.Entity{
+		TenantId:          tenantId,
+		WorldId:           0,
+		ChannelId:         1,
+		Name:              "Cathedral",
+		Type:              venue.TypeCathedral,
+		Capacity:          capacity,
+		SlotLengthSeconds: int64(time.Hour.Seconds()),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := db.Create(&entity).Error; err != nil {
+		t.Fatalf("Failed to create venue: %v", err)
+	}
+	return entity
+}
+
+// nextSlot returns the start of a slot a day from now, aligned to the hour
+func nextSlot() time.Time {
+	return time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+}
+
+func TestProcessor_ScheduleCeremonyAtVenue_Conflict(t *testing.T) {
+	processor, venueId, marriageId1, marriageId2 := setupVenueBookingTest(t)
+	startsAt := nextSlot()
+
+	ceremony, err := processor.ScheduleCeremonyAtVenue(marriageId1, startsAt, []uint32{}, VenueTierStandard, venueId)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ceremony.VenueId() != venueId {
+		t.Errorf("Expected ceremony to be at venue %d, got %d", venueId, ceremony.VenueId())
+	}
+
+	if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt, []uint32{}, VenueTierStandard, venueId)(); !errors.Is(err, ErrVenueSlotUnavailable) {
+		t.Errorf("Expected slot unavailable for a booked slot, got %v", err)
+	}
+	if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt.Add(30*time.Minute), []uint32{}, VenueTierStandard, venueId)(); !errors.Is(err, ErrInvalidVenueSlot) {
+		t.Errorf("Expected an invalid slot for a time between slots, got %v", err)
+	}
+	if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt, []uint32{}, VenueTierStandard, venueId+1)(); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("Expected venue not found, got %v", err)
+	}
+	if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt.Add(time.Hour), []uint32{}, VenueTierStandard, venueId)(); err != nil {
+		t.Errorf("Expected the following slot to be free, got %v", err)
+	}
+}
+
+func TestProcessor_BookVenue_ConcurrentBooking(t *testing.T) {
+	processor, venueId, marriageId1, marriageId2 := setupVenueBookingTest(t)
+	startsAt := nextSlot()
+	p := processor.(*ProcessorImpl)
+
+	// The venue is found free, then a concurrent booking of the slot commits before this one is made
+	v, err := p.bookableVenue(venueId, 0, startsAt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err = processor.ScheduleCeremonyAtVenue(marriageId1, startsAt, []uint32{}, VenueTierStandard, venueId)(); err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+
+	ceremony, err := processor.ScheduleCeremonyAtVenue(marriageId2, startsAt.Add(time.Hour), []uint32{}, VenueTierStandard, venueId)()
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if err = p.bookVenue(v, ceremony.Id(), startsAt); !errors.Is(err, ErrVenueSlotUnavailable) {
+		t.Errorf("Expected the violated booking index to report the slot unavailable, got %v", err)
+	}
+}
+
+func TestProcessor_ScheduleCeremony_VenueRequired(t *testing.T) {
+	processor, _, marriageId, _ := setupVenueBookingTest(t)
+
+	// A tenant with a venue catalogue cannot schedule a ceremony that holds no booking
+	if _, err := processor.ScheduleCeremony(marriageId, nextSlot(), []uint32{})(); !errors.Is(err, ErrVenueRequired) {
+		t.Errorf("Expected ErrVenueRequired, got %v", err)
+	}
+}
+
+func TestProcessor_CreateVenue(t *testing.T) {
+	processor, _, _, _ := setupVenueBookingTest(t)
+
+	chapel, err := processor.CreateVenue(0, 2, "Chapel", venue.TypeChapel, 10, 30*time.Minute)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if chapel.Id() == 0 || chapel.Type() != venue.TypeChapel || chapel.SlotLength() != 30*time.Minute {
+		t.Errorf("Unexpected venue %+v", chapel)
+	}
+
+	venues, err := processor.GetVenues(0, 2)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(venues) != 1 || venues[0].Id() != chapel.Id() || venues[0].Capacity() != 10 {
+		t.Errorf("Expected the chapel in the catalogue, got %+v", venues)
+	}
+
+	var validationErr ValidationError
+	if _, err = processor.CreateVenue(0, 2, "Garden", venue.TypeChapel, 10, 7*time.Hour)(); !errors.As(err, &validationErr) || validationErr.Code != marriageMsg.ErrorCodeInvalidVenue {
+		t.Errorf("Expected an invalid venue for a slot length not dividing a day, got %v", err)
+	}
+	if _, err = processor.CreateVenue(0, 2, "Garden", "GARDEN", 10, time.Hour)(); !errors.As(err, &validationErr) {
+		t.Errorf("Expected an invalid venue for an unknown type, got %v", err)
+	}
+}
+
+func TestProcessor_ScheduleCeremonyAtVenue_Capacity(t *testing.T) {
+	processor, venueId, marriageId, _ := setupVenueBookingTest(t)
+
+	_, err := processor.ScheduleCeremonyAtVenue(marriageId, nextSlot(), []uint32{5, 6, 7}, VenueTierStandard, venueId)()
+	var limitErr InviteeLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != 2 {
+		t.Fatalf("Expected invitee limit of the venue capacity, got %v", err)
+	}
+
+	ceremony, err := processor.ScheduleCeremonyAtVenue(marriageId, nextSlot(), []uint32{5, 6}, VenueTierStandard, venueId)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ceremony.MaxInvitees() != 2 {
+		t.Errorf("Expected ceremony to hold at most 2 invitees, got %d", ceremony.MaxInvitees())
+	}
+}
+
+func TestProcessor_RescheduleCeremony_MovesBooking(t *testing.T) {
+	processor, venueId, marriageId1, marriageId2 := setupVenueBookingTest(t)
+	startsAt := nextSlot()
+
+	ceremony1, err := processor.ScheduleCeremonyAtVenue(marriageId1, startsAt, []uint32{}, VenueTierStandard, venueId)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ceremony2, err := processor.ScheduleCeremonyAtVenue(marriageId2, startsAt.Add(2*time.Hour), []uint32{}, VenueTierStandard, venueId)()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err = processor.RescheduleCeremony(ceremony1.Id(), startsAt.Add(2*time.Hour))(); !errors.Is(err, ErrVenueSlotUnavailable) {
+		t.Errorf("Expected slot unavailable when moving onto another booking, got %v", err)
+	}
+	if _, err = processor.RescheduleCeremony(ceremony1.Id(), startsAt.Add(30*time.Minute))(); err != nil {
+		t.Fatalf("Expected ceremony to move within its own booking, got %v", err)
+	}
+	if _, err = processor.RescheduleCeremony(ceremony2.Id(), startsAt.Add(-time.Hour))(); err != nil {
+		t.Errorf("Expected the slot before the first ceremony to be free, got %v", err)
+	}
+}
+
+func TestProcessor_ReleaseVenue(t *testing.T) {
+	t.Run("cancelled", func(t *testing.T) {
+		processor, venueId, marriageId1, marriageId2 := setupVenueBookingTest(t)
+		startsAt := nextSlot()
+
+		ceremony, err := processor.ScheduleCeremonyAtVenue(marriageId1, startsAt, []uint32{}, VenueTierStandard, venueId)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err = processor.CancelCeremonyAndEmit(uuid.New(), ceremony.Id(), 1, "changed_mind"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt, []uint32{}, VenueTierStandard, venueId)(); err != nil {
+			t.Errorf("Expected the cancelled ceremony's slot to be free, got %v", err)
+		}
+	})
+
+	t.Run("missed", func(t *testing.T) {
+		processor, venueId, marriageId1, marriageId2 := setupVenueBookingTest(t)
+		startsAt := nextSlot()
+
+		ceremony, err := processor.ScheduleCeremonyAtVenue(marriageId1, startsAt, []uint32{}, VenueTierStandard, venueId)()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err = processor.MissCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err = processor.ScheduleCeremonyAtVenue(marriageId2, startsAt, []uint32{}, VenueTierStandard, venueId)(); err != nil {
+			t.Fatalf("Expected the postponed ceremony's slot to be free, got %v", err)
+		}
+
+		// The postponed ceremony books the venue again when rescheduled
+		if _, err = processor.RescheduleCeremony(ceremony.Id(), startsAt)(); !errors.Is(err, ErrVenueSlotUnavailable) {
+			t.Errorf("Expected slot unavailable, got %v", err)
+		}
+		if _, err = processor.RescheduleCeremony(ceremony.Id(), startsAt.Add(time.Hour))(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestProcessor_GetCeremonyAvailability(t *testing.T) {
+	processor, venueId, marriageId, _ := setupVenueBookingTest(t)
+	startsAt := nextSlot()
+
+	if _, err := processor.ScheduleCeremonyAtVenue(marriageId, startsAt.Add(time.Hour), []uint32{}, VenueTierStandard, venueId)(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	availability, err := processor.GetCeremonyAvailability(0, 1, startsAt, startsAt.Add(3*time.Hour))()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(availability) != 1 || availability[0].Venue().Id() != venueId {
+		t.Fatalf("Expected availability of the one venue, got %d venues", len(availability))
+	}
+	slots := availability[0].Slots()
+	if len(slots) != 2 || !slots[0].StartsAt().Equal(startsAt) || !slots[1].StartsAt().Equal(startsAt.Add(2*time.Hour)) {
+		t.Errorf("Expected the slots either side of the booking to be free, got %v", slots)
+	}
+
+	other, err := processor.GetCeremonyAvailability(0, 2, startsAt, startsAt.Add(3*time.Hour))()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("Expected no venues in another channel, got %d", len(other))
+	}
+}
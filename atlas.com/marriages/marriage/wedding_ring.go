@@ -0,0 +1,38 @@
+package marriage
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math"
+)
+
+// newRingSerial returns a random wedding ring serial. Serials stay within the signed 64-bit range so they can be
+// stored and exchanged by services using signed integers
+func newRingSerial() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if serial := binary.BigEndian.Uint64(b[:]) & math.MaxInt64; serial != 0 {
+			return serial, nil
+		}
+	}
+}
+
+// newRingSerials returns a distinct pair of wedding ring serials, one for each partner
+func newRingSerials() (uint64, uint64, error) {
+	serial1, err := newRingSerial()
+	if err != nil {
+		return 0, 0, err
+	}
+	for {
+		serial2, err := newRingSerial()
+		if err != nil {
+			return 0, 0, err
+		}
+		if serial2 != serial1 {
+			return serial1, serial2, nil
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package marriage
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	inventoryMsg "atlas-marriages/kafka/message/inventory"
+	"atlas-marriages/rules"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// completeTestCeremony marries an engaged couple through a ceremony, emitting the resulting messages
+func completeTestCeremony(t *testing.T, db *gorm.DB, tenantId uuid.UUID, processor Processor) Entity {
+	now := time.Now()
+	marriageEntity := Entity{
+		CharacterId1: 1,
+		CharacterId2: 2,
+		Status:       StatusEngaged,
+		ProposedAt:   now,
+		EngagedAt:    &now,
+		TenantId:     tenantId,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := db.Create(&marriageEntity).Error; err != nil {
+		t.Fatalf("Failed to create marriage: %v", err)
+	}
+
+	ceremony, err := processor.ScheduleCeremony(marriageEntity.ID, now.Add(time.Hour), []uint32{})()
+	if err != nil {
+		t.Fatalf("Failed to schedule ceremony: %v", err)
+	}
+	if _, err := processor.StartCeremony(ceremony.Id())(); err != nil {
+		t.Fatalf("Failed to start ceremony: %v", err)
+	}
+	advanceToFinalStage(t, processor, ceremony.Id())
+	if _, err := processor.CompleteCeremonyAndEmit(uuid.New(), ceremony.Id()); err != nil {
+		t.Fatalf("Failed to complete ceremony: %v", err)
+	}
+
+	var married Entity
+	if err := db.First(&married, marriageEntity.ID).Error; err != nil {
+		t.Fatalf("Failed to load marriage: %v", err)
+	}
+	return married
+}
+
+// createRingCommands returns the wedding ring commands among the produced messages
+func createRingCommands(t *testing.T, producer *MockProducer) []inventoryMsg.Command[inventoryMsg.CreateRingCommandBody] {
+	var commands []inventoryMsg.Command[inventoryMsg.CreateRingCommandBody]
+	for _, m := range producer.GetProducedMessages() {
+		var command inventoryMsg.Command[inventoryMsg.CreateRingCommandBody]
+		if err := json.Unmarshal(m.Value, &command); err != nil {
+			t.Fatalf("Failed to decode message: %v", err)
+		}
+		if command.Type == inventoryMsg.CommandCreateRing {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+func TestNewRingSerials(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		serial1, serial2, err := newRingSerials()
+		if err != nil {
+			t.Fatalf("Failed to generate ring serials: %v", err)
+		}
+		if serial1 == 0 || serial2 == 0 || serial1 == serial2 {
+			t.Fatalf("Expected distinct non-zero serials, got %d and %d", serial1, serial2)
+		}
+		if serial1 > math.MaxInt64 || serial2 > math.MaxInt64 {
+			t.Fatalf("Expected serials within the signed 64-bit range, got %d and %d", serial1, serial2)
+		}
+	}
+}
+
+func TestProcessor_CompleteCeremony_IssuesWeddingRings(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	producer := NewMockProducer()
+	processor := NewProcessor(log, setupTestContext(tenantId), db).WithProducer(producer.Provider)
+
+	married := completeTestCeremony(t, db, tenantId, processor)
+	if married.RingItemId != rules.DefaultWeddingRingItemId {
+		t.Errorf("Expected wedding ring item %d, got %d", rules.DefaultWeddingRingItemId, married.RingItemId)
+	}
+	if married.RingSerial1 == 0 || married.RingSerial2 == 0 || married.RingSerial1 == married.RingSerial2 {
+		t.Fatalf("Expected distinct ring serials, got %d and %d", married.RingSerial1, married.RingSerial2)
+	}
+
+	commands := createRingCommands(t, producer)
+	if len(commands) != 2 {
+		t.Fatalf("Expected a wedding ring command for each partner, got %d", len(commands))
+	}
+	expected := map[uint32][2]uint64{
+		married.CharacterId1: {married.RingSerial1, married.RingSerial2},
+		married.CharacterId2: {married.RingSerial2, married.RingSerial1},
+	}
+	for _, command := range commands {
+		serials, ok := expected[command.CharacterId]
+		if !ok {
+			t.Fatalf("Unexpected wedding ring command for character %d", command.CharacterId)
+		}
+		delete(expected, command.CharacterId)
+		if command.Body.Serial != serials[0] || command.Body.PartnerSerial != serials[1] {
+			t.Errorf("Expected serials %d/%d for character %d, got %d/%d", serials[0], serials[1], command.CharacterId, command.Body.Serial, command.Body.PartnerSerial)
+		}
+		if command.Body.MarriageId != married.ID || command.Body.ItemId != married.RingItemId {
+			t.Errorf("Expected ring %d for marriage %d, got ring %d for marriage %d", married.RingItemId, married.ID, command.Body.ItemId, command.Body.MarriageId)
+		}
+	}
+}
+
+func TestProcessor_CompleteCeremony_WeddingRingsDisabled(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+
+	itemId := uint32(0)
+	if err := db.Create(&rules.Entity{TenantId: tenantId, WeddingRingItemId: &itemId, UpdatedAt: time.Now()}).Error; err != nil {
+		t.Fatalf("Failed to create rules: %v", err)
+	}
+	rules.GetRegistry().Invalidate(tenantId)
+
+	producer := NewMockProducer()
+	processor := NewProcessor(log, setupTestContext(tenantId), db).WithProducer(producer.Provider)
+
+	married := completeTestCeremony(t, db, tenantId, processor)
+	if married.Status != StatusMarried {
+		t.Fatalf("Expected married status, got %d", married.Status)
+	}
+	if married.RingItemId != 0 || married.RingSerial1 != 0 || married.RingSerial2 != 0 {
+		t.Errorf("Expected no wedding rings, got item %d serials %d/%d", married.RingItemId, married.RingSerial1, married.RingSerial2)
+	}
+	if commands := createRingCommands(t, producer); len(commands) != 0 {
+		t.Errorf("Expected no wedding ring commands, got %d", len(commands))
+	}
+}
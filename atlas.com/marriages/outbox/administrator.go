@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// createEntry persists a staged message in the outbox
+func createEntry(db *gorm.DB, log logrus.FieldLogger) func(entity Entity) (Entity, error) {
+	return func(entity Entity) (Entity, error) {
+		log.WithFields(logrus.Fields{
+			"tenantId": entity.TenantId,
+			"topic":    entity.Topic,
+		}).Debug("Creating outbox entry")
+
+		if entity.CreatedAt.IsZero() {
+			entity.CreatedAt = time.Now()
+		}
+		if err := db.Create(&entity).Error; err != nil {
+			return Entity{}, err
+		}
+		return entity, nil
+	}
+}
+
+// markSent flags an outbox entry as published
+func markSent(db *gorm.DB) func(id uint64, sentAt time.Time) error {
+	return func(id uint64, sentAt time.Time) error {
+		return db.Model(&Entity{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"sent_at":  sentAt,
+				"attempts": gorm.Expr("attempts + 1"),
+			}).Error
+	}
+}
+
+// markFailed records an unsuccessful publication attempt for an outbox entry
+func markFailed(db *gorm.DB) func(id uint64, cause error) error {
+	return func(id uint64, cause error) error {
+		return db.Model(&Entity{}).
+			Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"last_error": cause.Error(),
+				"attempts":   gorm.Expr("attempts + 1"),
+			}).Error
+	}
+}
+
+// deleteSentBefore removes published outbox entries older than the cutoff
+func deleteSentBefore(db *gorm.DB) func(cutoff time.Time) (int64, error) {
+	return func(cutoff time.Time) (int64, error) {
+		result := db.Where("sent_at IS NOT NULL AND sent_at < ?", cutoff).Delete(&Entity{})
+		return result.RowsAffected, result.Error
+	}
+}
@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/kafka/producer"
+
+	kafkaProducer "github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Batch stages the messages emitted during a single database transaction in the outbox
+// and remembers them so they can be dispatched as soon as the transaction commits
+type Batch struct {
+	l             logrus.FieldLogger
+	ctx           context.Context
+	db            *gorm.DB
+	transactionId uuid.UUID
+	ids           []uint64
+}
+
+// NewBatch creates a batch that writes outbox entries for a transaction id through the given database transaction
+func NewBatch(l logrus.FieldLogger, ctx context.Context, tx *gorm.DB, transactionId uuid.UUID) *Batch {
+	return &Batch{
+		l:             l,
+		ctx:           ctx,
+		db:            tx,
+		transactionId: transactionId,
+	}
+}
+
+// Provider stages messages for the given topic token in the outbox. It satisfies producer.Provider
+func (b *Batch) Provider(token string) kafkaProducer.MessageProducer {
+	return func(provider model.Provider[[]kafka.Message]) error {
+		ms, err := provider()
+		if err != nil {
+			return err
+		}
+		t, err := tenant.FromContext(b.ctx)()
+		if err != nil {
+			return err
+		}
+		for _, m := range ms {
+			e, err := createEntry(b.db, b.l)(Entity{
+				TenantId:      t.Id(),
+				TransactionId: b.transactionId,
+				Region:        t.Region(),
+				MajorVersion:  t.MajorVersion(),
+				MinorVersion:  t.MinorVersion(),
+				Topic:         token,
+				Key:           m.Key,
+				Value:         m.Value,
+			})
+			if err != nil {
+				return err
+			}
+			b.ids = append(b.ids, e.ID)
+		}
+		return nil
+	}
+}
+
+// Size returns the number of messages staged by the batch
+func (b *Batch) Size() int {
+	return len(b.ids)
+}
+
+// Dispatch publishes the staged messages after the transaction has committed.
+// Failures are logged and left pending for the Relay to retry
+func (b *Batch) Dispatch(db *gorm.DB, p producer.Provider) {
+	if len(b.ids) == 0 {
+		return
+	}
+	es, err := getPendingEntriesByIds(db)(b.ids)
+	if err != nil {
+		b.l.WithError(err).Warn("Unable to load outbox entries for dispatch, deferring to relay")
+		return
+	}
+	sent, err := publish(b.l, b.ctx, db, func(_ context.Context) producer.Provider { return p })(es)
+	if err != nil {
+		b.l.WithError(err).WithField("pending", len(es)-sent).Warn("Unable to dispatch outbox entries, deferring to relay")
+	}
+}
+
+// publish sends outbox entries in order, marking each as sent. It stops at the first failure to preserve ordering
+func publish(l logrus.FieldLogger, ctx context.Context, db *gorm.DB, producerFor func(ctx context.Context) producer.Provider) func(es []Entity) (int, error) {
+	return func(es []Entity) (int, error) {
+		for i, e := range es {
+			t, err := tenant.Create(e.TenantId, e.Region, e.MajorVersion, e.MinorVersion)
+			if err != nil {
+				return i, err
+			}
+			tctx := tenant.WithContext(ctx, t)
+
+			ms := []kafka.Message{{Key: e.Key, Value: e.Value}}
+			if err = producerFor(tctx)(e.Topic)(model.FixedProvider(ms)); err != nil {
+				if ferr := markFailed(db)(e.ID, err); ferr != nil {
+					l.WithError(ferr).WithField("outboxId", e.ID).Error("Unable to record outbox publication failure")
+				}
+				return i, err
+			}
+			if err = markSent(db)(e.ID, time.Now()); err != nil {
+				// The message was delivered; it will be delivered again by the relay, which is acceptable for at-least-once.
+				l.WithError(err).WithField("outboxId", e.ID).Error("Unable to mark outbox entry as sent")
+				return i, err
+			}
+		}
+		return len(es), nil
+	}
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents a Kafka message staged in the outbox awaiting publication
+type Entity struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement"`
+	TenantId      uuid.UUID  `gorm:"type:uuid;index:idx_outbox_tenant_transaction,priority:1;not null"`
+	TransactionId uuid.UUID  `gorm:"type:uuid;index:idx_outbox_tenant_transaction,priority:2;not null"`
+	Region        string     `gorm:"not null"`
+	MajorVersion  uint16     `gorm:"not null"`
+	MinorVersion  uint16     `gorm:"not null"`
+	Topic         string     `gorm:"not null"`
+	Key           []byte     `gorm:"not null"`
+	Value         []byte     `gorm:"not null"`
+	Attempts      uint32     `gorm:"not null;default:0"`
+	LastError     string     `gorm:"type:text"`
+	CreatedAt     time.Time  `gorm:"index;not null"`
+	SentAt        *time.Time `gorm:"index"`
+}
+
+// TableName returns the table name for the outbox entity
+func (Entity) TableName() string {
+	return "marriage_outbox"
+}
+
+// Migration performs the database migration for the outbox entity
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// getPendingEntries retrieves unsent outbox entries created before the cutoff, oldest first
+func getPendingEntries(db *gorm.DB) func(createdBefore time.Time, limit int) ([]Entity, error) {
+	return func(createdBefore time.Time, limit int) ([]Entity, error) {
+		var entities []Entity
+		err := db.Where("sent_at IS NULL AND created_at <= ?", createdBefore).
+			Order("id ASC").
+			Limit(limit).
+			Find(&entities).Error
+		return entities, err
+	}
+}
+
+// getPendingEntriesByIds retrieves the unsent outbox entries among the given ids, oldest first
+func getPendingEntriesByIds(db *gorm.DB) func(ids []uint64) ([]Entity, error) {
+	return func(ids []uint64) ([]Entity, error) {
+		var entities []Entity
+		if len(ids) == 0 {
+			return entities, nil
+		}
+		err := db.Where("id IN ? AND sent_at IS NULL", ids).
+			Order("id ASC").
+			Find(&entities).Error
+		return entities, err
+	}
+}
+
+// getEntriesByTransaction retrieves the outbox entries recorded for a tenant's transaction, oldest first
+func getEntriesByTransaction(db *gorm.DB) func(tenantId uuid.UUID, transactionId uuid.UUID) ([]Entity, error) {
+	return func(tenantId uuid.UUID, transactionId uuid.UUID) ([]Entity, error) {
+		var entities []Entity
+		err := db.Where("tenant_id = ? AND transaction_id = ?", tenantId, transactionId).
+			Order("id ASC").
+			Find(&entities).Error
+		return entities, err
+	}
+}
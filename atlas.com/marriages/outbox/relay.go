@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/kafka/producer"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Relay periodically publishes outbox entries which were not dispatched when their transaction committed
+type Relay struct {
+	log         logrus.FieldLogger
+	ctx         context.Context
+	db          *gorm.DB
+	interval    time.Duration
+	batchSize   int
+	minAge      time.Duration
+	retention   time.Duration
+	producerFor func(ctx context.Context) producer.Provider
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewRelay creates a new outbox relay
+func NewRelay(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *Relay {
+	l := log.WithField("component", "outbox-relay")
+	return &Relay{
+		log:       l,
+		ctx:       ctx,
+		db:        db,
+		interval:  5 * time.Second,
+		batchSize: 100,
+		minAge:    10 * time.Second, // Leave fresh entries to the dispatching request
+		retention: 24 * time.Hour,
+		producerFor: func(ctx context.Context) producer.Provider {
+			return producer.ProviderImpl(l)(ctx)
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// WithInterval sets the polling interval
+func (r *Relay) WithInterval(interval time.Duration) *Relay {
+	r.interval = interval
+	return r
+}
+
+// WithBatchSize sets the maximum number of entries published per poll
+func (r *Relay) WithBatchSize(batchSize int) *Relay {
+	r.batchSize = batchSize
+	return r
+}
+
+// WithMinAge sets how old a pending entry must be before the relay publishes it
+func (r *Relay) WithMinAge(minAge time.Duration) *Relay {
+	r.minAge = minAge
+	return r
+}
+
+// WithRetention sets how long sent entries are kept before being purged
+func (r *Relay) WithRetention(retention time.Duration) *Relay {
+	r.retention = retention
+	return r
+}
+
+// WithProducer sets the producer used to publish entries for a tenant context
+func (r *Relay) WithProducer(producerFor func(ctx context.Context) producer.Provider) *Relay {
+	r.producerFor = producerFor
+	return r
+}
+
+// Start begins relaying pending outbox entries in the background
+func (r *Relay) Start() {
+	r.log.WithField("interval", r.interval).Info("Starting outbox relay")
+
+	go r.run()
+}
+
+// Stop gracefully stops the relay
+func (r *Relay) Stop() {
+	r.log.Info("Stopping outbox relay")
+	close(r.stop)
+	<-r.done
+	r.log.Info("Outbox relay stopped")
+}
+
+// run is the main loop for the relay
+func (r *Relay) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	// Relay immediately on start to flush anything left over from a previous run
+	r.relayPending()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.relayPending()
+			r.purgeSent()
+		case <-r.stop:
+			return
+		case <-r.ctx.Done():
+			r.log.Info("Context cancelled, stopping outbox relay")
+			return
+		}
+	}
+}
+
+// relayPending publishes pending outbox entries until none remain or a publication fails
+func (r *Relay) relayPending() {
+	for {
+		es, err := getPendingEntries(r.db)(time.Now().Add(-r.minAge), r.batchSize)
+		if err != nil {
+			r.log.WithError(err).Error("Failed to retrieve pending outbox entries")
+			return
+		}
+		if len(es) == 0 {
+			return
+		}
+
+		sent, err := publish(r.log, r.ctx, r.db, r.producerFor)(es)
+		if sent > 0 {
+			r.log.WithField("count", sent).Debug("Relayed outbox entries")
+		}
+		if err != nil {
+			r.log.WithError(err).WithField("pending", len(es)-sent).Warn("Failed to relay outbox entries, will retry")
+			return
+		}
+		if len(es) < r.batchSize {
+			return
+		}
+	}
+}
+
+// purgeSent removes sent entries older than the retention period
+func (r *Relay) purgeSent() {
+	count, err := deleteSentBefore(r.db)(time.Now().Add(-r.retention))
+	if err != nil {
+		r.log.WithError(err).Error("Failed to purge sent outbox entries")
+		return
+	}
+	if count > 0 {
+		r.log.WithField("count", count).Debug("Purged sent outbox entries")
+	}
+}
@@ -0,0 +1,295 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-marriages/kafka/producer"
+
+	kafkaProducer "github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type published struct {
+	tenant tenant.Model
+	topic  string
+	msg    kafka.Message
+}
+
+// recordingProducer captures published messages along with the tenant they were published for
+type recordingProducer struct {
+	fail     bool
+	messages []published
+}
+
+func (r *recordingProducer) For(ctx context.Context) producer.Provider {
+	return func(token string) kafkaProducer.MessageProducer {
+		return func(provider model.Provider[[]kafka.Message]) error {
+			if r.fail {
+				return errors.New("kafka unavailable")
+			}
+			ms, err := provider()
+			if err != nil {
+				return err
+			}
+			t := tenant.MustFromContext(ctx)
+			for _, m := range ms {
+				r.messages = append(r.messages, published{tenant: t, topic: token, msg: m})
+			}
+			return nil
+		}
+	}
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, Migration(db))
+	return db
+}
+
+func setupTestContext(t *testing.T) (context.Context, tenant.Model) {
+	tm, err := tenant.Create(uuid.New(), "GMS", 83, 1)
+	require.NoError(t, err)
+	return tenant.WithContext(context.Background(), tm), tm
+}
+
+func stage(t *testing.T, db *gorm.DB, ctx context.Context, keys ...string) []uint64 {
+	b := NewBatch(logrus.New(), ctx, db, uuid.New())
+	for _, k := range keys {
+		err := b.Provider("EVENT_TOPIC_TEST")(model.FixedProvider([]kafka.Message{{Key: []byte(k), Value: []byte("{}")}}))
+		require.NoError(t, err)
+	}
+	return b.ids
+}
+
+func TestBatch_StagesWithinTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, tm := setupTestContext(t)
+	rp := &recordingProducer{}
+
+	var batch *Batch
+	err := db.Transaction(func(tx *gorm.DB) error {
+		batch = NewBatch(logrus.New(), ctx, tx, uuid.New())
+		return batch.Provider("EVENT_TOPIC_TEST")(model.FixedProvider([]kafka.Message{
+			{Key: []byte("1"), Value: []byte("a")},
+			{Key: []byte("2"), Value: []byte("b")},
+		}))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, batch.Size())
+
+	var entries []Entity
+	require.NoError(t, db.Order("id").Find(&entries).Error)
+	require.Len(t, entries, 2)
+	assert.Equal(t, tm.Id(), entries[0].TenantId)
+	assert.Equal(t, "GMS", entries[0].Region)
+	assert.Equal(t, uint16(83), entries[0].MajorVersion)
+	assert.Equal(t, uint16(1), entries[0].MinorVersion)
+	assert.Equal(t, "EVENT_TOPIC_TEST", entries[0].Topic)
+	assert.Nil(t, entries[0].SentAt)
+
+	batch.Dispatch(db, rp.For(ctx))
+	require.Len(t, rp.messages, 2)
+	assert.Equal(t, []byte("1"), rp.messages[0].msg.Key)
+	assert.Equal(t, []byte("2"), rp.messages[1].msg.Key)
+
+	require.NoError(t, db.Order("id").Find(&entries).Error)
+	for _, e := range entries {
+		assert.NotNil(t, e.SentAt)
+	}
+}
+
+func TestBatch_DiscardedOnRollback(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		b := NewBatch(logrus.New(), ctx, tx, uuid.New())
+		if err := b.Provider("EVENT_TOPIC_TEST")(model.FixedProvider([]kafka.Message{{Key: []byte("1"), Value: []byte("a")}})); err != nil {
+			return err
+		}
+		return errors.New("domain change failed")
+	})
+	require.Error(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&Entity{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestBatch_DispatchFailureLeavesEntriesPending(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+	rp := &recordingProducer{fail: true}
+
+	b := NewBatch(logrus.New(), ctx, db, uuid.New())
+	require.NoError(t, b.Provider("EVENT_TOPIC_TEST")(model.FixedProvider([]kafka.Message{{Key: []byte("1"), Value: []byte("a")}})))
+	b.Dispatch(db, rp.For(ctx))
+
+	var e Entity
+	require.NoError(t, db.First(&e).Error)
+	assert.Nil(t, e.SentAt)
+	assert.Equal(t, uint32(1), e.Attempts)
+	assert.Equal(t, "kafka unavailable", e.LastError)
+}
+
+func TestRelay_Creation(t *testing.T) {
+	db := setupTestDB(t)
+
+	r := NewRelay(logrus.New(), context.Background(), db)
+	assert.Equal(t, 5*time.Second, r.interval)
+	assert.Equal(t, 100, r.batchSize)
+
+	r = r.WithInterval(time.Second).WithBatchSize(10).WithMinAge(0).WithRetention(time.Hour)
+	assert.Equal(t, time.Second, r.interval)
+	assert.Equal(t, 10, r.batchSize)
+	assert.Equal(t, time.Duration(0), r.minAge)
+	assert.Equal(t, time.Hour, r.retention)
+}
+
+func TestRelay_RelaysPendingEntries(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, tm := setupTestContext(t)
+	rp := &recordingProducer{}
+	stage(t, db, ctx, "1", "2", "3")
+
+	r := NewRelay(logrus.New(), context.Background(), db).
+		WithMinAge(0).
+		WithBatchSize(2).
+		WithProducer(rp.For)
+	r.relayPending()
+
+	require.Len(t, rp.messages, 3)
+	for i, k := range []string{"1", "2", "3"} {
+		assert.Equal(t, []byte(k), rp.messages[i].msg.Key)
+		assert.Equal(t, "EVENT_TOPIC_TEST", rp.messages[i].topic)
+		assert.Equal(t, tm.Id(), rp.messages[i].tenant.Id())
+		assert.Equal(t, "GMS", rp.messages[i].tenant.Region())
+	}
+
+	var pending int64
+	require.NoError(t, db.Model(&Entity{}).Where("sent_at IS NULL").Count(&pending).Error)
+	assert.Equal(t, int64(0), pending)
+
+	// Already sent entries are not relayed again
+	r.relayPending()
+	assert.Len(t, rp.messages, 3)
+}
+
+func TestRelay_SkipsFreshEntries(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+	rp := &recordingProducer{}
+	stage(t, db, ctx, "1")
+
+	r := NewRelay(logrus.New(), context.Background(), db).
+		WithMinAge(time.Minute).
+		WithProducer(rp.For)
+	r.relayPending()
+
+	assert.Empty(t, rp.messages)
+}
+
+func TestRelay_RetriesAfterFailure(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+	rp := &recordingProducer{fail: true}
+	stage(t, db, ctx, "1", "2")
+
+	r := NewRelay(logrus.New(), context.Background(), db).
+		WithMinAge(0).
+		WithProducer(rp.For)
+	r.relayPending()
+	assert.Empty(t, rp.messages)
+
+	var first Entity
+	require.NoError(t, db.Order("id").First(&first).Error)
+	assert.Equal(t, uint32(1), first.Attempts)
+	assert.Nil(t, first.SentAt)
+
+	rp.fail = false
+	r.relayPending()
+	require.Len(t, rp.messages, 2)
+	assert.Equal(t, []byte("1"), rp.messages[0].msg.Key)
+}
+
+func TestRelay_PurgesSentEntries(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+	ids := stage(t, db, ctx, "1", "2", "3")
+
+	require.NoError(t, markSent(db)(ids[0], time.Now().Add(-48*time.Hour)))
+	require.NoError(t, markSent(db)(ids[1], time.Now()))
+
+	r := NewRelay(logrus.New(), context.Background(), db).WithRetention(24 * time.Hour)
+	r.purgeSent()
+
+	var remaining []Entity
+	require.NoError(t, db.Order("id").Find(&remaining).Error)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, ids[1], remaining[0].ID)
+	assert.Equal(t, ids[2], remaining[1].ID)
+}
+
+func TestRelay_StartStop(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, _ := setupTestContext(t)
+	rp := &recordingProducer{}
+	stage(t, db, ctx, "1")
+
+	r := NewRelay(logrus.New(), context.Background(), db).
+		WithInterval(10 * time.Millisecond).
+		WithMinAge(0).
+		WithProducer(rp.For)
+	r.Start()
+	time.Sleep(50 * time.Millisecond)
+	r.Stop()
+
+	assert.Len(t, rp.messages, 1)
+}
+
+func TestReplay(t *testing.T) {
+	db := setupTestDB(t)
+	ctx, tm := setupTestContext(t)
+	rp := &recordingProducer{}
+	transactionId := uuid.New()
+
+	b := NewBatch(logrus.New(), ctx, db, transactionId)
+	require.NoError(t, b.Provider("EVENT_TOPIC_TEST")(model.FixedProvider([]kafka.Message{
+		{Key: []byte("1"), Value: []byte("a")},
+		{Key: []byte("2"), Value: []byte("b")},
+	})))
+	require.NoError(t, b.Provider("COMMAND_TOPIC_TEST")(model.FixedProvider([]kafka.Message{
+		{Key: []byte("4"), Value: []byte("c")},
+	})))
+	stage(t, db, ctx, "3")
+
+	// Only the messages on the replayed topic are sent again
+	replayed, err := Replay(logrus.New(), db)(tm.Id(), transactionId, "EVENT_TOPIC_TEST", rp.For(ctx))
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	require.Len(t, rp.messages, 2)
+	assert.Equal(t, []byte("1"), rp.messages[0].msg.Key)
+	assert.Equal(t, []byte("2"), rp.messages[1].msg.Key)
+
+	// Transactions are scoped to the tenant
+	replayed, err = Replay(logrus.New(), db)(uuid.New(), transactionId, "EVENT_TOPIC_TEST", rp.For(ctx))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	replayed, err = Replay(logrus.New(), db)(tm.Id(), uuid.New(), "EVENT_TOPIC_TEST", rp.For(ctx))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.Len(t, rp.messages, 2)
+}
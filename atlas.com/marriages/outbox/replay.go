@@ -0,0 +1,45 @@
+package outbox
+
+import (
+	"atlas-marriages/kafka/producer"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Replay republishes, in their original order, the messages recorded on a topic for a transaction which has
+// already been processed for the tenant. Messages the transaction staged on other topics, such as commands to
+// other services, are never sent again. It reports whether the transaction was found. Entries are retained, and
+// therefore replayable, for the relay's retention period
+func Replay(l logrus.FieldLogger, db *gorm.DB) func(tenantId uuid.UUID, transactionId uuid.UUID, topic string, p producer.Provider) (bool, error) {
+	return func(tenantId uuid.UUID, transactionId uuid.UUID, topic string, p producer.Provider) (bool, error) {
+		es, err := getEntriesByTransaction(db)(tenantId, transactionId)
+		if err != nil {
+			return false, err
+		}
+		if len(es) == 0 {
+			return false, nil
+		}
+
+		l.WithFields(logrus.Fields{
+			"tenantId":      tenantId,
+			"transactionId": transactionId,
+			"topic":         topic,
+			"count":         len(es),
+		}).Debug("Replaying outbox entries for processed transaction")
+
+		for _, e := range es {
+			if e.Topic != topic {
+				continue
+			}
+			ms := []kafka.Message{{Key: e.Key, Value: e.Value}}
+			if err = p(e.Topic)(model.FixedProvider(ms)); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrItemUnavailable is returned when a gift would take more of an item than remains on the wish list
+var ErrItemUnavailable = errors.New("registry item has fewer remaining than given")
+
+// CreateItem persists an item on a ceremony's wish list
+func CreateItem(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, itemId uint32, quantity uint32, tenantId uuid.UUID) model.Provider[Item] {
+	return func(ceremonyId uint32, itemId uint32, quantity uint32, tenantId uuid.UUID) model.Provider[Item] {
+		return func() (Item, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId": ceremonyId,
+				"itemId":     itemId,
+				"quantity":   quantity,
+				"tenantId":   tenantId,
+			}).Debug("Creating registry item entity")
+
+			now := time.Now()
+			entity := ItemEntity{
+				TenantId:   tenantId,
+				CeremonyId: ceremonyId,
+				ItemId:     itemId,
+				Quantity:   quantity,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}
+			if err := db.Create(&entity).Error; err != nil {
+				return Item{}, err
+			}
+			return MakeItem(entity)
+		}
+	}
+}
+
+// DeleteItem removes an item from a ceremony's wish list
+func DeleteItem(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, itemId uint32, tenantId uuid.UUID) error {
+	return func(ceremonyId uint32, itemId uint32, tenantId uuid.UUID) error {
+		log.WithFields(logrus.Fields{
+			"ceremonyId": ceremonyId,
+			"itemId":     itemId,
+			"tenantId":   tenantId,
+		}).Debug("Deleting registry item entity")
+
+		return db.Where("tenant_id = ? AND ceremony_id = ? AND item_id = ?", tenantId, ceremonyId, itemId).Delete(&ItemEntity{}).Error
+	}
+}
+
+// CreateGift persists items an invitee gave from a ceremony's wish list, counting them against the item. It returns
+// ErrItemUnavailable, without persisting the gift, when fewer of the item remain than were given
+func CreateGift(db *gorm.DB, log logrus.FieldLogger) func(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32, tenantId uuid.UUID) model.Provider[Gift] {
+	return func(transactionId uuid.UUID, ceremonyId uint32, characterId uint32, itemId uint32, quantity uint32, tenantId uuid.UUID) model.Provider[Gift] {
+		return func() (Gift, error) {
+			log.WithFields(logrus.Fields{
+				"transactionId": transactionId,
+				"ceremonyId":    ceremonyId,
+				"characterId":   characterId,
+				"itemId":        itemId,
+				"quantity":      quantity,
+				"tenantId":      tenantId,
+			}).Debug("Creating gift entity")
+
+			now := time.Now()
+			entity := GiftEntity{
+				TenantId:      tenantId,
+				TransactionId: transactionId,
+				CeremonyId:    ceremonyId,
+				CharacterId:   characterId,
+				ItemId:        itemId,
+				Quantity:      quantity,
+				GivenAt:       now,
+			}
+			err := db.Transaction(func(tx *gorm.DB) error {
+				// Counting the gift only while enough remain guards against invitees giving the same items at once
+				result := tx.Model(&ItemEntity{}).
+					Where("tenant_id = ? AND ceremony_id = ? AND item_id = ? AND received + ? <= quantity", tenantId, ceremonyId, itemId, quantity).
+					Updates(map[string]interface{}{"received": gorm.Expr("received + ?", quantity), "updated_at": now})
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return ErrItemUnavailable
+				}
+				return tx.Create(&entity).Error
+			})
+			if err != nil {
+				return Gift{}, err
+			}
+			return MakeGift(entity)
+		}
+	}
+}
+
+// DeleteGift removes the gift whose items the inventory transfer identified by transactionId moves, no longer counting
+// them against the item. It returns nil when no such gift remains, so a repeated removal changes nothing
+func DeleteGift(db *gorm.DB, log logrus.FieldLogger) func(transactionId uuid.UUID, tenantId uuid.UUID) model.Provider[*Gift] {
+	return func(transactionId uuid.UUID, tenantId uuid.UUID) model.Provider[*Gift] {
+		return func() (*Gift, error) {
+			log.WithFields(logrus.Fields{
+				"transactionId": transactionId,
+				"tenantId":      tenantId,
+			}).Debug("Deleting gift entity")
+
+			var deleted *Gift
+			err := db.Transaction(func(tx *gorm.DB) error {
+				var entity GiftEntity
+				err := tx.Where("tenant_id = ? AND transaction_id = ?", tenantId, transactionId).First(&entity).Error
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+
+				// Deleting by id guards against the same removal being processed twice at once
+				result := tx.Where("id = ?", entity.ID).Delete(&GiftEntity{})
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected == 0 {
+					return nil
+				}
+				err = tx.Model(&ItemEntity{}).
+					Where("tenant_id = ? AND ceremony_id = ? AND item_id = ? AND received >= ?", tenantId, entity.CeremonyId, entity.ItemId, entity.Quantity).
+					Updates(map[string]interface{}{"received": gorm.Expr("received - ?", entity.Quantity), "updated_at": time.Now()}).Error
+				if err != nil {
+					return err
+				}
+
+				gift, err := MakeGift(entity)
+				if err != nil {
+					return err
+				}
+				deleted = &gift
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return deleted, nil
+		}
+	}
+}
+
+// CreateBlessing persists an invitee's blessing of a ceremony
+func CreateBlessing(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, characterId uint32, message string, tenantId uuid.UUID) model.Provider[Blessing] {
+	return func(ceremonyId uint32, characterId uint32, message string, tenantId uuid.UUID) model.Provider[Blessing] {
+		return func() (Blessing, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId":  ceremonyId,
+				"characterId": characterId,
+				"tenantId":    tenantId,
+			}).Debug("Creating blessing entity")
+
+			entity := BlessingEntity{
+				TenantId:    tenantId,
+				CeremonyId:  ceremonyId,
+				CharacterId: characterId,
+				Message:     message,
+				BlessedAt:   time.Now(),
+			}
+			if err := db.Create(&entity).Error; err != nil {
+				return Blessing{}, err
+			}
+			return MakeBlessing(entity)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBlessingMessageLength is the longest message an invitee may send with a blessing
+const MaxBlessingMessageLength = 120
+
+// ItemBuilder provides fluent construction of wish list Items
+type ItemBuilder struct {
+	id         uint32
+	tenantId   uuid.UUID
+	ceremonyId uint32
+	itemId     uint32
+	quantity   uint32
+	received   uint32
+	createdAt  time.Time
+}
+
+// NewItemBuilder creates a builder for an item on a ceremony's wish list
+func NewItemBuilder(tenantId uuid.UUID, id uint32, ceremonyId uint32, itemId uint32) *ItemBuilder {
+	return &ItemBuilder{
+		id:         id,
+		tenantId:   tenantId,
+		ceremonyId: ceremonyId,
+		itemId:     itemId,
+		quantity:   1,
+		createdAt:  time.Now(),
+	}
+}
+
+// SetQuantity sets how many of the item the couple wishes for
+func (b *ItemBuilder) SetQuantity(quantity uint32) *ItemBuilder {
+	b.quantity = quantity
+	return b
+}
+
+// SetReceived sets how many of the item invitees have given
+func (b *ItemBuilder) SetReceived(received uint32) *ItemBuilder {
+	b.received = received
+	return b
+}
+
+// SetCreatedAt sets when the item was added to the wish list
+func (b *ItemBuilder) SetCreatedAt(createdAt time.Time) *ItemBuilder {
+	b.createdAt = createdAt
+	return b
+}
+
+// Build validates and builds the wish list item
+func (b *ItemBuilder) Build() (Item, error) {
+	if b.ceremonyId == 0 {
+		return Item{}, errors.New("registry item ceremony ID is required")
+	}
+	if b.itemId == 0 {
+		return Item{}, errors.New("registry item ID is required")
+	}
+	if b.quantity == 0 {
+		return Item{}, errors.New("registry item quantity must be positive")
+	}
+	if b.received > b.quantity {
+		return Item{}, errors.New("registry item cannot receive more than its quantity")
+	}
+
+	return Item{
+		id:         b.id,
+		tenantId:   b.tenantId,
+		ceremonyId: b.ceremonyId,
+		itemId:     b.itemId,
+		quantity:   b.quantity,
+		received:   b.received,
+		createdAt:  b.createdAt,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ItemEntity represents an item on a couple's wish list. A ceremony lists each item at most once
+type ItemEntity struct {
+	ID         uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId   uuid.UUID `gorm:"type:uuid;index;not null"`
+	CeremonyId uint32    `gorm:"uniqueIndex:idx_marriage_registry_items_item;not null"`
+	ItemId     uint32    `gorm:"uniqueIndex:idx_marriage_registry_items_item;not null"`
+	Quantity   uint32    `gorm:"not null"`
+	Received   uint32    `gorm:"not null;default:0"`
+	CreatedAt  time.Time `gorm:"not null"`
+	UpdatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the registry item entity
+func (ItemEntity) TableName() string {
+	return "marriage_registry_items"
+}
+
+// GiftEntity represents items an invitee gave a couple from their wish list. TransactionId identifies the inventory
+// transfer moving the items to the couple
+type GiftEntity struct {
+	ID            uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId      uuid.UUID `gorm:"type:uuid;index;not null"`
+	TransactionId uuid.UUID `gorm:"type:uuid;index"`
+	CeremonyId    uint32    `gorm:"index;not null"`
+	CharacterId   uint32    `gorm:"index;not null"`
+	ItemId        uint32    `gorm:"not null"`
+	Quantity      uint32    `gorm:"not null"`
+	GivenAt       time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the gift entity
+func (GiftEntity) TableName() string {
+	return "marriage_registry_gifts"
+}
+
+// BlessingEntity represents an invitee's blessing of a couple. An invitee blesses a ceremony at most once
+type BlessingEntity struct {
+	ID          uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId    uuid.UUID `gorm:"type:uuid;index;not null"`
+	CeremonyId  uint32    `gorm:"uniqueIndex:idx_marriage_ceremony_blessings_character;not null"`
+	CharacterId uint32    `gorm:"uniqueIndex:idx_marriage_ceremony_blessings_character;not null"`
+	Message     string    `gorm:"not null;default:''"`
+	BlessedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the blessing entity
+func (BlessingEntity) TableName() string {
+	return "marriage_ceremony_blessings"
+}
+
+// Migration performs the database migration for the registry item, gift and blessing entities
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&ItemEntity{}, &GiftEntity{}, &BlessingEntity{})
+}
+
+// MakeItem transforms a registry item entity to a domain model
+func MakeItem(entity ItemEntity) (Item, error) {
+	return NewItemBuilder(entity.TenantId, entity.ID, entity.CeremonyId, entity.ItemId).
+		SetQuantity(entity.Quantity).
+		SetReceived(entity.Received).
+		SetCreatedAt(entity.CreatedAt).
+		Build()
+}
+
+// MakeGift transforms a gift entity to a domain model
+func MakeGift(entity GiftEntity) (Gift, error) {
+	return Gift{
+		id:            entity.ID,
+		tenantId:      entity.TenantId,
+		transactionId: entity.TransactionId,
+		ceremonyId:    entity.CeremonyId,
+		characterId:   entity.CharacterId,
+		itemId:        entity.ItemId,
+		quantity:      entity.Quantity,
+		givenAt:       entity.GivenAt,
+	}, nil
+}
+
+// MakeBlessing transforms a blessing entity to a domain model
+func MakeBlessing(entity BlessingEntity) (Blessing, error) {
+	return Blessing{
+		id:          entity.ID,
+		tenantId:    entity.TenantId,
+		ceremonyId:  entity.CeremonyId,
+		characterId: entity.CharacterId,
+		message:     entity.Message,
+		blessedAt:   entity.BlessedAt,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package registry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Model represents a ceremony's gift registry: the items on the couple's wish list, the gifts invitees gave from it and
+// the blessings invitees sent
+type Model struct {
+	ceremonyId uint32
+	items      []Item
+	gifts      []Gift
+	blessings  []Blessing
+}
+
+// CeremonyId returns the ceremony the registry belongs to
+func (m Model) CeremonyId() uint32 {
+	return m.ceremonyId
+}
+
+// Items returns the items on the couple's wish list
+func (m Model) Items() []Item {
+	return append([]Item(nil), m.items...)
+}
+
+// Gifts returns the gifts invitees gave, oldest first
+func (m Model) Gifts() []Gift {
+	return append([]Gift(nil), m.gifts...)
+}
+
+// Blessings returns the blessings invitees sent, oldest first
+func (m Model) Blessings() []Blessing {
+	return append([]Blessing(nil), m.blessings...)
+}
+
+// Item returns the wish list entry for an item, or false when the couple has not listed it
+func (m Model) Item(itemId uint32) (Item, bool) {
+	for _, i := range m.items {
+		if i.itemId == itemId {
+			return i, true
+		}
+	}
+	return Item{}, false
+}
+
+// BlessingCount returns the number of blessings the couple received
+func (m Model) BlessingCount() uint32 {
+	return uint32(len(m.blessings))
+}
+
+// GiftCount returns the number of items the couple received as gifts
+func (m Model) GiftCount() uint32 {
+	var count uint32
+	for _, g := range m.gifts {
+		count += g.quantity
+	}
+	return count
+}
+
+// HasBlessed returns true if the character has already blessed the ceremony
+func (m Model) HasBlessed(characterId uint32) bool {
+	for _, b := range m.blessings {
+		if b.characterId == characterId {
+			return true
+		}
+	}
+	return false
+}
+
+// Item represents an item on a couple's wish list and how many of it invitees have given
+type Item struct {
+	id         uint32
+	tenantId   uuid.UUID
+	ceremonyId uint32
+	itemId     uint32
+	quantity   uint32
+	received   uint32
+	createdAt  time.Time
+}
+
+// Id returns the wish list entry ID
+func (i Item) Id() uint32 {
+	return i.id
+}
+
+// TenantId returns the tenant the wish list entry belongs to
+func (i Item) TenantId() uuid.UUID {
+	return i.tenantId
+}
+
+// CeremonyId returns the ceremony the wish list entry belongs to
+func (i Item) CeremonyId() uint32 {
+	return i.ceremonyId
+}
+
+// ItemId returns the item the couple wishes for
+func (i Item) ItemId() uint32 {
+	return i.itemId
+}
+
+// Quantity returns how many of the item the couple wishes for
+func (i Item) Quantity() uint32 {
+	return i.quantity
+}
+
+// Received returns how many of the item invitees have given
+func (i Item) Received() uint32 {
+	return i.received
+}
+
+// Remaining returns how many more of the item invitees may give
+func (i Item) Remaining() uint32 {
+	if i.received >= i.quantity {
+		return 0
+	}
+	return i.quantity - i.received
+}
+
+// IsFulfilled returns true if invitees have given every item the couple wished for
+func (i Item) IsFulfilled() bool {
+	return i.Remaining() == 0
+}
+
+// CreatedAt returns when the item was added to the wish list
+func (i Item) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// Gift represents items an invitee gave a couple from their wish list
+type Gift struct {
+	id            uint32
+	tenantId      uuid.UUID
+	transactionId uuid.UUID
+	ceremonyId    uint32
+	characterId   uint32
+	itemId        uint32
+	quantity      uint32
+	givenAt       time.Time
+}
+
+// Id returns the gift ID
+func (g Gift) Id() uint32 {
+	return g.id
+}
+
+// TenantId returns the tenant the gift belongs to
+func (g Gift) TenantId() uuid.UUID {
+	return g.tenantId
+}
+
+// TransactionId returns the inventory transfer moving the gift to the couple
+func (g Gift) TransactionId() uuid.UUID {
+	return g.transactionId
+}
+
+// CeremonyId returns the ceremony the gift was given at
+func (g Gift) CeremonyId() uint32 {
+	return g.ceremonyId
+}
+
+// CharacterId returns the invitee who gave the gift
+func (g Gift) CharacterId() uint32 {
+	return g.characterId
+}
+
+// ItemId returns the item given
+func (g Gift) ItemId() uint32 {
+	return g.itemId
+}
+
+// Quantity returns how many of the item were given
+func (g Gift) Quantity() uint32 {
+	return g.quantity
+}
+
+// GivenAt returns when the gift was given
+func (g Gift) GivenAt() time.Time {
+	return g.givenAt
+}
+
+// Blessing represents an invitee's blessing of a couple during their ceremony
+type Blessing struct {
+	id          uint32
+	tenantId    uuid.UUID
+	ceremonyId  uint32
+	characterId uint32
+	message     string
+	blessedAt   time.Time
+}
+
+// Id returns the blessing ID
+func (b Blessing) Id() uint32 {
+	return b.id
+}
+
+// TenantId returns the tenant the blessing belongs to
+func (b Blessing) TenantId() uuid.UUID {
+	return b.tenantId
+}
+
+// CeremonyId returns the ceremony the blessing was sent during
+func (b Blessing) CeremonyId() uint32 {
+	return b.ceremonyId
+}
+
+// CharacterId returns the invitee who sent the blessing
+func (b Blessing) CharacterId() uint32 {
+	return b.characterId
+}
+
+// Message returns the invitee's message to the couple, which may be empty
+func (b Blessing) Message() string {
+	return b.message
+}
+
+// BlessedAt returns when the blessing was sent
+func (b Blessing) BlessedAt() time.Time {
+	return b.blessedAt
+}
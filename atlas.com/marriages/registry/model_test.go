@@ -0,0 +1,67 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_Remaining(t *testing.T) {
+	i, err := NewItemBuilder(uuid.New(), 1, 7, 2000000).SetQuantity(3).SetReceived(1).Build()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), i.Remaining())
+	assert.False(t, i.IsFulfilled())
+
+	i, err = NewItemBuilder(uuid.New(), 1, 7, 2000000).SetQuantity(3).SetReceived(3).Build()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), i.Remaining())
+	assert.True(t, i.IsFulfilled())
+}
+
+func TestItemBuilder_Validation(t *testing.T) {
+	_, err := NewItemBuilder(uuid.New(), 1, 0, 2000000).Build()
+	assert.Error(t, err, "an item belongs to a ceremony")
+
+	_, err = NewItemBuilder(uuid.New(), 1, 7, 0).Build()
+	assert.Error(t, err, "an item requires an item ID")
+
+	_, err = NewItemBuilder(uuid.New(), 1, 7, 2000000).SetQuantity(0).Build()
+	assert.Error(t, err)
+
+	_, err = NewItemBuilder(uuid.New(), 1, 7, 2000000).SetQuantity(1).SetReceived(2).Build()
+	assert.Error(t, err)
+}
+
+func TestModel_Totals(t *testing.T) {
+	m := Model{
+		ceremonyId: 7,
+		gifts: []Gift{
+			{ceremonyId: 7, characterId: 3, itemId: 2000000, quantity: 2},
+			{ceremonyId: 7, characterId: 4, itemId: 2000001, quantity: 1},
+		},
+		blessings: []Blessing{{ceremonyId: 7, characterId: 3, message: "Congratulations!"}},
+	}
+
+	assert.Equal(t, uint32(3), m.GiftCount())
+	assert.Equal(t, uint32(1), m.BlessingCount())
+	assert.True(t, m.HasBlessed(3))
+	assert.False(t, m.HasBlessed(4))
+	_, listed := m.Item(2000000)
+	assert.False(t, listed)
+}
+
+func TestEntity_RoundTrip(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	i, err := MakeItem(ItemEntity{ID: 5, TenantId: uuid.New(), CeremonyId: 7, ItemId: 2000000, Quantity: 4, Received: 1, CreatedAt: createdAt})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(5), i.Id())
+	assert.Equal(t, uint32(7), i.CeremonyId())
+	assert.Equal(t, uint32(2000000), i.ItemId())
+	assert.Equal(t, uint32(4), i.Quantity())
+	assert.Equal(t, uint32(1), i.Received())
+	assert.Equal(t, createdAt, i.CreatedAt())
+}
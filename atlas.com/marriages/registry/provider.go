@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"errors"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetByCeremonyIdProvider retrieves a ceremony's registry, which is empty until the couple lists an item or an invitee
+// sends a blessing
+func GetByCeremonyIdProvider(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, tenantId uuid.UUID) model.Provider[Model] {
+	return func(ceremonyId uint32, tenantId uuid.UUID) model.Provider[Model] {
+		return func() (Model, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId": ceremonyId,
+				"tenantId":   tenantId,
+			}).Debug("Retrieving ceremony registry")
+
+			var itemEntities []ItemEntity
+			err := db.Where("tenant_id = ? AND ceremony_id = ?", tenantId, ceremonyId).Order("id ASC").Find(&itemEntities).Error
+			if err != nil {
+				return Model{}, err
+			}
+			var giftEntities []GiftEntity
+			err = db.Where("tenant_id = ? AND ceremony_id = ?", tenantId, ceremonyId).Order("id ASC").Find(&giftEntities).Error
+			if err != nil {
+				return Model{}, err
+			}
+			var blessingEntities []BlessingEntity
+			err = db.Where("tenant_id = ? AND ceremony_id = ?", tenantId, ceremonyId).Order("id ASC").Find(&blessingEntities).Error
+			if err != nil {
+				return Model{}, err
+			}
+
+			m := Model{
+				ceremonyId: ceremonyId,
+				items:      make([]Item, 0, len(itemEntities)),
+				gifts:      make([]Gift, 0, len(giftEntities)),
+				blessings:  make([]Blessing, 0, len(blessingEntities)),
+			}
+			for _, entity := range itemEntities {
+				i, err := MakeItem(entity)
+				if err != nil {
+					return Model{}, err
+				}
+				m.items = append(m.items, i)
+			}
+			for _, entity := range giftEntities {
+				g, err := MakeGift(entity)
+				if err != nil {
+					return Model{}, err
+				}
+				m.gifts = append(m.gifts, g)
+			}
+			for _, entity := range blessingEntities {
+				b, err := MakeBlessing(entity)
+				if err != nil {
+					return Model{}, err
+				}
+				m.blessings = append(m.blessings, b)
+			}
+			return m, nil
+		}
+	}
+}
+
+// GetItemProvider retrieves an item on a ceremony's wish list, returning nil when the couple has not listed it
+func GetItemProvider(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, itemId uint32, tenantId uuid.UUID) model.Provider[*Item] {
+	return func(ceremonyId uint32, itemId uint32, tenantId uuid.UUID) model.Provider[*Item] {
+		return func() (*Item, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId": ceremonyId,
+				"itemId":     itemId,
+				"tenantId":   tenantId,
+			}).Debug("Retrieving registry item")
+
+			var entity ItemEntity
+			err := db.Where("tenant_id = ? AND ceremony_id = ? AND item_id = ?", tenantId, ceremonyId, itemId).First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			i, err := MakeItem(entity)
+			if err != nil {
+				return nil, err
+			}
+			return &i, nil
+		}
+	}
+}
@@ -97,3 +97,31 @@ func ParseCharacterId(l logrus.FieldLogger, next CharacterIdHandler) http.Handle
 		next(uint32(characterId))(w, r)
 	}
 }
+
+type ProposalIdHandler func(proposalId uint32) http.HandlerFunc
+
+func ParseProposalId(l logrus.FieldLogger, next ProposalIdHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		proposalId, err := strconv.Atoi(mux.Vars(r)["proposalId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse proposalId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(uint32(proposalId))(w, r)
+	}
+}
+
+type CeremonyIdHandler func(ceremonyId uint32) http.HandlerFunc
+
+func ParseCeremonyId(l logrus.FieldLogger, next CeremonyIdHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ceremonyId, err := strconv.Atoi(mux.Vars(r)["ceremonyId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse ceremonyId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(uint32(ceremonyId))(w, r)
+	}
+}
@@ -0,0 +1,221 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents a tenant's marriage rules configuration. A nil column leaves the default rule in effect
+type Entity struct {
+	TenantId                        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EligibilityLevel                *byte
+	ProposalExpirySeconds           *int64
+	GlobalCooldownSeconds           *int64
+	InitialPerTargetCooldownSeconds *int64
+	MaxInvitees                     *int
+	DisconnectionTimeoutSeconds     *int64
+	EngagementRingItemId            *uint32
+	WeddingRingItemId               *uint32
+	StandardCeremonyCost            *uint32
+	PremiumCeremonyCost             *uint32
+	DivorceCost                     *uint32
+	SagaStepTimeoutSeconds          *int64
+	DivorceFilingRequired           *bool
+	DivorceWaitingPeriodSeconds     *int64
+	RemarriageCooldownSeconds       *int64
+	ExPartnerCooldownSeconds        *int64
+	AnniversaryMilestoneDays        *string // Comma separated days married, such as "7,30,100,365"
+	BondLevelThresholds             *string // Comma separated bond points, such as "100,300,600,1000,1500"
+	BondDecayPoints                 *uint32
+	BondDecayIntervalSeconds        *int64
+	CoupleSkills                    *string // Comma separated skills, such as "1000:engaged,1001,1002:3"
+	CeremonyReminderLeadTimeSeconds *int64
+	CeremonyGracePeriodSeconds      *int64
+	CancelMissedCeremonies          *bool
+	CeremonyStages                  *string // Comma separated stage names, such as "GUESTS_SEATED,VOWS,RECEPTION"
+	BlessingBondPoints              *uint32
+	GiftBondPoints                  *uint32
+	UpdatedAt                       time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the rules entity
+func (Entity) TableName() string {
+	return "marriage_rules"
+}
+
+// Migration performs the database migration for the rules entity
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// Make transforms a rules entity to a domain model, applying the defaults for every rule it does not override
+func Make(entity Entity) (Model, error) {
+	b := NewBuilder()
+	if entity.EligibilityLevel != nil {
+		b.SetEligibilityLevel(*entity.EligibilityLevel)
+	}
+	if entity.ProposalExpirySeconds != nil {
+		b.SetProposalExpiry(seconds(*entity.ProposalExpirySeconds))
+	}
+	if entity.GlobalCooldownSeconds != nil {
+		b.SetGlobalCooldown(seconds(*entity.GlobalCooldownSeconds))
+	}
+	if entity.InitialPerTargetCooldownSeconds != nil {
+		b.SetInitialPerTargetCooldown(seconds(*entity.InitialPerTargetCooldownSeconds))
+	}
+	if entity.MaxInvitees != nil {
+		b.SetMaxInvitees(*entity.MaxInvitees)
+	}
+	if entity.DisconnectionTimeoutSeconds != nil {
+		b.SetDisconnectionTimeout(seconds(*entity.DisconnectionTimeoutSeconds))
+	}
+	if entity.EngagementRingItemId != nil {
+		b.SetEngagementRingItemId(*entity.EngagementRingItemId)
+	}
+	if entity.WeddingRingItemId != nil {
+		b.SetWeddingRingItemId(*entity.WeddingRingItemId)
+	}
+	if entity.StandardCeremonyCost != nil {
+		b.SetStandardCeremonyCost(*entity.StandardCeremonyCost)
+	}
+	if entity.PremiumCeremonyCost != nil {
+		b.SetPremiumCeremonyCost(*entity.PremiumCeremonyCost)
+	}
+	if entity.DivorceCost != nil {
+		b.SetDivorceCost(*entity.DivorceCost)
+	}
+	if entity.SagaStepTimeoutSeconds != nil {
+		b.SetSagaStepTimeout(seconds(*entity.SagaStepTimeoutSeconds))
+	}
+	if entity.DivorceFilingRequired != nil {
+		b.SetDivorceFilingRequired(*entity.DivorceFilingRequired)
+	}
+	if entity.DivorceWaitingPeriodSeconds != nil {
+		b.SetDivorceWaitingPeriod(seconds(*entity.DivorceWaitingPeriodSeconds))
+	}
+	if entity.RemarriageCooldownSeconds != nil {
+		b.SetRemarriageCooldown(seconds(*entity.RemarriageCooldownSeconds))
+	}
+	if entity.ExPartnerCooldownSeconds != nil {
+		b.SetExPartnerCooldown(seconds(*entity.ExPartnerCooldownSeconds))
+	}
+	if entity.AnniversaryMilestoneDays != nil {
+		days, err := parseList(*entity.AnniversaryMilestoneDays, "anniversary milestone")
+		if err != nil {
+			return Model{}, err
+		}
+		b.SetAnniversaryMilestones(days)
+	}
+	if entity.BondLevelThresholds != nil {
+		points, err := parseList(*entity.BondLevelThresholds, "bond level threshold")
+		if err != nil {
+			return Model{}, err
+		}
+		b.SetBondLevelThresholds(points)
+	}
+	if entity.BondDecayPoints != nil {
+		b.SetBondDecayPoints(*entity.BondDecayPoints)
+	}
+	if entity.BondDecayIntervalSeconds != nil {
+		b.SetBondDecayInterval(seconds(*entity.BondDecayIntervalSeconds))
+	}
+	if entity.CoupleSkills != nil {
+		skills, err := parseCoupleSkills(*entity.CoupleSkills)
+		if err != nil {
+			return Model{}, err
+		}
+		b.SetCoupleSkills(skills)
+	}
+	if entity.CeremonyReminderLeadTimeSeconds != nil {
+		b.SetCeremonyReminderLeadTime(seconds(*entity.CeremonyReminderLeadTimeSeconds))
+	}
+	if entity.CeremonyGracePeriodSeconds != nil {
+		b.SetCeremonyGracePeriod(seconds(*entity.CeremonyGracePeriodSeconds))
+	}
+	if entity.CancelMissedCeremonies != nil {
+		b.SetCancelMissedCeremonies(*entity.CancelMissedCeremonies)
+	}
+	if entity.CeremonyStages != nil {
+		b.SetCeremonyStages(parseStages(*entity.CeremonyStages))
+	}
+	if entity.BlessingBondPoints != nil {
+		b.SetBlessingBondPoints(*entity.BlessingBondPoints)
+	}
+	if entity.GiftBondPoints != nil {
+		b.SetGiftBondPoints(*entity.GiftBondPoints)
+	}
+	return b.Build()
+}
+
+// parseList converts a comma separated column value to a list of numbers, naming the value in errors. An empty value is
+// an empty list
+func parseList(value string, name string) ([]uint32, error) {
+	values := make([]uint32, 0)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", name, field, err)
+		}
+		values = append(values, uint32(parsed))
+	}
+	return values, nil
+}
+
+// parseCoupleSkills converts a comma separated column value to a couple skill catalogue. Each skill is a skill ID,
+// optionally followed by ":engaged" when it is unlocked from engagement, or by ":" and the bond level it requires
+func parseCoupleSkills(value string) ([]CoupleSkill, error) {
+	skills := make([]CoupleSkill, 0)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		idField, requirement, hasRequirement := strings.Cut(field, ":")
+		skillId, err := strconv.ParseUint(strings.TrimSpace(idField), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid couple skill %q: %w", field, err)
+		}
+		requirement = strings.TrimSpace(requirement)
+		switch {
+		case !hasRequirement:
+			skills = append(skills, NewCoupleSkill(uint32(skillId), false, 0))
+		case requirement == "engaged":
+			skills = append(skills, NewCoupleSkill(uint32(skillId), true, 0))
+		default:
+			bondLevel, err := strconv.ParseUint(requirement, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid couple skill bond level %q: %w", field, err)
+			}
+			skills = append(skills, NewCoupleSkill(uint32(skillId), false, byte(bondLevel)))
+		}
+	}
+	return skills, nil
+}
+
+// parseStages converts a comma separated column value to a ceremony stage sequence. Stage names are upper cased, and an
+// empty value is a ceremony without stages
+func parseStages(value string) []string {
+	stages := make([]string, 0)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.ToUpper(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		stages = append(stages, field)
+	}
+	return stages
+}
+
+// seconds converts a column value in seconds to a duration
+func seconds(value int64) time.Duration {
+	return time.Duration(value) * time.Second
+}
@@ -0,0 +1,647 @@
+package rules
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default marriage rules, applied to tenants without a rules configuration and to any rule a tenant does not override
+const (
+	DefaultEligibilityLevel         = 10               // Minimum character level to propose or be proposed to
+	DefaultProposalExpiry           = 24 * time.Hour   // How long a proposal awaits a response
+	DefaultGlobalCooldown           = 4 * time.Hour    // Time between any two proposals by a character
+	DefaultInitialPerTargetCooldown = 24 * time.Hour   // Initial cooldown after a proposal to a target is declined or expires
+	DefaultMaxInvitees              = 15               // Maximum number of ceremony invitees
+	DefaultDisconnectionTimeout     = 5 * time.Minute  // Timeout for disconnection before an active ceremony is postponed
+	DefaultEngagementRingItemId     = 0                // Item a proposer must hold to propose, or 0 when no item is required
+	DefaultWeddingRingItemId        = 1112803          // Ring issued to both partners when they marry, or 0 when no ring is issued
+	DefaultStandardCeremonyCost     = 0                // Mesos charged to schedule a ceremony at a standard venue
+	DefaultPremiumCeremonyCost      = 0                // Mesos charged to schedule a ceremony at a premium venue
+	DefaultDivorceCost              = 0                // Mesos charged to the partner who initiates a divorce
+	DefaultSagaStepTimeout          = 1 * time.Minute  // Time a service has to complete a ceremony saga step
+	DefaultDivorceFilingRequired    = false            // Whether divorces must be filed rather than granted immediately
+	DefaultDivorceWaitingPeriod     = 168 * time.Hour  // Time after which a filed divorce is finalized without consent
+	DefaultRemarriageCooldown       = 24 * time.Hour   // Time after a divorce before either former partner may propose or be proposed to
+	DefaultExPartnerCooldown        = 0                // Time after a divorce before the former partners may propose to each other again
+	DefaultBondDecayPoints          = 0                // Bond points an inactive couple loses each decay interval, or 0 when bonds do not decay
+	DefaultBondDecayInterval        = 24 * time.Hour   // Time without bond points awarded after which a couple's bond decays
+	DefaultCeremonyReminderLeadTime = 15 * time.Minute // Time before a ceremony's scheduled start at which a reminder is emitted
+	DefaultCeremonyGracePeriod      = 30 * time.Minute // Time after a ceremony's scheduled start within which it must start
+	DefaultCancelMissedCeremonies   = false            // Whether ceremonies not started within the grace period are cancelled rather than postponed
+	DefaultBlessingBondPoints       = 1                // Bond points a couple earns at their ceremony's completion for each blessing received
+	DefaultGiftBondPoints           = 5                // Bond points a couple earns at their ceremony's completion for each registry item given
+)
+
+// DefaultAnniversaryMilestones are the days married at which a marriage anniversary is celebrated
+var DefaultAnniversaryMilestones = []uint32{7, 30, 100, 365}
+
+// DefaultBondLevelThresholds are the bond points a couple needs to reach each bond level, starting at level 1
+var DefaultBondLevelThresholds = []uint32{100, 300, 600, 1000, 1500}
+
+// DefaultCeremonyStages are the stages an officiant advances an active ceremony through, in order, before it completes.
+// Tenants have none unless they configure them, so a ceremony may complete as soon as it starts
+var DefaultCeremonyStages = []string{}
+
+// CoupleSkill is a skill granted to both partners of a couple while they meet its requirements. A skill is unlocked
+// either from engagement, or once married at a minimum bond level
+type CoupleSkill struct {
+	skillId        uint32
+	fromEngagement bool
+	bondLevel      byte
+}
+
+// NewCoupleSkill creates a couple skill unlocked from engagement, or once married at the given bond level
+func NewCoupleSkill(skillId uint32, fromEngagement bool, bondLevel byte) CoupleSkill {
+	return CoupleSkill{skillId: skillId, fromEngagement: fromEngagement, bondLevel: bondLevel}
+}
+
+// SkillId returns the skill granted to both partners
+func (s CoupleSkill) SkillId() uint32 {
+	return s.skillId
+}
+
+// FromEngagement returns true if the skill is unlocked once the couple is engaged, rather than once they marry
+func (s CoupleSkill) FromEngagement() bool {
+	return s.fromEngagement
+}
+
+// BondLevel returns the bond level a married couple must reach to unlock the skill
+func (s CoupleSkill) BondLevel() byte {
+	return s.bondLevel
+}
+
+// Model represents the immutable marriage rules in effect for a tenant
+type Model struct {
+	eligibilityLevel         byte
+	proposalExpiry           time.Duration
+	globalCooldown           time.Duration
+	initialPerTargetCooldown time.Duration
+	maxInvitees              int
+	disconnectionTimeout     time.Duration
+	engagementRingItemId     uint32
+	weddingRingItemId        uint32
+	standardCeremonyCost     uint32
+	premiumCeremonyCost      uint32
+	divorceCost              uint32
+	sagaStepTimeout          time.Duration
+	divorceFilingRequired    bool
+	divorceWaitingPeriod     time.Duration
+	remarriageCooldown       time.Duration
+	exPartnerCooldown        time.Duration
+	anniversaryMilestones    []uint32
+	bondLevelThresholds      []uint32
+	bondDecayPoints          uint32
+	bondDecayInterval        time.Duration
+	coupleSkills             []CoupleSkill
+	ceremonyReminderLeadTime time.Duration
+	ceremonyGracePeriod      time.Duration
+	cancelMissedCeremonies   bool
+	ceremonyStages           []string
+	blessingBondPoints       uint32
+	giftBondPoints           uint32
+}
+
+// Default returns the default marriage rules
+func Default() Model {
+	return Model{
+		eligibilityLevel:         DefaultEligibilityLevel,
+		proposalExpiry:           DefaultProposalExpiry,
+		globalCooldown:           DefaultGlobalCooldown,
+		initialPerTargetCooldown: DefaultInitialPerTargetCooldown,
+		maxInvitees:              DefaultMaxInvitees,
+		disconnectionTimeout:     DefaultDisconnectionTimeout,
+		engagementRingItemId:     DefaultEngagementRingItemId,
+		weddingRingItemId:        DefaultWeddingRingItemId,
+		standardCeremonyCost:     DefaultStandardCeremonyCost,
+		premiumCeremonyCost:      DefaultPremiumCeremonyCost,
+		divorceCost:              DefaultDivorceCost,
+		sagaStepTimeout:          DefaultSagaStepTimeout,
+		divorceFilingRequired:    DefaultDivorceFilingRequired,
+		divorceWaitingPeriod:     DefaultDivorceWaitingPeriod,
+		remarriageCooldown:       DefaultRemarriageCooldown,
+		exPartnerCooldown:        DefaultExPartnerCooldown,
+		anniversaryMilestones:    copyList(DefaultAnniversaryMilestones),
+		bondLevelThresholds:      copyList(DefaultBondLevelThresholds),
+		bondDecayPoints:          DefaultBondDecayPoints,
+		bondDecayInterval:        DefaultBondDecayInterval,
+		coupleSkills:             make([]CoupleSkill, 0),
+		ceremonyReminderLeadTime: DefaultCeremonyReminderLeadTime,
+		ceremonyGracePeriod:      DefaultCeremonyGracePeriod,
+		cancelMissedCeremonies:   DefaultCancelMissedCeremonies,
+		ceremonyStages:           copyStages(DefaultCeremonyStages),
+		blessingBondPoints:       DefaultBlessingBondPoints,
+		giftBondPoints:           DefaultGiftBondPoints,
+	}
+}
+
+// EligibilityLevel returns the minimum character level to propose or be proposed to
+func (m Model) EligibilityLevel() byte {
+	return m.eligibilityLevel
+}
+
+// ProposalExpiry returns how long a proposal awaits a response
+func (m Model) ProposalExpiry() time.Duration {
+	return m.proposalExpiry
+}
+
+// GlobalCooldown returns the time between any two proposals by a character
+func (m Model) GlobalCooldown() time.Duration {
+	return m.globalCooldown
+}
+
+// InitialPerTargetCooldown returns the initial cooldown after a proposal to a target is declined or expires
+func (m Model) InitialPerTargetCooldown() time.Duration {
+	return m.initialPerTargetCooldown
+}
+
+// MaxInvitees returns the maximum number of ceremony invitees
+func (m Model) MaxInvitees() int {
+	return m.maxInvitees
+}
+
+// DisconnectionTimeout returns the timeout for disconnection before an active ceremony is postponed
+func (m Model) DisconnectionTimeout() time.Duration {
+	return m.disconnectionTimeout
+}
+
+// EngagementRingItemId returns the item a proposer must hold to propose, or 0 when no item is required
+func (m Model) EngagementRingItemId() uint32 {
+	return m.engagementRingItemId
+}
+
+// RequiresEngagementRing returns true if proposing requires holding an engagement ring item
+func (m Model) RequiresEngagementRing() bool {
+	return m.engagementRingItemId != 0
+}
+
+// WeddingRingItemId returns the ring issued to both partners when they marry, or 0 when no ring is issued
+func (m Model) WeddingRingItemId() uint32 {
+	return m.weddingRingItemId
+}
+
+// StandardCeremonyCost returns the mesos charged to schedule a ceremony at a standard venue
+func (m Model) StandardCeremonyCost() uint32 {
+	return m.standardCeremonyCost
+}
+
+// PremiumCeremonyCost returns the mesos charged to schedule a ceremony at a premium venue
+func (m Model) PremiumCeremonyCost() uint32 {
+	return m.premiumCeremonyCost
+}
+
+// DivorceCost returns the mesos charged to the partner who initiates a divorce
+func (m Model) DivorceCost() uint32 {
+	return m.divorceCost
+}
+
+// SagaStepTimeout returns the time a service has to complete a ceremony saga step
+func (m Model) SagaStepTimeout() time.Duration {
+	return m.sagaStepTimeout
+}
+
+// DivorceFilingRequired returns whether divorces must be filed, rather than granted immediately to either partner
+func (m Model) DivorceFilingRequired() bool {
+	return m.divorceFilingRequired
+}
+
+// DivorceWaitingPeriod returns the time after which a filed divorce is finalized without the partner's consent
+func (m Model) DivorceWaitingPeriod() time.Duration {
+	return m.divorceWaitingPeriod
+}
+
+// RemarriageCooldown returns the time after a divorce before either former partner may propose or be proposed to
+func (m Model) RemarriageCooldown() time.Duration {
+	return m.remarriageCooldown
+}
+
+// ExPartnerCooldown returns the time after a divorce before the former partners may propose to each other again
+func (m Model) ExPartnerCooldown() time.Duration {
+	return m.exPartnerCooldown
+}
+
+// AnniversaryMilestones returns the days married at which a marriage anniversary is celebrated, in ascending order
+func (m Model) AnniversaryMilestones() []uint32 {
+	return copyList(m.anniversaryMilestones)
+}
+
+// BondLevelThresholds returns the bond points a couple needs to reach each bond level, in ascending order. The first
+// threshold reaches level 1, and the number of thresholds is the maximum bond level
+func (m Model) BondLevelThresholds() []uint32 {
+	return copyList(m.bondLevelThresholds)
+}
+
+// BondDecayPoints returns the bond points an inactive couple loses each decay interval, or 0 when bonds do not decay
+func (m Model) BondDecayPoints() uint32 {
+	return m.bondDecayPoints
+}
+
+// BondDecayInterval returns the time without bond points awarded after which a couple's bond decays
+func (m Model) BondDecayInterval() time.Duration {
+	return m.bondDecayInterval
+}
+
+// CoupleSkills returns the catalogue of skills granted to couples, which is empty unless the tenant configures one
+func (m Model) CoupleSkills() []CoupleSkill {
+	return copyCoupleSkills(m.coupleSkills)
+}
+
+// CeremonyReminderLeadTime returns the time before a ceremony's scheduled start at which a reminder is emitted, or 0
+// when no reminder is emitted
+func (m Model) CeremonyReminderLeadTime() time.Duration {
+	return m.ceremonyReminderLeadTime
+}
+
+// CeremonyGracePeriod returns the time after a ceremony's scheduled start within which both partners must be logged in
+// for it to start
+func (m Model) CeremonyGracePeriod() time.Duration {
+	return m.ceremonyGracePeriod
+}
+
+// CancelMissedCeremonies returns whether ceremonies not started within the grace period are cancelled, rather than
+// postponed until the couple reschedules them
+func (m Model) CancelMissedCeremonies() bool {
+	return m.cancelMissedCeremonies
+}
+
+// CeremonyStages returns the stages an officiant advances an active ceremony through, in order, before it may complete.
+// A ceremony with no stages may complete as soon as it starts
+func (m Model) CeremonyStages() []string {
+	return copyStages(m.ceremonyStages)
+}
+
+// BlessingBondPoints returns the bond points a couple earns at their ceremony's completion for each blessing received
+func (m Model) BlessingBondPoints() uint32 {
+	return m.blessingBondPoints
+}
+
+// GiftBondPoints returns the bond points a couple earns at their ceremony's completion for each registry item given
+func (m Model) GiftBondPoints() uint32 {
+	return m.giftBondPoints
+}
+
+// Builder creates a builder initialized with the rules
+func (m Model) Builder() *Builder {
+	return &Builder{
+		eligibilityLevel:         m.eligibilityLevel,
+		proposalExpiry:           m.proposalExpiry,
+		globalCooldown:           m.globalCooldown,
+		initialPerTargetCooldown: m.initialPerTargetCooldown,
+		maxInvitees:              m.maxInvitees,
+		disconnectionTimeout:     m.disconnectionTimeout,
+		engagementRingItemId:     m.engagementRingItemId,
+		weddingRingItemId:        m.weddingRingItemId,
+		standardCeremonyCost:     m.standardCeremonyCost,
+		premiumCeremonyCost:      m.premiumCeremonyCost,
+		divorceCost:              m.divorceCost,
+		sagaStepTimeout:          m.sagaStepTimeout,
+		divorceFilingRequired:    m.divorceFilingRequired,
+		divorceWaitingPeriod:     m.divorceWaitingPeriod,
+		remarriageCooldown:       m.remarriageCooldown,
+		exPartnerCooldown:        m.exPartnerCooldown,
+		anniversaryMilestones:    copyList(m.anniversaryMilestones),
+		bondLevelThresholds:      copyList(m.bondLevelThresholds),
+		bondDecayPoints:          m.bondDecayPoints,
+		bondDecayInterval:        m.bondDecayInterval,
+		coupleSkills:             copyCoupleSkills(m.coupleSkills),
+		ceremonyReminderLeadTime: m.ceremonyReminderLeadTime,
+		ceremonyGracePeriod:      m.ceremonyGracePeriod,
+		cancelMissedCeremonies:   m.cancelMissedCeremonies,
+		ceremonyStages:           copyStages(m.ceremonyStages),
+		blessingBondPoints:       m.blessingBondPoints,
+		giftBondPoints:           m.giftBondPoints,
+	}
+}
+
+// Builder provides fluent construction of rules Models
+type Builder struct {
+	eligibilityLevel         byte
+	proposalExpiry           time.Duration
+	globalCooldown           time.Duration
+	initialPerTargetCooldown time.Duration
+	maxInvitees              int
+	disconnectionTimeout     time.Duration
+	engagementRingItemId     uint32
+	weddingRingItemId        uint32
+	standardCeremonyCost     uint32
+	premiumCeremonyCost      uint32
+	divorceCost              uint32
+	sagaStepTimeout          time.Duration
+	divorceFilingRequired    bool
+	divorceWaitingPeriod     time.Duration
+	remarriageCooldown       time.Duration
+	exPartnerCooldown        time.Duration
+	anniversaryMilestones    []uint32
+	bondLevelThresholds      []uint32
+	bondDecayPoints          uint32
+	bondDecayInterval        time.Duration
+	coupleSkills             []CoupleSkill
+	ceremonyReminderLeadTime time.Duration
+	ceremonyGracePeriod      time.Duration
+	cancelMissedCeremonies   bool
+	ceremonyStages           []string
+	blessingBondPoints       uint32
+	giftBondPoints           uint32
+}
+
+// NewBuilder creates a builder initialized with the default rules
+func NewBuilder() *Builder {
+	return Default().Builder()
+}
+
+// SetEligibilityLevel sets the minimum character level
+func (b *Builder) SetEligibilityLevel(level byte) *Builder {
+	b.eligibilityLevel = level
+	return b
+}
+
+// SetProposalExpiry sets the proposal expiry duration
+func (b *Builder) SetProposalExpiry(expiry time.Duration) *Builder {
+	b.proposalExpiry = expiry
+	return b
+}
+
+// SetGlobalCooldown sets the global proposal cooldown
+func (b *Builder) SetGlobalCooldown(cooldown time.Duration) *Builder {
+	b.globalCooldown = cooldown
+	return b
+}
+
+// SetInitialPerTargetCooldown sets the initial per-target proposal cooldown
+func (b *Builder) SetInitialPerTargetCooldown(cooldown time.Duration) *Builder {
+	b.initialPerTargetCooldown = cooldown
+	return b
+}
+
+// SetMaxInvitees sets the maximum number of ceremony invitees
+func (b *Builder) SetMaxInvitees(maxInvitees int) *Builder {
+	b.maxInvitees = maxInvitees
+	return b
+}
+
+// SetDisconnectionTimeout sets the ceremony disconnection timeout
+func (b *Builder) SetDisconnectionTimeout(timeout time.Duration) *Builder {
+	b.disconnectionTimeout = timeout
+	return b
+}
+
+// SetEngagementRingItemId sets the item a proposer must hold to propose
+func (b *Builder) SetEngagementRingItemId(itemId uint32) *Builder {
+	b.engagementRingItemId = itemId
+	return b
+}
+
+// SetWeddingRingItemId sets the ring issued to both partners when they marry
+func (b *Builder) SetWeddingRingItemId(itemId uint32) *Builder {
+	b.weddingRingItemId = itemId
+	return b
+}
+
+// SetStandardCeremonyCost sets the mesos charged to schedule a ceremony at a standard venue
+func (b *Builder) SetStandardCeremonyCost(cost uint32) *Builder {
+	b.standardCeremonyCost = cost
+	return b
+}
+
+// SetPremiumCeremonyCost sets the mesos charged to schedule a ceremony at a premium venue
+func (b *Builder) SetPremiumCeremonyCost(cost uint32) *Builder {
+	b.premiumCeremonyCost = cost
+	return b
+}
+
+// SetDivorceCost sets the mesos charged to the partner who initiates a divorce
+func (b *Builder) SetDivorceCost(cost uint32) *Builder {
+	b.divorceCost = cost
+	return b
+}
+
+// SetSagaStepTimeout sets the time a service has to complete a ceremony saga step
+func (b *Builder) SetSagaStepTimeout(timeout time.Duration) *Builder {
+	b.sagaStepTimeout = timeout
+	return b
+}
+
+// SetDivorceFilingRequired sets whether divorces must be filed rather than granted immediately
+func (b *Builder) SetDivorceFilingRequired(required bool) *Builder {
+	b.divorceFilingRequired = required
+	return b
+}
+
+// SetDivorceWaitingPeriod sets the time after which a filed divorce is finalized without consent
+func (b *Builder) SetDivorceWaitingPeriod(period time.Duration) *Builder {
+	b.divorceWaitingPeriod = period
+	return b
+}
+
+// SetRemarriageCooldown sets the time after a divorce before either former partner may propose or be proposed to
+func (b *Builder) SetRemarriageCooldown(cooldown time.Duration) *Builder {
+	b.remarriageCooldown = cooldown
+	return b
+}
+
+// SetExPartnerCooldown sets the time after a divorce before the former partners may propose to each other again
+func (b *Builder) SetExPartnerCooldown(cooldown time.Duration) *Builder {
+	b.exPartnerCooldown = cooldown
+	return b
+}
+
+// SetAnniversaryMilestones sets the days married at which a marriage anniversary is celebrated
+func (b *Builder) SetAnniversaryMilestones(days []uint32) *Builder {
+	b.anniversaryMilestones = copyList(days)
+	return b
+}
+
+// SetBondLevelThresholds sets the bond points a couple needs to reach each bond level
+func (b *Builder) SetBondLevelThresholds(points []uint32) *Builder {
+	b.bondLevelThresholds = copyList(points)
+	return b
+}
+
+// SetBondDecayPoints sets the bond points an inactive couple loses each decay interval
+func (b *Builder) SetBondDecayPoints(points uint32) *Builder {
+	b.bondDecayPoints = points
+	return b
+}
+
+// SetBondDecayInterval sets the time without bond points awarded after which a couple's bond decays
+func (b *Builder) SetBondDecayInterval(interval time.Duration) *Builder {
+	b.bondDecayInterval = interval
+	return b
+}
+
+// SetCoupleSkills sets the catalogue of skills granted to couples
+func (b *Builder) SetCoupleSkills(skills []CoupleSkill) *Builder {
+	b.coupleSkills = copyCoupleSkills(skills)
+	return b
+}
+
+// SetCeremonyReminderLeadTime sets the time before a ceremony's scheduled start at which a reminder is emitted
+func (b *Builder) SetCeremonyReminderLeadTime(leadTime time.Duration) *Builder {
+	b.ceremonyReminderLeadTime = leadTime
+	return b
+}
+
+// SetCeremonyGracePeriod sets the time after a ceremony's scheduled start within which it must start
+func (b *Builder) SetCeremonyGracePeriod(period time.Duration) *Builder {
+	b.ceremonyGracePeriod = period
+	return b
+}
+
+// SetCancelMissedCeremonies sets whether ceremonies not started within the grace period are cancelled
+func (b *Builder) SetCancelMissedCeremonies(cancel bool) *Builder {
+	b.cancelMissedCeremonies = cancel
+	return b
+}
+
+// SetCeremonyStages sets the stages an officiant advances an active ceremony through, in order
+func (b *Builder) SetCeremonyStages(stages []string) *Builder {
+	b.ceremonyStages = copyStages(stages)
+	return b
+}
+
+// SetBlessingBondPoints sets the bond points a couple earns for each blessing received at their ceremony
+func (b *Builder) SetBlessingBondPoints(points uint32) *Builder {
+	b.blessingBondPoints = points
+	return b
+}
+
+// SetGiftBondPoints sets the bond points a couple earns for each registry item given at their ceremony
+func (b *Builder) SetGiftBondPoints(points uint32) *Builder {
+	b.giftBondPoints = points
+	return b
+}
+
+// Build validates and constructs the final rules Model
+func (b *Builder) Build() (Model, error) {
+	if b.proposalExpiry <= 0 {
+		return Model{}, errors.New("proposal expiry must be positive")
+	}
+	if b.globalCooldown < 0 {
+		return Model{}, errors.New("global cooldown cannot be negative")
+	}
+	if b.initialPerTargetCooldown < 0 {
+		return Model{}, errors.New("initial per-target cooldown cannot be negative")
+	}
+	if b.maxInvitees < 0 {
+		return Model{}, errors.New("max invitees cannot be negative")
+	}
+	if b.disconnectionTimeout <= 0 {
+		return Model{}, errors.New("disconnection timeout must be positive")
+	}
+	if b.sagaStepTimeout <= 0 {
+		return Model{}, errors.New("saga step timeout must be positive")
+	}
+	if b.divorceWaitingPeriod < 0 {
+		return Model{}, errors.New("divorce waiting period cannot be negative")
+	}
+	if b.remarriageCooldown < 0 {
+		return Model{}, errors.New("remarriage cooldown cannot be negative")
+	}
+	if b.exPartnerCooldown < 0 {
+		return Model{}, errors.New("ex-partner cooldown cannot be negative")
+	}
+	milestones := copyList(b.anniversaryMilestones)
+	sort.Slice(milestones, func(i, j int) bool { return milestones[i] < milestones[j] })
+	for i, days := range milestones {
+		if days == 0 {
+			return Model{}, errors.New("anniversary milestones must be positive")
+		}
+		if i > 0 && milestones[i-1] == days {
+			return Model{}, errors.New("anniversary milestones cannot repeat")
+		}
+	}
+	thresholds := copyList(b.bondLevelThresholds)
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i] < thresholds[j] })
+	for i, points := range thresholds {
+		if points == 0 {
+			return Model{}, errors.New("bond level thresholds must be positive")
+		}
+		if i > 0 && thresholds[i-1] == points {
+			return Model{}, errors.New("bond level thresholds cannot repeat")
+		}
+	}
+	if b.bondDecayInterval <= 0 {
+		return Model{}, errors.New("bond decay interval must be positive")
+	}
+	skillIds := make(map[uint32]bool, len(b.coupleSkills))
+	for _, skill := range b.coupleSkills {
+		if skill.skillId == 0 {
+			return Model{}, errors.New("couple skill ID is required")
+		}
+		if skillIds[skill.skillId] {
+			return Model{}, errors.New("couple skills cannot repeat")
+		}
+		if skill.fromEngagement && skill.bondLevel != 0 {
+			return Model{}, errors.New("couple skills unlocked from engagement cannot require a bond level")
+		}
+		skillIds[skill.skillId] = true
+	}
+	if b.ceremonyReminderLeadTime < 0 {
+		return Model{}, errors.New("ceremony reminder lead time cannot be negative")
+	}
+	if b.ceremonyGracePeriod <= 0 {
+		return Model{}, errors.New("ceremony grace period must be positive")
+	}
+	stages := make(map[string]bool, len(b.ceremonyStages))
+	for _, stage := range b.ceremonyStages {
+		if strings.TrimSpace(stage) == "" {
+			return Model{}, errors.New("ceremony stage name is required")
+		}
+		if stages[stage] {
+			return Model{}, errors.New("ceremony stages cannot repeat")
+		}
+		stages[stage] = true
+	}
+
+	return Model{
+		eligibilityLevel:         b.eligibilityLevel,
+		proposalExpiry:           b.proposalExpiry,
+		globalCooldown:           b.globalCooldown,
+		initialPerTargetCooldown: b.initialPerTargetCooldown,
+		maxInvitees:              b.maxInvitees,
+		disconnectionTimeout:     b.disconnectionTimeout,
+		engagementRingItemId:     b.engagementRingItemId,
+		weddingRingItemId:        b.weddingRingItemId,
+		standardCeremonyCost:     b.standardCeremonyCost,
+		premiumCeremonyCost:      b.premiumCeremonyCost,
+		divorceCost:              b.divorceCost,
+		sagaStepTimeout:          b.sagaStepTimeout,
+		divorceFilingRequired:    b.divorceFilingRequired,
+		divorceWaitingPeriod:     b.divorceWaitingPeriod,
+		remarriageCooldown:       b.remarriageCooldown,
+		exPartnerCooldown:        b.exPartnerCooldown,
+		anniversaryMilestones:    milestones,
+		bondLevelThresholds:      thresholds,
+		bondDecayPoints:          b.bondDecayPoints,
+		bondDecayInterval:        b.bondDecayInterval,
+		coupleSkills:             copyCoupleSkills(b.coupleSkills),
+		ceremonyReminderLeadTime: b.ceremonyReminderLeadTime,
+		ceremonyGracePeriod:      b.ceremonyGracePeriod,
+		cancelMissedCeremonies:   b.cancelMissedCeremonies,
+		ceremonyStages:           copyStages(b.ceremonyStages),
+		blessingBondPoints:       b.blessingBondPoints,
+		giftBondPoints:           b.giftBondPoints,
+	}, nil
+}
+
+// copyList returns a copy of a list of milestones or thresholds, so models never share one
+func copyList(days []uint32) []uint32 {
+	copied := make([]uint32, len(days))
+	copy(copied, days)
+	return copied
+}
+
+// copyCoupleSkills returns a copy of a couple skill catalogue, so models never share one
+func copyCoupleSkills(skills []CoupleSkill) []CoupleSkill {
+	copied := make([]CoupleSkill, len(skills))
+	copy(copied, skills)
+	return copied
+}
+
+// copyStages returns a copy of a ceremony stage sequence, so models never share one
+func copyStages(stages []string) []string {
+	copied := make([]string, len(stages))
+	copy(copied, stages)
+	return copied
+}
@@ -0,0 +1,21 @@
+package rules
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// getByTenantId retrieves the rules configuration for a tenant, returning nil when the tenant has none
+func getByTenantId(db *gorm.DB) func(tenantId uuid.UUID) (*Entity, error) {
+	return func(tenantId uuid.UUID) (*Entity, error) {
+		var entities []Entity
+		err := db.Where("tenant_id = ?", tenantId).Limit(1).Find(&entities).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(entities) == 0 {
+			return nil, nil
+		}
+		return &entities[0], nil
+	}
+}
@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultCacheTTL is how long a tenant's rules are cached before being reloaded from the database, bounding how
+// long a configuration change takes to apply
+const DefaultCacheTTL = 1 * time.Minute
+
+type cacheEntry struct {
+	rules    Model
+	loadedAt time.Time
+	failed   bool
+}
+
+// Registry caches the marriage rules of each tenant, reloading them once they are older than the cache TTL
+type Registry struct {
+	lock    sync.RWMutex
+	ttl     time.Duration
+	entries map[uuid.UUID]cacheEntry
+}
+
+var registry *Registry
+var once sync.Once
+
+// GetRegistry returns the process-wide rules registry
+func GetRegistry() *Registry {
+	once.Do(func() {
+		registry = NewRegistry(DefaultCacheTTL)
+	})
+	return registry
+}
+
+// NewRegistry creates a rules registry caching rules for the given duration
+func NewRegistry(ttl time.Duration) *Registry {
+	return &Registry{
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]cacheEntry),
+	}
+}
+
+// Get returns the rules in effect for a tenant. Tenants without a configuration use the defaults. When the
+// configuration cannot be loaded or is invalid the last known rules, or the defaults, remain in effect for the TTL
+func (r *Registry) Get(l logrus.FieldLogger, db *gorm.DB) func(tenantId uuid.UUID) Model {
+	return func(tenantId uuid.UUID) Model {
+		r.lock.RLock()
+		entry, ok := r.entries[tenantId]
+		r.lock.RUnlock()
+		if ok && time.Since(entry.loadedAt) < r.ttl {
+			return entry.rules
+		}
+
+		rules, err := load(db)(tenantId)
+		if err != nil {
+			// The fallback is cached like loaded rules, so an invalid configuration is neither reloaded nor
+			// reported on every lookup. It is reported once, until the configuration loads again
+			fallback := Default()
+			if ok {
+				fallback = entry.rules
+			}
+			if !ok || !entry.failed {
+				l.WithError(err).WithField("tenantId", tenantId).Warn("Unable to load marriage rules, using last known rules")
+			}
+			r.lock.Lock()
+			r.entries[tenantId] = cacheEntry{rules: fallback, loadedAt: time.Now(), failed: true}
+			r.lock.Unlock()
+			return fallback
+		}
+
+		r.lock.Lock()
+		r.entries[tenantId] = cacheEntry{rules: rules, loadedAt: time.Now()}
+		r.lock.Unlock()
+		return rules
+	}
+}
+
+// Invalidate discards the cached rules for a tenant, so the next lookup reloads them
+func (r *Registry) Invalidate(tenantId uuid.UUID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.entries, tenantId)
+}
+
+// load reads the rules configuration for a tenant from the database
+func load(db *gorm.DB) func(tenantId uuid.UUID) (Model, error) {
+	return func(tenantId uuid.UUID) (Model, error) {
+		entity, err := getByTenantId(db)(tenantId)
+		if err != nil {
+			return Model{}, err
+		}
+		if entity == nil {
+			return Default(), nil
+		}
+		return Make(*entity)
+	}
+}
+
+// ForTenant returns the rules in effect for a tenant from the process-wide registry
+func ForTenant(l logrus.FieldLogger, db *gorm.DB) func(tenantId uuid.UUID) Model {
+	return GetRegistry().Get(l, db)
+}
@@ -0,0 +1,311 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, Migration(db))
+	return db
+}
+
+func testLogger() logrus.FieldLogger {
+	l := logrus.New()
+	l.SetLevel(logrus.FatalLevel)
+	return l
+}
+
+func TestRegistry_DefaultsWithoutConfiguration(t *testing.T) {
+	db := setupTestDB(t)
+	r := NewRegistry(time.Minute)
+
+	assert.Equal(t, Default(), r.Get(testLogger(), db)(uuid.New()))
+}
+
+func TestRegistry_AppliesOverrides(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	level := byte(30)
+	maxInvitees := 40
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, EligibilityLevel: &level, MaxInvitees: &maxInvitees, UpdatedAt: time.Now()}).Error)
+
+	rules := NewRegistry(time.Minute).Get(testLogger(), db)(tenantId)
+	assert.Equal(t, level, rules.EligibilityLevel())
+	assert.Equal(t, maxInvitees, rules.MaxInvitees())
+	assert.Equal(t, DefaultProposalExpiry, rules.ProposalExpiry())
+	assert.Equal(t, DefaultDisconnectionTimeout, rules.DisconnectionTimeout())
+}
+
+func TestRegistry_ReloadsAfterInvalidate(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	r := NewRegistry(time.Hour)
+
+	assert.Equal(t, DefaultMaxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+
+	maxInvitees := 5
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, MaxInvitees: &maxInvitees, UpdatedAt: time.Now()}).Error)
+	assert.Equal(t, DefaultMaxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees(), "expected cached rules until invalidated")
+
+	r.Invalidate(tenantId)
+	assert.Equal(t, maxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+}
+
+func TestRegistry_ReloadsAfterTTL(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	r := NewRegistry(0)
+
+	assert.Equal(t, DefaultMaxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+
+	maxInvitees := 5
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, MaxInvitees: &maxInvitees, UpdatedAt: time.Now()}).Error)
+	assert.Equal(t, maxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+}
+
+func TestRegistry_KeepsLastKnownRulesOnError(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	maxInvitees := 5
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, MaxInvitees: &maxInvitees, UpdatedAt: time.Now()}).Error)
+	r := NewRegistry(0)
+	assert.Equal(t, maxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+
+	require.NoError(t, db.Migrator().DropTable(&Entity{}))
+	assert.Equal(t, maxInvitees, r.Get(testLogger(), db)(tenantId).MaxInvitees())
+	assert.Equal(t, Default(), r.Get(testLogger(), db)(uuid.New()))
+}
+
+func TestRegistry_CachesFallbackForInvalidConfiguration(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	expiry := int64(0)
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, ProposalExpirySeconds: &expiry, UpdatedAt: time.Now()}).Error)
+	l, hook := test.NewNullLogger()
+	r := NewRegistry(time.Hour)
+
+	assert.Equal(t, Default(), r.Get(l, db)(tenantId))
+	assert.Equal(t, Default(), r.Get(l, db)(tenantId))
+	assert.Len(t, hook.AllEntries(), 1, "expected the invalid configuration to be reported once")
+
+	// The fallback is cached for the TTL, so the corrected configuration applies once it is reloaded
+	expiry = 3600
+	require.NoError(t, db.Model(&Entity{}).Where("tenant_id = ?", tenantId).Update("proposal_expiry_seconds", expiry).Error)
+	assert.Equal(t, DefaultProposalExpiry, r.Get(l, db)(tenantId).ProposalExpiry())
+	r.Invalidate(tenantId)
+	assert.Equal(t, time.Hour, r.Get(l, db)(tenantId).ProposalExpiry())
+}
+
+func TestRegistry_ReportsInvalidConfigurationOnceAcrossReloads(t *testing.T) {
+	db := setupTestDB(t)
+	tenantId := uuid.New()
+	expiry := int64(0)
+	require.NoError(t, db.Create(&Entity{TenantId: tenantId, ProposalExpirySeconds: &expiry, UpdatedAt: time.Now()}).Error)
+	l, hook := test.NewNullLogger()
+	r := NewRegistry(0)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, Default(), r.Get(l, db)(tenantId))
+	}
+	assert.Len(t, hook.AllEntries(), 1)
+}
+
+func TestMake_RejectsInvalidOverrides(t *testing.T) {
+	expiry := int64(0)
+	_, err := Make(Entity{TenantId: uuid.New(), ProposalExpirySeconds: &expiry})
+	assert.Error(t, err)
+
+	cooldown := int64(90)
+	rules, err := Make(Entity{TenantId: uuid.New(), GlobalCooldownSeconds: &cooldown})
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, rules.GlobalCooldown())
+}
+
+func TestMake_CeremonySagaOverrides(t *testing.T) {
+	stepTimeout := int64(30)
+	rules, err := Make(Entity{TenantId: uuid.New(), SagaStepTimeoutSeconds: &stepTimeout})
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, rules.SagaStepTimeout())
+
+	stepTimeout = 0
+	_, err = Make(Entity{TenantId: uuid.New(), SagaStepTimeoutSeconds: &stepTimeout})
+	assert.Error(t, err)
+}
+
+func TestMake_CostOverrides(t *testing.T) {
+	premium := uint32(5000000)
+	divorce := uint32(500000)
+	rules, err := Make(Entity{TenantId: uuid.New(), PremiumCeremonyCost: &premium, DivorceCost: &divorce})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultStandardCeremonyCost), rules.StandardCeremonyCost())
+	assert.Equal(t, premium, rules.PremiumCeremonyCost())
+	assert.Equal(t, divorce, rules.DivorceCost())
+}
+
+func TestMake_DivorceFilingOverrides(t *testing.T) {
+	required := true
+	waitingPeriod := int64(3600)
+	rules, err := Make(Entity{TenantId: uuid.New(), DivorceFilingRequired: &required, DivorceWaitingPeriodSeconds: &waitingPeriod})
+	require.NoError(t, err)
+	assert.True(t, rules.DivorceFilingRequired())
+	assert.Equal(t, time.Hour, rules.DivorceWaitingPeriod())
+
+	waitingPeriod = -1
+	_, err = Make(Entity{TenantId: uuid.New(), DivorceWaitingPeriodSeconds: &waitingPeriod})
+	assert.Error(t, err)
+}
+
+func TestMake_RemarriageCooldownOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRemarriageCooldown, defaults.RemarriageCooldown())
+	assert.Equal(t, time.Duration(DefaultExPartnerCooldown), defaults.ExPartnerCooldown())
+
+	remarriage := int64(0)
+	exPartner := int64(7200)
+	rules, err := Make(Entity{TenantId: uuid.New(), RemarriageCooldownSeconds: &remarriage, ExPartnerCooldownSeconds: &exPartner})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), rules.RemarriageCooldown())
+	assert.Equal(t, 2*time.Hour, rules.ExPartnerCooldown())
+
+	exPartner = -1
+	_, err = Make(Entity{TenantId: uuid.New(), ExPartnerCooldownSeconds: &exPartner})
+	assert.Error(t, err)
+}
+
+func TestMake_AnniversaryMilestoneOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAnniversaryMilestones, defaults.AnniversaryMilestones())
+
+	milestones := " 365, 7 ,1000"
+	rules, err := Make(Entity{TenantId: uuid.New(), AnniversaryMilestoneDays: &milestones})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{7, 365, 1000}, rules.AnniversaryMilestones())
+
+	milestones = ""
+	rules, err = Make(Entity{TenantId: uuid.New(), AnniversaryMilestoneDays: &milestones})
+	require.NoError(t, err)
+	assert.Empty(t, rules.AnniversaryMilestones())
+
+	for _, invalid := range []string{"7,seven", "0,30", "30,30"} {
+		milestones = invalid
+		_, err = Make(Entity{TenantId: uuid.New(), AnniversaryMilestoneDays: &milestones})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMake_BondOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBondLevelThresholds, defaults.BondLevelThresholds())
+	assert.Equal(t, uint32(DefaultBondDecayPoints), defaults.BondDecayPoints())
+	assert.Equal(t, DefaultBondDecayInterval, defaults.BondDecayInterval())
+
+	thresholds := "500, 50,200"
+	decayPoints := uint32(10)
+	decayInterval := int64(3600)
+	rules, err := Make(Entity{TenantId: uuid.New(), BondLevelThresholds: &thresholds, BondDecayPoints: &decayPoints, BondDecayIntervalSeconds: &decayInterval})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{50, 200, 500}, rules.BondLevelThresholds())
+	assert.Equal(t, uint32(10), rules.BondDecayPoints())
+	assert.Equal(t, time.Hour, rules.BondDecayInterval())
+
+	for _, invalid := range []string{"100,lots", "0,100", "100,100"} {
+		thresholds = invalid
+		_, err = Make(Entity{TenantId: uuid.New(), BondLevelThresholds: &thresholds})
+		assert.Error(t, err, invalid)
+	}
+
+	decayInterval = 0
+	_, err = Make(Entity{TenantId: uuid.New(), BondDecayIntervalSeconds: &decayInterval})
+	assert.Error(t, err)
+}
+
+func TestMake_CoupleSkillOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Empty(t, defaults.CoupleSkills())
+
+	skills := "1000:engaged, 1001,1002:3"
+	rules, err := Make(Entity{TenantId: uuid.New(), CoupleSkills: &skills})
+	require.NoError(t, err)
+	assert.Equal(t, []CoupleSkill{
+		NewCoupleSkill(1000, true, 0),
+		NewCoupleSkill(1001, false, 0),
+		NewCoupleSkill(1002, false, 3),
+	}, rules.CoupleSkills())
+
+	for _, invalid := range []string{"1000:soon", "0", "1000,1000:2", "lots", "1000:300"} {
+		skills = invalid
+		_, err = Make(Entity{TenantId: uuid.New(), CoupleSkills: &skills})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMake_CeremonyScheduleOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultCeremonyReminderLeadTime, defaults.CeremonyReminderLeadTime())
+	assert.Equal(t, DefaultCeremonyGracePeriod, defaults.CeremonyGracePeriod())
+	assert.False(t, defaults.CancelMissedCeremonies())
+
+	leadTime := int64(0)
+	gracePeriod := int64(600)
+	cancel := true
+	rules, err := Make(Entity{TenantId: uuid.New(), CeremonyReminderLeadTimeSeconds: &leadTime, CeremonyGracePeriodSeconds: &gracePeriod, CancelMissedCeremonies: &cancel})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), rules.CeremonyReminderLeadTime())
+	assert.Equal(t, 10*time.Minute, rules.CeremonyGracePeriod())
+	assert.True(t, rules.CancelMissedCeremonies())
+
+	gracePeriod = 0
+	_, err = Make(Entity{TenantId: uuid.New(), CeremonyGracePeriodSeconds: &gracePeriod})
+	assert.Error(t, err)
+}
+
+func TestMake_CeremonyStageOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Empty(t, defaults.CeremonyStages())
+
+	stages := "vows, ring_exchange,RECEPTION"
+	rules, err := Make(Entity{TenantId: uuid.New(), CeremonyStages: &stages})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VOWS", "RING_EXCHANGE", "RECEPTION"}, rules.CeremonyStages())
+
+	stages = ""
+	rules, err = Make(Entity{TenantId: uuid.New(), CeremonyStages: &stages})
+	require.NoError(t, err)
+	assert.Empty(t, rules.CeremonyStages())
+
+	stages = "VOWS,RECEPTION,vows"
+	_, err = Make(Entity{TenantId: uuid.New(), CeremonyStages: &stages})
+	assert.Error(t, err)
+}
+
+func TestMake_CeremonyRegistryOverrides(t *testing.T) {
+	defaults, err := Make(Entity{TenantId: uuid.New()})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(DefaultBlessingBondPoints), defaults.BlessingBondPoints())
+	assert.Equal(t, uint32(DefaultGiftBondPoints), defaults.GiftBondPoints())
+
+	blessingPoints := uint32(0)
+	giftPoints := uint32(20)
+	rules, err := Make(Entity{TenantId: uuid.New(), BlessingBondPoints: &blessingPoints, GiftBondPoints: &giftPoints})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), rules.BlessingBondPoints())
+	assert.Equal(t, uint32(20), rules.GiftBondPoints())
+}
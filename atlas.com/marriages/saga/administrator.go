@@ -0,0 +1,50 @@
+package saga
+
+import (
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Create persists a new saga
+func Create(db *gorm.DB, log logrus.FieldLogger) func(m Model) model.Provider[Model] {
+	return func(m Model) model.Provider[Model] {
+		return func() (Model, error) {
+			log.WithFields(logrus.Fields{
+				"sagaId":     m.Id(),
+				"ceremonyId": m.CeremonyId(),
+				"tenantId":   m.TenantId(),
+			}).Debug("Creating saga entity")
+
+			entity, err := m.ToEntity()
+			if err != nil {
+				return Model{}, err
+			}
+			if err := db.Create(&entity).Error; err != nil {
+				return Model{}, err
+			}
+			return Make(entity)
+		}
+	}
+}
+
+// Update persists the progress of an existing saga
+func Update(db *gorm.DB, log logrus.FieldLogger) func(m Model) model.Provider[Model] {
+	return func(m Model) model.Provider[Model] {
+		return func() (Model, error) {
+			log.WithFields(logrus.Fields{
+				"sagaId": m.Id(),
+				"status": m.Status().String(),
+			}).Debug("Updating saga entity")
+
+			entity, err := m.ToEntity()
+			if err != nil {
+				return Model{}, err
+			}
+			if err := db.Save(&entity).Error; err != nil {
+				return Model{}, err
+			}
+			return Make(entity)
+		}
+	}
+}
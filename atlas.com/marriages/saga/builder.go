@@ -0,0 +1,148 @@
+package saga
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Builder provides fluent construction of saga Models
+type Builder struct {
+	id            uuid.UUID
+	tenantId      uuid.UUID
+	sagaType      Type
+	ceremonyId    uint32
+	marriageId    uint32
+	characterId1  uint32
+	characterId2  uint32
+	fee           uint32
+	status        Status
+	steps         []Step
+	stepDeadline  *time.Time
+	failureReason string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewCeremonyBuilder creates a builder for a ceremony saga with the ceremony steps and default values
+func NewCeremonyBuilder(id uuid.UUID, tenantId uuid.UUID, ceremonyId uint32, marriageId uint32, characterId1 uint32, characterId2 uint32) *Builder {
+	now := time.Now()
+	return &Builder{
+		id:           id,
+		tenantId:     tenantId,
+		sagaType:     TypeCeremony,
+		ceremonyId:   ceremonyId,
+		marriageId:   marriageId,
+		characterId1: characterId1,
+		characterId2: characterId2,
+		status:       StatusRunning,
+		steps:        CeremonySteps(0),
+		createdAt:    now,
+		updatedAt:    now,
+	}
+}
+
+// SetType sets the workflow the saga orchestrates
+func (b *Builder) SetType(sagaType Type) *Builder {
+	b.sagaType = sagaType
+	return b
+}
+
+// SetFee sets the ceremony fee debited when the ceremony was scheduled, skipping the fee step when the ceremony is free
+func (b *Builder) SetFee(fee uint32) *Builder {
+	b.fee = fee
+	b.steps = CeremonySteps(fee)
+	return b
+}
+
+// SetStatus sets the saga status
+func (b *Builder) SetStatus(status Status) *Builder {
+	b.status = status
+	return b
+}
+
+// SetSteps sets the saga steps in execution order
+func (b *Builder) SetSteps(steps []Step) *Builder {
+	b.steps = steps
+	return b
+}
+
+// SetStepDeadline sets when the step in progress times out
+func (b *Builder) SetStepDeadline(stepDeadline *time.Time) *Builder {
+	b.stepDeadline = stepDeadline
+	return b
+}
+
+// SetFailureReason sets why the saga was compensated
+func (b *Builder) SetFailureReason(failureReason string) *Builder {
+	b.failureReason = failureReason
+	return b
+}
+
+// SetCreatedAt sets the creation timestamp
+func (b *Builder) SetCreatedAt(createdAt time.Time) *Builder {
+	b.createdAt = createdAt
+	return b
+}
+
+// SetUpdatedAt sets the last update timestamp
+func (b *Builder) SetUpdatedAt(updatedAt time.Time) *Builder {
+	b.updatedAt = updatedAt
+	return b
+}
+
+// Build validates and constructs the final saga Model
+func (b *Builder) Build() (Model, error) {
+	if b.id == uuid.Nil {
+		return Model{}, errors.New("saga id is required")
+	}
+	if b.tenantId == uuid.Nil {
+		return Model{}, errors.New("tenant id is required")
+	}
+	if b.sagaType == "" {
+		return Model{}, errors.New("saga type is required")
+	}
+	if b.ceremonyId == 0 {
+		return Model{}, errors.New("ceremony id is required")
+	}
+	if len(b.steps) == 0 {
+		return Model{}, errors.New("saga requires at least one step")
+	}
+
+	inProgress := 0
+	for _, s := range b.steps {
+		if s.status == StepStatusInProgress {
+			inProgress++
+		}
+	}
+	if inProgress > 1 {
+		return Model{}, errors.New("saga can have only one step in progress")
+	}
+	if inProgress == 1 && (b.status != StatusRunning || b.stepDeadline == nil) {
+		return Model{}, errors.New("saga step in progress requires a running saga with a step deadline")
+	}
+	if inProgress == 0 && b.stepDeadline != nil {
+		return Model{}, errors.New("saga step deadline requires a step in progress")
+	}
+
+	steps := make([]Step, len(b.steps))
+	copy(steps, b.steps)
+
+	return Model{
+		id:            b.id,
+		tenantId:      b.tenantId,
+		sagaType:      b.sagaType,
+		ceremonyId:    b.ceremonyId,
+		marriageId:    b.marriageId,
+		characterId1:  b.characterId1,
+		characterId2:  b.characterId2,
+		fee:           b.fee,
+		status:        b.status,
+		steps:         steps,
+		stepDeadline:  b.stepDeadline,
+		failureReason: b.failureReason,
+		createdAt:     b.createdAt,
+		updatedAt:     b.updatedAt,
+	}, nil
+}
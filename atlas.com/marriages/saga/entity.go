@@ -0,0 +1,110 @@
+package saga
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of a saga
+type Entity struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantId      uuid.UUID  `gorm:"type:uuid;index;not null"`
+	Type          Type       `gorm:"not null"`
+	CeremonyId    uint32     `gorm:"index;not null"`
+	MarriageId    uint32     `gorm:"not null"`
+	CharacterId1  uint32     `gorm:"not null"`
+	CharacterId2  uint32     `gorm:"not null"`
+	Fee           uint32     `gorm:"not null;default:0"`
+	Status        Status     `gorm:"index;not null"`
+	Steps         string     `gorm:"type:text;not null"` // JSON array of steps in execution order
+	StepDeadline  *time.Time `gorm:"index"`
+	FailureReason string     `gorm:"type:text"`
+	CreatedAt     time.Time  `gorm:"not null"`
+	UpdatedAt     time.Time  `gorm:"not null"`
+}
+
+// TableName returns the table name for the saga entity
+func (Entity) TableName() string {
+	return "marriage_sagas"
+}
+
+// Migration performs the database migration for the saga entity
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// stepEntity represents the stored form of a saga step
+type stepEntity struct {
+	Action        Action     `json:"action"`
+	Status        StepStatus `json:"status"`
+	StartedAt     *time.Time `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+	FailureReason string     `json:"failureReason,omitempty"`
+}
+
+// Make transforms a saga entity to a domain model
+func Make(entity Entity) (Model, error) {
+	var ses []stepEntity
+	if err := json.Unmarshal([]byte(entity.Steps), &ses); err != nil {
+		return Model{}, err
+	}
+	steps := make([]Step, 0, len(ses))
+	for _, se := range ses {
+		steps = append(steps, Step{
+			action:        se.Action,
+			status:        se.Status,
+			startedAt:     se.StartedAt,
+			finishedAt:    se.FinishedAt,
+			failureReason: se.FailureReason,
+		})
+	}
+
+	return NewCeremonyBuilder(entity.ID, entity.TenantId, entity.CeremonyId, entity.MarriageId, entity.CharacterId1, entity.CharacterId2).
+		SetType(entity.Type).
+		SetFee(entity.Fee).
+		SetStatus(entity.Status).
+		SetSteps(steps).
+		SetStepDeadline(entity.StepDeadline).
+		SetFailureReason(entity.FailureReason).
+		SetCreatedAt(entity.CreatedAt).
+		SetUpdatedAt(entity.UpdatedAt).
+		Build()
+}
+
+// ToEntity converts a saga domain model to a database entity
+func (m Model) ToEntity() (Entity, error) {
+	ses := make([]stepEntity, 0, len(m.steps))
+	for _, s := range m.steps {
+		ses = append(ses, stepEntity{
+			Action:        s.action,
+			Status:        s.status,
+			StartedAt:     s.startedAt,
+			FinishedAt:    s.finishedAt,
+			FailureReason: s.failureReason,
+		})
+	}
+	steps, err := json.Marshal(ses)
+	if err != nil {
+		return Entity{}, err
+	}
+
+	return Entity{
+		ID:            m.id,
+		TenantId:      m.tenantId,
+		Type:          m.sagaType,
+		CeremonyId:    m.ceremonyId,
+		MarriageId:    m.marriageId,
+		CharacterId1:  m.characterId1,
+		CharacterId2:  m.characterId2,
+		Fee:           m.fee,
+		Status:        m.status,
+		Steps:         string(steps),
+		StepDeadline:  m.stepDeadline,
+		FailureReason: m.failureReason,
+		CreatedAt:     m.createdAt,
+		UpdatedAt:     m.updatedAt,
+	}, nil
+}
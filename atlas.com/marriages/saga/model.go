@@ -0,0 +1,460 @@
+package saga
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies the workflow a saga orchestrates
+type Type string
+
+const (
+	// TypeCeremony orchestrates the services involved in scheduling and running a ceremony
+	TypeCeremony Type = "CEREMONY"
+)
+
+// Status represents the current state of a saga
+type Status uint8
+
+const (
+	// StatusRunning represents a saga waiting for a participant to complete its current step
+	StatusRunning Status = iota
+	// StatusAwaiting represents a saga whose next step waits for the ceremony to start or be rescheduled
+	StatusAwaiting
+	// StatusCompleted represents a saga whose steps all completed or were no longer needed
+	StatusCompleted
+	// StatusCompensated represents a saga which failed or was aborted and whose completed steps were rolled back
+	StatusCompensated
+)
+
+// String returns the string representation of Status
+func (s Status) String() string {
+	switch s {
+	case StatusRunning:
+		return "running"
+	case StatusAwaiting:
+		return "awaiting"
+	case StatusCompleted:
+		return "completed"
+	case StatusCompensated:
+		return "compensated"
+	default:
+		return "unknown"
+	}
+}
+
+// IsFinished returns true if the saga has reached a terminal state
+func (s Status) IsFinished() bool {
+	return s == StatusCompleted || s == StatusCompensated
+}
+
+// Action identifies the work performed by a saga step
+type Action string
+
+const (
+	// ActionReserveChapel reserves the chapel map for the ceremony
+	ActionReserveChapel Action = "RESERVE_CHAPEL"
+	// ActionChargeFee records the ceremony fee debited from the first partner when the ceremony was scheduled
+	ActionChargeFee Action = "CHARGE_FEE"
+	// ActionWarpGuests warps the couple and their invitees to the chapel once the ceremony starts
+	ActionWarpGuests Action = "WARP_GUESTS"
+)
+
+// AwaitsCeremonyStart returns true if the action may only run once the ceremony has started
+func (a Action) AwaitsCeremonyStart() bool {
+	return a == ActionWarpGuests
+}
+
+// IsCompensable returns true if a completed step for the action must be rolled back when the saga fails
+func (a Action) IsCompensable() bool {
+	return a == ActionReserveChapel || a == ActionChargeFee
+}
+
+// StepStatus represents the current state of a saga step
+type StepStatus uint8
+
+const (
+	// StepStatusPending represents a step which has not started
+	StepStatusPending StepStatus = iota
+	// StepStatusInProgress represents a step awaiting completion by a participant
+	StepStatusInProgress
+	// StepStatusCompleted represents a step completed by its participant
+	StepStatusCompleted
+	// StepStatusFailed represents a step which failed or timed out
+	StepStatusFailed
+	// StepStatusCompensated represents a completed step which was rolled back
+	StepStatusCompensated
+	// StepStatusSkipped represents a step which was not needed
+	StepStatusSkipped
+)
+
+// String returns the string representation of StepStatus
+func (s StepStatus) String() string {
+	switch s {
+	case StepStatusPending:
+		return "pending"
+	case StepStatusInProgress:
+		return "in_progress"
+	case StepStatusCompleted:
+		return "completed"
+	case StepStatusFailed:
+		return "failed"
+	case StepStatusCompensated:
+		return "compensated"
+	case StepStatusSkipped:
+		return "skipped"
+	default:
+		return "unknown"
+	}
+}
+
+// Step represents an immutable step of a saga
+type Step struct {
+	action        Action
+	status        StepStatus
+	startedAt     *time.Time
+	finishedAt    *time.Time
+	failureReason string
+}
+
+// NewStep creates a pending step for the given action
+func NewStep(action Action) Step {
+	return Step{action: action, status: StepStatusPending}
+}
+
+// Action returns the work performed by the step
+func (s Step) Action() Action {
+	return s.action
+}
+
+// Status returns the step status
+func (s Step) Status() StepStatus {
+	return s.status
+}
+
+// StartedAt returns when the step was started
+func (s Step) StartedAt() *time.Time {
+	return s.startedAt
+}
+
+// FinishedAt returns when the step completed, failed, was compensated or skipped
+func (s Step) FinishedAt() *time.Time {
+	return s.finishedAt
+}
+
+// FailureReason returns why the step failed
+func (s Step) FailureReason() string {
+	return s.failureReason
+}
+
+// CeremonySteps returns the ordered steps of a ceremony saga. The fee is debited before the ceremony is scheduled, so
+// its step starts completed, to be refunded if the saga is rolled back, or skipped when the ceremony is free
+func CeremonySteps(fee uint32) []Step {
+	chargeFee := NewStep(ActionChargeFee)
+	chargeFee.status = StepStatusCompleted
+	if fee == 0 {
+		chargeFee.status = StepStatusSkipped
+	}
+	return []Step{
+		chargeFee,
+		NewStep(ActionReserveChapel),
+		NewStep(ActionWarpGuests),
+	}
+}
+
+// Model represents an immutable saga orchestrating the steps of a ceremony across services
+type Model struct {
+	id            uuid.UUID
+	tenantId      uuid.UUID
+	sagaType      Type
+	ceremonyId    uint32
+	marriageId    uint32
+	characterId1  uint32
+	characterId2  uint32
+	fee           uint32
+	status        Status
+	steps         []Step
+	stepDeadline  *time.Time
+	failureReason string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// Id returns the saga id
+func (m Model) Id() uuid.UUID {
+	return m.id
+}
+
+// TenantId returns the tenant id
+func (m Model) TenantId() uuid.UUID {
+	return m.tenantId
+}
+
+// Type returns the workflow the saga orchestrates
+func (m Model) Type() Type {
+	return m.sagaType
+}
+
+// CeremonyId returns the ceremony the saga orchestrates
+func (m Model) CeremonyId() uint32 {
+	return m.ceremonyId
+}
+
+// MarriageId returns the marriage of the ceremony
+func (m Model) MarriageId() uint32 {
+	return m.marriageId
+}
+
+// CharacterId1 returns the first partner
+func (m Model) CharacterId1() uint32 {
+	return m.characterId1
+}
+
+// CharacterId2 returns the second partner
+func (m Model) CharacterId2() uint32 {
+	return m.characterId2
+}
+
+// Fee returns the ceremony fee charged by the saga
+func (m Model) Fee() uint32 {
+	return m.fee
+}
+
+// Status returns the saga status
+func (m Model) Status() Status {
+	return m.status
+}
+
+// Steps returns a copy of the saga steps in execution order
+func (m Model) Steps() []Step {
+	steps := make([]Step, len(m.steps))
+	copy(steps, m.steps)
+	return steps
+}
+
+// StepDeadline returns when the step in progress times out
+func (m Model) StepDeadline() *time.Time {
+	return m.stepDeadline
+}
+
+// FailureReason returns why the saga was compensated
+func (m Model) FailureReason() string {
+	return m.failureReason
+}
+
+// CreatedAt returns the creation timestamp
+func (m Model) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// UpdatedAt returns the last update timestamp
+func (m Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// CurrentStep returns the step in progress
+func (m Model) CurrentStep() (Step, bool) {
+	for _, s := range m.steps {
+		if s.status == StepStatusInProgress {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// IsTimedOut returns true if the step in progress has passed its deadline
+func (m Model) IsTimedOut(now time.Time) bool {
+	return m.status == StatusRunning && m.stepDeadline != nil && now.After(*m.stepDeadline)
+}
+
+// Next starts the first pending step, which must complete before the timeout. A step awaiting the ceremony start
+// leaves the saga awaiting until the ceremony has started, and a saga without pending steps is completed. It returns
+// the started step, if any
+func (m Model) Next(ceremonyStarted bool, timeout time.Duration, now time.Time) (Model, *Step, error) {
+	if m.status != StatusRunning && m.status != StatusAwaiting {
+		return Model{}, nil, fmt.Errorf("saga cannot advance from status %s", m.status)
+	}
+	if _, ok := m.CurrentStep(); ok {
+		return Model{}, nil, errors.New("saga already has a step in progress")
+	}
+
+	steps := m.Steps()
+	for i, s := range steps {
+		if s.status != StepStatusPending {
+			continue
+		}
+		if s.action.AwaitsCeremonyStart() && !ceremonyStarted {
+			next, err := m.Builder().SetStatus(StatusAwaiting).SetStepDeadline(nil).SetUpdatedAt(now).Build()
+			return next, nil, err
+		}
+
+		startedAt := now
+		deadline := now.Add(timeout)
+		s.status = StepStatusInProgress
+		s.startedAt = &startedAt
+		steps[i] = s
+		next, err := m.Builder().SetStatus(StatusRunning).SetSteps(steps).SetStepDeadline(&deadline).SetUpdatedAt(now).Build()
+		if err != nil {
+			return Model{}, nil, err
+		}
+		return next, &s, nil
+	}
+
+	next, err := m.Builder().SetStatus(StatusCompleted).SetStepDeadline(nil).SetUpdatedAt(now).Build()
+	return next, nil, err
+}
+
+// CompleteStep records the completion of the step in progress for the given action
+func (m Model) CompleteStep(action Action, now time.Time) (Model, error) {
+	steps, err := m.finishCurrentStep(action, StepStatusCompleted, "", now)
+	if err != nil {
+		return Model{}, err
+	}
+	return m.Builder().SetSteps(steps).SetStepDeadline(nil).SetUpdatedAt(now).Build()
+}
+
+// Fail records the failure of the step in progress for the given action and compensates the saga. It returns the
+// completed steps to roll back, most recent first
+func (m Model) Fail(action Action, reason string, now time.Time) (Model, []Step, error) {
+	steps, err := m.finishCurrentStep(action, StepStatusFailed, reason, now)
+	if err != nil {
+		return Model{}, nil, err
+	}
+	return m.compensate(steps, fmt.Sprintf("%s: %s", action, reason), now)
+}
+
+// Abort compensates a saga which is no longer wanted, such as for a cancelled ceremony. The step in progress is
+// failed, as its outcome is no longer awaited. It returns the completed steps to roll back, most recent first
+func (m Model) Abort(reason string, now time.Time) (Model, []Step, error) {
+	if m.status.IsFinished() {
+		return Model{}, nil, fmt.Errorf("saga cannot be aborted from status %s", m.status)
+	}
+	steps := m.Steps()
+	if current, ok := m.CurrentStep(); ok {
+		var err error
+		steps, err = m.finishCurrentStep(current.action, StepStatusFailed, reason, now)
+		if err != nil {
+			return Model{}, nil, err
+		}
+	}
+	return m.compensate(steps, reason, now)
+}
+
+// Rewind returns a saga whose ceremony was postponed or rescheduled to await the ceremony at its new time. The steps
+// completed or in progress, other than the fee paid for the ceremony, return to pending so they run again. It returns
+// the steps whose work must be released, most recent first
+func (m Model) Rewind(now time.Time) (Model, []Step, error) {
+	if m.status.IsFinished() {
+		return Model{}, nil, fmt.Errorf("saga cannot be rewound from status %s", m.status)
+	}
+	steps := m.Steps()
+	var release []Step
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if s.action == ActionChargeFee || (s.status != StepStatusCompleted && s.status != StepStatusInProgress) {
+			continue
+		}
+		// A reservation still in progress is released too, as the participant handles the release after it
+		if s.action.IsCompensable() {
+			release = append(release, s)
+		}
+		steps[i] = NewStep(s.action)
+	}
+	next, err := m.Builder().SetStatus(StatusAwaiting).SetSteps(steps).SetStepDeadline(nil).SetUpdatedAt(now).Build()
+	if err != nil {
+		return Model{}, nil, err
+	}
+	return next, release, nil
+}
+
+// Finish completes a saga whose ceremony has completed. Steps which have not run are skipped
+func (m Model) Finish(now time.Time) (Model, error) {
+	if m.status.IsFinished() {
+		return Model{}, fmt.Errorf("saga cannot be finished from status %s", m.status)
+	}
+	steps := m.Steps()
+	for i, s := range steps {
+		if s.status == StepStatusPending || s.status == StepStatusInProgress {
+			finishedAt := now
+			s.status = StepStatusSkipped
+			s.finishedAt = &finishedAt
+			steps[i] = s
+		}
+	}
+	return m.Builder().SetStatus(StatusCompleted).SetSteps(steps).SetStepDeadline(nil).SetUpdatedAt(now).Build()
+}
+
+// finishCurrentStep returns the steps with the step in progress for the given action moved to the given status
+func (m Model) finishCurrentStep(action Action, status StepStatus, reason string, now time.Time) ([]Step, error) {
+	if m.status != StatusRunning {
+		return nil, fmt.Errorf("saga step cannot finish from status %s", m.status)
+	}
+	steps := m.Steps()
+	for i, s := range steps {
+		if s.action != action {
+			continue
+		}
+		if s.status != StepStatusInProgress {
+			return nil, fmt.Errorf("saga step %s is %s, not in progress", action, s.status)
+		}
+		finishedAt := now
+		s.status = status
+		s.finishedAt = &finishedAt
+		s.failureReason = reason
+		steps[i] = s
+		return steps, nil
+	}
+	return nil, fmt.Errorf("saga has no step %s", action)
+}
+
+// compensate marks the completed compensable steps as compensated and the saga as compensated, returning the steps
+// to roll back, most recent first
+func (m Model) compensate(steps []Step, reason string, now time.Time) (Model, []Step, error) {
+	var rollback []Step
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if s.status != StepStatusCompleted || !s.action.IsCompensable() {
+			continue
+		}
+		finishedAt := now
+		s.status = StepStatusCompensated
+		s.finishedAt = &finishedAt
+		steps[i] = s
+		rollback = append(rollback, s)
+	}
+
+	next, err := m.Builder().
+		SetStatus(StatusCompensated).
+		SetSteps(steps).
+		SetStepDeadline(nil).
+		SetFailureReason(reason).
+		SetUpdatedAt(now).
+		Build()
+	if err != nil {
+		return Model{}, nil, err
+	}
+	return next, rollback, nil
+}
+
+// Builder creates a builder initialized with the saga's values
+func (m Model) Builder() *Builder {
+	return &Builder{
+		id:            m.id,
+		tenantId:      m.tenantId,
+		sagaType:      m.sagaType,
+		ceremonyId:    m.ceremonyId,
+		marriageId:    m.marriageId,
+		characterId1:  m.characterId1,
+		characterId2:  m.characterId2,
+		fee:           m.fee,
+		status:        m.status,
+		steps:         m.Steps(),
+		stepDeadline:  m.stepDeadline,
+		failureReason: m.failureReason,
+		createdAt:     m.createdAt,
+		updatedAt:     m.updatedAt,
+	}
+}
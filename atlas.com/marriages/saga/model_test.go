@@ -0,0 +1,258 @@
+package saga
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSaga(t *testing.T, fee uint32) Model {
+	m, err := NewCeremonyBuilder(uuid.New(), uuid.New(), 1, 2, 100, 101).SetFee(fee).Build()
+	require.NoError(t, err)
+	return m
+}
+
+func actions(steps []Step) []Action {
+	result := make([]Action, 0, len(steps))
+	for _, s := range steps {
+		result = append(result, s.Action())
+	}
+	return result
+}
+
+func TestCeremonySteps(t *testing.T) {
+	steps := CeremonySteps(500)
+	assert.Equal(t, []Action{ActionChargeFee, ActionReserveChapel, ActionWarpGuests}, actions(steps))
+	assert.Equal(t, StepStatusCompleted, steps[0].Status(), "the fee is debited before the saga starts")
+	for _, s := range steps[1:] {
+		assert.Equal(t, StepStatusPending, s.Status())
+	}
+
+	steps = CeremonySteps(0)
+	assert.Equal(t, StepStatusSkipped, steps[0].Status(), "the fee step is skipped when the ceremony is free")
+}
+
+func TestModel_Next(t *testing.T) {
+	now := time.Now()
+	m := newTestSaga(t, 500)
+	assert.Equal(t, StatusRunning, m.Status())
+
+	m, step, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	require.NotNil(t, step)
+	assert.Equal(t, ActionReserveChapel, step.Action())
+	assert.Equal(t, StepStatusInProgress, step.Status())
+	require.NotNil(t, m.StepDeadline())
+	assert.Equal(t, now.Add(time.Minute), *m.StepDeadline())
+
+	_, _, err = m.Next(false, time.Minute, now)
+	assert.Error(t, err, "a saga cannot start a step while another is in progress")
+
+	m, err = m.CompleteStep(ActionReserveChapel, now)
+	require.NoError(t, err)
+	assert.Nil(t, m.StepDeadline())
+
+	// Guests are warped once the ceremony starts
+	m, step, err = m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	assert.Nil(t, step)
+	assert.Equal(t, StatusAwaiting, m.Status())
+
+	m, step, err = m.Next(true, time.Minute, now)
+	require.NoError(t, err)
+	require.NotNil(t, step)
+	assert.Equal(t, ActionWarpGuests, step.Action())
+	assert.Equal(t, StatusRunning, m.Status())
+
+	m, err = m.CompleteStep(ActionWarpGuests, now)
+	require.NoError(t, err)
+
+	m, step, err = m.Next(true, time.Minute, now)
+	require.NoError(t, err)
+	assert.Nil(t, step)
+	assert.Equal(t, StatusCompleted, m.Status())
+
+	_, _, err = m.Next(true, time.Minute, now)
+	assert.Error(t, err, "a completed saga cannot advance")
+}
+
+func TestModel_CompleteStep_NotInProgress(t *testing.T) {
+	now := time.Now()
+	m, _, err := newTestSaga(t, 500).Next(false, time.Minute, now)
+	require.NoError(t, err)
+
+	_, err = m.CompleteStep(ActionChargeFee, now)
+	assert.Error(t, err)
+}
+
+func TestModel_IsTimedOut(t *testing.T) {
+	now := time.Now()
+	m := newTestSaga(t, 0)
+	assert.False(t, m.IsTimedOut(now.Add(time.Hour)), "a saga without a step in progress cannot time out")
+
+	m, _, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	assert.False(t, m.IsTimedOut(now.Add(30*time.Second)))
+	assert.True(t, m.IsTimedOut(now.Add(2*time.Minute)))
+}
+
+func TestModel_Fail(t *testing.T) {
+	now := time.Now()
+	m := newTestSaga(t, 500)
+	m, _, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	m, err = m.CompleteStep(ActionReserveChapel, now)
+	require.NoError(t, err)
+	m, _, err = m.Next(true, time.Minute, now)
+	require.NoError(t, err)
+
+	m, rollback, err := m.Fail(ActionWarpGuests, "map unavailable", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompensated, m.Status())
+	assert.Equal(t, "WARP_GUESTS: map unavailable", m.FailureReason())
+	assert.Equal(t, []Action{ActionReserveChapel, ActionChargeFee}, actions(rollback), "completed steps are rolled back most recent first")
+
+	steps := m.Steps()
+	assert.Equal(t, StepStatusCompensated, steps[0].Status())
+	assert.Equal(t, StepStatusCompensated, steps[1].Status())
+	assert.Equal(t, StepStatusFailed, steps[2].Status())
+	assert.Equal(t, "map unavailable", steps[2].FailureReason())
+	assert.Nil(t, m.StepDeadline())
+}
+
+func TestModel_Fail_FirstStep(t *testing.T) {
+	now := time.Now()
+	m, _, err := newTestSaga(t, 500).Next(false, time.Minute, now)
+	require.NoError(t, err)
+
+	m, rollback, err := m.Fail(ActionReserveChapel, "chapel booked", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompensated, m.Status())
+	assert.Equal(t, []Action{ActionChargeFee}, actions(rollback), "the fee paid before the saga started is refunded")
+}
+
+func TestModel_Abort(t *testing.T) {
+	now := time.Now()
+	m := newTestSaga(t, 0)
+	m, _, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	m, err = m.CompleteStep(ActionReserveChapel, now)
+	require.NoError(t, err)
+	m, _, err = m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	require.Equal(t, StatusAwaiting, m.Status())
+
+	m, rollback, err := m.Abort("ceremony_cancelled", now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompensated, m.Status())
+	assert.Equal(t, "ceremony_cancelled", m.FailureReason())
+	assert.Equal(t, []Action{ActionReserveChapel}, actions(rollback))
+
+	_, _, err = m.Abort("ceremony_cancelled", now)
+	assert.Error(t, err, "a compensated saga cannot be aborted again")
+}
+
+func TestModel_Rewind(t *testing.T) {
+	now := time.Now()
+	m := newTestSaga(t, 500)
+	m, _, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	m, err = m.CompleteStep(ActionReserveChapel, now)
+	require.NoError(t, err)
+	m, _, err = m.Next(true, time.Minute, now)
+	require.NoError(t, err)
+	require.Equal(t, StatusRunning, m.Status())
+
+	m, release, err := m.Rewind(now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusAwaiting, m.Status())
+	assert.Nil(t, m.StepDeadline())
+	assert.Equal(t, []Action{ActionReserveChapel}, actions(release))
+	steps := m.Steps()
+	assert.Equal(t, StepStatusCompleted, steps[0].Status(), "the fee stays paid")
+	for _, s := range steps[1:] {
+		assert.Equal(t, StepStatusPending, s.Status())
+	}
+
+	// A rewound saga reserves the chapel again
+	m, step, err := m.Next(false, time.Minute, now)
+	require.NoError(t, err)
+	require.NotNil(t, step)
+	assert.Equal(t, ActionReserveChapel, step.Action())
+
+	m, release, err = m.Rewind(now)
+	require.NoError(t, err)
+	assert.Equal(t, []Action{ActionReserveChapel}, actions(release), "a reservation in progress is released")
+
+	_, release, err = m.Rewind(now)
+	require.NoError(t, err)
+	assert.Empty(t, release, "a rewound saga has nothing to release")
+
+	m, err = m.Finish(now)
+	require.NoError(t, err)
+	_, _, err = m.Rewind(now)
+	assert.Error(t, err, "a finished saga cannot be rewound")
+}
+
+func TestModel_Finish(t *testing.T) {
+	now := time.Now()
+	m, _, err := newTestSaga(t, 500).Next(false, time.Minute, now)
+	require.NoError(t, err)
+
+	m, err = m.Finish(now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, m.Status())
+	assert.Nil(t, m.StepDeadline())
+	steps := m.Steps()
+	assert.Equal(t, StepStatusCompleted, steps[0].Status())
+	for _, s := range steps[1:] {
+		assert.Equal(t, StepStatusSkipped, s.Status())
+	}
+
+	_, err = m.Finish(now)
+	assert.Error(t, err)
+}
+
+func TestBuilder_Validation(t *testing.T) {
+	deadline := time.Now()
+	inProgress := Step{action: ActionReserveChapel, status: StepStatusInProgress}
+
+	_, err := NewCeremonyBuilder(uuid.Nil, uuid.New(), 1, 2, 100, 101).Build()
+	assert.Error(t, err)
+
+	_, err = NewCeremonyBuilder(uuid.New(), uuid.New(), 0, 2, 100, 101).Build()
+	assert.Error(t, err)
+
+	_, err = NewCeremonyBuilder(uuid.New(), uuid.New(), 1, 2, 100, 101).SetSteps([]Step{inProgress, inProgress}).SetStepDeadline(&deadline).Build()
+	assert.Error(t, err, "only one step can be in progress")
+
+	_, err = NewCeremonyBuilder(uuid.New(), uuid.New(), 1, 2, 100, 101).SetSteps([]Step{inProgress}).Build()
+	assert.Error(t, err, "a step in progress requires a deadline")
+
+	_, err = NewCeremonyBuilder(uuid.New(), uuid.New(), 1, 2, 100, 101).SetStepDeadline(&deadline).Build()
+	assert.Error(t, err, "a deadline requires a step in progress")
+}
+
+func TestEntity_RoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	m, _, err := newTestSaga(t, 500).Next(false, time.Minute, now)
+	require.NoError(t, err)
+
+	e, err := m.ToEntity()
+	require.NoError(t, err)
+	restored, err := Make(e)
+	require.NoError(t, err)
+
+	assert.Equal(t, m.Id(), restored.Id())
+	assert.Equal(t, m.Fee(), restored.Fee())
+	assert.Equal(t, m.Status(), restored.Status())
+	assert.Equal(t, actions(m.Steps()), actions(restored.Steps()))
+	current, ok := restored.CurrentStep()
+	require.True(t, ok)
+	assert.Equal(t, ActionReserveChapel, current.Action())
+	require.NotNil(t, current.StartedAt())
+	assert.True(t, now.Equal(*current.StartedAt()))
+}
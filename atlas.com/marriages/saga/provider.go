@@ -0,0 +1,108 @@
+package saga
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetByIdProvider retrieves a tenant's saga by id, returning nil when it does not exist
+func GetByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(sagaId uuid.UUID, tenantId uuid.UUID) model.Provider[*Model] {
+	return func(sagaId uuid.UUID, tenantId uuid.UUID) model.Provider[*Model] {
+		return func() (*Model, error) {
+			log.WithFields(logrus.Fields{
+				"sagaId":   sagaId,
+				"tenantId": tenantId,
+			}).Debug("Retrieving saga by ID")
+
+			var entity Entity
+			err := db.Where("id = ? AND tenant_id = ?", sagaId, tenantId).First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			m, err := Make(entity)
+			if err != nil {
+				return nil, err
+			}
+			return &m, nil
+		}
+	}
+}
+
+// GetUnfinishedByCeremonyIdProvider retrieves the running or awaiting saga of a ceremony, returning nil when the
+// ceremony has none
+func GetUnfinishedByCeremonyIdProvider(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+	return func(ceremonyId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+		return func() (*Model, error) {
+			log.WithFields(logrus.Fields{
+				"ceremonyId": ceremonyId,
+				"tenantId":   tenantId,
+			}).Debug("Retrieving unfinished saga for ceremony")
+
+			var entities []Entity
+			err := db.Where("ceremony_id = ? AND tenant_id = ? AND status IN ?", ceremonyId, tenantId, []Status{StatusRunning, StatusAwaiting}).
+				Order("created_at DESC").
+				Limit(1).
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+			if len(entities) == 0 {
+				return nil, nil
+			}
+
+			m, err := Make(entities[0])
+			if err != nil {
+				return nil, err
+			}
+			return &m, nil
+		}
+	}
+}
+
+// GetTimedOutProvider retrieves a tenant's running sagas whose step in progress passed its deadline
+func GetTimedOutProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, now time.Time) model.Provider[[]Model] {
+	return func(tenantId uuid.UUID, now time.Time) model.Provider[[]Model] {
+		return func() ([]Model, error) {
+			log.WithField("tenantId", tenantId).Debug("Retrieving timed out sagas")
+
+			var entities []Entity
+			err := db.Where("tenant_id = ? AND status = ? AND step_deadline < ?", tenantId, StatusRunning, now).
+				Order("step_deadline ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]Model, 0, len(entities))
+			for _, entity := range entities {
+				m, err := Make(entity)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, m)
+			}
+			return results, nil
+		}
+	}
+}
+
+// GetTenantsWithTimedOutSagas retrieves the tenants with a running saga whose step in progress passed its deadline
+func GetTenantsWithTimedOutSagas(db *gorm.DB) func(now time.Time) ([]uuid.UUID, error) {
+	return func(now time.Time) ([]uuid.UUID, error) {
+		var tenantIds []uuid.UUID
+		err := db.Model(&Entity{}).
+			Where("status = ? AND step_deadline < ?", StatusRunning, now).
+			Distinct("tenant_id").
+			Pluck("tenant_id", &tenantIds).Error
+		return tenantIds, err
+	}
+}
@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/retry"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// AnniversaryScheduler handles periodic detection of marriages reaching anniversary milestones
+type AnniversaryScheduler struct {
+	log      logrus.FieldLogger
+	ctx      context.Context
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewAnniversaryScheduler creates a new anniversary scheduler
+func NewAnniversaryScheduler(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *AnniversaryScheduler {
+	return &AnniversaryScheduler{
+		log:      log.WithField("component", "anniversary-scheduler"),
+		ctx:      ctx,
+		db:       db,
+		interval: 1 * time.Hour, // Milestones are counted in days, so hourly checks suffice
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WithInterval sets the check interval
+func (s *AnniversaryScheduler) WithInterval(interval time.Duration) *AnniversaryScheduler {
+	s.interval = interval
+	return s
+}
+
+// Start begins the background anniversary checking
+func (s *AnniversaryScheduler) Start() {
+	s.log.WithField("interval", s.interval).Info("Starting anniversary scheduler")
+
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler
+func (s *AnniversaryScheduler) Stop() {
+	s.log.Info("Stopping anniversary scheduler")
+	close(s.stop)
+	<-s.done
+	s.log.Info("Anniversary scheduler stopped")
+}
+
+// run is the main loop for the scheduler
+func (s *AnniversaryScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Process immediately on start
+	s.processAnniversaries()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processAnniversaries()
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			s.log.Info("Context cancelled, stopping anniversary scheduler")
+			return
+		}
+	}
+}
+
+// processAnniversaries celebrates anniversary milestones for all tenants
+func (s *AnniversaryScheduler) processAnniversaries() {
+	s.log.Debug("Processing marriage anniversaries for all tenants")
+
+	tenantIds, err := s.getTenantsWithMarriages()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get tenants with marriages")
+		return
+	}
+
+	if len(tenantIds) == 0 {
+		s.log.Debug("No tenants with marriages found")
+		return
+	}
+
+	s.log.WithField("tenantCount", len(tenantIds)).Debug("Processing marriage anniversaries for tenants")
+
+	for _, tenantId := range tenantIds {
+		s.processAnniversariesForTenant(tenantId)
+	}
+}
+
+// getTenantsWithMarriages retrieves all tenant IDs that have an active marriage
+func (s *AnniversaryScheduler) getTenantsWithMarriages() ([]uuid.UUID, error) {
+	var tenantIds []uuid.UUID
+
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithField("operation", "get-tenants-with-marriages")).
+		WithContext(s.ctx).
+		WithMaxRetries(2).
+		WithInitialDelay(500 * time.Millisecond)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		return s.db.Model(&marriage.Entity{}).
+			Where("status IN (?)", []marriage.MarriageStatus{marriage.StatusMarried, marriage.StatusDivorcePending}).
+			Distinct("tenant_id").
+			Pluck("tenant_id", &tenantIds).Error
+	})
+
+	return tenantIds, err
+}
+
+// processAnniversariesForTenant celebrates anniversary milestones for a specific tenant
+func (s *AnniversaryScheduler) processAnniversariesForTenant(tenantId uuid.UUID) {
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithFields(logrus.Fields{
+			"operation": "process-anniversaries",
+			"tenantId":  tenantId,
+		})).
+		WithContext(s.ctx).
+		WithMaxRetries(3).
+		WithInitialDelay(1 * time.Second).
+		WithMaxDelay(10 * time.Second)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		tenantModel, err := tenant.Create(tenantId, "anniversary-scheduler", 1, 0)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"error":    err,
+			}).Error("Failed to create tenant model")
+			return err
+		}
+
+		tenantCtx := tenant.WithContext(s.ctx, tenantModel)
+		processor := marriage.NewProcessor(s.log, tenantCtx, s.db)
+		return processor.ProcessAnniversaries()
+	})
+
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tenantId": tenantId,
+			"error":    err,
+		}).Error("Failed to process marriage anniversaries for tenant after retries")
+		return
+	}
+
+	s.log.WithField("tenantId", tenantId).Debug("Successfully processed marriage anniversaries for tenant")
+}
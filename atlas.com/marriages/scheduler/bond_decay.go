@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/retry"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// BondDecayScheduler handles periodic decay of the bonds of couples who have not been awarded bond points
+type BondDecayScheduler struct {
+	log      logrus.FieldLogger
+	ctx      context.Context
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewBondDecayScheduler creates a new bond decay scheduler
+func NewBondDecayScheduler(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *BondDecayScheduler {
+	return &BondDecayScheduler{
+		log:      log.WithField("component", "bond-decay-scheduler"),
+		ctx:      ctx,
+		db:       db,
+		interval: 1 * time.Hour, // Decay carries partial intervals over, so checking more often than it occurs gains nothing
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WithInterval sets the check interval
+func (s *BondDecayScheduler) WithInterval(interval time.Duration) *BondDecayScheduler {
+	s.interval = interval
+	return s
+}
+
+// Start begins the background bond decay checking
+func (s *BondDecayScheduler) Start() {
+	s.log.WithField("interval", s.interval).Info("Starting bond decay scheduler")
+
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler
+func (s *BondDecayScheduler) Stop() {
+	s.log.Info("Stopping bond decay scheduler")
+	close(s.stop)
+	<-s.done
+	s.log.Info("Bond decay scheduler stopped")
+}
+
+// run is the main loop for the scheduler
+func (s *BondDecayScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Process immediately on start
+	s.processBondDecay()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processBondDecay()
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			s.log.Info("Context cancelled, stopping bond decay scheduler")
+			return
+		}
+	}
+}
+
+// processBondDecay decays the bonds of inactive couples for all tenants
+func (s *BondDecayScheduler) processBondDecay() {
+	s.log.Debug("Processing bond decay for all tenants")
+
+	tenantIds, err := s.getTenantsWithMarriages()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get tenants with marriages")
+		return
+	}
+
+	if len(tenantIds) == 0 {
+		s.log.Debug("No tenants with marriages found")
+		return
+	}
+
+	s.log.WithField("tenantCount", len(tenantIds)).Debug("Processing bond decay for tenants")
+
+	for _, tenantId := range tenantIds {
+		s.processBondDecayForTenant(tenantId)
+	}
+}
+
+// getTenantsWithMarriages retrieves all tenant IDs that have an active marriage holding bond points
+func (s *BondDecayScheduler) getTenantsWithMarriages() ([]uuid.UUID, error) {
+	var tenantIds []uuid.UUID
+
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithField("operation", "get-tenants-with-marriages")).
+		WithContext(s.ctx).
+		WithMaxRetries(2).
+		WithInitialDelay(500 * time.Millisecond)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		return s.db.Model(&marriage.Entity{}).
+			Where("status IN (?) AND bond_points > 0", []marriage.MarriageStatus{marriage.StatusMarried, marriage.StatusDivorcePending}).
+			Distinct("tenant_id").
+			Pluck("tenant_id", &tenantIds).Error
+	})
+
+	return tenantIds, err
+}
+
+// processBondDecayForTenant decays the bonds of inactive couples for a specific tenant
+func (s *BondDecayScheduler) processBondDecayForTenant(tenantId uuid.UUID) {
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithFields(logrus.Fields{
+			"operation": "process-bond-decay",
+			"tenantId":  tenantId,
+		})).
+		WithContext(s.ctx).
+		WithMaxRetries(3).
+		WithInitialDelay(1 * time.Second).
+		WithMaxDelay(10 * time.Second)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		tenantModel, err := tenant.Create(tenantId, "bond-decay-scheduler", 1, 0)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"error":    err,
+			}).Error("Failed to create tenant model")
+			return err
+		}
+
+		tenantCtx := tenant.WithContext(s.ctx, tenantModel)
+		processor := marriage.NewProcessor(s.log, tenantCtx, s.db)
+		return processor.ProcessBondDecay()
+	})
+
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tenantId": tenantId,
+			"error":    err,
+		}).Error("Failed to process bond decay for tenant after retries")
+		return
+	}
+
+	s.log.WithField("tenantId", tenantId).Debug("Successfully processed bond decay for tenant")
+}
@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/retry"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CeremonyStartScheduler handles periodic reminders, automatic starts and missed start handling of scheduled ceremonies
+type CeremonyStartScheduler struct {
+	log      logrus.FieldLogger
+	ctx      context.Context
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewCeremonyStartScheduler creates a new ceremony start scheduler
+func NewCeremonyStartScheduler(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *CeremonyStartScheduler {
+	return &CeremonyStartScheduler{
+		log:      log.WithField("component", "ceremony-start-scheduler"),
+		ctx:      ctx,
+		db:       db,
+		interval: 1 * time.Minute, // Check every minute so ceremonies start promptly
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WithInterval sets the check interval
+func (s *CeremonyStartScheduler) WithInterval(interval time.Duration) *CeremonyStartScheduler {
+	s.interval = interval
+	return s
+}
+
+// Start begins the background scheduled ceremony checking
+func (s *CeremonyStartScheduler) Start() {
+	s.log.WithField("interval", s.interval).Info("Starting ceremony start scheduler")
+
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler
+func (s *CeremonyStartScheduler) Stop() {
+	s.log.Info("Stopping ceremony start scheduler")
+	close(s.stop)
+	<-s.done
+	s.log.Info("Ceremony start scheduler stopped")
+}
+
+// run is the main loop for the scheduler
+func (s *CeremonyStartScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Process immediately on start
+	s.processScheduledCeremonies()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processScheduledCeremonies()
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			s.log.Info("Context cancelled, stopping ceremony start scheduler")
+			return
+		}
+	}
+}
+
+// processScheduledCeremonies processes scheduled ceremonies for all tenants
+func (s *CeremonyStartScheduler) processScheduledCeremonies() {
+	s.log.Debug("Processing scheduled ceremonies for all tenants")
+
+	tenantIds, err := s.getTenantsWithScheduledCeremonies()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get tenants with scheduled ceremonies")
+		return
+	}
+
+	if len(tenantIds) == 0 {
+		s.log.Debug("No tenants with scheduled ceremonies found")
+		return
+	}
+
+	s.log.WithField("tenantCount", len(tenantIds)).Debug("Processing scheduled ceremonies for tenants")
+
+	for _, tenantId := range tenantIds {
+		s.processScheduledCeremoniesForTenant(tenantId)
+	}
+}
+
+// getTenantsWithScheduledCeremonies retrieves all tenant IDs that have a scheduled ceremony
+func (s *CeremonyStartScheduler) getTenantsWithScheduledCeremonies() ([]uuid.UUID, error) {
+	var tenantIds []uuid.UUID
+
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithField("operation", "get-tenants-with-scheduled-ceremonies")).
+		WithContext(s.ctx).
+		WithMaxRetries(2).
+		WithInitialDelay(500 * time.Millisecond)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		return s.db.Model(&marriage.CeremonyEntity{}).
+			Where("status = ?", marriage.CeremonyStatusScheduled).
+			Distinct("tenant_id").
+			Pluck("tenant_id", &tenantIds).Error
+	})
+
+	return tenantIds, err
+}
+
+// processScheduledCeremoniesForTenant processes scheduled ceremonies for a specific tenant
+func (s *CeremonyStartScheduler) processScheduledCeremoniesForTenant(tenantId uuid.UUID) {
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithFields(logrus.Fields{
+			"operation": "process-scheduled-ceremonies",
+			"tenantId":  tenantId,
+		})).
+		WithContext(s.ctx).
+		WithMaxRetries(3).
+		WithInitialDelay(1 * time.Second).
+		WithMaxDelay(10 * time.Second)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		tenantModel, err := tenant.Create(tenantId, "ceremony-start-scheduler", 1, 0)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"error":    err,
+			}).Error("Failed to create tenant model")
+			return err
+		}
+
+		tenantCtx := tenant.WithContext(s.ctx, tenantModel)
+		processor := marriage.NewProcessor(s.log, tenantCtx, s.db)
+		return processor.ProcessCeremonySchedules()
+	})
+
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tenantId": tenantId,
+			"error":    err,
+		}).Error("Failed to process scheduled ceremonies for tenant after retries")
+		return
+	}
+
+	s.log.WithField("tenantId", tenantId).Debug("Successfully processed scheduled ceremonies for tenant")
+}
@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/retry"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DivorceFinalizationScheduler handles periodic finalization of filed divorces whose waiting period has passed
+type DivorceFinalizationScheduler struct {
+	log      logrus.FieldLogger
+	ctx      context.Context
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewDivorceFinalizationScheduler creates a new divorce finalization scheduler
+func NewDivorceFinalizationScheduler(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *DivorceFinalizationScheduler {
+	return &DivorceFinalizationScheduler{
+		log:      log.WithField("component", "divorce-finalization-scheduler"),
+		ctx:      ctx,
+		db:       db,
+		interval: 5 * time.Minute, // Waiting periods are long, so infrequent checks suffice
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WithInterval sets the check interval
+func (s *DivorceFinalizationScheduler) WithInterval(interval time.Duration) *DivorceFinalizationScheduler {
+	s.interval = interval
+	return s
+}
+
+// Start begins the background divorce finalization checking
+func (s *DivorceFinalizationScheduler) Start() {
+	s.log.WithField("interval", s.interval).Info("Starting divorce finalization scheduler")
+
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler
+func (s *DivorceFinalizationScheduler) Stop() {
+	s.log.Info("Stopping divorce finalization scheduler")
+	close(s.stop)
+	<-s.done
+	s.log.Info("Divorce finalization scheduler stopped")
+}
+
+// run is the main loop for the scheduler
+func (s *DivorceFinalizationScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Process immediately on start
+	s.processMaturedDivorces()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processMaturedDivorces()
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			s.log.Info("Context cancelled, stopping divorce finalization scheduler")
+			return
+		}
+	}
+}
+
+// processMaturedDivorces finalizes matured divorces for all tenants
+func (s *DivorceFinalizationScheduler) processMaturedDivorces() {
+	s.log.Debug("Processing matured divorces for all tenants")
+
+	tenantIds, err := s.getTenantsWithMaturedDivorces()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get tenants with matured divorces")
+		return
+	}
+
+	if len(tenantIds) == 0 {
+		s.log.Debug("No tenants with matured divorces found")
+		return
+	}
+
+	s.log.WithField("tenantCount", len(tenantIds)).Debug("Processing matured divorces for tenants")
+
+	for _, tenantId := range tenantIds {
+		s.processMaturedDivorcesForTenant(tenantId)
+	}
+}
+
+// getTenantsWithMaturedDivorces retrieves all tenant IDs that have a pending divorce past its waiting period
+func (s *DivorceFinalizationScheduler) getTenantsWithMaturedDivorces() ([]uuid.UUID, error) {
+	var tenantIds []uuid.UUID
+
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithField("operation", "get-tenants-with-matured-divorces")).
+		WithContext(s.ctx).
+		WithMaxRetries(2).
+		WithInitialDelay(500 * time.Millisecond)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		return s.db.Model(&marriage.Entity{}).
+			Where("status = ? AND divorce_matures_at <= ?", marriage.StatusDivorcePending, time.Now()).
+			Distinct("tenant_id").
+			Pluck("tenant_id", &tenantIds).Error
+	})
+
+	return tenantIds, err
+}
+
+// processMaturedDivorcesForTenant finalizes matured divorces for a specific tenant
+func (s *DivorceFinalizationScheduler) processMaturedDivorcesForTenant(tenantId uuid.UUID) {
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithFields(logrus.Fields{
+			"operation": "process-matured-divorces",
+			"tenantId":  tenantId,
+		})).
+		WithContext(s.ctx).
+		WithMaxRetries(3).
+		WithInitialDelay(1 * time.Second).
+		WithMaxDelay(10 * time.Second)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		tenantModel, err := tenant.Create(tenantId, "divorce-finalization-scheduler", 1, 0)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"error":    err,
+			}).Error("Failed to create tenant model")
+			return err
+		}
+
+		tenantCtx := tenant.WithContext(s.ctx, tenantModel)
+		processor := marriage.NewProcessor(s.log, tenantCtx, s.db)
+		return processor.ProcessMaturedDivorces()
+	})
+
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tenantId": tenantId,
+			"error":    err,
+		}).Error("Failed to process matured divorces for tenant after retries")
+		return
+	}
+
+	s.log.WithField("tenantId", tenantId).Debug("Successfully processed matured divorces for tenant")
+}
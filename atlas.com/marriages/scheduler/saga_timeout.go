@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/retry"
+	"atlas-marriages/saga"
+	"github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// SagaTimeoutScheduler handles periodic compensation of ceremony sagas whose step was not completed in time
+type SagaTimeoutScheduler struct {
+	log      logrus.FieldLogger
+	ctx      context.Context
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewSagaTimeoutScheduler creates a new saga timeout scheduler
+func NewSagaTimeoutScheduler(log logrus.FieldLogger, ctx context.Context, db *gorm.DB) *SagaTimeoutScheduler {
+	return &SagaTimeoutScheduler{
+		log:      log.WithField("component", "saga-timeout-scheduler"),
+		ctx:      ctx,
+		db:       db,
+		interval: 15 * time.Second, // Step deadlines are short, so check frequently
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WithInterval sets the check interval
+func (s *SagaTimeoutScheduler) WithInterval(interval time.Duration) *SagaTimeoutScheduler {
+	s.interval = interval
+	return s
+}
+
+// Start begins the background saga timeout checking
+func (s *SagaTimeoutScheduler) Start() {
+	s.log.WithField("interval", s.interval).Info("Starting saga timeout scheduler")
+
+	go s.run()
+}
+
+// Stop gracefully stops the scheduler
+func (s *SagaTimeoutScheduler) Stop() {
+	s.log.Info("Stopping saga timeout scheduler")
+	close(s.stop)
+	<-s.done
+	s.log.Info("Saga timeout scheduler stopped")
+}
+
+// run is the main loop for the scheduler
+func (s *SagaTimeoutScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	// Process immediately on start
+	s.processTimedOutSagas()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.processTimedOutSagas()
+		case <-s.stop:
+			return
+		case <-s.ctx.Done():
+			s.log.Info("Context cancelled, stopping saga timeout scheduler")
+			return
+		}
+	}
+}
+
+// processTimedOutSagas processes timed out sagas for all tenants
+func (s *SagaTimeoutScheduler) processTimedOutSagas() {
+	s.log.Debug("Processing timed out sagas")
+
+	tenantIds, err := s.getTenantsWithTimedOutSagas()
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get tenants with timed out sagas")
+		return
+	}
+
+	if len(tenantIds) == 0 {
+		s.log.Debug("No tenants with timed out sagas found")
+		return
+	}
+
+	s.log.WithField("tenantCount", len(tenantIds)).Debug("Processing timed out sagas for tenants")
+
+	for _, tenantId := range tenantIds {
+		s.processTimedOutSagasForTenant(tenantId)
+	}
+}
+
+// getTenantsWithTimedOutSagas retrieves all tenant IDs that have a saga step past its deadline
+func (s *SagaTimeoutScheduler) getTenantsWithTimedOutSagas() ([]uuid.UUID, error) {
+	var tenantIds []uuid.UUID
+
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithField("operation", "get-tenants-with-timed-out-sagas")).
+		WithContext(s.ctx).
+		WithMaxRetries(2).
+		WithInitialDelay(500 * time.Millisecond)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		var err error
+		tenantIds, err = saga.GetTenantsWithTimedOutSagas(s.db)(time.Now())
+		return err
+	})
+
+	return tenantIds, err
+}
+
+// processTimedOutSagasForTenant processes timed out sagas for a specific tenant
+func (s *SagaTimeoutScheduler) processTimedOutSagasForTenant(tenantId uuid.UUID) {
+	retryConfig := retry.DefaultRetryConfig().
+		WithLogger(s.log.WithFields(logrus.Fields{
+			"operation": "process-saga-timeouts",
+			"tenantId":  tenantId,
+		})).
+		WithContext(s.ctx).
+		WithMaxRetries(3).
+		WithInitialDelay(1 * time.Second).
+		WithMaxDelay(10 * time.Second)
+
+	err := retry.ExecuteWithRetry(retryConfig, func() error {
+		tenantModel, err := tenant.Create(tenantId, "saga-timeout-scheduler", 1, 0)
+		if err != nil {
+			s.log.WithFields(logrus.Fields{
+				"tenantId": tenantId,
+				"error":    err,
+			}).Error("Failed to create tenant model")
+			return err
+		}
+
+		tenantCtx := tenant.WithContext(s.ctx, tenantModel)
+		processor := marriage.NewProcessor(s.log, tenantCtx, s.db)
+		return processor.ProcessSagaTimeouts()
+	})
+
+	if err != nil {
+		s.log.WithFields(logrus.Fields{
+			"tenantId": tenantId,
+			"error":    err,
+		}).Error("Failed to process saga timeouts for tenant after retries")
+		return
+	}
+
+	s.log.WithField("tenantId", tenantId).Debug("Successfully processed saga timeouts for tenant")
+}
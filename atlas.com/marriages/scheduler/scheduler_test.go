@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"atlas-marriages/marriage"
+	"atlas-marriages/saga"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// periodicScheduler is the lifecycle shared by the periodic schedulers
+type periodicScheduler interface {
+	Start()
+	Stop()
+}
+
+// TestPeriodicSchedulers checks the default interval of each periodic scheduler, and that the scheduler starts and
+// stops without hanging
+func TestPeriodicSchedulers(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		create   func(logrus.FieldLogger, context.Context, *gorm.DB) (periodicScheduler, *time.Duration)
+	}{
+		{"Anniversary", time.Hour, func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (periodicScheduler, *time.Duration) {
+			s := NewAnniversaryScheduler(l, ctx, db)
+			return s, &s.interval
+		}},
+		{"BondDecay", time.Hour, func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (periodicScheduler, *time.Duration) {
+			s := NewBondDecayScheduler(l, ctx, db)
+			return s, &s.interval
+		}},
+		{"CeremonyStart", time.Minute, func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (periodicScheduler, *time.Duration) {
+			s := NewCeremonyStartScheduler(l, ctx, db)
+			return s, &s.interval
+		}},
+		{"DivorceFinalization", 5 * time.Minute, func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (periodicScheduler, *time.Duration) {
+			s := NewDivorceFinalizationScheduler(l, ctx, db)
+			return s, &s.interval
+		}},
+		{"SagaTimeout", 15 * time.Second, func(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (periodicScheduler, *time.Duration) {
+			s := NewSagaTimeoutScheduler(l, ctx, db)
+			return s, &s.interval
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			require.NoError(t, err)
+			require.NoError(t, marriage.Migration(db))
+			require.NoError(t, saga.Migration(db))
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			scheduler, interval := tt.create(logger, ctx, db)
+			assert.Equal(t, tt.interval, *interval)
+
+			*interval = 10 * time.Millisecond
+			scheduler.Start()
+			time.Sleep(50 * time.Millisecond)
+
+			// Should not panic or hang
+			scheduler.Stop()
+		})
+	}
+}
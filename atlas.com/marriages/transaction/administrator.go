@@ -0,0 +1,27 @@
+package transaction
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Record persists the outcome of a transaction unless one is already recorded for the tenant's transaction id, and
+// reports whether it was recorded. While another database transaction holds an uncommitted record for the same id the
+// insert waits for it to finish, so exactly one of two concurrent deliveries records the transaction
+func Record(db *gorm.DB, log logrus.FieldLogger) func(m Model) (bool, error) {
+	return func(m Model) (bool, error) {
+		log.WithFields(logrus.Fields{
+			"transactionId": m.TransactionId(),
+			"tenantId":      m.TenantId(),
+			"status":        m.Status(),
+		}).Debug("Recording processed transaction")
+
+		entity := m.ToEntity()
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		return result.RowsAffected == 1, nil
+	}
+}
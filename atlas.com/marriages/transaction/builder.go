@@ -0,0 +1,74 @@
+package transaction
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Builder provides fluent construction of processed transaction Models
+type Builder struct {
+	tenantId      uuid.UUID
+	transactionId uuid.UUID
+	characterId   uint32
+	status        Status
+	errorType     string
+	errorCode     string
+	errorMessage  string
+	errorContext  string
+	processedAt   time.Time
+}
+
+// NewBuilder creates a builder for a transaction processed for a tenant. The transaction succeeds unless a failure
+// is set
+func NewBuilder(tenantId uuid.UUID, transactionId uuid.UUID, characterId uint32) *Builder {
+	return &Builder{
+		tenantId:      tenantId,
+		transactionId: transactionId,
+		characterId:   characterId,
+		status:        StatusSucceeded,
+		processedAt:   time.Now(),
+	}
+}
+
+// SetFailure records the error event type, code, message and operation of a failed transaction
+func (b *Builder) SetFailure(errorType string, errorCode string, message string, context string) *Builder {
+	b.status = StatusFailed
+	b.errorType = errorType
+	b.errorCode = errorCode
+	b.errorMessage = message
+	b.errorContext = context
+	return b
+}
+
+// SetProcessedAt sets when the transaction was processed
+func (b *Builder) SetProcessedAt(processedAt time.Time) *Builder {
+	b.processedAt = processedAt
+	return b
+}
+
+// Build validates and builds the processed transaction
+func (b *Builder) Build() (Model, error) {
+	if b.tenantId == uuid.Nil {
+		return Model{}, errors.New("processed transaction tenant ID is required")
+	}
+	if b.transactionId == uuid.Nil {
+		return Model{}, errors.New("processed transaction ID is required")
+	}
+	if b.status == StatusFailed && b.errorCode == "" {
+		return Model{}, errors.New("failed transaction error code is required")
+	}
+
+	return Model{
+		tenantId:      b.tenantId,
+		transactionId: b.transactionId,
+		characterId:   b.characterId,
+		status:        b.status,
+		errorType:     b.errorType,
+		errorCode:     b.errorCode,
+		errorMessage:  b.errorMessage,
+		errorContext:  b.errorContext,
+		processedAt:   b.processedAt,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of a processed transaction. A tenant's transaction
+// id is unique, so concurrent deliveries of a command cannot both record it
+type Entity struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement"`
+	TenantId      uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_processed_transaction,priority:1;not null"`
+	TransactionId uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_processed_transaction,priority:2;not null"`
+	CharacterId   uint32    `gorm:"not null"`
+	Status        Status    `gorm:"not null"`
+	ErrorType     string
+	ErrorCode     string
+	ErrorMessage  string `gorm:"type:text"`
+	ErrorContext  string
+	ProcessedAt   time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the processed transaction entity
+func (Entity) TableName() string {
+	return "processed_transactions"
+}
+
+// Migration performs the database migration for the processed transaction entity
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// Make transforms a processed transaction entity to a domain model
+func Make(entity Entity) Model {
+	return Model{
+		tenantId:      entity.TenantId,
+		transactionId: entity.TransactionId,
+		characterId:   entity.CharacterId,
+		status:        entity.Status,
+		errorType:     entity.ErrorType,
+		errorCode:     entity.ErrorCode,
+		errorMessage:  entity.ErrorMessage,
+		errorContext:  entity.ErrorContext,
+		processedAt:   entity.ProcessedAt,
+	}
+}
+
+// ToEntity converts a processed transaction domain model to a database entity
+func (m Model) ToEntity() Entity {
+	return Entity{
+		TenantId:      m.tenantId,
+		TransactionId: m.transactionId,
+		CharacterId:   m.characterId,
+		Status:        m.status,
+		ErrorType:     m.errorType,
+		ErrorCode:     m.errorCode,
+		ErrorMessage:  m.errorMessage,
+		ErrorContext:  m.errorContext,
+		ProcessedAt:   m.processedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status represents the outcome of a processed transaction
+type Status string
+
+const (
+	// StatusSucceeded represents a transaction whose changes were committed
+	StatusSucceeded Status = "SUCCEEDED"
+	// StatusFailed represents a transaction which was rejected or could not be completed
+	StatusFailed Status = "FAILED"
+)
+
+// Model represents the recorded outcome of a command processed under a tenant's transaction id
+type Model struct {
+	tenantId      uuid.UUID
+	transactionId uuid.UUID
+	characterId   uint32
+	status        Status
+	errorType     string
+	errorCode     string
+	errorMessage  string
+	errorContext  string
+	processedAt   time.Time
+}
+
+// TenantId returns the tenant the transaction was processed for
+func (m Model) TenantId() uuid.UUID {
+	return m.tenantId
+}
+
+// TransactionId returns the caller supplied id of the transaction
+func (m Model) TransactionId() uuid.UUID {
+	return m.transactionId
+}
+
+// CharacterId returns the character who issued the command
+func (m Model) CharacterId() uint32 {
+	return m.characterId
+}
+
+// Status returns the outcome of the transaction
+func (m Model) Status() Status {
+	return m.status
+}
+
+// Succeeded returns true if the transaction's changes were committed
+func (m Model) Succeeded() bool {
+	return m.status == StatusSucceeded
+}
+
+// ErrorType returns the error event type of a failed transaction
+func (m Model) ErrorType() string {
+	return m.errorType
+}
+
+// ErrorCode returns the error event code of a failed transaction
+func (m Model) ErrorCode() string {
+	return m.errorCode
+}
+
+// ErrorMessage returns the reason a failed transaction was rejected
+func (m Model) ErrorMessage() string {
+	return m.errorMessage
+}
+
+// ErrorContext returns the operation a failed transaction was performing
+func (m Model) ErrorContext() string {
+	return m.errorContext
+}
+
+// ProcessedAt returns when the transaction was processed
+func (m Model) ProcessedAt() time.Time {
+	return m.processedAt
+}
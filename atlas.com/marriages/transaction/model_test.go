@@ -0,0 +1,66 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBuilder_Validation(t *testing.T) {
+	_, err := NewBuilder(uuid.Nil, uuid.New(), 1).Build()
+	assert.Error(t, err)
+
+	_, err = NewBuilder(uuid.New(), uuid.Nil, 1).Build()
+	assert.Error(t, err)
+
+	_, err = NewBuilder(uuid.New(), uuid.New(), 1).SetFailure("VALIDATION_ERROR", "", "rejected", "ceremony_start").Build()
+	assert.Error(t, err)
+
+	m, err := NewBuilder(uuid.New(), uuid.New(), 1).Build()
+	require.NoError(t, err)
+	assert.True(t, m.Succeeded())
+}
+
+func TestRecord_OncePerTenantTransaction(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, Migration(db))
+	l, _ := test.NewNullLogger()
+
+	tenantId := uuid.New()
+	transactionId := uuid.New()
+	succeeded, err := NewBuilder(tenantId, transactionId, 1).Build()
+	require.NoError(t, err)
+	failed, err := NewBuilder(tenantId, transactionId, 1).SetFailure("STATE_TRANSITION_ERROR", "INVALID_STATE", "ceremony cannot transition", "ceremony_start").Build()
+	require.NoError(t, err)
+
+	recorded, err := Record(db, l)(succeeded)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	// A second outcome for the same transaction is not recorded
+	recorded, err = Record(db, l)(failed)
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	// The same transaction id is independent for another tenant
+	other, err := NewBuilder(uuid.New(), transactionId, 1).Build()
+	require.NoError(t, err)
+	recorded, err = Record(db, l)(other)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	stored, err := GetByIdProvider(db, l)(transactionId, tenantId)()
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, StatusSucceeded, stored.Status())
+
+	missing, err := GetByIdProvider(db, l)(uuid.New(), tenantId)()
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,34 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// GetByIdProvider retrieves the recorded outcome of a tenant's transaction, returning nil when it has not been processed
+func GetByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(transactionId uuid.UUID, tenantId uuid.UUID) model.Provider[*Model] {
+	return func(transactionId uuid.UUID, tenantId uuid.UUID) model.Provider[*Model] {
+		return func() (*Model, error) {
+			log.WithFields(logrus.Fields{
+				"transactionId": transactionId,
+				"tenantId":      tenantId,
+			}).Debug("Retrieving processed transaction")
+
+			var entity Entity
+			err := db.Where("tenant_id = ? AND transaction_id = ?", tenantId, transactionId).First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			m := Make(entity)
+			return &m, nil
+		}
+	}
+}
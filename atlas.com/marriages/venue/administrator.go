@@ -0,0 +1,96 @@
+package venue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrSlotBooked is returned when a booking would start at the same time as another booking of the venue
+var ErrSlotBooked = errors.New("venue slot is already booked")
+
+// Create persists a venue in its tenant's catalogue
+func Create(db *gorm.DB, log logrus.FieldLogger) func(m Model) model.Provider[Model] {
+	return func(m Model) model.Provider[Model] {
+		return func() (Model, error) {
+			log.WithFields(logrus.Fields{
+				"worldId":   m.WorldId(),
+				"channelId": m.ChannelId(),
+				"name":      m.Name(),
+				"tenantId":  m.TenantId(),
+			}).Debug("Creating venue entity")
+
+			now := time.Now()
+			entity := Entity{
+				TenantId:          m.TenantId(),
+				WorldId:           m.WorldId(),
+				ChannelId:         m.ChannelId(),
+				Name:              m.Name(),
+				Type:              m.Type(),
+				Capacity:          m.Capacity(),
+				SlotLengthSeconds: int64(m.SlotLength() / time.Second),
+				CreatedAt:         now,
+				UpdatedAt:         now,
+			}
+			if err := db.Create(&entity).Error; err != nil {
+				return Model{}, err
+			}
+			return Make(entity)
+		}
+	}
+}
+
+// CreateBooking persists a ceremony's booking of a venue. It returns ErrSlotBooked when another booking of the venue
+// starts at the same time, which a concurrent booking committed since the venue was found free
+func CreateBooking(db *gorm.DB, log logrus.FieldLogger) func(venueId uint32, ceremonyId uint32, startsAt time.Time, endsAt time.Time, tenantId uuid.UUID) model.Provider[Booking] {
+	return func(venueId uint32, ceremonyId uint32, startsAt time.Time, endsAt time.Time, tenantId uuid.UUID) model.Provider[Booking] {
+		return func() (Booking, error) {
+			log.WithFields(logrus.Fields{
+				"venueId":    venueId,
+				"ceremonyId": ceremonyId,
+				"startsAt":   startsAt,
+				"tenantId":   tenantId,
+			}).Debug("Creating venue booking entity")
+
+			entity := BookingEntity{
+				TenantId:   tenantId,
+				VenueId:    venueId,
+				CeremonyId: ceremonyId,
+				StartsAt:   startsAt,
+				EndsAt:     endsAt,
+				CreatedAt:  time.Now(),
+			}
+			if err := db.Create(&entity).Error; err != nil {
+				if isDuplicatedKey(db, err) {
+					return Booking{}, ErrSlotBooked
+				}
+				return Booking{}, err
+			}
+			return MakeBooking(entity)
+		}
+	}
+}
+
+// DeleteBookingByCeremonyId releases the venue booked by a ceremony, if it holds a booking
+func DeleteBookingByCeremonyId(db *gorm.DB, log logrus.FieldLogger) func(ceremonyId uint32, tenantId uuid.UUID) error {
+	return func(ceremonyId uint32, tenantId uuid.UUID) error {
+		log.WithFields(logrus.Fields{
+			"ceremonyId": ceremonyId,
+			"tenantId":   tenantId,
+		}).Debug("Deleting venue booking entity")
+
+		return db.Where("ceremony_id = ? AND tenant_id = ?", ceremonyId, tenantId).Delete(&BookingEntity{}).Error
+	}
+}
+
+// isDuplicatedKey returns true if an error reports a violated unique index, as the database's dialect translates it
+func isDuplicatedKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -0,0 +1,87 @@
+package venue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Builder provides fluent construction of venue Models
+type Builder struct {
+	id         uint32
+	tenantId   uuid.UUID
+	worldId    byte
+	channelId  byte
+	name       string
+	venueType  Type
+	capacity   int
+	slotLength time.Duration
+}
+
+// NewBuilder creates a builder for a tenant's venue
+func NewBuilder(tenantId uuid.UUID, id uint32) *Builder {
+	return &Builder{
+		id:        id,
+		tenantId:  tenantId,
+		venueType: TypeChapel,
+	}
+}
+
+// SetLocation sets the world and channel the venue is in
+func (b *Builder) SetLocation(worldId byte, channelId byte) *Builder {
+	b.worldId = worldId
+	b.channelId = channelId
+	return b
+}
+
+// SetName sets the venue name
+func (b *Builder) SetName(name string) *Builder {
+	b.name = name
+	return b
+}
+
+// SetType sets the kind of building the venue is
+func (b *Builder) SetType(venueType Type) *Builder {
+	b.venueType = venueType
+	return b
+}
+
+// SetCapacity sets the number of invitees the venue holds, zero leaving only the tenant's invitee limit
+func (b *Builder) SetCapacity(capacity int) *Builder {
+	b.capacity = capacity
+	return b
+}
+
+// SetSlotLength sets how long each booking at the venue lasts
+func (b *Builder) SetSlotLength(slotLength time.Duration) *Builder {
+	b.slotLength = slotLength
+	return b
+}
+
+// Build validates and builds the venue
+func (b *Builder) Build() (Model, error) {
+	if !b.venueType.IsValid() {
+		return Model{}, errors.New("venue type must be CATHEDRAL or CHAPEL")
+	}
+	if b.capacity < 0 {
+		return Model{}, errors.New("venue capacity cannot be negative")
+	}
+	if b.slotLength <= 0 {
+		return Model{}, errors.New("venue slot length must be positive")
+	}
+	if b.slotLength%time.Second != 0 || (24*time.Hour)%b.slotLength != 0 {
+		return Model{}, errors.New("venue slot length must be a whole number of seconds dividing a day evenly")
+	}
+
+	return Model{
+		id:         b.id,
+		tenantId:   b.tenantId,
+		worldId:    b.worldId,
+		channelId:  b.channelId,
+		name:       b.name,
+		venueType:  b.venueType,
+		capacity:   b.capacity,
+		slotLength: b.slotLength,
+	}, nil
+}
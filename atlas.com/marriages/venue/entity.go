@@ -0,0 +1,72 @@
+package venue
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents a venue in a tenant's catalogue
+type Entity struct {
+	ID                uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId          uuid.UUID `gorm:"type:uuid;index:idx_marriage_venues_location;not null"`
+	WorldId           byte      `gorm:"index:idx_marriage_venues_location;not null"`
+	ChannelId         byte      `gorm:"index:idx_marriage_venues_location;not null"`
+	Name              string    `gorm:"not null"`
+	Type              Type      `gorm:"not null;default:CHAPEL"`
+	Capacity          int       `gorm:"not null;default:0"`
+	SlotLengthSeconds int64     `gorm:"not null"`
+	CreatedAt         time.Time `gorm:"not null"`
+	UpdatedAt         time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the venue entity
+func (Entity) TableName() string {
+	return "marriage_venues"
+}
+
+// BookingEntity represents a ceremony's booking of a venue. A venue cannot hold two bookings starting at the same time
+type BookingEntity struct {
+	ID         uint32    `gorm:"primaryKey;autoIncrement"`
+	TenantId   uuid.UUID `gorm:"type:uuid;index;not null"`
+	VenueId    uint32    `gorm:"uniqueIndex:idx_marriage_venue_bookings_slot;not null"`
+	CeremonyId uint32    `gorm:"uniqueIndex;not null"`
+	StartsAt   time.Time `gorm:"uniqueIndex:idx_marriage_venue_bookings_slot;not null"`
+	EndsAt     time.Time `gorm:"index;not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the booking entity
+func (BookingEntity) TableName() string {
+	return "marriage_venue_bookings"
+}
+
+// Migration performs the database migration for the venue and booking entities
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{}, &BookingEntity{})
+}
+
+// Make transforms a venue entity to a domain model
+func Make(entity Entity) (Model, error) {
+	return NewBuilder(entity.TenantId, entity.ID).
+		SetLocation(entity.WorldId, entity.ChannelId).
+		SetName(entity.Name).
+		SetType(entity.Type).
+		SetCapacity(entity.Capacity).
+		SetSlotLength(time.Duration(entity.SlotLengthSeconds) * time.Second).
+		Build()
+}
+
+// MakeBooking transforms a booking entity to a domain model
+func MakeBooking(entity BookingEntity) (Booking, error) {
+	return Booking{
+		id:         entity.ID,
+		tenantId:   entity.TenantId,
+		venueId:    entity.VenueId,
+		ceremonyId: entity.CeremonyId,
+		startsAt:   entity.StartsAt,
+		endsAt:     entity.EndsAt,
+		createdAt:  entity.CreatedAt,
+	}, nil
+}
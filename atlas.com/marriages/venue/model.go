@@ -0,0 +1,189 @@
+package venue
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type identifies the kind of building a venue is
+type Type string
+
+const (
+	TypeCathedral Type = "CATHEDRAL"
+	TypeChapel    Type = "CHAPEL"
+)
+
+// IsValid returns true if the type is a known kind of venue
+func (t Type) IsValid() bool {
+	return t == TypeCathedral || t == TypeChapel
+}
+
+// Model represents a venue in a tenant's world and channel, which couples book time slots at to hold their ceremony
+type Model struct {
+	id         uint32
+	tenantId   uuid.UUID
+	worldId    byte
+	channelId  byte
+	name       string
+	venueType  Type
+	capacity   int
+	slotLength time.Duration
+}
+
+// Id returns the venue ID
+func (m Model) Id() uint32 {
+	return m.id
+}
+
+// TenantId returns the tenant the venue belongs to
+func (m Model) TenantId() uuid.UUID {
+	return m.tenantId
+}
+
+// WorldId returns the world the venue is in
+func (m Model) WorldId() byte {
+	return m.worldId
+}
+
+// ChannelId returns the channel the venue is in
+func (m Model) ChannelId() byte {
+	return m.channelId
+}
+
+// Name returns the venue name
+func (m Model) Name() string {
+	return m.name
+}
+
+// Type returns the kind of building the venue is
+func (m Model) Type() Type {
+	return m.venueType
+}
+
+// Capacity returns the number of invitees the venue holds, or zero if only the tenant's invitee limit applies
+func (m Model) Capacity() int {
+	return m.capacity
+}
+
+// SlotLength returns how long each booking at the venue lasts
+func (m Model) SlotLength() time.Duration {
+	return m.slotLength
+}
+
+// SlotEnd returns when a booking starting at the given time ends
+func (m Model) SlotEnd(startsAt time.Time) time.Time {
+	return startsAt.Add(m.slotLength)
+}
+
+// IsSlotStart returns true if a booking at the venue can start at the given time. Slots follow one another from
+// midnight UTC, and as the slot length divides a day evenly every day has the same slots
+func (m Model) IsSlotStart(startsAt time.Time) bool {
+	if m.slotLength <= 0 {
+		return false
+	}
+	return startsAt.Sub(dayStart(startsAt))%m.slotLength == 0
+}
+
+// FreeSlots returns the venue's slots starting within [from, to) which overlap none of the bookings. Slots follow one
+// another from midnight UTC
+func (m Model) FreeSlots(bookings []Booking, from time.Time, to time.Time) []Slot {
+	slots := make([]Slot, 0)
+	if m.slotLength <= 0 {
+		return slots
+	}
+
+	from = from.UTC()
+	startsAt := dayStart(from).Add(from.Sub(dayStart(from)).Truncate(m.slotLength))
+	if startsAt.Before(from) {
+		startsAt = startsAt.Add(m.slotLength)
+	}
+
+	for ; startsAt.Before(to); startsAt = startsAt.Add(m.slotLength) {
+		endsAt := m.SlotEnd(startsAt)
+		booked := false
+		for _, b := range bookings {
+			if b.Overlaps(startsAt, endsAt) {
+				booked = true
+				break
+			}
+		}
+		if !booked {
+			slots = append(slots, Slot{startsAt: startsAt, endsAt: endsAt})
+		}
+	}
+	return slots
+}
+
+// dayStart returns midnight UTC of the day a time falls on
+func dayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Slot represents a period a venue can be booked for
+type Slot struct {
+	startsAt time.Time
+	endsAt   time.Time
+}
+
+// StartsAt returns when the slot starts
+func (s Slot) StartsAt() time.Time {
+	return s.startsAt
+}
+
+// EndsAt returns when the slot ends
+func (s Slot) EndsAt() time.Time {
+	return s.endsAt
+}
+
+// Booking represents a ceremony holding a venue for a period
+type Booking struct {
+	id         uint32
+	tenantId   uuid.UUID
+	venueId    uint32
+	ceremonyId uint32
+	startsAt   time.Time
+	endsAt     time.Time
+	createdAt  time.Time
+}
+
+// Id returns the booking ID
+func (b Booking) Id() uint32 {
+	return b.id
+}
+
+// TenantId returns the tenant the booking belongs to
+func (b Booking) TenantId() uuid.UUID {
+	return b.tenantId
+}
+
+// VenueId returns the booked venue
+func (b Booking) VenueId() uint32 {
+	return b.venueId
+}
+
+// CeremonyId returns the ceremony holding the booking
+func (b Booking) CeremonyId() uint32 {
+	return b.ceremonyId
+}
+
+// StartsAt returns when the booking starts
+func (b Booking) StartsAt() time.Time {
+	return b.startsAt
+}
+
+// EndsAt returns when the booking ends
+func (b Booking) EndsAt() time.Time {
+	return b.endsAt
+}
+
+// CreatedAt returns when the booking was made
+func (b Booking) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// Overlaps returns true if the booking holds the venue for any part of [startsAt, endsAt)
+func (b Booking) Overlaps(startsAt time.Time, endsAt time.Time) bool {
+	return b.startsAt.Before(endsAt) && startsAt.Before(b.endsAt)
+}
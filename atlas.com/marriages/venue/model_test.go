@@ -0,0 +1,99 @@
+package venue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVenue(t *testing.T, slotLength time.Duration) Model {
+	m, err := NewBuilder(uuid.New(), 1).
+		SetLocation(0, 1).
+		SetName("Cathedral").
+		SetType(TypeCathedral).
+		SetCapacity(20).
+		SetSlotLength(slotLength).
+		Build()
+	require.NoError(t, err)
+	return m
+}
+
+func startTimes(slots []Slot) []time.Time {
+	result := make([]time.Time, 0, len(slots))
+	for _, s := range slots {
+		result = append(result, s.StartsAt())
+	}
+	return result
+}
+
+func TestModel_FreeSlots(t *testing.T) {
+	m := newTestVenue(t, time.Hour)
+	day := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	booking := Booking{venueId: m.Id(), ceremonyId: 7, startsAt: day.Add(11 * time.Hour), endsAt: day.Add(12 * time.Hour)}
+
+	slots := m.FreeSlots([]Booking{booking}, day.Add(9*time.Hour+30*time.Minute), day.Add(13*time.Hour))
+	assert.Equal(t, []time.Time{day.Add(10 * time.Hour), day.Add(12 * time.Hour)}, startTimes(slots))
+	assert.Equal(t, day.Add(11*time.Hour), slots[0].EndsAt())
+}
+
+func TestModel_FreeSlots_MisalignedBooking(t *testing.T) {
+	m := newTestVenue(t, time.Hour)
+	day := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+	booking := Booking{venueId: m.Id(), ceremonyId: 7, startsAt: day.Add(10*time.Hour + 30*time.Minute), endsAt: day.Add(11*time.Hour + 30*time.Minute)}
+
+	slots := m.FreeSlots([]Booking{booking}, day.Add(9*time.Hour), day.Add(13*time.Hour))
+	assert.Equal(t, []time.Time{day.Add(9 * time.Hour), day.Add(12 * time.Hour)}, startTimes(slots))
+}
+
+func TestModel_IsSlotStart(t *testing.T) {
+	m := newTestVenue(t, 90*time.Minute)
+	day := time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, m.IsSlotStart(day))
+	assert.True(t, m.IsSlotStart(day.Add(3*time.Hour)))
+	assert.True(t, m.IsSlotStart(day.Add(24*time.Hour+90*time.Minute)), "every day has the same slots")
+	assert.True(t, m.IsSlotStart(day.Add(3*time.Hour).In(time.FixedZone("UTC+2", 2*60*60))))
+	assert.False(t, m.IsSlotStart(day.Add(time.Hour)))
+	assert.False(t, m.IsSlotStart(day.Add(3*time.Hour+time.Second)))
+}
+
+func TestBooking_Overlaps(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 10, 0, 0, 0, time.UTC)
+	b := Booking{startsAt: start, endsAt: start.Add(time.Hour)}
+
+	assert.True(t, b.Overlaps(start.Add(30*time.Minute), start.Add(90*time.Minute)))
+	assert.False(t, b.Overlaps(start.Add(time.Hour), start.Add(2*time.Hour)), "back to back bookings do not overlap")
+	assert.False(t, b.Overlaps(start.Add(-time.Hour), start))
+}
+
+func TestBuilder_Validation(t *testing.T) {
+	_, err := NewBuilder(uuid.New(), 1).SetType("GARDEN").SetSlotLength(time.Hour).Build()
+	assert.Error(t, err)
+
+	_, err = NewBuilder(uuid.New(), 1).SetCapacity(-1).SetSlotLength(time.Hour).Build()
+	assert.Error(t, err)
+
+	_, err = NewBuilder(uuid.New(), 1).Build()
+	assert.Error(t, err, "a venue requires a slot length")
+
+	_, err = NewBuilder(uuid.New(), 1).SetSlotLength(7 * time.Hour).Build()
+	assert.Error(t, err, "a venue's slots must divide a day evenly")
+
+	_, err = NewBuilder(uuid.New(), 1).SetSlotLength(1500 * time.Millisecond).Build()
+	assert.Error(t, err, "a venue's slot length is stored in seconds")
+}
+
+func TestEntity_RoundTrip(t *testing.T) {
+	m, err := Make(Entity{ID: 3, TenantId: uuid.New(), WorldId: 1, ChannelId: 2, Name: "Chapel", Type: TypeChapel, Capacity: 10, SlotLengthSeconds: 1800})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(3), m.Id())
+	assert.Equal(t, byte(1), m.WorldId())
+	assert.Equal(t, byte(2), m.ChannelId())
+	assert.Equal(t, TypeChapel, m.Type())
+	assert.Equal(t, 10, m.Capacity())
+	assert.Equal(t, 30*time.Minute, m.SlotLength())
+}
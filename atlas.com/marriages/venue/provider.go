@@ -0,0 +1,147 @@
+package venue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetByIdProvider retrieves a tenant's venue by id, returning nil when it does not exist
+func GetByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(venueId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+	return func(venueId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+		return func() (*Model, error) {
+			log.WithFields(logrus.Fields{
+				"venueId":  venueId,
+				"tenantId": tenantId,
+			}).Debug("Retrieving venue by ID")
+
+			var entity Entity
+			err := db.Where("id = ? AND tenant_id = ?", venueId, tenantId).First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			m, err := Make(entity)
+			if err != nil {
+				return nil, err
+			}
+			return &m, nil
+		}
+	}
+}
+
+// LockByIdProvider retrieves a tenant's venue by id, returning nil when it does not exist. The venue's row stays locked
+// until the database transaction ends, so concurrent bookings of the venue are checked and made one at a time
+func LockByIdProvider(db *gorm.DB, log logrus.FieldLogger) func(venueId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+	return func(venueId uint32, tenantId uuid.UUID) model.Provider[*Model] {
+		return func() (*Model, error) {
+			log.WithFields(logrus.Fields{
+				"venueId":  venueId,
+				"tenantId": tenantId,
+			}).Debug("Locking venue by ID")
+
+			var entity Entity
+			err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND tenant_id = ?", venueId, tenantId).
+				First(&entity).Error
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, nil
+				}
+				return nil, err
+			}
+
+			m, err := Make(entity)
+			if err != nil {
+				return nil, err
+			}
+			return &m, nil
+		}
+	}
+}
+
+// HasCatalogueProvider reports whether a tenant has any venue in its catalogue
+func HasCatalogueProvider(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[bool] {
+	return func(tenantId uuid.UUID) model.Provider[bool] {
+		return func() (bool, error) {
+			log.WithField("tenantId", tenantId).Debug("Checking for a venue catalogue")
+
+			var count int64
+			if err := db.Model(&Entity{}).Where("tenant_id = ?", tenantId).Limit(1).Count(&count).Error; err != nil {
+				return false, err
+			}
+			return count > 0, nil
+		}
+	}
+}
+
+// GetByLocationProvider retrieves a tenant's venues in a world and channel
+func GetByLocationProvider(db *gorm.DB, log logrus.FieldLogger) func(worldId byte, channelId byte, tenantId uuid.UUID) model.Provider[[]Model] {
+	return func(worldId byte, channelId byte, tenantId uuid.UUID) model.Provider[[]Model] {
+		return func() ([]Model, error) {
+			log.WithFields(logrus.Fields{
+				"worldId":   worldId,
+				"channelId": channelId,
+				"tenantId":  tenantId,
+			}).Debug("Retrieving venues by location")
+
+			var entities []Entity
+			err := db.Where("tenant_id = ? AND world_id = ? AND channel_id = ?", tenantId, worldId, channelId).
+				Order("id ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]Model, 0, len(entities))
+			for _, entity := range entities {
+				m, err := Make(entity)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, m)
+			}
+			return results, nil
+		}
+	}
+}
+
+// GetBookingsProvider retrieves the bookings holding a venue for any part of [from, to)
+func GetBookingsProvider(db *gorm.DB, log logrus.FieldLogger) func(venueId uint32, from time.Time, to time.Time, tenantId uuid.UUID) model.Provider[[]Booking] {
+	return func(venueId uint32, from time.Time, to time.Time, tenantId uuid.UUID) model.Provider[[]Booking] {
+		return func() ([]Booking, error) {
+			log.WithFields(logrus.Fields{
+				"venueId":  venueId,
+				"from":     from,
+				"to":       to,
+				"tenantId": tenantId,
+			}).Debug("Retrieving venue bookings")
+
+			var entities []BookingEntity
+			err := db.Where("tenant_id = ? AND venue_id = ? AND starts_at < ? AND ends_at > ?", tenantId, venueId, to, from).
+				Order("starts_at ASC").
+				Find(&entities).Error
+			if err != nil {
+				return nil, err
+			}
+
+			results := make([]Booking, 0, len(entities))
+			for _, entity := range entities {
+				b, err := MakeBooking(entity)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, b)
+			}
+			return results, nil
+		}
+	}
+}